@@ -0,0 +1,30 @@
+// Package cdbtest содержит вспомогательные функции для тестов, работающих с базой контрактов.
+package cdbtest
+
+import (
+	"testing"
+
+	"tgp/internal/cdb"
+	"tgp/internal/model"
+)
+
+// SeedVersion записывает проект в базу контрактов root как тег version проекта projectKey.
+func SeedVersion(t testing.TB, root string, projectKey string, version string, project *model.Project) {
+
+	t.Helper()
+
+	idx, err := cdb.LoadIndex(root)
+	if err != nil {
+		t.Fatalf("LoadIndex: %v", err)
+	}
+	projectFile, err := cdb.UpsertProject(root, idx, projectKey, projectKey, project.ModulePath, version, cdb.VersionKindTag)
+	if err != nil {
+		t.Fatalf("UpsertProject: %v", err)
+	}
+	if err = cdb.SaveIndex(root, idx); err != nil {
+		t.Fatalf("SaveIndex: %v", err)
+	}
+	if err = cdb.WriteProject(root, projectFile, project); err != nil {
+		t.Fatalf("WriteProject: %v", err)
+	}
+}
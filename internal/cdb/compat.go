@@ -0,0 +1,464 @@
+package cdb
+
+import (
+	"sort"
+	"strings"
+
+	"tgp/internal/model"
+)
+
+// Причины, по которым ломающее изменение не блокирует проверку (Finding.Suppressed).
+const (
+	SuppressedDeprecated = "deprecated"
+	SuppressedPolicy     = "policy"
+)
+
+// Finding — изменение модели, классифицированное для одного семейства транспорта.
+// Family пуст, если изменение не затрагивает ни один транспорт (неиспользуемый тип, аннотации проекта).
+type Finding struct {
+	Change
+	Family     string `json:"family,omitempty"`
+	Breaking   bool   `json:"breaking"`
+	Reason     string `json:"reason,omitempty"`
+	Suppressed string `json:"suppressed,omitempty"`
+}
+
+// Blocking — ломающее изменение, не подавленное @tg deprecated или политикой контракта.
+func (f Finding) Blocking() (ok bool) {
+
+	return f.Breaking && f.Suppressed == ""
+}
+
+// HasBlocking сообщает, есть ли среди находок блокирующие.
+func HasBlocking(findings []Finding) (ok bool) {
+
+	for _, finding := range findings {
+		if finding.Blocking() {
+			return true
+		}
+	}
+	return
+}
+
+// wireAnnotations — аннотации метода, меняющие формат обмена только своего семейства.
+var wireAnnotations = map[string]string{
+	model.TagHttpSuccess:         FamilyHTTP,
+	model.TagHttpArg:             FamilyHTTP,
+	model.TagHttpHeader:          FamilyHTTP,
+	model.TagHttpCookies:         FamilyHTTP,
+	model.TagRequestContentType:  FamilyHTTP,
+	model.TagResponseContentType: FamilyHTTP,
+	model.TagHttpMultipart:       FamilyHTTP,
+	model.TagKafkaCodec:          FamilyKafka,
+	model.TagKafkaKey:            FamilyKafka,
+	model.TagKafkaHeaders:        FamilyKafka,
+	model.TagKafkaMessage:        FamilyKafka,
+}
+
+// methodRef — метод контракта, через который изменение видно клиентам. Пустой method — контракт целиком.
+type methodRef struct {
+	contract string
+	method   string
+}
+
+// projectIndex — контракты и методы одной версии по именам.
+type projectIndex struct {
+	project   *model.Project
+	contracts map[string]*model.Contract
+	methods   map[string]map[string]*model.Method
+}
+
+type compatChecker struct {
+	from  projectIndex
+	to    projectIndex
+	usage map[string][]methodRef
+}
+
+// CheckCompatibility сравнивает две версии проекта и классифицирует каждое изменение как ломающее или совместимое
+// для каждого семейства транспорта (http, jsonrpc, ws, sse, kafka), через которое оно видно клиентам.
+// Ломающее изменение подавляется, если затронутые методы помечены @tg deprecated или контракт задал @tg compat=warn;
+// контракты с @tg compat=off и семейства из @tg compat-ignore не проверяются.
+func CheckCompatibility(from *model.Project, to *model.Project) (findings []Finding) {
+
+	if from == nil {
+		from = &model.Project{}
+	}
+	if to == nil {
+		to = &model.Project{}
+	}
+	checker := &compatChecker{from: newProjectIndex(from), to: newProjectIndex(to), usage: make(map[string][]methodRef)}
+	checker.collectUsage(checker.from)
+	checker.collectUsage(checker.to)
+
+	for _, change := range DiffProjects(from, to) {
+		findings = append(findings, checker.classify(change)...)
+	}
+	return
+}
+
+func (c *compatChecker) classify(change Change) (findings []Finding) {
+
+	breaking, reason, onlyFamily := c.breaking(change)
+	if !breaking {
+		reason = ""
+	}
+
+	byFamily := make(map[string][]methodRef)
+	var excluded bool
+	for _, ref := range c.refs(change) {
+		contract := c.to.contracts[ref.contract]
+		project := c.to.project
+		if contract == nil {
+			contract, project = c.from.contracts[ref.contract], c.from.project
+		}
+		ignored := model.ContractCompatIgnored(project, contract)
+		if model.ContractCompatPolicy(project, contract) == model.CompatOff {
+			excluded = true
+			continue
+		}
+		for _, family := range c.families(ref) {
+			if onlyFamily != "" && family != onlyFamily {
+				continue
+			}
+			if _, ok := ignored[family]; ok {
+				excluded = true
+				continue
+			}
+			byFamily[family] = append(byFamily[family], ref)
+		}
+	}
+
+	if len(byFamily) == 0 {
+		if !excluded {
+			findings = append(findings, Finding{Change: change})
+		}
+		return
+	}
+	families := make([]string, 0, len(byFamily))
+	for family := range byFamily {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	for _, family := range families {
+		finding := Finding{Change: change, Family: family, Breaking: breaking, Reason: reason}
+		if breaking {
+			finding.Suppressed = c.suppressed(byFamily[family])
+		}
+		findings = append(findings, finding)
+	}
+	return
+}
+
+// breaking решает, ломает ли изменение существующих клиентов. onlyFamily — изменение касается одного семейства.
+func (c *compatChecker) breaking(change Change) (breaking bool, reason string, onlyFamily string) {
+
+	switch change.Entity {
+	case EntityContract:
+		return change.Kind == ChangeRemoved, "contract removed", ""
+	case EntityMethod:
+		return change.Kind == ChangeRemoved, "method removed", ""
+	case EntityArgument:
+		switch change.Kind {
+		case ChangeAdded:
+			return c.argumentRequired(change), "new required argument", ""
+		case ChangeChanged:
+			return true, "argument type changed", ""
+		}
+	case EntityResult:
+		switch change.Kind {
+		case ChangeRemoved:
+			return true, "result removed", ""
+		case ChangeChanged:
+			return true, "result type changed", ""
+		}
+	case EntityRoute:
+		return change.Kind != ChangeAdded, "route " + string(change.Kind), change.Name
+	case EntityTopic:
+		return change.Kind != ChangeAdded, "kafka topic " + string(change.Kind), FamilyKafka
+	case EntityAnnotation:
+		return c.annotationBreaking(change)
+	case EntityType:
+		return change.Kind == ChangeChanged, "type shape changed", ""
+	case EntityField:
+		switch change.Kind {
+		case ChangeAdded:
+			return c.fieldRequired(change), "new required field", ""
+		case ChangeRemoved:
+			return true, "field removed", ""
+		case ChangeChanged:
+			return true, "field type changed", ""
+		}
+	case EntityFieldTag:
+		if change.Name == "json" && jsonName(change.Before, fieldName(change)) != jsonName(change.After, fieldName(change)) {
+			return true, "json name changed", ""
+		}
+	case EntityEnum:
+		return change.Kind == ChangeRemoved, "enum value removed", ""
+	}
+	return
+}
+
+// annotationBreaking классифицирует изменение аннотации метода, аргумента (<var>.<key>) или поля.
+func (c *compatChecker) annotationBreaking(change Change) (breaking bool, reason string, onlyFamily string) {
+
+	if change.Method == "" && change.TypeID == "" && change.Contract == "" {
+		return
+	}
+	key := change.Name
+	if varName, subKey, ok := strings.Cut(key, "."); ok && change.Method != "" {
+		// Аннотации нового аргумента уже учтены в его добавлении.
+		if !c.hasVariable(c.from, change, varName) {
+			return
+		}
+		key = subKey
+	}
+	if family, ok := wireAnnotations[key]; ok && key == change.Name {
+		return true, key + " " + string(change.Kind), family
+	}
+	switch key {
+	case model.TagParamTags:
+		return true, "serialization tags " + string(change.Kind), ""
+	case model.TagRequired:
+		return change.Kind == ChangeAdded, "became required", ""
+	case "enums":
+		return enumsNarrowed(change.Before, change.After), "enum narrowed", ""
+	}
+	return
+}
+
+// refs — методы, через которые изменение видно клиентам.
+func (c *compatChecker) refs(change Change) (refs []methodRef) {
+
+	switch {
+	case change.TypeID != "":
+		return c.usage[change.TypeID]
+	case change.Method != "":
+		return []methodRef{{contract: change.Contract, method: change.Method}}
+	case change.Contract != "":
+		return []methodRef{{contract: change.Contract}}
+	}
+	return
+}
+
+// families — семейства транспорта метода (или всех методов контракта) в обеих версиях.
+func (c *compatChecker) families(ref methodRef) (families []string) {
+
+	seen := make(map[string]struct{})
+	for _, idx := range []projectIndex{c.from, c.to} {
+		contract := idx.contracts[ref.contract]
+		if contract == nil {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if ref.method != "" && method.Name != ref.method {
+				continue
+			}
+			for family := range methodRoutes(idx.project, contract, method) {
+				seen[family] = struct{}{}
+			}
+			if model.ContractIsKafka(idx.project, contract) {
+				seen[FamilyKafka] = struct{}{}
+			}
+		}
+	}
+	for family := range seen {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	return
+}
+
+// suppressed — причина подавления, если каждый затронутый метод устарел или его контракт в режиме warn.
+func (c *compatChecker) suppressed(refs []methodRef) (reason string) {
+
+	deprecatedAll := true
+	for _, ref := range refs {
+		if c.deprecated(ref) {
+			continue
+		}
+		deprecatedAll = false
+		contract, project := c.to.contracts[ref.contract], c.to.project
+		if contract == nil {
+			contract, project = c.from.contracts[ref.contract], c.from.project
+		}
+		if model.ContractCompatPolicy(project, contract) != model.CompatWarn {
+			return ""
+		}
+	}
+	if deprecatedAll {
+		return SuppressedDeprecated
+	}
+	return SuppressedPolicy
+}
+
+// deprecated — метод (контракт) помечен @tg deprecated в любой из версий.
+func (c *compatChecker) deprecated(ref methodRef) (ok bool) {
+
+	for _, idx := range []projectIndex{c.from, c.to} {
+		contract := idx.contracts[ref.contract]
+		if contract == nil {
+			continue
+		}
+		method := idx.methods[ref.contract][ref.method]
+		if ref.method != "" && method == nil {
+			continue
+		}
+		if model.IsAnnotationSet(idx.project, contract, method, nil, model.TagDeprecated) {
+			return true
+		}
+	}
+	return
+}
+
+func (c *compatChecker) hasVariable(idx projectIndex, change Change, name string) (ok bool) {
+
+	method := idx.methods[change.Contract][change.Method]
+	if method == nil {
+		return
+	}
+	for _, variable := range append(append([]*model.Variable{}, method.Args...), method.Results...) {
+		if variable.Name == name {
+			return true
+		}
+	}
+	return
+}
+
+func (c *compatChecker) argumentRequired(change Change) (ok bool) {
+
+	method := c.to.methods[change.Contract][change.Method]
+	if method == nil {
+		return
+	}
+	for _, arg := range method.Args {
+		if arg.Name == change.Name {
+			return arg.Annotations.IsSet(model.TagRequired) || method.Annotations.Sub(arg.Name).IsSet(model.TagRequired)
+		}
+	}
+	return
+}
+
+func (c *compatChecker) fieldRequired(change Change) (ok bool) {
+
+	typ := c.to.project.Types[change.TypeID]
+	if typ == nil {
+		return
+	}
+	for _, field := range typ.StructFields {
+		if field.Name == change.Name {
+			return field.Annotations.IsSet(model.TagRequired)
+		}
+	}
+	return
+}
+
+// collectUsage сопоставляет каждому типу методы, из аргументов или результатов которых он достижим.
+func (c *compatChecker) collectUsage(idx projectIndex) {
+
+	for _, contract := range idx.project.Contracts {
+		for _, method := range contract.Methods {
+			ref := methodRef{contract: contract.Name, method: method.Name}
+			seen := make(map[string]struct{})
+			for _, variable := range append(append([]*model.Variable{}, method.Args...), method.Results...) {
+				walkTypeRef(idx.project, &variable.TypeRef, seen)
+			}
+			for typeID := range seen {
+				if !containsRef(c.usage[typeID], ref) {
+					c.usage[typeID] = append(c.usage[typeID], ref)
+				}
+			}
+		}
+	}
+}
+
+func walkTypeRef(project *model.Project, ref *model.TypeRef, seen map[string]struct{}) {
+
+	if ref == nil {
+		return
+	}
+	walkTypeID(project, ref.TypeID, seen)
+	walkTypeRef(project, ref.MapKey, seen)
+	walkTypeRef(project, ref.MapValue, seen)
+	walkTypeRef(project, ref.ChanOf, seen)
+}
+
+func walkTypeID(project *model.Project, typeID string, seen map[string]struct{}) {
+
+	if typeID == "" {
+		return
+	}
+	if _, ok := seen[typeID]; ok {
+		return
+	}
+	seen[typeID] = struct{}{}
+	typ := project.Types[typeID]
+	if typ == nil {
+		return
+	}
+	for _, id := range []string{typ.AliasOf, typ.UnderlyingTypeID, typ.ArrayOfID, typ.ChanOfID} {
+		walkTypeID(project, id, seen)
+	}
+	walkTypeRef(project, typ.MapKey, seen)
+	walkTypeRef(project, typ.MapValue, seen)
+	for _, field := range typ.StructFields {
+		walkTypeRef(project, &field.TypeRef, seen)
+	}
+}
+
+func containsRef(refs []methodRef, ref methodRef) (ok bool) {
+
+	for _, item := range refs {
+		if item == ref {
+			return true
+		}
+	}
+	return
+}
+
+func newProjectIndex(project *model.Project) (idx projectIndex) {
+
+	idx = projectIndex{project: project, contracts: contractsByName(project), methods: make(map[string]map[string]*model.Method)}
+	for _, contract := range project.Contracts {
+		idx.methods[contract.Name] = methodsByName(contract)
+	}
+	return
+}
+
+// fieldName извлекает имя поля из пути "typeID.Field#tag".
+func fieldName(change Change) (name string) {
+
+	name = strings.TrimPrefix(change.Path, change.TypeID+".")
+	name, _, _ = strings.Cut(name, "#")
+	return
+}
+
+// jsonName — имя поля в JSON по значению тега (без опций); пустое имя означает имя Go-поля.
+func jsonName(tag string, field string) (name string) {
+
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "" {
+		return field
+	}
+	return
+}
+
+// enumsNarrowed — список @tg enums появился или из него исчезло хотя бы одно значение.
+func enumsNarrowed(before string, after string) (ok bool) {
+
+	switch {
+	case after == "":
+		return false
+	case before == "":
+		return true
+	}
+	allowed := make(map[string]struct{})
+	for _, value := range strings.Split(after, ",") {
+		allowed[strings.TrimSpace(value)] = struct{}{}
+	}
+	for _, value := range strings.Split(before, ",") {
+		if _, found := allowed[strings.TrimSpace(value)]; !found {
+			return true
+		}
+	}
+	return
+}
@@ -0,0 +1,107 @@
+package cdb
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func compatSummary(findings []Finding) (got []string) {
+
+	got = make([]string, 0, len(findings))
+	for _, finding := range findings {
+		if !finding.Breaking {
+			continue
+		}
+		got = append(got, strings.Join([]string{finding.Path, finding.Family, finding.Reason, finding.Suppressed}, "|"))
+	}
+	sort.Strings(got)
+	return
+}
+
+func TestCheckCompatibility(t *testing.T) {
+
+	t.Parallel()
+
+	from := diffFixture(nil)
+	to := diffFixture(func(project *model.Project) {
+		users := project.Contracts[0]
+		users.Methods = users.Methods[:1]
+		get := users.Methods[0]
+		get.Annotations[model.TagHttpPath] = "/users/:userId"
+		get.Annotations["expand."+model.TagRequired] = ""
+		get.Args = append(get.Args, &model.Variable{Name: "expand", TypeRef: model.TypeRef{TypeID: "bool"}}, &model.Variable{Name: "fields", TypeRef: model.TypeRef{TypeID: "string"}})
+		project.Contracts[1].Methods[0].Annotations[model.TagKafkaCodec] = "avro"
+
+		user := project.Types["example.com/app/dto:User"]
+		user.StructFields = []*model.StructField{
+			{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"fullName"}}},
+			{Name: "Age", TypeRef: model.TypeRef{TypeID: "int"}, Tags: map[string][]string{"json": {"Age", "omitempty"}}},
+			{Name: "Email", TypeRef: model.TypeRef{TypeID: "string", NumberOfPointers: 1}},
+		}
+	})
+
+	want := []string{
+		"Events.Created@kafka-codec|kafka|kafka-codec added|",
+		"Users.Delete|jsonrpc|method removed|",
+		"Users.Get.expand|http|new required argument|",
+		"Users.Get|http|route changed|",
+		"example.com/app/dto:User.Name#json|http|json name changed|",
+	}
+	got := compatSummary(CheckCompatibility(from, to))
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("breaking:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCheckCompatibility_deprecatedAndPolicy(t *testing.T) {
+
+	t.Parallel()
+
+	from := diffFixture(func(project *model.Project) {
+		project.Contracts[0].Methods[1].Annotations = tags.DocTags{model.TagDeprecated: ""}
+		project.Contracts[1].Annotations[model.TagCompat] = model.CompatWarn
+	})
+	to := diffFixture(func(project *model.Project) {
+		project.Contracts[0].Methods = project.Contracts[0].Methods[:1]
+		project.Contracts[0].Annotations[model.TagCompatIgnore] = FamilyHTTP
+		project.Contracts[0].Methods[0].Annotations[model.TagHttpPath] = "/users/:userId"
+		project.Contracts[1].Annotations[model.TagCompat] = model.CompatWarn
+		project.Contracts[1].Methods[0].Annotations[model.TagKafkaTopic] = "users.created.v2"
+	})
+
+	findings := CheckCompatibility(from, to)
+	want := []string{
+		"Events.Created|kafka|kafka topic changed|policy",
+		"Users.Delete|jsonrpc|method removed|deprecated",
+	}
+	if got := compatSummary(findings); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("breaking:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if HasBlocking(findings) {
+		t.Fatal("suppressed findings must not block")
+	}
+}
+
+func TestEnumsNarrowed(t *testing.T) {
+
+	t.Parallel()
+
+	cases := []struct {
+		before, after string
+		want          bool
+	}{
+		{before: "a,b", after: "a,b,c", want: false},
+		{before: "a,b", after: "a", want: true},
+		{before: "", after: "a", want: true},
+		{before: "a", after: "", want: false},
+	}
+	for _, tc := range cases {
+		if got := enumsNarrowed(tc.before, tc.after); got != tc.want {
+			t.Fatalf("enumsNarrowed(%q, %q)=%v, want %v", tc.before, tc.after, got, tc.want)
+		}
+	}
+}
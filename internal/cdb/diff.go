@@ -0,0 +1,425 @@
+package cdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+// ChangeKind — вид изменения элемента модели между двумя версиями проекта.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Entity — элемент модели, к которому относится изменение.
+type Entity string
+
+const (
+	EntityContract   Entity = "contract"
+	EntityMethod     Entity = "method"
+	EntityArgument   Entity = "argument"
+	EntityResult     Entity = "result"
+	EntityAnnotation Entity = "annotation"
+	EntityRoute      Entity = "route"
+	EntityTopic      Entity = "kafka-topic"
+	EntityType       Entity = "type"
+	EntityField      Entity = "field"
+	EntityFieldTag   Entity = "field-tag"
+	EntityEnum       Entity = "enum"
+)
+
+// Семейства транспорта: Change.Name для EntityRoute и Finding.Family при проверке совместимости.
+const (
+	FamilyHTTP    = "http"
+	FamilyJSONRPC = "jsonrpc"
+	FamilyWS      = "ws"
+	FamilySSE     = "sse"
+	FamilyKafka   = "kafka"
+)
+
+// Change — одно семантическое изменение модели.
+// Path — человекочитаемый адрес элемента (Contract.Method.arg, typeID.Field, Contract.Method@annotation).
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Entity   Entity     `json:"entity"`
+	Path     string     `json:"path"`
+	Name     string     `json:"name,omitempty"`
+	Before   string     `json:"before,omitempty"`
+	After    string     `json:"after,omitempty"`
+	Contract string     `json:"contract,omitempty"`
+	Method   string     `json:"method,omitempty"`
+	TypeID   string     `json:"typeID,omitempty"`
+}
+
+// DiffProjects сравнивает две версии проекта: контракты, методы, аргументы и результаты, аннотации,
+// HTTP/JSON-RPC/WS/SSE маршруты, Kafka-топики, типы, поля, json-теги и значения enum.
+func DiffProjects(from *model.Project, to *model.Project) (changes []Change) {
+
+	if from == nil {
+		from = &model.Project{}
+	}
+	if to == nil {
+		to = &model.Project{}
+	}
+
+	changes = append(changes, diffAnnotations(from.Annotations, to.Annotations, Change{})...)
+
+	fromContracts := contractsByName(from)
+	toContracts := contractsByName(to)
+	for _, name := range unionKeys(fromContracts, toContracts) {
+		before, after := fromContracts[name], toContracts[name]
+		switch {
+		case before == nil:
+			changes = append(changes, Change{Kind: ChangeAdded, Entity: EntityContract, Path: name, Contract: name})
+		case after == nil:
+			changes = append(changes, Change{Kind: ChangeRemoved, Entity: EntityContract, Path: name, Contract: name})
+		default:
+			changes = append(changes, diffContract(from, to, before, after)...)
+		}
+	}
+
+	for _, typeID := range unionKeys(from.Types, to.Types) {
+		before, after := from.Types[typeID], to.Types[typeID]
+		switch {
+		case before == nil:
+			changes = append(changes, Change{Kind: ChangeAdded, Entity: EntityType, Path: typeID, TypeID: typeID})
+		case after == nil:
+			changes = append(changes, Change{Kind: ChangeRemoved, Entity: EntityType, Path: typeID, TypeID: typeID})
+		default:
+			changes = append(changes, diffType(typeID, before, after)...)
+		}
+	}
+	return
+}
+
+func diffContract(fromProject *model.Project, toProject *model.Project, from *model.Contract, to *model.Contract) (changes []Change) {
+
+	changes = append(changes, diffAnnotations(from.Annotations, to.Annotations, Change{Path: to.Name, Contract: to.Name})...)
+
+	fromMethods := methodsByName(from)
+	toMethods := methodsByName(to)
+	for _, name := range unionKeys(fromMethods, toMethods) {
+		before, after := fromMethods[name], toMethods[name]
+		base := Change{Path: to.Name + "." + name, Contract: to.Name, Method: name}
+		switch {
+		case before == nil:
+			changes = append(changes, withKind(base, ChangeAdded, EntityMethod))
+		case after == nil:
+			changes = append(changes, withKind(base, ChangeRemoved, EntityMethod))
+		default:
+			changes = append(changes, diffVariables(before.Args, after.Args, EntityArgument, base)...)
+			changes = append(changes, diffVariables(before.Results, after.Results, EntityResult, base)...)
+			changes = append(changes, diffAnnotations(before.Annotations, after.Annotations, base)...)
+			changes = append(changes, diffRoutes(methodRoutes(fromProject, from, before), methodRoutes(toProject, to, after), base)...)
+			beforeTopic, afterTopic := methodTopic(fromProject, from, before), methodTopic(toProject, to, after)
+			if change, ok := diffValue(beforeTopic, afterTopic, withKind(base, "", EntityTopic)); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return
+}
+
+func diffVariables(from []*model.Variable, to []*model.Variable, entity Entity, base Change) (changes []Change) {
+
+	fromVars := variablesByName(from)
+	toVars := variablesByName(to)
+	for _, name := range unionKeys(fromVars, toVars) {
+		before, after := fromVars[name], toVars[name]
+		change := base
+		change.Entity = entity
+		change.Path = base.Path + "." + name
+		change.Name = name
+		switch {
+		case before == nil:
+			change.Kind = ChangeAdded
+			change.After = TypeRefString(&after.TypeRef)
+		case after == nil:
+			change.Kind = ChangeRemoved
+			change.Before = TypeRefString(&before.TypeRef)
+		default:
+			var ok bool
+			if change, ok = diffValue(TypeRefString(&before.TypeRef), TypeRefString(&after.TypeRef), change); !ok {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+func diffAnnotations(from tags.DocTags, to tags.DocTags, base Change) (changes []Change) {
+
+	for _, key := range unionKeys(from, to) {
+		before, inBefore := from[key]
+		after, inAfter := to[key]
+		change := withKind(base, "", EntityAnnotation)
+		change.Path = base.Path + "@" + key
+		change.Name = key
+		change.Before, change.After = before, after
+		switch {
+		case !inBefore:
+			change.Kind = ChangeAdded
+		case !inAfter:
+			change.Kind = ChangeRemoved
+		case before != after:
+			change.Kind = ChangeChanged
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+func diffRoutes(from map[string]string, to map[string]string, base Change) (changes []Change) {
+
+	for _, family := range unionKeys(from, to) {
+		change := withKind(base, "", EntityRoute)
+		change.Name = family
+		change.Before, change.After = from[family], to[family]
+		switch {
+		case change.Before == "":
+			change.Kind = ChangeAdded
+		case change.After == "":
+			change.Kind = ChangeRemoved
+		case change.Before != change.After:
+			change.Kind = ChangeChanged
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+func diffType(typeID string, from *model.Type, to *model.Type) (changes []Change) {
+
+	base := Change{Path: typeID, TypeID: typeID}
+	if change, ok := diffValue(typeShape(from), typeShape(to), withKind(base, "", EntityType)); ok {
+		changes = append(changes, change)
+	}
+
+	fromFields := fieldsByName(from.StructFields)
+	toFields := fieldsByName(to.StructFields)
+	for _, name := range unionKeys(fromFields, toFields) {
+		before, after := fromFields[name], toFields[name]
+		fieldBase := base
+		fieldBase.Path = typeID + "." + name
+		fieldBase.Name = name
+		switch {
+		case before == nil:
+			change := withKind(fieldBase, ChangeAdded, EntityField)
+			change.After = TypeRefString(&after.TypeRef)
+			changes = append(changes, change)
+		case after == nil:
+			change := withKind(fieldBase, ChangeRemoved, EntityField)
+			change.Before = TypeRefString(&before.TypeRef)
+			changes = append(changes, change)
+		default:
+			if change, ok := diffValue(TypeRefString(&before.TypeRef), TypeRefString(&after.TypeRef), withKind(fieldBase, "", EntityField)); ok {
+				changes = append(changes, change)
+			}
+			for _, tagName := range unionKeys(before.Tags, after.Tags) {
+				tagChange := withKind(fieldBase, "", EntityFieldTag)
+				tagChange.Path = fieldBase.Path + "#" + tagName
+				tagChange.Name = tagName
+				if change, ok := diffValue(strings.Join(before.Tags[tagName], ","), strings.Join(after.Tags[tagName], ","), tagChange); ok {
+					changes = append(changes, change)
+				}
+			}
+			changes = append(changes, diffAnnotations(before.Annotations, after.Annotations, fieldBase)...)
+		}
+	}
+
+	fromEnums := enumsByValue(from.Enums)
+	toEnums := enumsByValue(to.Enums)
+	for _, value := range unionKeys(fromEnums, toEnums) {
+		change := withKind(base, "", EntityEnum)
+		change.Path = typeID + "=" + value
+		change.Name = value
+		_, inBefore := fromEnums[value]
+		_, inAfter := toEnums[value]
+		switch {
+		case !inBefore:
+			change.Kind = ChangeAdded
+		case !inAfter:
+			change.Kind = ChangeRemoved
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+// diffValue возвращает изменение с заполненными Before/After: added/removed для пустых значений, changed для различающихся.
+func diffValue(before string, after string, change Change) (result Change, ok bool) {
+
+	if before == after {
+		return
+	}
+	change.Before, change.After = before, after
+	switch {
+	case before == "":
+		change.Kind = ChangeAdded
+	case after == "":
+		change.Kind = ChangeRemoved
+	default:
+		change.Kind = ChangeChanged
+	}
+	return change, true
+}
+
+func withKind(base Change, kind ChangeKind, entity Entity) (change Change) {
+
+	change = base
+	change.Kind = kind
+	change.Entity = entity
+	return
+}
+
+// methodRoutes — маршруты метода по семействам транспорта.
+func methodRoutes(project *model.Project, contract *model.Contract, method *model.Method) (routes map[string]string) {
+
+	routes = make(map[string]string)
+	if model.MethodIsHTTP(project, contract, method) {
+		routes[FamilyHTTP] = strings.ToUpper(model.GetHTTPMethod(project, contract, method)) + " " + model.MethodHTTPFullPath(project, contract, method)
+	}
+	if model.MethodIsJSONRPC(project, contract, method) {
+		routes[FamilyJSONRPC] = model.JsonRPCWireMethod(contract.Name, method.Name)
+	}
+	if model.MethodIsWS(project, contract, method) {
+		routes[FamilyWS] = model.ContractWSPath(project, contract) + " " + model.MethodStreamMode(project, contract, method)
+	}
+	if model.MethodIsSSE(project, contract, method) {
+		routes[FamilySSE] = model.MethodSSEPath(project, contract, method)
+	}
+	return
+}
+
+func methodTopic(project *model.Project, contract *model.Contract, method *model.Method) (topic string) {
+
+	if !model.ContractIsKafka(project, contract) {
+		return
+	}
+	return model.MethodKafkaTopic(project, contract, method)
+}
+
+// typeShape — краткое описание вида типа для сравнения (kind, базовый тип, элемент коллекции).
+func typeShape(typ *model.Type) (shape string) {
+
+	parts := []string{string(typ.Kind)}
+	switch {
+	case typ.AliasOf != "":
+		parts = append(parts, "= "+typ.AliasOf)
+	case typ.UnderlyingTypeID != "":
+		parts = append(parts, typ.UnderlyingTypeID)
+	case typ.ArrayOfID != "":
+		parts = append(parts, TypeRefString(&model.TypeRef{TypeID: typ.ArrayOfID, IsSlice: typ.IsSlice, ArrayLen: typ.ArrayLen, ElementPointers: typ.ElementPointers}))
+	case typ.MapKey != nil && typ.MapValue != nil:
+		parts = append(parts, "map["+TypeRefString(typ.MapKey)+"]"+TypeRefString(typ.MapValue))
+	}
+	return strings.Join(parts, " ")
+}
+
+// TypeRefString форматирует ссылку на тип в Go-подобном виде: *[]pkg/path:Name, map[K]V, chan T.
+func TypeRefString(ref *model.TypeRef) (s string) {
+
+	if ref == nil {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("*", ref.NumberOfPointers))
+	switch {
+	case ref.IsEllipsis:
+		sb.WriteString("...")
+	case ref.IsSlice:
+		sb.WriteString("[]")
+	case ref.ArrayLen > 0:
+		fmt.Fprintf(&sb, "[%d]", ref.ArrayLen)
+	}
+	sb.WriteString(strings.Repeat("*", ref.ElementPointers))
+	switch {
+	case ref.MapKey != nil && ref.MapValue != nil:
+		sb.WriteString("map[" + TypeRefString(ref.MapKey) + "]" + TypeRefString(ref.MapValue))
+	case ref.ChanOf != nil:
+		sb.WriteString("chan " + TypeRefString(ref.ChanOf))
+	default:
+		sb.WriteString(ref.TypeID)
+	}
+	return sb.String()
+}
+
+func contractsByName(project *model.Project) (contracts map[string]*model.Contract) {
+
+	contracts = make(map[string]*model.Contract, len(project.Contracts))
+	for _, contract := range project.Contracts {
+		contracts[contract.Name] = contract
+	}
+	return
+}
+
+func methodsByName(contract *model.Contract) (methods map[string]*model.Method) {
+
+	methods = make(map[string]*model.Method, len(contract.Methods))
+	for _, method := range contract.Methods {
+		methods[method.Name] = method
+	}
+	return
+}
+
+func variablesByName(vars []*model.Variable) (byName map[string]*model.Variable) {
+
+	byName = make(map[string]*model.Variable, len(vars))
+	for idx, variable := range vars {
+		name := variable.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", idx+1)
+		}
+		byName[name] = variable
+	}
+	return
+}
+
+func fieldsByName(fields []*model.StructField) (byName map[string]*model.StructField) {
+
+	byName = make(map[string]*model.StructField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+	return
+}
+
+func enumsByValue(enums []*model.EnumValue) (byValue map[string]*model.EnumValue) {
+
+	byValue = make(map[string]*model.EnumValue, len(enums))
+	for _, enum := range enums {
+		byValue[enum.Value] = enum
+	}
+	return
+}
+
+func unionKeys[V any](left map[string]V, right map[string]V) (keys []string) {
+
+	seen := make(map[string]struct{}, len(left)+len(right))
+	for key := range left {
+		seen[key] = struct{}{}
+	}
+	for key := range right {
+		seen[key] = struct{}{}
+	}
+	keys = make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
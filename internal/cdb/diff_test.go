@@ -0,0 +1,139 @@
+package cdb
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func diffFixture(mutate func(project *model.Project)) (project *model.Project) {
+
+	project = &model.Project{
+		ModulePath: "example.com/app",
+		Contracts: []*model.Contract{
+			{
+				Name:        "Users",
+				Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagServerJsonRPC: "", model.TagHttpPrefix: "api/v1"},
+				Methods: []*model.Method{
+					{
+						Name:        "Get",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/users/:id"},
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+							{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}},
+						},
+						Results: []*model.Variable{
+							{Name: "user", TypeRef: model.TypeRef{TypeID: "example.com/app/dto:User", NumberOfPointers: 1}},
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+					},
+					{Name: "Delete"},
+				},
+			},
+			{
+				Name:        "Events",
+				Annotations: tags.DocTags{model.TagKafka: ""},
+				Methods:     []*model.Method{{Name: "Created", Annotations: tags.DocTags{model.TagKafkaTopic: "users.created"}}},
+			},
+		},
+		Types: map[string]*model.Type{
+			"example.com/app/dto:User": {
+				Kind: model.TypeKindStruct,
+				StructFields: []*model.StructField{
+					{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"name"}}},
+					{Name: "Age", TypeRef: model.TypeRef{TypeID: "int"}},
+				},
+			},
+			"example.com/app/dto:Role": {
+				Kind:             model.TypeKindString,
+				UnderlyingTypeID: "string",
+				Enums:            []*model.EnumValue{{Name: "RoleAdmin", Value: "admin"}, {Name: "RoleUser", Value: "user"}},
+			},
+		},
+	}
+	if mutate != nil {
+		mutate(project)
+	}
+	return
+}
+
+func TestDiffProjects(t *testing.T) {
+
+	t.Parallel()
+
+	from := diffFixture(nil)
+	to := diffFixture(func(project *model.Project) {
+		users := project.Contracts[0]
+		users.Methods = users.Methods[:1]
+		get := users.Methods[0]
+		get.Annotations[model.TagHttpPath] = "/users/:userId"
+		get.Args[1].TypeID = "int64"
+		get.Args = append(get.Args, &model.Variable{Name: "expand", TypeRef: model.TypeRef{TypeID: "bool"}})
+		project.Contracts[1].Methods[0].Annotations[model.TagKafkaTopic] = "users.created.v2"
+		project.Contracts = append(project.Contracts, &model.Contract{Name: "Orders"})
+
+		user := project.Types["example.com/app/dto:User"]
+		user.StructFields = []*model.StructField{
+			{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"fullName"}}},
+			{Name: "Email", TypeRef: model.TypeRef{TypeID: "string", NumberOfPointers: 1}},
+		}
+		project.Types["example.com/app/dto:Role"].Enums = []*model.EnumValue{{Name: "RoleAdmin", Value: "admin"}, {Name: "RoleGuest", Value: "guest"}}
+	})
+
+	got := make([]string, 0)
+	for _, change := range DiffProjects(from, to) {
+		got = append(got, strings.Join([]string{string(change.Kind), string(change.Entity), change.Path, change.Name, change.Before, change.After}, "|"))
+	}
+	want := []string{
+		"added|contract|Orders|||",
+		"changed|kafka-topic|Events.Created||users.created|users.created.v2",
+		"changed|annotation|Events.Created@kafka-topic|kafka-topic|users.created|users.created.v2",
+		"removed|method|Users.Delete|||",
+		"added|argument|Users.Get.expand|expand||bool",
+		"changed|argument|Users.Get.id|id|string|int64",
+		"changed|annotation|Users.Get@http-path|http-path|/users/:id|/users/:userId",
+		"changed|route|Users.Get|http|GET /api/v1/users/:id|GET /api/v1/users/:userId",
+		"removed|enum|example.com/app/dto:Role=user|user||",
+		"added|enum|example.com/app/dto:Role=guest|guest||",
+		"removed|field|example.com/app/dto:User.Age|Age|int|",
+		"added|field|example.com/app/dto:User.Email|Email||*string",
+		"changed|field-tag|example.com/app/dto:User.Name#json|json|name|fullName",
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDiffProjects_noChanges(t *testing.T) {
+
+	t.Parallel()
+
+	if changes := DiffProjects(diffFixture(nil), diffFixture(nil)); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestTypeRefString(t *testing.T) {
+
+	t.Parallel()
+
+	cases := []struct {
+		ref  model.TypeRef
+		want string
+	}{
+		{ref: model.TypeRef{TypeID: "string"}, want: "string"},
+		{ref: model.TypeRef{TypeID: "example/dto:User", IsSlice: true, ElementPointers: 1}, want: "[]*example/dto:User"},
+		{ref: model.TypeRef{NumberOfPointers: 1, MapKey: &model.TypeRef{TypeID: "string"}, MapValue: &model.TypeRef{TypeID: "int"}}, want: "*map[string]int"},
+		{ref: model.TypeRef{TypeID: "byte", ArrayLen: 16}, want: "[16]byte"},
+	}
+	for _, tc := range cases {
+		if got := TypeRefString(&tc.ref); got != tc.want {
+			t.Fatalf("TypeRefString(%+v)=%q, want %q", tc.ref, got, tc.want)
+		}
+	}
+}
@@ -82,3 +82,27 @@ func WriteProject(root string, relPath string, project *model.Project) (err erro
 	}
 	return
 }
+
+// LoadRef загружает проект по ссылке project[:contracts][@version] и оставляет только перечисленные в ней контракты.
+func LoadRef(root string, ref string) (project *model.Project, err error) {
+
+	var parsed Ref
+	if parsed, err = ParseRef(ref); err != nil {
+		return nil, err
+	}
+
+	var idx *Index
+	if idx, err = LoadIndex(root); err != nil {
+		return nil, err
+	}
+
+	var projectFile string
+	if _, projectFile, err = ResolveRef(idx, parsed); err != nil {
+		return nil, err
+	}
+
+	if project, err = ReadProject(root, projectFile); err != nil {
+		return nil, err
+	}
+	return FilterProject(project, parsed.Contracts), nil
+}
@@ -14,6 +14,8 @@ const (
 	CodecCBOR    = "cbor"
 	CodecYAML    = "yaml"
 	CodecXML     = "xml"
+	CodecAvro    = "avro"
+	CodecProto   = "protobuf"
 )
 
 // BuiltinCodecNames возвращает имена встроенных кодеков.
@@ -26,6 +28,8 @@ func BuiltinCodecNames() (names []string) {
 		CodecCBOR,
 		CodecYAML,
 		CodecXML,
+		CodecAvro,
+		CodecProto,
 	}
 }
 
@@ -0,0 +1,563 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"tgp/internal/model"
+)
+
+// MessageSchema — схема тела Kafka-сообщения для schema registry.
+type MessageSchema struct {
+	Name   string // полное имя записи (namespace.Name), используется RecordNameStrategy
+	Schema string // текст схемы: Avro JSON или proto3
+}
+
+// IsSchemaCodec сообщает, требует ли кодек schema registry.
+func IsSchemaCodec(name string) (ok bool) {
+
+	return name == CodecAvro || name == CodecProto
+}
+
+// SchemaFor строит схему сообщения для кодека avro или protobuf; для пакетного сообщения — схему элемента.
+func SchemaFor(codec string, project *model.Project, typeRef model.TypeRef) (schema MessageSchema, err error) {
+
+	switch codec {
+	case CodecAvro:
+		return AvroSchema(project, typeRef)
+	case CodecProto:
+		return ProtobufSchema(project, typeRef)
+	default:
+		return MessageSchema{}, fmt.Errorf("kafka codec %q has no schema", codec)
+	}
+}
+
+// AvroSchema строит Avro-схему записи по типу сообщения.
+func AvroSchema(project *model.Project, typeRef model.TypeRef) (schema MessageSchema, err error) {
+
+	var root *schemaRecord
+	if root, err = buildSchemaRoot(project, typeRef); err != nil {
+		return MessageSchema{}, err
+	}
+	emitted := make(map[string]struct{})
+	var value any
+	if value, err = avroRecordValue(root, emitted); err != nil {
+		return MessageSchema{}, err
+	}
+	var data []byte
+	if data, err = json.Marshal(value); err != nil {
+		return MessageSchema{}, fmt.Errorf("marshal avro schema %s: %w", root.fullName(), err)
+	}
+	return MessageSchema{Name: root.fullName(), Schema: string(data)}, nil
+}
+
+// ProtobufSchema строит proto3-схему: первое сообщение файла — тип сообщения Kafka.
+func ProtobufSchema(project *model.Project, typeRef model.TypeRef) (schema MessageSchema, err error) {
+
+	var root *schemaRecord
+	if root, err = buildSchemaRoot(project, typeRef); err != nil {
+		return MessageSchema{}, err
+	}
+	var builder strings.Builder
+	builder.WriteString("syntax = \"proto3\";\n")
+	if root.namespace != "" {
+		builder.WriteString("\npackage " + root.namespace + ";\n")
+	}
+	emitted := make(map[string]struct{})
+	queue := []*schemaRecord{root}
+	for len(queue) != 0 {
+		record := queue[0]
+		queue = queue[1:]
+		if _, ok := emitted[record.name]; ok {
+			continue
+		}
+		emitted[record.name] = struct{}{}
+		builder.WriteString("\nmessage " + record.name + " {\n")
+		numbers := make(map[int]string)
+		for _, field := range record.fields {
+			if err = protobufFieldNumber(field, numbers); err != nil {
+				return MessageSchema{}, fmt.Errorf("protobuf schema %s.%s: %w", record.name, field.name, err)
+			}
+			var declaration string
+			if declaration, err = protobufField(field, &queue); err != nil {
+				return MessageSchema{}, fmt.Errorf("protobuf schema %s.%s: %w", record.name, field.name, err)
+			}
+			builder.WriteString(fmt.Sprintf("  %s = %d;\n", declaration, field.number))
+		}
+		builder.WriteString("}\n")
+	}
+	return MessageSchema{Name: root.fullName(), Schema: builder.String()}, nil
+}
+
+type schemaKind string
+
+const (
+	schemaString    schemaKind = "string"
+	schemaBool      schemaKind = "bool"
+	schemaInt32     schemaKind = "int32"
+	schemaInt64     schemaKind = "int64"
+	schemaUint16    schemaKind = "uint16"
+	schemaUint32    schemaKind = "uint32"
+	schemaUint64    schemaKind = "uint64"
+	schemaFloat     schemaKind = "float"
+	schemaDouble    schemaKind = "double"
+	schemaBytes     schemaKind = "bytes"
+	schemaTimestamp schemaKind = "timestamp"
+	schemaArray     schemaKind = "array"
+	schemaMap       schemaKind = "map"
+	schemaRecordRef schemaKind = "record"
+)
+
+type schemaNode struct {
+	kind     schemaKind
+	optional bool
+	items    *schemaNode
+	key      *schemaNode
+	values   *schemaNode
+	record   *schemaRecord
+}
+
+type schemaRecord struct {
+	typeID    string
+	name      string
+	namespace string
+	fields    []schemaField
+}
+
+type schemaField struct {
+	name   string
+	number int // номер поля protobuf из тега protobuf:"N"; 0 — не задан
+	node   *schemaNode
+}
+
+func (record *schemaRecord) fullName() (name string) {
+
+	if record.namespace == "" {
+		return record.name
+	}
+	return record.namespace + "." + record.name
+}
+
+var schemaIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// protobufMaxFieldNumber — наибольший номер поля protobuf (2^29 - 1).
+const protobufMaxFieldNumber = 1<<29 - 1
+
+type schemaBuilder struct {
+	project *model.Project
+	records map[string]*schemaRecord
+	names   map[string]string
+}
+
+func buildSchemaRoot(project *model.Project, typeRef model.TypeRef) (root *schemaRecord, err error) {
+
+	builder := &schemaBuilder{project: project, records: make(map[string]*schemaRecord), names: make(map[string]string)}
+	typeRef, _ = model.TypeRefKafkaMessageElement(&typeRef)
+	typeRef.NumberOfPointers, typeRef.ElementPointers = 0, 0
+	var node *schemaNode
+	if node, err = builder.node(typeRef); err != nil {
+		return nil, err
+	}
+	if node.kind != schemaRecordRef {
+		return nil, fmt.Errorf("kafka schema message must be a named struct, got %q", typeRef.TypeID)
+	}
+	return node.record, nil
+}
+
+func (b *schemaBuilder) node(typeRef model.TypeRef) (node *schemaNode, err error) {
+
+	optional := typeRef.NumberOfPointers > 0
+	if model.TypeRefIsByteSlice(&model.TypeRef{TypeID: typeRef.TypeID, IsSlice: typeRef.IsSlice, ElementPointers: typeRef.ElementPointers}) {
+		return &schemaNode{kind: schemaBytes, optional: optional}, nil
+	}
+	if typeRef.ArrayLen > 0 {
+		return nil, fmt.Errorf("kafka schema: fixed-size arrays are not supported")
+	}
+	if typeRef.IsSlice || typeRef.IsEllipsis {
+		var items *schemaNode
+		if items, err = b.node(model.TypeRef{TypeID: typeRef.TypeID, NumberOfPointers: typeRef.ElementPointers}); err != nil {
+			return nil, err
+		}
+		return &schemaNode{kind: schemaArray, optional: optional, items: items}, nil
+	}
+	if typeRef.MapKey != nil && typeRef.MapValue != nil {
+		return b.mapNode(*typeRef.MapKey, *typeRef.MapValue, optional)
+	}
+	if typeRef.TypeID == "time:Time" {
+		return &schemaNode{kind: schemaTimestamp, optional: optional}, nil
+	}
+	if kind, ok := scalarSchemaKind(typeRef.TypeID); ok {
+		return &schemaNode{kind: kind, optional: optional}, nil
+	}
+	typ, found := b.project.Types[typeRef.TypeID]
+	if !found {
+		return nil, fmt.Errorf("kafka schema: type %q is not supported", typeRef.TypeID)
+	}
+	switch typ.Kind {
+	case model.TypeKindStruct:
+		var record *schemaRecord
+		if record, err = b.record(typeRef.TypeID, typ); err != nil {
+			return nil, err
+		}
+		return &schemaNode{kind: schemaRecordRef, optional: optional, record: record}, nil
+	case model.TypeKindAlias:
+		node, err = b.node(model.TypeRef{TypeID: typ.AliasOf})
+	case model.TypeKindArray:
+		if typ.ArrayLen > 0 {
+			return nil, fmt.Errorf("kafka schema: fixed-size array type %q is not supported", typeRef.TypeID)
+		}
+		if typ.ArrayOfID == "byte" || typ.ArrayOfID == "uint8" {
+			node = &schemaNode{kind: schemaBytes}
+			break
+		}
+		var items *schemaNode
+		if items, err = b.node(model.TypeRef{TypeID: typ.ArrayOfID, NumberOfPointers: typ.ElementPointers}); err == nil {
+			node = &schemaNode{kind: schemaArray, items: items}
+		}
+	case model.TypeKindMap:
+		if typ.MapKey == nil || typ.MapValue == nil {
+			return nil, fmt.Errorf("kafka schema: map type %q has no key or value", typeRef.TypeID)
+		}
+		node, err = b.mapNode(*typ.MapKey, *typ.MapValue, false)
+	default:
+		kind, ok := scalarSchemaKind(string(typ.Kind))
+		if !ok {
+			return nil, fmt.Errorf("kafka schema: type %q of kind %q is not supported", typeRef.TypeID, typ.Kind)
+		}
+		node = &schemaNode{kind: kind}
+	}
+	if err != nil {
+		return nil, err
+	}
+	node.optional = node.optional || optional
+	return node, nil
+}
+
+func (b *schemaBuilder) mapNode(keyRef model.TypeRef, valueRef model.TypeRef, optional bool) (node *schemaNode, err error) {
+
+	var key, values *schemaNode
+	if key, err = b.node(keyRef); err != nil {
+		return nil, err
+	}
+	if values, err = b.node(valueRef); err != nil {
+		return nil, err
+	}
+	return &schemaNode{kind: schemaMap, optional: optional, key: key, values: values}, nil
+}
+
+func (b *schemaBuilder) record(typeID string, typ *model.Type) (record *schemaRecord, err error) {
+
+	if record = b.records[typeID]; record != nil {
+		return record, nil
+	}
+	name := typ.TypeName
+	if name == "" {
+		name = typeID[strings.LastIndex(typeID, ":")+1:]
+	}
+	if !schemaIdentifier.MatchString(name) {
+		return nil, fmt.Errorf("kafka schema: type name %q is not a valid schema identifier", name)
+	}
+	if owner, exists := b.names[name]; exists && owner != typeID {
+		return nil, fmt.Errorf("kafka schema: types %q and %q share the name %s", owner, typeID, name)
+	}
+	b.names[name] = typeID
+	record = &schemaRecord{typeID: typeID, name: name, namespace: schemaNamespace(typ.ImportPkgPath)}
+	b.records[typeID] = record
+	if err = b.appendFields(record, typ.StructFields, map[string]struct{}{typeID: {}}); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// appendFields добавляет поля структуры в запись. Встроенная структура без json-имени разворачивается,
+// как в encoding/json; встроенные не-структуры и встроенные структуры с json-именем не поддерживаются.
+func (b *schemaBuilder) appendFields(record *schemaRecord, fields []*model.StructField, embedding map[string]struct{}) (err error) {
+
+	for _, field := range fields {
+		if field.Embedded {
+			var embedded *model.Type
+			if embedded, err = b.embeddedStruct(record, field); err != nil {
+				return err
+			}
+			if _, cycle := embedding[field.TypeID]; cycle || embedded == nil {
+				continue
+			}
+			embedding[field.TypeID] = struct{}{}
+			err = b.appendFields(record, embedded.StructFields, embedding)
+			delete(embedding, field.TypeID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		fieldName := schemaFieldName(field)
+		if fieldName == "" {
+			continue
+		}
+		if !schemaIdentifier.MatchString(fieldName) {
+			return fmt.Errorf("kafka schema: field %s.%s name %q is not a valid schema identifier", record.name, field.Name, fieldName)
+		}
+		for _, existing := range record.fields {
+			if existing.name == fieldName {
+				return fmt.Errorf("kafka schema: %s has duplicate field %q", record.name, fieldName)
+			}
+		}
+		var node *schemaNode
+		if node, err = b.node(field.TypeRef); err != nil {
+			return fmt.Errorf("field %s.%s: %w", record.name, field.Name, err)
+		}
+		record.fields = append(record.fields, schemaField{name: fieldName, number: schemaFieldNumber(field), node: node})
+	}
+	return nil
+}
+
+// embeddedStruct возвращает тип встроенной структуры для разворачивания; nil — поле исключено тегом json:"-".
+func (b *schemaBuilder) embeddedStruct(record *schemaRecord, field *model.StructField) (embedded *model.Type, err error) {
+
+	if tag, ok := field.Tags["json"]; ok && len(tag) > 0 {
+		switch name := strings.TrimSpace(tag[0]); name {
+		case "-":
+			return nil, nil
+		case "":
+		default:
+			return nil, fmt.Errorf("kafka schema: embedded field %s.%s with json name %q is not supported", record.name, field.Name, name)
+		}
+	}
+	typ, found := b.project.Types[field.TypeID]
+	if !found || typ.Kind != model.TypeKindStruct || field.IsSlice || field.MapKey != nil {
+		return nil, fmt.Errorf("kafka schema: embedded field %s.%s must be a struct", record.name, field.Name)
+	}
+	return typ, nil
+}
+
+// schemaFieldNumber возвращает номер поля protobuf: первое число тега protobuf ("3" или "varint,3,opt,...").
+func schemaFieldNumber(field *model.StructField) (number int) {
+
+	for _, part := range field.Tags["protobuf"] {
+		if value, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			return value
+		}
+	}
+	return 0
+}
+
+// schemaFieldName повторяет правила encoding/json: json-тег, иначе имя поля; неэкспортируемые и "-" пропускаются.
+// Встроенные поля разворачивает appendFields.
+func schemaFieldName(field *model.StructField) (name string) {
+
+	if field.Name == "" || strings.ToLower(field.Name[:1]) == field.Name[:1] {
+		return ""
+	}
+	if tag, ok := field.Tags["json"]; ok && len(tag) > 0 {
+		name = strings.TrimSpace(tag[0])
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func schemaNamespace(pkgPath string) (namespace string) {
+
+	parts := strings.FieldsFunc(pkgPath, func(r rune) bool { return r == '/' || r == '.' })
+	for index, part := range parts {
+		part = strings.Map(func(r rune) rune {
+			if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return '_'
+		}, part)
+		if part[0] >= '0' && part[0] <= '9' {
+			part = "_" + part
+		}
+		parts[index] = part
+	}
+	return strings.Join(parts, ".")
+}
+
+func scalarSchemaKind(typeID string) (kind schemaKind, ok bool) {
+
+	switch typeID {
+	case "string":
+		return schemaString, true
+	case "bool":
+		return schemaBool, true
+	case "int8", "int16", "int32", "rune":
+		return schemaInt32, true
+	case "int", "int64":
+		return schemaInt64, true
+	case "uint8", "byte", "uint16":
+		return schemaUint16, true
+	case "uint32":
+		return schemaUint32, true
+	case "uint", "uint64":
+		return schemaUint64, true
+	case "float32":
+		return schemaFloat, true
+	case "float64":
+		return schemaDouble, true
+	}
+	return "", false
+}
+
+type avroRecordSchema struct {
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Fields    []avroFieldSchema `json:"fields"`
+}
+
+type avroFieldSchema struct {
+	Name    string          `json:"name"`
+	Type    any             `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+func avroRecordValue(record *schemaRecord, emitted map[string]struct{}) (value any, err error) {
+
+	if _, ok := emitted[record.fullName()]; ok {
+		return record.fullName(), nil
+	}
+	emitted[record.fullName()] = struct{}{}
+	result := avroRecordSchema{Type: "record", Name: record.name, Namespace: record.namespace, Fields: make([]avroFieldSchema, 0, len(record.fields))}
+	for _, field := range record.fields {
+		var fieldType any
+		if fieldType, err = avroNodeValue(field.node, emitted); err != nil {
+			return nil, fmt.Errorf("avro schema %s.%s: %w", record.name, field.name, err)
+		}
+		schemaField := avroFieldSchema{Name: field.name, Type: fieldType}
+		if field.node.optional {
+			schemaField.Type = []any{"null", fieldType}
+			schemaField.Default = json.RawMessage("null")
+		}
+		result.Fields = append(result.Fields, schemaField)
+	}
+	return result, nil
+}
+
+func avroNodeValue(node *schemaNode, emitted map[string]struct{}) (value any, err error) {
+
+	switch node.kind {
+	case schemaString, schemaBool, schemaFloat, schemaDouble, schemaBytes:
+		return string(node.kind), nil
+	case schemaInt32, schemaUint16:
+		return "int", nil
+	case schemaInt64, schemaUint32:
+		return "long", nil
+	case schemaUint64:
+		return nil, fmt.Errorf("unsigned 64-bit integers are not supported by avro")
+	case schemaTimestamp:
+		return map[string]string{"type": "long", "logicalType": "timestamp-millis"}, nil
+	case schemaArray:
+		var items any
+		if items, err = avroItemValue(node.items, emitted); err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case schemaMap:
+		if node.key.kind != schemaString {
+			return nil, fmt.Errorf("avro map keys must be strings, got %s", node.key.kind)
+		}
+		var values any
+		if values, err = avroItemValue(node.values, emitted); err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "map", "values": values}, nil
+	case schemaRecordRef:
+		return avroRecordValue(node.record, emitted)
+	}
+	return nil, fmt.Errorf("schema kind %q is not supported by avro", node.kind)
+}
+
+func avroItemValue(node *schemaNode, emitted map[string]struct{}) (value any, err error) {
+
+	if value, err = avroNodeValue(node, emitted); err != nil || !node.optional {
+		return value, err
+	}
+	return []any{"null", value}, nil
+}
+
+// protobufFieldNumber проверяет явный номер поля: задан, в допустимом диапазоне и не повторяется в сообщении.
+func protobufFieldNumber(field schemaField, numbers map[int]string) (err error) {
+
+	switch {
+	case field.number == 0:
+		return fmt.Errorf("field number is required: add a protobuf:\"N\" tag")
+	case field.number < 1 || field.number > protobufMaxFieldNumber:
+		return fmt.Errorf("field number %d is out of range 1..%d", field.number, protobufMaxFieldNumber)
+	case field.number >= 19000 && field.number <= 19999:
+		return fmt.Errorf("field number %d is reserved by protobuf", field.number)
+	}
+	if owner, exists := numbers[field.number]; exists {
+		return fmt.Errorf("field number %d is already used by %s", field.number, owner)
+	}
+	numbers[field.number] = field.name
+	return nil
+}
+
+func protobufField(field schemaField, queue *[]*schemaRecord) (declaration string, err error) {
+
+	node := field.node
+	switch node.kind {
+	case schemaArray:
+		if node.items.kind == schemaArray || node.items.kind == schemaMap {
+			return "", fmt.Errorf("nested repeated fields are not supported by protobuf")
+		}
+		var items string
+		if items, err = protobufType(node.items, queue); err != nil {
+			return "", err
+		}
+		return "repeated " + items + " " + field.name, nil
+	case schemaMap:
+		switch node.key.kind {
+		case schemaString, schemaBool, schemaInt32, schemaInt64, schemaUint16, schemaUint32, schemaUint64:
+		default:
+			return "", fmt.Errorf("protobuf map keys must be strings, integers or bools, got %s", node.key.kind)
+		}
+		if node.values.kind == schemaArray || node.values.kind == schemaMap {
+			return "", fmt.Errorf("protobuf map values cannot be repeated or maps")
+		}
+		var values string
+		if values, err = protobufType(node.values, queue); err != nil {
+			return "", err
+		}
+		var key string
+		if key, err = protobufType(node.key, queue); err != nil {
+			return "", err
+		}
+		return "map<" + key + ", " + values + "> " + field.name, nil
+	}
+	var typeName string
+	if typeName, err = protobufType(node, queue); err != nil {
+		return "", err
+	}
+	if node.optional && node.kind != schemaRecordRef {
+		return "optional " + typeName + " " + field.name, nil
+	}
+	return typeName + " " + field.name, nil
+}
+
+func protobufType(node *schemaNode, queue *[]*schemaRecord) (typeName string, err error) {
+
+	switch node.kind {
+	case schemaString, schemaBool, schemaInt32, schemaInt64, schemaUint32, schemaUint64, schemaFloat, schemaDouble, schemaBytes:
+		return string(node.kind), nil
+	case schemaUint16:
+		return "uint32", nil
+	case schemaTimestamp:
+		return "int64", nil
+	case schemaRecordRef:
+		*queue = append(*queue, node.record)
+		return node.record.name, nil
+	}
+	return "", fmt.Errorf("schema kind %q is not supported by protobuf", node.kind)
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+
+package kafka
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+)
+
+func schemaProject() (project *model.Project) {
+
+	return &model.Project{Types: map[string]*model.Type{
+		"example.com/app/events:Order": {
+			Kind:          model.TypeKindStruct,
+			TypeName:      "Order",
+			ImportPkgPath: "example.com/app/events",
+			StructFields: []*model.StructField{
+				{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}, "protobuf": {"1"}}},
+				{Name: "Amount", TypeRef: model.TypeRef{TypeID: "int64"}, Tags: map[string][]string{"json": {"amount", "omitempty"}, "protobuf": {"2"}}},
+				{Name: "Note", TypeRef: model.TypeRef{TypeID: "string", NumberOfPointers: 1}, Tags: map[string][]string{"json": {"note"}, "protobuf": {"3"}}},
+				{Name: "CreatedAt", TypeRef: model.TypeRef{TypeID: "time:Time"}, Tags: map[string][]string{"json": {"createdAt"}, "protobuf": {"4"}}},
+				{Name: "Items", TypeRef: model.TypeRef{TypeID: "example.com/app/events:Item", IsSlice: true}, Tags: map[string][]string{"json": {"items"}, "protobuf": {"5"}}},
+				{Name: "Labels", TypeRef: model.TypeRef{MapKey: &model.TypeRef{TypeID: "string"}, MapValue: &model.TypeRef{TypeID: "string"}}, Tags: map[string][]string{"json": {"labels"}, "protobuf": {"6"}}},
+				{Name: "Payload", TypeRef: model.TypeRef{TypeID: "byte", IsSlice: true}, Tags: map[string][]string{"protobuf": {"bytes", "9", "opt"}}},
+				{Name: "Internal", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"-"}}},
+				{Name: "hidden", TypeRef: model.TypeRef{TypeID: "string"}},
+			},
+		},
+		"example.com/app/events:Item": {
+			Kind:          model.TypeKindStruct,
+			TypeName:      "Item",
+			ImportPkgPath: "example.com/app/events",
+			StructFields: []*model.StructField{
+				{Name: "SKU", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"sku"}, "protobuf": {"1"}}},
+				{Name: "Count", TypeRef: model.TypeRef{TypeID: "uint16"}, Tags: map[string][]string{"json": {"count"}, "protobuf": {"2"}}},
+			},
+		},
+		"example.com/app/events:Matrix": {
+			Kind:          model.TypeKindStruct,
+			TypeName:      "Matrix",
+			ImportPkgPath: "example.com/app/events",
+			StructFields: []*model.StructField{
+				{Name: "Rows", TypeRef: model.TypeRef{TypeID: "example.com/app/events:Row", IsSlice: true}, Tags: map[string][]string{"protobuf": {"1"}}},
+			},
+		},
+		"example.com/app/events:Row": {
+			Kind:      model.TypeKindArray,
+			TypeName:  "Row",
+			IsSlice:   true,
+			ArrayOfID: "int",
+		},
+	}}
+}
+
+func TestAvroSchema(t *testing.T) {
+
+	schema, err := AvroSchema(schemaProject(), model.TypeRef{TypeID: "example.com/app/events:Order"})
+	if err != nil {
+		t.Fatalf("AvroSchema() error = %v", err)
+	}
+	if schema.Name != "example.com.app.events.Order" {
+		t.Fatalf("schema name = %q", schema.Name)
+	}
+	var parsed struct {
+		Namespace string `json:"namespace"`
+		Fields    []struct {
+			Name    string          `json:"name"`
+			Type    json.RawMessage `json:"type"`
+			Default json.RawMessage `json:"default"`
+		} `json:"fields"`
+	}
+	if err = json.Unmarshal([]byte(schema.Schema), &parsed); err != nil {
+		t.Fatalf("schema is not JSON: %v\n%s", err, schema.Schema)
+	}
+	want := map[string]string{
+		"id":        `"string"`,
+		"amount":    `"long"`,
+		"note":      `["null","string"]`,
+		"createdAt": `{"logicalType":"timestamp-millis","type":"long"}`,
+		"items":     `{"items":{"type":"record","name":"Item","namespace":"example.com.app.events","fields":[{"name":"sku","type":"string"},{"name":"count","type":"int"}]},"type":"array"}`,
+		"labels":    `{"type":"map","values":"string"}`,
+		"Payload":   `"bytes"`,
+	}
+	if len(parsed.Fields) != len(want) {
+		t.Fatalf("fields = %d, want %d: %s", len(parsed.Fields), len(want), schema.Schema)
+	}
+	for _, field := range parsed.Fields {
+		if string(field.Type) != want[field.Name] {
+			t.Errorf("field %s type = %s, want %s", field.Name, field.Type, want[field.Name])
+		}
+		if field.Name == "note" && string(field.Default) != "null" {
+			t.Errorf("optional field default = %s, want null", field.Default)
+		}
+	}
+}
+
+func TestProtobufSchema(t *testing.T) {
+
+	schema, err := ProtobufSchema(schemaProject(), model.TypeRef{TypeID: "example.com/app/events:Order"})
+	if err != nil {
+		t.Fatalf("ProtobufSchema() error = %v", err)
+	}
+	for _, line := range []string{
+		"package example.com.app.events;",
+		"message Order {",
+		"  string id = 1;",
+		"  int64 amount = 2;",
+		"  optional string note = 3;",
+		"  int64 createdAt = 4;",
+		"  repeated Item items = 5;",
+		"  map<string, string> labels = 6;",
+		"  bytes Payload = 9;",
+		"message Item {",
+		"  uint32 count = 2;",
+	} {
+		if !strings.Contains(schema.Schema, line+"\n") {
+			t.Errorf("protobuf schema does not contain %q:\n%s", line, schema.Schema)
+		}
+	}
+	if strings.Index(schema.Schema, "message Order") > strings.Index(schema.Schema, "message Item") {
+		t.Fatalf("message type must come first:\n%s", schema.Schema)
+	}
+}
+
+func TestSchemaUnsupported(t *testing.T) {
+
+	project := schemaProject()
+	if _, err := ProtobufSchema(project, model.TypeRef{TypeID: "example.com/app/events:Matrix"}); err == nil || !strings.Contains(err.Error(), "nested repeated") {
+		t.Fatalf("ProtobufSchema(Matrix) error = %v, want nested repeated", err)
+	}
+	if _, err := AvroSchema(project, model.TypeRef{TypeID: "example.com/app/events:Matrix"}); err != nil {
+		t.Fatalf("AvroSchema(Matrix) error = %v", err)
+	}
+	if _, err := AvroSchema(project, model.TypeRef{TypeID: "string"}); err == nil {
+		t.Fatal("AvroSchema(string) must require a struct message")
+	}
+	project.Types["example.com/app/events:Item"].StructFields[1].TypeID = "uint64"
+	if _, err := AvroSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err == nil {
+		t.Fatal("AvroSchema must reject uint64")
+	}
+}
+
+func TestProtobufSchemaFieldNumbers(t *testing.T) {
+
+	project := schemaProject()
+	item := project.Types["example.com/app/events:Item"]
+	item.StructFields[1].Tags = map[string][]string{"json": {"count"}}
+	if _, err := ProtobufSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err == nil || !strings.Contains(err.Error(), "Item.count: field number is required") {
+		t.Fatalf("ProtobufSchema() without number error = %v", err)
+	}
+	item.StructFields[1].Tags = map[string][]string{"json": {"count"}, "protobuf": {"1"}}
+	if _, err := ProtobufSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err == nil || !strings.Contains(err.Error(), "field number 1 is already used by sku") {
+		t.Fatalf("ProtobufSchema() duplicate number error = %v", err)
+	}
+	item.StructFields[1].Tags = map[string][]string{"json": {"count"}, "protobuf": {"19500"}}
+	if _, err := ProtobufSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Fatalf("ProtobufSchema() reserved number error = %v", err)
+	}
+	if _, err := AvroSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err != nil {
+		t.Fatalf("AvroSchema() must not require protobuf numbers: %v", err)
+	}
+}
+
+func TestSchemaEmbeddedStruct(t *testing.T) {
+
+	project := schemaProject()
+	project.Types["example.com/app/events:Audit"] = &model.Type{
+		Kind:          model.TypeKindStruct,
+		TypeName:      "Audit",
+		ImportPkgPath: "example.com/app/events",
+		StructFields: []*model.StructField{
+			{Name: "Author", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"author"}, "protobuf": {"20"}}},
+		},
+	}
+	order := project.Types["example.com/app/events:Order"]
+	order.StructFields = append(order.StructFields, &model.StructField{Name: "Audit", Embedded: true, TypeRef: model.TypeRef{TypeID: "example.com/app/events:Audit", NumberOfPointers: 1}})
+	schema, err := ProtobufSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"})
+	if err != nil {
+		t.Fatalf("ProtobufSchema() error = %v", err)
+	}
+	if !strings.Contains(schema.Schema, "  string author = 20;\n") || strings.Contains(schema.Schema, "message Audit") {
+		t.Fatalf("embedded struct fields must be promoted:\n%s", schema.Schema)
+	}
+	order.StructFields[len(order.StructFields)-1].Tags = map[string][]string{"json": {"audit"}}
+	if _, err = AvroSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err == nil || !strings.Contains(err.Error(), "json name") {
+		t.Fatalf("AvroSchema() embedded with json name error = %v", err)
+	}
+	order.StructFields[len(order.StructFields)-1] = &model.StructField{Name: "Status", Embedded: true, TypeRef: model.TypeRef{TypeID: "string"}}
+	if _, err = AvroSchema(project, model.TypeRef{TypeID: "example.com/app/events:Order"}); err == nil || !strings.Contains(err.Error(), "must be a struct") {
+		t.Fatalf("AvroSchema() embedded non-struct error = %v", err)
+	}
+}
@@ -80,6 +80,42 @@ func WritePoll(outDir string, pkgName string) (err error) {
 	return writeTemplate(outDir, pkgName, "poll.go", nil)
 }
 
+// WriteDeadLetter записывает runtime повторов через retry-топики и DLQ.
+func WriteDeadLetter(outDir string, pkgName string) (err error) {
+
+	return writeTemplate(outDir, pkgName, "deadletter.go", nil)
+}
+
+// WriteSchemaRegistry записывает runtime клиента schema registry и wire format Confluent.
+func WriteSchemaRegistry(outDir string, pkgName string) (err error) {
+
+	return writeTemplate(outDir, pkgName, "registry.go", nil)
+}
+
+// WriteAvro записывает runtime кодека avro.
+func WriteAvro(outDir string, pkgName string) (err error) {
+
+	return writeTemplate(outDir, pkgName, "avro.go", nil)
+}
+
+// WriteProtobuf записывает runtime кодека protobuf.
+func WriteProtobuf(outDir string, pkgName string) (err error) {
+
+	return writeTemplate(outDir, pkgName, "protobuf.go", nil)
+}
+
+// WriteTransaction записывает runtime завершения транзакций Kafka.
+func WriteTransaction(outDir string, pkgName string) (err error) {
+
+	return writeTemplate(outDir, pkgName, "transaction.go", nil)
+}
+
+// WriteOutbox записывает runtime транзакционного outbox и его relay.
+func WriteOutbox(outDir string, pkgName string) (err error) {
+
+	return writeTemplate(outDir, pkgName, "outbox.go", nil)
+}
+
 // WriteSecurity записывает runtime построения SASL-механизма.
 func WriteSecurity(outDir string, pkgName string) (err error) {
 
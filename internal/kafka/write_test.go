@@ -36,13 +36,37 @@ func TestWriteRuntime(t *testing.T) {
 	if err := WriteSecurity(runtimeDir, "kafka"); err != nil {
 		t.Fatalf("WriteSecurity() error = %v", err)
 	}
+	if err := WriteDeadLetter(runtimeDir, "kafka"); err != nil {
+		t.Fatalf("WriteDeadLetter() error = %v", err)
+	}
+	if err := WriteSchemaRegistry(runtimeDir, "kafka"); err != nil {
+		t.Fatalf("WriteSchemaRegistry() error = %v", err)
+	}
+	if err := WriteAvro(runtimeDir, "kafka"); err != nil {
+		t.Fatalf("WriteAvro() error = %v", err)
+	}
+	if err := WriteProtobuf(runtimeDir, "kafka"); err != nil {
+		t.Fatalf("WriteProtobuf() error = %v", err)
+	}
+	if err := WriteTransaction(runtimeDir, "kafka"); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+	if err := WriteOutbox(runtimeDir, "kafka"); err != nil {
+		t.Fatalf("WriteOutbox() error = %v", err)
+	}
 
 	wantSymbols := map[string][]string{
-		"codec.go":    {"type codec interface", `codecs["msgpack"]`, `codecs["cbor"]`, `codecs["yaml"]`, `codecs["xml"]`},
-		"produce.go":  {"func produceAndWait", "func joinOutcomes"},
-		"record.go":   {"type Meta struct", "func HeaderValue", "AtStart"},
-		"poll.go":     {"func groupRecordsByTopic", "func sortedTopics", "func dispatchTopics", "type TopicHandler"},
-		"security.go": {"func saslMechanism", `case "PLAIN"`, `case "SCRAM-SHA-256"`, `case "SCRAM-SHA-512"`},
+		"codec.go":       {"type codec interface", `codecs["msgpack"]`, `codecs["cbor"]`, `codecs["yaml"]`, `codecs["xml"]`},
+		"produce.go":     {"func produceAndWait", "func joinOutcomes"},
+		"record.go":      {"type Meta struct", "func HeaderValue", "AtStart"},
+		"poll.go":        {"func groupRecordsByTopic", "func sortedTopics", "func dispatchTopics", "type TopicHandler", "func dispatchTopicsConcurrent"},
+		"security.go":    {"func saslMechanism", `case "PLAIN"`, `case "SCRAM-SHA-256"`, `case "SCRAM-SHA-512"`},
+		"deadletter.go":  {"type deadLetterPolicy struct", "func parkDestination", "func parkedRecord", "type retryHold struct"},
+		"registry.go":    {"type SchemaRegistry struct", "func NewSchemaRegistry", "func TopicNameStrategy", "func RecordNameStrategy", "func TopicRecordNameStrategy", "func frameSchemaID"},
+		"avro.go":        {"type avroCodec struct", "func newAvroCodec"},
+		"protobuf.go":    {"type protobufCodec struct", "func newProtobufCodec"},
+		"transaction.go": {"func endTransaction", "kgo.TryCommit", "kgo.TryAbort"},
+		"outbox.go":      {"type OutboxDialect struct", "OutboxPostgres", "OutboxMySQL", "OutboxSQLite", "func outboxInsert", "func relayOutbox", "func runOutboxRelay"},
 	}
 	for name, symbols := range wantSymbols {
 		content, err := os.ReadFile(filepath.Join(runtimeDir, name))
@@ -66,9 +90,13 @@ func TestWriteRuntime(t *testing.T) {
 	runtimeTest := `package kafka
 
 import (
+	"context"
 	"errors"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/twmb/franz-go/pkg/kgo"
 )
@@ -98,13 +126,161 @@ func TestGeneratedRuntimeBehavior(t *testing.T) {
 	if !errors.Is(joined, first) || !errors.Is(joined, second) {
 		t.Fatalf("joinOutcomes() = %v, want both errors", joined)
 	}
+	var mu sync.Mutex
+	seen := make(map[string][]int64)
+	concurrent := map[string][]*kgo.Record{"orders": {
+		{Topic: "orders", Partition: 0, Offset: 10, Key: []byte("a")},
+		{Topic: "orders", Partition: 0, Offset: 11, Key: []byte("b")},
+		{Topic: "orders", Partition: 0, Offset: 12, Key: []byte("a")},
+		{Topic: "orders", Partition: 1, Offset: 5, Key: []byte("c")},
+	}}
+	failOn := recordLane(&kgo.Record{Key: []byte("b")}, 4)
+	committable, err := dispatchTopicsConcurrent(t.Context(), concurrent, map[string]TopicHandler{"orders": func(ctx context.Context, records []*kgo.Record) (err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, record := range records {
+			if string(record.Key) == "b" {
+				return first
+			}
+			seen[string(record.Key)] = append(seen[string(record.Key)], record.Offset)
+		}
+		return nil
+	}}, 4)
+	if !errors.Is(err, first) {
+		t.Fatalf("dispatchTopicsConcurrent() error = %v, want first", err)
+	}
+	committed := make(map[int32]int64)
+	for _, record := range committable {
+		committed[record.Partition] = record.Offset
+	}
+	if recordLane(&kgo.Record{Key: []byte("a")}, 4) != failOn {
+		if !reflect.DeepEqual(seen["a"], []int64{10, 12}) {
+			t.Fatalf("key a processed out of order: %v", seen["a"])
+		}
+		if offset, ok := committed[0]; !ok || offset != 10 {
+			t.Fatalf("partition 0 committable = %d %v, want 10 before failed offset 11", offset, ok)
+		}
+	} else if _, ok := committed[0]; ok {
+		t.Fatalf("partition 0 must not be committable when offset 10 failed: %v", committed)
+	}
+	if recordLane(&kgo.Record{Key: []byte("c")}, 4) != failOn && committed[1] != 5 {
+		t.Fatalf("partition 1 committable = %v, want 5", committed)
+	}
+	policy := deadLetterPolicy{topic: "orders", retries: 2, dlq: "orders.dlq"}
+	original := &kgo.Record{Topic: "orders", Partition: 3, Offset: 42, Key: []byte("k"), Value: []byte("v"), Headers: []kgo.RecordHeader{{Key: "trace", Value: []byte("t")}}}
+	topic, kind, attempt, ok := parkDestination(policy, original)
+	if !ok || topic != "orders.retry.1" || kind != parkRetry || attempt != 1 {
+		t.Fatalf("parkDestination(original) = %q %q %d %v", topic, kind, attempt, ok)
+	}
+	retried := parkedRecord(original, topic, kind, attempt, time.Now(), first)
+	retried.Partition, retried.Offset = 0, 7
+	if topic, kind, attempt, ok = parkDestination(policy, retried); !ok || topic != "orders.retry.2" || attempt != 2 {
+		t.Fatalf("parkDestination(retry.1) = %q %q %d %v", topic, kind, attempt, ok)
+	}
+	retried = parkedRecord(retried, topic, kind, attempt, time.Now(), first)
+	if topic, kind, attempt, ok = parkDestination(policy, retried); !ok || topic != "orders.dlq" || kind != parkDLQ || attempt != 2 {
+		t.Fatalf("parkDestination(retry.2) = %q %q %d %v", topic, kind, attempt, ok)
+	}
+	dead := parkedRecord(retried, topic, kind, attempt, time.Time{}, second)
+	if string(dead.Key) != "k" || string(dead.Value) != "v" {
+		t.Fatalf("dead letter must keep key and value: %+v", dead)
+	}
+	for key, want := range map[string]string{"trace": "t", HeaderOriginalTopic: "orders", HeaderOriginalPartition: "3", HeaderOriginalOffset: "42", HeaderRetryAttempt: "2", HeaderError: "second"} {
+		if value, found := recordHeader(dead, key); !found || string(value) != want {
+			t.Fatalf("dead letter header %s = %q, want %q", key, value, want)
+		}
+	}
+	if _, found := recordHeader(dead, HeaderRetryNotBefore); found {
+		t.Fatal("dead letter must not carry retry delay")
+	}
+	if topics := retryTopics(map[string]deadLetterPolicy{"orders": policy}); !reflect.DeepEqual(topics, []string{"orders.retry.1", "orders.retry.2"}) {
+		t.Fatalf("retryTopics() = %v", topics)
+	}
+	if delay := retryDelay(time.Second, 3); delay != 4*time.Second {
+		t.Fatalf("retryDelay(1s, 3) = %v", delay)
+	}
+}
+
+type fakeRetryFetcher struct {
+	paused  map[string][]int32
+	resumed map[string][]int32
+	offsets map[string]map[int32]kgo.EpochOffset
+}
+
+func (fetcher *fakeRetryFetcher) PauseFetchPartitions(topicPartitions map[string][]int32) (paused map[string][]int32) {
+
+	fetcher.paused = topicPartitions
+	return topicPartitions
+}
+
+func (fetcher *fakeRetryFetcher) ResumeFetchPartitions(topicPartitions map[string][]int32) {
+
+	fetcher.resumed = topicPartitions
+}
+
+func (fetcher *fakeRetryFetcher) SetOffsets(setOffsets map[string]map[int32]kgo.EpochOffset) {
+
+	fetcher.offsets = setOffsets
+}
+
+func TestGeneratedRetryHold(t *testing.T) {
+
+	now := time.UnixMilli(1_000_000)
+	retry := func(partition int32, offset int64, due time.Time) (record *kgo.Record) {
+		record = &kgo.Record{Topic: "orders.retry.1", Partition: partition, Offset: offset, LeaderEpoch: 2}
+		record.Headers = []kgo.RecordHeader{{Key: HeaderRetryNotBefore, Value: []byte(strconv.FormatInt(due.UnixMilli(), 10))}}
+		return record
+	}
+	byTopic := map[string][]*kgo.Record{
+		"orders": {{Topic: "orders", Partition: 0, Offset: 1}},
+		"orders.retry.1": {
+			retry(0, 10, now.Add(-time.Second)),
+			retry(0, 11, now.Add(time.Minute)),
+			retry(0, 12, now.Add(-time.Second)),
+			retry(1, 5, now.Add(-time.Second)),
+		},
+	}
+	fetcher := &fakeRetryFetcher{}
+	var hold retryHold
+	hold.holdNotDue(fetcher, byTopic, now)
+	var offsets []int64
+	for _, record := range byTopic["orders.retry.1"] {
+		offsets = append(offsets, record.Offset)
+	}
+	if !reflect.DeepEqual(offsets, []int64{10, 5}) || len(byTopic["orders"]) != 1 {
+		t.Fatalf("holdNotDue kept %v and %d original records", offsets, len(byTopic["orders"]))
+	}
+	if !reflect.DeepEqual(fetcher.paused, map[string][]int32{"orders.retry.1": {0}}) {
+		t.Fatalf("paused = %v, want only partition 0 of the retry topic", fetcher.paused)
+	}
+	if offset := fetcher.offsets["orders.retry.1"][0]; offset.Offset != 11 || offset.Epoch != 2 {
+		t.Fatalf("rewind offset = %+v, want first held record 11", offset)
+	}
+	pollCtx, cancel := hold.pollContext(context.Background(), fetcher, now)
+	deadline, ok := pollCtx.Deadline()
+	cancel()
+	if !ok || !deadline.Equal(now.Add(time.Minute)) || fetcher.resumed != nil {
+		t.Fatalf("pollContext before due: deadline %v %v, resumed %v", deadline, ok, fetcher.resumed)
+	}
+	pollCtx, cancel = hold.pollContext(context.Background(), fetcher, now.Add(time.Minute))
+	_, ok = pollCtx.Deadline()
+	cancel()
+	if ok || !reflect.DeepEqual(fetcher.resumed, map[string][]int32{"orders.retry.1": {0}}) || len(hold.until) != 0 {
+		t.Fatalf("pollContext after due: deadline %v, resumed %v, held %v", ok, fetcher.resumed, hold.until)
+	}
 }
 `
 	if err := os.WriteFile(filepath.Join(runtimeDir, "runtime_test.go"), []byte(runtimeTest), 0o644); err != nil {
 		t.Fatalf("write runtime test: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(runtimeDir, "registry_test.go"), []byte(registryRuntimeTest), 0o644); err != nil {
+		t.Fatalf("write registry runtime test: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(runtimeDir, "outbox_test.go"), []byte(outboxRuntimeTest), 0o644); err != nil {
+		t.Fatalf("write outbox runtime test: %v", err)
+	}
 
-	goMod := "module example.test/generated\n\ngo 1.26\n\nrequire github.com/twmb/franz-go v1.21.5\n"
+	goMod := "module example.test/generated\n\ngo 1.26\n\nrequire (\n\tgithub.com/hamba/avro/v2 v2.31.0\n\tgithub.com/twmb/franz-go v1.21.5\n)\n"
 	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
 		t.Fatalf("write go.mod: %v", err)
 	}
@@ -114,3 +290,376 @@ func TestGeneratedRuntimeBehavior(t *testing.T) {
 		t.Fatalf("go test ./...: %v\n%s", err, output)
 	}
 }
+
+// registryRuntimeTest проверяет кодеки avro/protobuf против HTTP-заглушки schema registry.
+const registryRuntimeTest = `package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type registryItem struct {
+	SKU   string ` + "`json:\"sku\" protobuf:\"1\"`" + `
+	Count uint16 ` + "`json:\"count\" protobuf:\"2\"`" + `
+}
+
+type registryOrder struct {
+	ID        string            ` + "`json:\"id\" protobuf:\"1\"`" + `
+	Amount    int64             ` + "`json:\"amount,omitempty\" protobuf:\"2\"`" + `
+	Note      *string           ` + "`json:\"note\" protobuf:\"3\"`" + `
+	CreatedAt time.Time         ` + "`json:\"createdAt\" protobuf:\"4\"`" + `
+	Items     []registryItem    ` + "`json:\"items\" protobuf:\"5\"`" + `
+	Labels    map[string]string ` + "`json:\"labels\" protobuf:\"6\"`" + `
+	Scores    []int32           ` + "`json:\"scores\" protobuf:\"7\"`" + `
+	Internal  string            ` + "`json:\"-\"`" + `
+}
+
+type RegistryAudit struct {
+	Author string ` + "`json:\"author\" protobuf:\"9\"`" + `
+}
+
+type registryAudited struct {
+	*RegistryAudit
+	ID string ` + "`json:\"id\" protobuf:\"1\"`" + `
+}
+
+type registryUntagged struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+type registryDuplicate struct {
+	ID   string ` + "`json:\"id\" protobuf:\"1\"`" + `
+	Name string ` + "`json:\"name\" protobuf:\"1\"`" + `
+}
+
+const registryOrderAvro = ` + "`" + `{"type":"record","name":"Order","namespace":"example.events","fields":[{"name":"id","type":"string"},{"name":"amount","type":"long"},{"name":"note","type":["null","string"],"default":null},{"name":"createdAt","type":{"type":"long","logicalType":"timestamp-millis"}},{"name":"items","type":{"type":"array","items":{"type":"record","name":"Item","namespace":"example.events","fields":[{"name":"sku","type":"string"},{"name":"count","type":"int"}]}}},{"name":"labels","type":{"type":"map","values":"string"}},{"name":"scores","type":{"type":"array","items":"int"}}]}` + "`" + `
+
+type fakeRegistry struct {
+	mu       sync.Mutex
+	subjects map[string]int
+	schemas  []registrySchema
+	paths    []string
+}
+
+func (registry *fakeRegistry) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.paths = append(registry.paths, request.Method+" "+request.URL.Path)
+	if request.Method == http.MethodGet && strings.HasPrefix(request.URL.Path, "/schemas/ids/") {
+		id, _ := strconv.Atoi(strings.TrimPrefix(request.URL.Path, "/schemas/ids/"))
+		if id < 1 || id > len(registry.schemas) {
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = writer.Write([]byte(` + "`" + `{"error_code":40403,"message":"Schema not found"}` + "`" + `))
+			return
+		}
+		_ = json.NewEncoder(writer).Encode(registry.schemas[id-1])
+		return
+	}
+	var body registrySchema
+	_ = json.NewDecoder(request.Body).Decode(&body)
+	subject, register := strings.CutSuffix(strings.TrimPrefix(request.URL.Path, "/subjects/"), "/versions")
+	key := subject + "|" + body.Schema
+	id, found := registry.subjects[key]
+	if !found && !register {
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte(` + "`" + `{"error_code":40401,"message":"Subject not found"}` + "`" + `))
+		return
+	}
+	if !found {
+		registry.schemas = append(registry.schemas, body)
+		id = len(registry.schemas)
+		registry.subjects[key] = id
+	}
+	_ = json.NewEncoder(writer).Encode(map[string]int{"id": id})
+}
+
+func TestGeneratedSchemaRegistryCodecs(t *testing.T) {
+
+	fake := &fakeRegistry{subjects: make(map[string]int)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	registry, err := NewSchemaRegistry(server.URL)
+	if err != nil {
+		t.Fatalf("NewSchemaRegistry() error = %v", err)
+	}
+	note := "fragile"
+	order := registryOrder{
+		ID:        "o-1",
+		Amount:    -42,
+		Note:      &note,
+		CreatedAt: time.UnixMilli(1700000000123).UTC(),
+		Items:     []registryItem{{SKU: "a", Count: 2}, {SKU: "b"}},
+		Labels:    map[string]string{"channel": "web"},
+		Scores:    []int32{3, -1, 0},
+		Internal:  "skip",
+	}
+	want := order
+	want.Internal = ""
+
+	avroValue := newAvroCodec(registry, map[string]schemaDefinition{"orders": {name: "example.events.Order", schema: registryOrderAvro}})
+	data, err := marshalTopic(t.Context(), avroValue, "orders", order)
+	if err != nil {
+		t.Fatalf("avro MarshalTopic() error = %v", err)
+	}
+	if !bytes.Equal(data[:5], []byte{0, 0, 0, 0, 1}) {
+		t.Fatalf("avro frame = %v, want magic byte and schema id 1", data[:5])
+	}
+	reader, _ := NewSchemaRegistry(server.URL)
+	var decoded registryOrder
+	if err = unmarshalTopic(t.Context(), newAvroCodec(reader, nil), "orders", data, &decoded); err != nil {
+		t.Fatalf("avro UnmarshalTopic() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("avro round trip = %+v, want %+v", decoded, want)
+	}
+
+	protoRegistry, _ := NewSchemaRegistry(server.URL, RegistrySubjectStrategy(TopicRecordNameStrategy))
+	protoValue := newProtobufCodec(protoRegistry, map[string]schemaDefinition{"orders": {name: "example.events.Order", schema: "syntax = \"proto3\";\n"}})
+	if data, err = protoValue.MarshalTopic(t.Context(), "orders", &order); err != nil {
+		t.Fatalf("protobuf MarshalTopic() error = %v", err)
+	}
+	if !bytes.Equal(data[:6], []byte{0, 0, 0, 0, 2, 0}) {
+		t.Fatalf("protobuf frame = %v, want schema id 2 and message index 0", data[:6])
+	}
+	decoded = registryOrder{}
+	if err = protoValue.UnmarshalTopic(t.Context(), "orders", data, &decoded); err != nil {
+		t.Fatalf("protobuf UnmarshalTopic() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("protobuf round trip = %+v, want %+v", decoded, want)
+	}
+	audited := registryAudited{RegistryAudit: &RegistryAudit{Author: "ops"}, ID: "a-1"}
+	if data, err = protoValue.MarshalTopic(t.Context(), "orders", &audited); err != nil {
+		t.Fatalf("protobuf MarshalTopic(embedded) error = %v", err)
+	}
+	if !bytes.Contains(data, []byte{9<<3 | 2, 3, 'o', 'p', 's'}) {
+		t.Fatalf("embedded field must be encoded with its own number: %v", data)
+	}
+	var decodedAudited registryAudited
+	if err = protoValue.UnmarshalTopic(t.Context(), "orders", data, &decodedAudited); err != nil || !reflect.DeepEqual(decodedAudited, audited) {
+		t.Fatalf("protobuf round trip (embedded) = %+v %v, want %+v", decodedAudited, err, audited)
+	}
+	if _, err = protoValue.MarshalTopic(t.Context(), "orders", &registryUntagged{ID: "u"}); err == nil || !strings.Contains(err.Error(), "has no protobuf") {
+		t.Fatalf("protobuf MarshalTopic(untagged) error = %v", err)
+	}
+	if _, err = protoValue.MarshalTopic(t.Context(), "orders", &registryDuplicate{}); err == nil || !strings.Contains(err.Error(), "reuses number 1") {
+		t.Fatalf("protobuf MarshalTopic(duplicate) error = %v", err)
+	}
+	if fake.schemas[1].SchemaType != "PROTOBUF" || fake.schemas[0].SchemaType != "" {
+		t.Fatalf("schema types = %q %q", fake.schemas[0].SchemaType, fake.schemas[1].SchemaType)
+	}
+
+	lookup, _ := NewSchemaRegistry(server.URL, RegistrySubjectStrategy(RecordNameStrategy), RegistryAutoRegister(false))
+	if _, err = lookup.SchemaID(t.Context(), lookup.Subject("orders", "example.events.Order"), schemaTypeAvro, registryOrderAvro); err == nil || !strings.Contains(err.Error(), "40401") {
+		t.Fatalf("lookup of unregistered subject error = %v", err)
+	}
+	wantPaths := []string{"POST /subjects/orders-value/versions", "GET /schemas/ids/1", "POST /subjects/orders-example.events.Order/versions", "POST /subjects/example.events.Order"}
+	if !reflect.DeepEqual(fake.paths, wantPaths) {
+		t.Fatalf("registry requests = %v, want %v", fake.paths, wantPaths)
+	}
+	if data, err = marshalTopic(t.Context(), bytesCodec{}, "raw", []byte("x")); err != nil || string(data) != "x" {
+		t.Fatalf("marshalTopic(bytes) = %q %v", data, err)
+	}
+	if _, _, err = unframeSchemaID([]byte("{}")); err == nil {
+		t.Fatal("unframeSchemaID must reject records without magic byte")
+	}
+}
+`
+
+// outboxRuntimeTest проверяет SQL outbox и relay на драйвере database/sql в памяти.
+const outboxRuntimeTest = `package kafka
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type outboxRow struct {
+	id      int64
+	topic   string
+	key     []byte
+	value   []byte
+	headers string
+}
+
+type outboxStore struct {
+	mu      sync.Mutex
+	nextID  int64
+	rows    []outboxRow
+	queries []string
+}
+
+func (store *outboxStore) Open(string) (conn driver.Conn, err error) { return outboxConn{store: store}, nil }
+
+type outboxConn struct{ store *outboxStore }
+
+func (conn outboxConn) Prepare(query string) (stmt driver.Stmt, err error) {
+	return outboxStmt{store: conn.store, query: query}, nil
+}
+func (conn outboxConn) Close() (err error)           { return nil }
+func (conn outboxConn) Begin() (tx driver.Tx, err error) { return outboxTx{}, nil }
+
+type outboxTx struct{}
+
+func (outboxTx) Commit() (err error)   { return nil }
+func (outboxTx) Rollback() (err error) { return nil }
+
+type outboxStmt struct {
+	store *outboxStore
+	query string
+}
+
+func (stmt outboxStmt) Close() (err error) { return nil }
+func (stmt outboxStmt) NumInput() (count int) { return -1 }
+
+func (stmt outboxStmt) Exec(args []driver.Value) (result driver.Result, err error) {
+
+	store := stmt.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.queries = append(store.queries, stmt.query)
+	switch {
+	case strings.HasPrefix(stmt.query, "INSERT"):
+		for index := 0; index < len(args); index += 4 {
+			store.nextID++
+			key, _ := args[index+1].([]byte)
+			value, _ := args[index+2].([]byte)
+			store.rows = append(store.rows, outboxRow{id: store.nextID, topic: args[index].(string), key: key, value: value, headers: args[index+3].(string)})
+		}
+	case strings.HasPrefix(stmt.query, "DELETE"):
+		store.rows = slices.DeleteFunc(store.rows, func(row outboxRow) bool {
+			return slices.Contains(args, driver.Value(row.id))
+		})
+	default:
+		return nil, errors.New("unexpected exec " + stmt.query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (stmt outboxStmt) Query(args []driver.Value) (rows driver.Rows, err error) {
+
+	store := stmt.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.queries = append(store.queries, stmt.query)
+	limit, _ := strconv.Atoi(strings.Fields(stmt.query[strings.Index(stmt.query, "LIMIT ")+6:])[0])
+	selected := store.rows[:min(limit, len(store.rows))]
+	return &outboxRows{rows: slices.Clone(selected)}, nil
+}
+
+type outboxRows struct{ rows []outboxRow }
+
+func (rows *outboxRows) Columns() (columns []string) {
+	return []string{"id", "topic", "record_key", "record_value", "headers"}
+}
+func (rows *outboxRows) Close() (err error) { return nil }
+
+func (rows *outboxRows) Next(dest []driver.Value) (err error) {
+
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+	row := rows.rows[0]
+	rows.rows = rows.rows[1:]
+	dest[0], dest[1], dest[2], dest[3], dest[4] = row.id, row.topic, row.key, row.value, row.headers
+	return nil
+}
+
+func TestGeneratedOutbox(t *testing.T) {
+
+	store := &outboxStore{}
+	sql.Register("kafka-outbox-test", store)
+	db, err := sql.Open("kafka-outbox-test", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+	config := outboxConfig{table: "kafka_outbox", dialect: OutboxPostgres, batchSize: 2, interval: 10 * time.Millisecond}
+	tx, _ := db.BeginTx(t.Context(), nil)
+	records := []*kgo.Record{
+		{Topic: "orders", Key: []byte("k1"), Value: []byte("v1"), Headers: []kgo.RecordHeader{{Key: "trace", Value: []byte("t1")}}},
+		{Topic: "orders", Value: []byte("v2")},
+		{Topic: "audit", Key: []byte("k3"), Value: []byte("v3")},
+	}
+	if err = outboxInsert(t.Context(), tx, config, records...); err != nil {
+		t.Fatalf("outboxInsert() error = %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if want := "INSERT INTO kafka_outbox (topic, record_key, record_value, headers) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8), ($9, $10, $11, $12)"; store.queries[0] != want {
+		t.Fatalf("insert query = %q, want %q", store.queries[0], want)
+	}
+
+	failure := errors.New("broker down")
+	if _, err = relayOutbox(t.Context(), db, config, func(context.Context, []*kgo.Record) error { return failure }); !errors.Is(err, failure) {
+		t.Fatalf("relayOutbox() error = %v, want produce failure", err)
+	}
+	if len(store.rows) != 3 {
+		t.Fatalf("rows after failed relay = %d, want 3", len(store.rows))
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var produced []*kgo.Record
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runOutboxRelay(ctx, db, config, slog.New(slog.DiscardHandler), func(ctx context.Context, batch []*kgo.Record) error {
+			produced = append(produced, batch...)
+			return nil
+		})
+	}()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		store.mu.Lock()
+		drained := len(store.rows) == 0
+		store.mu.Unlock()
+		if drained {
+			break
+		}
+	}
+	cancel()
+	<-done
+	if len(produced) != len(records) {
+		t.Fatalf("relayed %d records, want %d", len(produced), len(records))
+	}
+	for index, record := range produced {
+		want := records[index]
+		if record.Topic != want.Topic || string(record.Key) != string(want.Key) || string(record.Value) != string(want.Value) || !reflect.DeepEqual(record.Headers, want.Headers) {
+			t.Fatalf("relayed record %d = %+v, want %+v", index, record, want)
+		}
+	}
+	if len(store.rows) != 0 {
+		t.Fatalf("rows after relay = %d, want 0", len(store.rows))
+	}
+	if want := "SELECT id, topic, record_key, record_value, headers FROM kafka_outbox ORDER BY id LIMIT 2 FOR UPDATE SKIP LOCKED"; store.queries[2] != want {
+		t.Fatalf("fetch query = %q, want %q", store.queries[2], want)
+	}
+	if want := "DELETE FROM kafka_outbox WHERE id IN ($1, $2)"; !slices.Contains(store.queries, want) {
+		t.Fatalf("queries %v do not contain %q", store.queries, want)
+	}
+}
+`
@@ -36,6 +36,8 @@ const (
 	TagKafkaMessage           = "kafka-message"
 	TagKafkaCodec             = "kafka-codec"
 	TagKafkaAcks              = "kafka-acks"
+	TagKafkaRetry             = "kafka-retry"
+	TagKafkaDLQ               = "kafka-dlq"
 	TagHttpEnableInlineSingle = "enableInlineSingle"
 	TagParamTags              = "tags"
 	TagRequired               = "required"
@@ -44,6 +46,10 @@ const (
 	TagTrace                  = "trace"
 	TagLogSkip                = "log-skip"
 	TagPackageJSON            = "packageJSON"
+	TagDeprecated             = "deprecated"
+	TagCompat                 = "compat"
+	TagCompatIgnore           = "compat-ignore"
+	TagRetry                  = "retry"
 )
 
 // Если аннотация http-method не задана, возвращает DefaultHTTPMethod.
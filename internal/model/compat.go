@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"strings"
+)
+
+// Политика проверки обратной совместимости контракта (@tg compat=...).
+const (
+	CompatStrict = "strict"
+	CompatWarn   = "warn"
+	CompatOff    = "off"
+)
+
+// ContractCompatPolicy возвращает политику совместимости контракта (контракт → проект), по умолчанию CompatStrict.
+func ContractCompatPolicy(project *Project, contract *Contract) (policy string) {
+
+	policy = strings.ToLower(strings.TrimSpace(GetAnnotationValue(project, contract, nil, nil, TagCompat, CompatStrict)))
+	switch policy {
+	case CompatWarn, CompatOff:
+		return policy
+	}
+	return CompatStrict
+}
+
+// ContractCompatIgnored возвращает семейства транспорта, исключённые из проверки совместимости (@tg compat-ignore=kafka,ws).
+func ContractCompatIgnored(project *Project, contract *Contract) (families map[string]struct{}) {
+
+	families = make(map[string]struct{})
+	for _, family := range strings.Split(GetAnnotationValue(project, contract, nil, nil, TagCompatIgnore, ""), ",") {
+		if family = strings.ToLower(strings.TrimSpace(family)); family != "" {
+			families[family] = struct{}{}
+		}
+	}
+	return
+}
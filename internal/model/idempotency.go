@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"strings"
+)
+
+const (
+	TagIdempotent        = "idempotent"
+	TagIdempotencyHeader = "idempotency-header"
+
+	DefaultIdempotencyHeader = "Idempotency-Key"
+)
+
+// MethodIsIdempotent — метод помечен @tg idempotent (метод → контракт → пакет) и обслуживается REST или JSON-RPC.
+// REST-методы с телом io.Reader / io.ReadCloser не поддерживаются: такой ответ нельзя сохранить для повтора.
+func MethodIsIdempotent(project *Project, contract *Contract, method *Method) (ok bool) {
+
+	if !IsAnnotationSet(project, contract, method, nil, TagIdempotent) {
+		return false
+	}
+	if MethodIsJSONRPC(project, contract, method) {
+		return true
+	}
+	return MethodIsHTTP(project, contract, method) && !MethodHasBodyStream(method)
+}
+
+// MethodIdempotencyHeader — имя заголовка ключа идемпотентности (метод → контракт → пакет), по умолчанию Idempotency-Key.
+func MethodIdempotencyHeader(project *Project, contract *Contract, method *Method) (header string) {
+
+	if header = strings.TrimSpace(GetAnnotationValue(project, contract, method, nil, TagIdempotencyHeader, "")); header == "" {
+		header = DefaultIdempotencyHeader
+	}
+	return
+}
+
+// MethodHasBodyStream — среди аргументов есть io.Reader или среди результатов io.ReadCloser.
+func MethodHasBodyStream(method *Method) (ok bool) {
+
+	for _, arg := range method.Args {
+		if arg.TypeID == typeIDIOReader {
+			return true
+		}
+	}
+	for _, res := range method.Results {
+		if res.TypeID == typeIDIOReadCloser {
+			return true
+		}
+	}
+	return false
+}
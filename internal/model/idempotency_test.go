@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"testing"
+
+	"tgp/internal/tags"
+)
+
+func TestMethodIsIdempotent(t *testing.T) {
+
+	contract := &Contract{
+		Name:        "Orders",
+		Annotations: tags.DocTags{TagServerHTTP: "", TagIdempotencyHeader: "X-Request-Key"},
+		Methods: []*Method{
+			{Name: "Create", Annotations: tags.DocTags{TagHTTPMethod: "POST", TagIdempotent: ""}},
+			{Name: "Upload", Annotations: tags.DocTags{TagHTTPMethod: "POST", TagIdempotent: ""}, Args: []*Variable{{Name: "body", TypeRef: TypeRef{TypeID: typeIDIOReader}}}},
+			{Name: "Get", Annotations: tags.DocTags{TagHTTPMethod: "GET", TagIdempotencyHeader: "Request-Id"}},
+		},
+	}
+	if !MethodIsIdempotent(nil, contract, contract.Methods[0]) {
+		t.Fatalf("method with @tg idempotent must be idempotent")
+	}
+	if MethodIsIdempotent(nil, contract, contract.Methods[1]) {
+		t.Fatalf("method with io.Reader body must not be idempotent")
+	}
+	if MethodIsIdempotent(nil, contract, contract.Methods[2]) {
+		t.Fatalf("method without @tg idempotent must not be idempotent")
+	}
+	if header := MethodIdempotencyHeader(nil, contract, contract.Methods[0]); header != "X-Request-Key" {
+		t.Fatalf("contract-level idempotency-header must apply to its methods, got %q", header)
+	}
+	if header := MethodIdempotencyHeader(nil, contract, contract.Methods[2]); header != "Request-Id" {
+		t.Fatalf("method-level idempotency-header must win, got %q", header)
+	}
+	if header := MethodIdempotencyHeader(nil, &Contract{Name: "Users"}, &Method{Name: "Create"}); header != DefaultIdempotencyHeader {
+		t.Fatalf("default idempotency-header must be %q, got %q", DefaultIdempotencyHeader, header)
+	}
+}
@@ -36,9 +36,13 @@ func TypeIsEmbeddable(project *Project, typeID string) (ok bool) {
 }
 
 // TypeNameFromTypeID returns the Go type name for composite literals and field selectors (pkg:Item → Item).
+// For generic instances it returns the generic type name (pkg:Page_User → Page), as Go names embedded Page[User].
 func TypeNameFromTypeID(project *Project, typeID string) (name string) {
 
 	if typ, ok := project.Types[typeID]; ok && typ.TypeName != "" {
+		if idx := strings.LastIndex(typ.GenericOf, ":"); idx >= 0 {
+			return typ.GenericOf[idx+1:]
+		}
 		return typ.TypeName
 	}
 	if idx := strings.Index(typeID, ":"); idx >= 0 && idx+1 < len(typeID) {
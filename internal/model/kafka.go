@@ -3,6 +3,7 @@
 package model
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -16,6 +17,8 @@ const (
 	KafkaCodecCBOR    = "cbor"
 	KafkaCodecYAML    = "yaml"
 	KafkaCodecXML     = "xml"
+	KafkaCodecAvro    = "avro"
+	KafkaCodecProto   = "protobuf"
 )
 
 // ContractHasLegacyKafkaRole — устаревшие @tg kafka-consumer / kafka-publisher.
@@ -74,6 +77,34 @@ func MethodKafkaAcks(project *Project, contract *Contract, method *Method) (acks
 	return KafkaAcksAllISR
 }
 
+// MethodKafkaRetry — число ступеней retry-топиков: method → interface → 0.
+func MethodKafkaRetry(project *Project, contract *Contract, method *Method) (retries int) {
+
+	raw := strings.TrimSpace(GetAnnotationValue(project, contract, method, nil, TagKafkaRetry, ""))
+	if retries, _ = strconv.Atoi(raw); retries < 0 {
+		return 0
+	}
+	return retries
+}
+
+// MethodKafkaDLQ — топик dead-letter очереди метода: method → interface (пусто = без DLQ).
+func MethodKafkaDLQ(project *Project, contract *Contract, method *Method) (topic string) {
+
+	return strings.TrimSpace(GetAnnotationValue(project, contract, method, nil, TagKafkaDLQ, ""))
+}
+
+// MethodKafkaHasDeadLetter — для метода включены retry-топики или DLQ.
+func MethodKafkaHasDeadLetter(project *Project, contract *Contract, method *Method) (ok bool) {
+
+	return MethodKafkaRetry(project, contract, method) > 0 || MethodKafkaDLQ(project, contract, method) != ""
+}
+
+// KafkaRetryTopic — имя retry-топика ступени attempt: <topic>.retry.<attempt>.
+func KafkaRetryTopic(topic string, attempt int) (name string) {
+
+	return topic + ".retry." + strconv.Itoa(attempt)
+}
+
 // MethodKafkaMessageArgName — явное имя сообщения: method → interface (без эвристики).
 func MethodKafkaMessageArgName(project *Project, contract *Contract, method *Method) (argName string) {
 
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tgp/internal/tags"
+)
+
+const (
+	TagRateLimit   = "rate-limit"
+	TagMaxInFlight = "max-inflight"
+	// Запас и ключ лимита отдельными тегами того же уровня: @tg rate-limit=100/s rate-limit-burst=200 rate-limit-key=ip.
+	TagRateLimitBurst = "rate-limit-burst"
+	TagRateLimitKey   = "rate-limit-key"
+
+	LimitKeyIP           = "ip"
+	LimitKeyClientID     = "client-id"
+	LimitKeyHeaderPrefix = "header:"
+)
+
+// RateLimit — правило @tg rate-limit: Rate запросов за Per, запас Burst, ключ клиента Key.
+type RateLimit struct {
+	Rate  int
+	Per   time.Duration
+	Burst int
+	Key   string
+}
+
+// ParseRateLimit разбирает значение @tg rate-limit: `<N>/<период>[ burst=<N>][ key=ip|client-id|header:<имя>]`.
+// Период — s, m, h или длительность Go (10s); части разделяются запятыми или пробелами (так к значению добавляются теги rate-limit-burst и rate-limit-key).
+func ParseRateLimit(value string) (limit RateLimit, err error) {
+
+	fields := strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+	if len(fields) == 0 {
+		return limit, fmt.Errorf("must be <N>/<period>, got %q", value)
+	}
+	rate, per, found := strings.Cut(fields[0], "/")
+	if !found {
+		return limit, fmt.Errorf("must be <N>/<period>, got %q", fields[0])
+	}
+	if limit.Rate, err = strconv.Atoi(rate); err != nil || limit.Rate <= 0 {
+		return limit, fmt.Errorf("rate must be a positive integer, got %q", rate)
+	}
+	if strings.IndexAny(per, "0123456789") < 0 {
+		per = "1" + per
+	}
+	if limit.Per, err = time.ParseDuration(per); err != nil || limit.Per <= 0 {
+		return limit, fmt.Errorf("period must be s, m, h or a positive duration, got %q", per)
+	}
+	limit.Burst = limit.Rate
+	limit.Key = LimitKeyIP
+	for _, field := range fields[1:] {
+		name, val, _ := strings.Cut(field, "=")
+		switch name {
+		case "burst":
+			if limit.Burst, err = strconv.Atoi(val); err != nil || limit.Burst <= 0 {
+				return limit, fmt.Errorf("burst must be a positive integer, got %q", val)
+			}
+		case "key":
+			if !IsLimitKey(val) {
+				return limit, fmt.Errorf("key must be ip|client-id|header:<name>, got %q", val)
+			}
+			limit.Key = val
+		default:
+			return limit, fmt.Errorf("unknown option %q (expected burst= or key=)", field)
+		}
+	}
+	return limit, nil
+}
+
+// IsLimitKey — допустимый ключ клиента лимита: ip, client-id или header:<имя>.
+func IsLimitKey(key string) (ok bool) {
+
+	return key == LimitKeyIP || key == LimitKeyClientID || strings.HasPrefix(key, LimitKeyHeaderPrefix) && key != LimitKeyHeaderPrefix
+}
+
+// ParseMaxInFlight разбирает значение @tg max-inflight: положительное число одновременных вызовов.
+func ParseMaxInFlight(value string) (limit int, err error) {
+
+	if limit, err = strconv.Atoi(strings.TrimSpace(value)); err != nil || limit <= 0 {
+		return 0, fmt.Errorf("must be a positive integer, got %q", value)
+	}
+	return limit, nil
+}
+
+// MethodRateLimit — лимит частоты метода (метод → контракт → пакет); ok=false, если лимит не задан или некорректен.
+func MethodRateLimit(project *Project, contract *Contract, method *Method) (limit RateLimit, ok bool) {
+
+	var value string
+	for _, annotations := range annotationLevels(project, contract, method) {
+		if value = rateLimitValue(annotations); value != "" {
+			break
+		}
+	}
+	if value == "" {
+		return
+	}
+	var err error
+	if limit, err = ParseRateLimit(value); err != nil {
+		return limit, false
+	}
+	return limit, true
+}
+
+// rateLimitValue — значение @tg rate-limit одного уровня вместе с тегами rate-limit-burst= и rate-limit-key= того же уровня.
+func rateLimitValue(annotations tags.DocTags) (value string) {
+
+	if value = strings.TrimSpace(annotations[TagRateLimit]); value == "" {
+		return
+	}
+	if burst, found := annotations[TagRateLimitBurst]; found {
+		value += " burst=" + burst
+	}
+	if key, found := annotations[TagRateLimitKey]; found {
+		value += " key=" + key
+	}
+	return
+}
+
+func annotationLevels(project *Project, contract *Contract, method *Method) (levels []tags.DocTags) {
+
+	if method != nil {
+		levels = append(levels, method.Annotations)
+	}
+	if contract != nil {
+		levels = append(levels, contract.Annotations)
+	}
+	if project != nil {
+		levels = append(levels, project.Annotations)
+	}
+	return
+}
+
+// MethodMaxInFlight — предел одновременных вызовов метода (метод → контракт → пакет); 0 — без предела.
+func MethodMaxInFlight(project *Project, contract *Contract, method *Method) (limit int) {
+
+	limit, _ = ParseMaxInFlight(GetAnnotationValue(project, contract, method, nil, TagMaxInFlight, "0"))
+	return
+}
+
+// MethodLimitScope — область лимита tagName: аннотация на методе даёт отдельный лимит метода (contract.method),
+// унаследованная с контракта или пакета — общий лимит контракта (contract).
+func MethodLimitScope(contract *Contract, method *Method, tagName string) (scope string) {
+
+	if _, found := method.Annotations[tagName]; found {
+		return contract.Name + "." + method.Name
+	}
+	return contract.Name
+}
+
+// MethodHasLimits — для метода задан лимит частоты или одновременных вызовов.
+func MethodHasLimits(project *Project, contract *Contract, method *Method) (ok bool) {
+
+	if _, ok = MethodRateLimit(project, contract, method); ok {
+		return
+	}
+	return MethodMaxInFlight(project, contract, method) > 0
+}
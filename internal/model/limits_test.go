@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"testing"
+	"time"
+
+	"tgp/internal/tags"
+)
+
+func TestParseRateLimit(t *testing.T) {
+
+	tests := []struct {
+		value   string
+		want    RateLimit
+		wantErr bool
+	}{
+		{value: "100/s", want: RateLimit{Rate: 100, Per: time.Second, Burst: 100, Key: LimitKeyIP}},
+		{value: "100/s burst=200 key=client-id", want: RateLimit{Rate: 100, Per: time.Second, Burst: 200, Key: LimitKeyClientID}},
+		{value: "5/10s,key=header:X-Api-Key", want: RateLimit{Rate: 5, Per: 10 * time.Second, Burst: 5, Key: "header:X-Api-Key"}},
+		{value: "60/m", want: RateLimit{Rate: 60, Per: time.Minute, Burst: 60, Key: LimitKeyIP}},
+		{value: "", wantErr: true},
+		{value: "100", wantErr: true},
+		{value: "0/s", wantErr: true},
+		{value: "100/week", wantErr: true},
+		{value: "100/s burst=0", wantErr: true},
+		{value: "100/s key=header:", wantErr: true},
+		{value: "100/s key=user", wantErr: true},
+		{value: "100/s window=1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRateLimit(%q) must fail, got %+v", tt.value, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseRateLimit(%q) = %+v, %v, want %+v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodLimitScope(t *testing.T) {
+
+	contract := &Contract{
+		Name:        "Orders",
+		Annotations: tags.DocTags{TagRateLimit: "10/s", TagMaxInFlight: "5"},
+		Methods: []*Method{
+			{Name: "Get", Annotations: tags.DocTags{TagRateLimit: "100/s"}},
+			{Name: "List"},
+		},
+	}
+	if scope := MethodLimitScope(contract, contract.Methods[0], TagRateLimit); scope != "Orders.Get" {
+		t.Fatalf("method-level limit must have its own scope, got %q", scope)
+	}
+	if scope := MethodLimitScope(contract, contract.Methods[1], TagRateLimit); scope != "Orders" {
+		t.Fatalf("inherited limit must be shared by the contract, got %q", scope)
+	}
+	if limit, ok := MethodRateLimit(nil, contract, contract.Methods[1]); !ok || limit.Rate != 10 {
+		t.Fatalf("contract-level rate-limit must apply to its methods, got %+v", limit)
+	}
+	if MethodMaxInFlight(nil, contract, contract.Methods[0]) != 5 {
+		t.Fatalf("contract-level max-inflight must apply to its methods")
+	}
+}
+
+func TestMethodRateLimit_SeparateOptions(t *testing.T) {
+
+	// @tg rate-limit=100/s rate-limit-burst=200 rate-limit-key=client-id сканер тегов разбирает на три ключа.
+	method := &Method{Name: "Get", Annotations: tags.ParseTags([]string{"// @tg rate-limit=100/s rate-limit-burst=200 rate-limit-key=client-id"})}
+	contract := &Contract{Name: "Orders", Methods: []*Method{method}}
+	want := RateLimit{Rate: 100, Per: time.Second, Burst: 200, Key: LimitKeyClientID}
+	if limit, ok := MethodRateLimit(nil, contract, method); !ok || limit != want {
+		t.Fatalf("MethodRateLimit = %+v, %v, want %+v", limit, ok, want)
+	}
+
+	// Общие ключи burst= и key= других аннотаций к лимиту не относятся.
+	method.Annotations = tags.ParseTags([]string{"// @tg rate-limit=100/s key=client-id"})
+	want = RateLimit{Rate: 100, Per: time.Second, Burst: 100, Key: LimitKeyIP}
+	if limit, ok := MethodRateLimit(nil, contract, method); !ok || limit != want {
+		t.Fatalf("MethodRateLimit = %+v, %v, want %+v", limit, ok, want)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	TagHttpErrors      = "http-errors"
+	TagHttpProblemBase = "http-problem-base"
+	HTTPErrorsProblem  = "problem"
+	ProblemContentType = "application/problem+json"
+	ProblemTypeBlank   = "about:blank"
+	DefaultProblemBase = "urn:problem-type:"
+)
+
+// HTTPErrorsAsProblem — REST-ошибки метода отдаются в формате RFC 9457 (@tg http-errors=problem).
+func HTTPErrorsAsProblem(project *Project, contract *Contract, method *Method) (ok bool) {
+
+	return strings.EqualFold(strings.TrimSpace(GetAnnotationValue(project, contract, method, nil, TagHttpErrors, "")), HTTPErrorsProblem)
+}
+
+// ContractHasProblemErrors — хотя бы один метод контракта отдаёт ошибки в формате RFC 9457.
+func ContractHasProblemErrors(project *Project, contract *Contract) (ok bool) {
+
+	for _, method := range contract.Methods {
+		if HTTPErrorsAsProblem(project, contract, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProblemType — стабильный URI типа проблемы для ошибки: база из @tg http-problem-base
+// (по умолчанию urn:problem-type:) и имя типа в kebab-case без префикса Err.
+func ProblemType(project *Project, contract *Contract, errInfo *ErrorInfo) (uri string) {
+
+	base := strings.TrimSpace(GetAnnotationValue(project, contract, nil, nil, TagHttpProblemBase, DefaultProblemBase))
+	if !strings.HasSuffix(base, ":") && !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base + problemTypeName(errInfo.TypeName)
+}
+
+// ProblemErrorKey — ключ типа ошибки в таблице типов проблем: путь пакета и имя типа, как их отдаёт reflect.
+func ProblemErrorKey(errInfo *ErrorInfo) (key string) {
+
+	return errInfo.PkgPath + "." + errInfo.TypeName
+}
+
+func problemTypeName(typeName string) (name string) {
+
+	if trimmed := strings.TrimPrefix(typeName, "Err"); trimmed != "" && trimmed != typeName && unicode.IsUpper(rune(trimmed[0])) {
+		typeName = trimmed
+	}
+	runes := []rune(typeName)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				sb.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"testing"
+
+	"tgp/internal/tags"
+)
+
+func TestProblemType(t *testing.T) {
+
+	tests := []struct {
+		base     string
+		typeName string
+		want     string
+	}{
+		{typeName: "ErrNotFound", want: "urn:problem-type:not-found"},
+		{typeName: "Error", want: "urn:problem-type:error"},
+		{typeName: "HTTPTimeoutError", want: "urn:problem-type:http-timeout-error"},
+		{base: "https://api.example.com/problems", typeName: "ErrOutOfStock", want: "https://api.example.com/problems/out-of-stock"},
+		{base: "https://api.example.com/problems/", typeName: "ErrOutOfStock", want: "https://api.example.com/problems/out-of-stock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			project := &Project{Annotations: tags.DocTags{}}
+			if tt.base != "" {
+				project.Annotations[TagHttpProblemBase] = tt.base
+			}
+			if got := ProblemType(project, &Contract{}, &ErrorInfo{TypeName: tt.typeName}); got != tt.want {
+				t.Fatalf("ProblemType(%q) = %q, want %q", tt.typeName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPErrorsAsProblem(t *testing.T) {
+
+	contract := &Contract{
+		Annotations: tags.DocTags{TagHttpErrors: "problem"},
+		Methods:     []*Method{{Name: "Get"}},
+	}
+	if !HTTPErrorsAsProblem(nil, contract, contract.Methods[0]) || !ContractHasProblemErrors(nil, contract) {
+		t.Fatalf("contract-level http-errors=problem must apply to its methods")
+	}
+	if ContractHasProblemErrors(nil, &Contract{Methods: []*Method{{Name: "Get"}}}) {
+		t.Fatalf("problem mode must be opt-in")
+	}
+}
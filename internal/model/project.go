@@ -163,6 +163,9 @@ type Type struct {
 
 	AliasOf string `json:"aliasOf,omitempty"`
 
+	GenericOf string     `json:"genericOf,omitempty"` // ID обобщённого типа, экземпляром которого является тип (например, "pkg:Page")
+	TypeArgs  []*TypeRef `json:"typeArgs,omitempty"`  // Аргументы инстанцирования обобщённого типа
+
 	ArrayLen        int    `json:"arrayLen,omitempty"`
 	IsSlice         bool   `json:"isSlice,omitempty"`
 	IsEllipsis      bool   `json:"isEllipsis,omitempty"`
@@ -210,6 +213,7 @@ type ParseFromStringInfo struct {
 type StructField struct {
 	TypeRef     `json:",inline"`
 	Name        string              `json:"name"`
+	Embedded    bool                `json:"embedded,omitempty"` // встроенное поле: Name — имя типа
 	Tags        map[string][]string `json:"tags,omitempty"`
 	Docs        []string            `json:"docs,omitempty"`
 	Directives  []string            `json:"directives,omitempty"`
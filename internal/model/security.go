@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	TagSecurity = "security"
+	TagScopes   = "scopes"
+
+	SecurityBearer = "bearer"
+	SecurityBasic  = "basic"
+	SecurityAPIKey = "apiKey"
+
+	SecurityInHeader = "header"
+	SecurityInQuery  = "query"
+	SecurityInCookie = "cookie"
+
+	// SecurityNone — значение @tg security=none: метод (или все методы контракта) доступен без аутентификации.
+	SecurityNone = "none"
+)
+
+// SecurityScheme — схема аутентификации из @tg security, которую проверяет сервер и заполняют клиенты.
+// Kind — bearer, basic или apiKey; для apiKey In (header, query, cookie) и Name задают, где лежит ключ.
+type SecurityScheme struct {
+	Kind string
+	In   string
+	Name string
+}
+
+// ID — идентификатор схемы в сгенерированном коде: bearer, basic или apiKey:<in>:<name>.
+func (scheme SecurityScheme) ID() (id string) {
+
+	if scheme.Kind == SecurityAPIKey {
+		return scheme.Kind + ":" + scheme.In + ":" + scheme.Name
+	}
+	return scheme.Kind
+}
+
+// SplitSecurityTokens делит значение @tg security на схемы: запятая начинает новую схему только перед
+// http:, apiKey:, openId: или oauth2:, поэтому scopes oauth2 через запятую остаются внутри своей схемы.
+func SplitSecurityTokens(raw string) (tokens []string) {
+
+	var current string
+
+	for _, part := range strings.Split(raw, ",") {
+		trimmed := strings.TrimSpace(part)
+		if trimmed == "" {
+			continue
+		}
+
+		isNew := false
+		if index := strings.Index(trimmed, ":"); index > 0 {
+			kind := strings.ToLower(strings.TrimSpace(trimmed[:index]))
+			switch kind {
+			case "http", "apikey", "openid", "oauth2":
+				isNew = true
+			}
+		}
+
+		switch {
+		case isNew:
+			if current != "" {
+				tokens = append(tokens, current)
+			}
+			current = trimmed
+		case current == "":
+			current = trimmed
+		default:
+			current += "," + trimmed
+		}
+	}
+
+	if current != "" {
+		tokens = append(tokens, current)
+	}
+
+	return
+}
+
+// ParseSecurityScheme разбирает одну схему @tg security в схему сервера: http:bearer, oauth2:* и openId:*
+// передают токен в заголовке Authorization: Bearer, http:basic — логин и пароль, apiKey:<in>:<name> — ключ.
+// ok=false для схем, которые сервер проверить не может (например, http:digest).
+func ParseSecurityScheme(token string) (scheme SecurityScheme, ok bool) {
+
+	parts := strings.Split(token, ":")
+	switch strings.ToLower(strings.TrimSpace(parts[0])) {
+	case "http":
+		if len(parts) != 2 {
+			return
+		}
+		switch strings.ToLower(strings.TrimSpace(parts[1])) {
+		case SecurityBearer:
+			return SecurityScheme{Kind: SecurityBearer}, true
+		case SecurityBasic:
+			return SecurityScheme{Kind: SecurityBasic}, true
+		}
+	case "apikey":
+		if len(parts) != 3 {
+			return
+		}
+		in := strings.ToLower(strings.TrimSpace(parts[1]))
+		name := strings.TrimSpace(parts[2])
+		if name == "" || in != SecurityInHeader && in != SecurityInQuery && in != SecurityInCookie {
+			return
+		}
+		return SecurityScheme{Kind: SecurityAPIKey, In: in, Name: name}, true
+	case "oauth2", "openid":
+		return SecurityScheme{Kind: SecurityBearer}, true
+	}
+	return
+}
+
+// ProjectSecuritySchemes — схемы @tg security пакета, контрактов и методов в порядке объявления, без повторов.
+// По ним сервер заводит аутентификаторы, а клиенты — учётные данные.
+func ProjectSecuritySchemes(project *Project) (schemes []SecurityScheme) {
+
+	if project == nil {
+		return
+	}
+	values := []string{GetAnnotationValue(project, nil, nil, nil, TagSecurity, "")}
+	for _, contract := range project.Contracts {
+		values = append(values, contract.Annotations[TagSecurity])
+		for _, method := range contract.Methods {
+			values = append(values, method.Annotations[TagSecurity])
+		}
+	}
+	for _, value := range values {
+		for _, scheme := range parseSecuritySchemes(value) {
+			if !slices.Contains(schemes, scheme) {
+				schemes = append(schemes, scheme)
+			}
+		}
+	}
+	return
+}
+
+// MethodSecuritySchemes — схемы @tg security, которыми аутентифицируется вызов метода (метод → контракт → пакет).
+// Пусто для публичного метода: без @tg security на всех уровнях или с @tg security=none.
+func MethodSecuritySchemes(project *Project, contract *Contract, method *Method) (schemes []SecurityScheme) {
+
+	return parseSecuritySchemes(GetAnnotationValue(project, contract, method, nil, TagSecurity, ""))
+}
+
+// IsSecurityNone — значение @tg security объявляет метод публичным (security=none).
+func IsSecurityNone(value string) (ok bool) {
+
+	return strings.EqualFold(strings.TrimSpace(value), SecurityNone)
+}
+
+func parseSecuritySchemes(value string) (schemes []SecurityScheme) {
+
+	if IsSecurityNone(value) {
+		return
+	}
+	for _, token := range SplitSecurityTokens(value) {
+		scheme, ok := ParseSecurityScheme(token)
+		if !ok || slices.Contains(schemes, scheme) {
+			continue
+		}
+		schemes = append(schemes, scheme)
+	}
+	return
+}
+
+// HasSecurityKind — среди схем есть схема вида kind (bearer, basic, apiKey).
+func HasSecurityKind(schemes []SecurityScheme, kind string) (ok bool) {
+
+	for _, scheme := range schemes {
+		if scheme.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// MethodScopes — scopes, которые должен иметь аутентифицированный вызов метода (@tg scopes на методе, контракте или пакете).
+func MethodScopes(project *Project, contract *Contract, method *Method) (scopes []string) {
+
+	for _, scope := range strings.Split(GetAnnotationValue(project, contract, method, nil, TagScopes, ""), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return
+}
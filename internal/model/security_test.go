@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"tgp/internal/tags"
+)
+
+func TestProjectSecuritySchemes(t *testing.T) {
+
+	project := &Project{Annotations: tags.DocTags{
+		TagSecurity: "http:bearer,apiKey:header:X-Api-Key,oauth2:clientCredentials:https://auth.example.com/token:api.read,api.write:jwt-bearer,http:digest,http:basic",
+	}}
+	want := []SecurityScheme{
+		{Kind: SecurityBearer},
+		{Kind: SecurityAPIKey, In: SecurityInHeader, Name: "X-Api-Key"},
+		{Kind: SecurityBasic},
+	}
+	if got := ProjectSecuritySchemes(project); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ProjectSecuritySchemes = %+v, want %+v", got, want)
+	}
+}
+
+func TestMethodScopes(t *testing.T) {
+
+	method := &Method{Name: "Create", Annotations: tags.DocTags{TagScopes: "orders.read, orders.write"}}
+	contract := &Contract{Name: "Orders", Annotations: tags.DocTags{TagScopes: "orders.read"}, Methods: []*Method{method, {Name: "List"}}}
+	if got := MethodScopes(nil, contract, method); !reflect.DeepEqual(got, []string{"orders.read", "orders.write"}) {
+		t.Fatalf("method scopes = %v", got)
+	}
+	if got := MethodScopes(nil, contract, contract.Methods[1]); !reflect.DeepEqual(got, []string{"orders.read"}) {
+		t.Fatalf("contract scopes must apply to methods without their own, got %v", got)
+	}
+}
+
+func TestMethodSecuritySchemes(t *testing.T) {
+
+	public := &Method{Name: "Health", Annotations: tags.DocTags{TagSecurity: "none"}}
+	keyed := &Method{Name: "Import", Annotations: tags.DocTags{TagSecurity: "apiKey:header:X-Import-Key"}}
+	inherited := &Method{Name: "List"}
+	orders := &Contract{Name: "Orders", Methods: []*Method{public, keyed, inherited}}
+	login := &Contract{Name: "Auth", Annotations: tags.DocTags{TagSecurity: "none"}, Methods: []*Method{{Name: "Login"}}}
+	project := &Project{Annotations: tags.DocTags{TagSecurity: "http:bearer"}, Contracts: []*Contract{orders, login}}
+
+	if got := MethodSecuritySchemes(project, orders, public); len(got) != 0 {
+		t.Fatalf("security=none must make the method public, got %+v", got)
+	}
+	if got := MethodSecuritySchemes(project, login, login.Methods[0]); len(got) != 0 {
+		t.Fatalf("contract security=none must make its methods public, got %+v", got)
+	}
+	if got := MethodSecuritySchemes(project, orders, keyed); !reflect.DeepEqual(got, []SecurityScheme{{Kind: SecurityAPIKey, In: SecurityInHeader, Name: "X-Import-Key"}}) {
+		t.Fatalf("method security must override the package, got %+v", got)
+	}
+	if got := MethodSecuritySchemes(project, orders, inherited); !reflect.DeepEqual(got, []SecurityScheme{{Kind: SecurityBearer}}) {
+		t.Fatalf("method without security must inherit the package, got %+v", got)
+	}
+	want := []SecurityScheme{{Kind: SecurityBearer}, {Kind: SecurityAPIKey, In: SecurityInHeader, Name: "X-Import-Key"}}
+	if got := ProjectSecuritySchemes(project); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ProjectSecuritySchemes must collect every level, got %+v", got)
+	}
+}
@@ -21,21 +21,19 @@ var allowedHTTPMethods = map[string]struct{}{
 	"OPTIONS": {},
 }
 
-func contractHTTPAnnotations(project *model.Project, contract *model.Contract) (err error) {
+func contractHTTPIssues(project *model.Project, contract *model.Contract) (list issueList) {
 
 	if contract == nil {
-		return nil
+		return
 	}
 	if !model.IsAnnotationSet(project, contract, nil, nil, model.TagServerHTTP) {
-		return nil
+		return
 	}
 
 	for _, method := range contract.Methods {
-		if err = methodHTTPAnnotations(project, contract, method); err != nil {
-			return
-		}
+		list.add(contract, method, "", methodHTTPAnnotations(project, contract, method))
 	}
-	return nil
+	return
 }
 
 func methodHTTPAnnotations(project *model.Project, contract *model.Contract, method *model.Method) (err error) {
@@ -52,17 +50,21 @@ func methodHTTPAnnotations(project *model.Project, contract *model.Contract, met
 
 	if httpMethod := strings.TrimSpace(model.GetAnnotationValue(project, contract, method, nil, model.TagHTTPMethod, "")); httpMethod != "" {
 		if _, ok := allowedHTTPMethods[strings.ToUpper(httpMethod)]; !ok {
-			return fmt.Errorf("contract %q: method %q: http-method %q is not supported", contractName, methodName, httpMethod)
+			return annotationErr(model.TagHTTPMethod, fmt.Errorf("contract %q: method %q: http-method %q is not supported", contractName, methodName, httpMethod))
 		}
 	}
 
 	if successValue := model.GetAnnotationValue(project, contract, method, nil, model.TagHttpSuccess, ""); successValue != "" {
 		var code int
 		if code, err = strconv.Atoi(successValue); err != nil || code <= 0 {
-			return fmt.Errorf("contract %q: method %q: http-success must be a positive integer", contractName, methodName)
+			return annotationErr(model.TagHttpSuccess, fmt.Errorf("contract %q: method %q: http-success must be a positive integer", contractName, methodName))
 		}
 	}
 
+	if model.IsAnnotationSet(project, contract, method, nil, model.TagIdempotent) && model.MethodHasBodyStream(method) {
+		return annotationErr(model.TagIdempotent, fmt.Errorf("contract %q: method %q: idempotent is not supported with io.Reader arguments or io.ReadCloser results", contractName, methodName))
+	}
+
 	if err = validateArgMapAnnotation(project, contract, method, model.TagHttpArg, "http-args"); err != nil {
 		return
 	}
@@ -90,7 +92,7 @@ func validateFormRequestAnnotations(project *model.Project, contract *model.Cont
 			continue
 		}
 		if _, ok := tags.FormFieldName(method.Annotations, arg.Name); !ok {
-			return fmt.Errorf("contract %q: method %q: argument %q requires form:<name> tag when requestContentType is %s", contract.Name, method.Name, arg.Name, requestContentType)
+			return annotationErr(arg.Name+"."+model.TagParamTags, fmt.Errorf("contract %q: method %q: argument %q requires form:<name> tag when requestContentType is %s", contract.Name, method.Name, arg.Name, requestContentType))
 		}
 	}
 	return nil
@@ -104,7 +106,7 @@ func validateArgMapAnnotation(project *model.Project, contract *model.Contract,
 	}
 
 	if _, err = model.ParseArgMapEntriesStrict(value); err != nil {
-		return fmt.Errorf("contract %q: method %q: %s: %w", contract.Name, method.Name, label, err)
+		return annotationErr(tagName, fmt.Errorf("contract %q: method %q: %s: %w", contract.Name, method.Name, label, err))
 	}
 	return nil
 }
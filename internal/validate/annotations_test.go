@@ -192,3 +192,42 @@ func TestMethodHTTPAnnotations_rejectsFormNonInlineStructArg(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestMethodHTTPAnnotations_rejectsIdempotentBodyStream(t *testing.T) {
+
+	project := &model.Project{ModulePath: "example"}
+	contract := &model.Contract{
+		Name: "Http",
+		Annotations: tags.DocTags{
+			model.TagServerHTTP: "",
+		},
+		Methods: []*model.Method{{
+			Name: "Upload",
+			Annotations: tags.DocTags{
+				model.TagHTTPMethod: "POST",
+				model.TagIdempotent: "",
+			},
+			Args: []*model.Variable{{Name: "body", TypeRef: model.TypeRef{TypeID: "io:Reader"}}},
+		}},
+	}
+
+	err := methodHTTPAnnotations(project, contract, contract.Methods[0])
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "idempotent") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateSecurityValue(t *testing.T) {
+
+	for _, value := range []string{"http:bearer", "none", "apiKey:header:X-Api-Key,http:basic"} {
+		if err := validateSecurityValue(value); err != nil {
+			t.Fatalf("%q must be valid: %v", value, err)
+		}
+	}
+	if err := validateSecurityValue("none,http:bearer"); err == nil || !strings.Contains(err.Error(), "none cannot be combined") {
+		t.Fatalf("none combined with a scheme must be rejected, got %v", err)
+	}
+}
@@ -20,55 +20,47 @@ func Contract(contract *model.Contract, project *model.Project) (err error) {
 	if contract == nil {
 		return fmt.Errorf("contract cannot be nil")
 	}
+	return contractIssues(contract, project).err()
+}
+
+func contractIssues(contract *model.Contract, project *model.Project) (list issueList) {
+
+	if contract == nil {
+		return
+	}
 
 	for _, method := range contract.Methods {
 		for i, arg := range method.Args {
 			if arg.Name == "" && arg.TypeID != "context:Context" {
-				return fmt.Errorf("contract %q: method %q: argument #%d has no name (all arguments except context.Context must be named)", contract.Name, method.Name, i+1)
+				list.add(contract, method, "", fmt.Errorf("contract %q: method %q: argument #%d has no name (all arguments except context.Context must be named)", contract.Name, method.Name, i+1))
 			}
 		}
 
 		for i, result := range method.Results {
 			if result.Name == "" && result.TypeID != "error" {
-				return fmt.Errorf("contract %q: method %q: result #%d has no name (all results except error must be named)", contract.Name, method.Name, i+1)
+				list.add(contract, method, "", fmt.Errorf("contract %q: method %q: result #%d has no name (all results except error must be named)", contract.Name, method.Name, i+1))
 			}
 		}
 
 		visited := make(map[string]struct{})
 
 		for _, arg := range method.Args {
-			if err = validateVariable(arg, project, contract.Name, method.Name, "argument", visited); err != nil {
-				return
-			}
+			list.add(contract, method, arg.Name, validateVariable(arg, project, contract.Name, method.Name, "argument", visited))
 		}
 
 		for _, result := range method.Results {
-			if err = validateVariable(result, project, contract.Name, method.Name, "result", visited); err != nil {
-				return
-			}
+			list.add(contract, method, result.Name, validateVariable(result, project, contract.Name, method.Name, "result", visited))
 		}
 	}
 
-	if err = validateContractStreamTypes(contract, project); err != nil {
-		return
-	}
-
-	if err = contractHTTPAnnotations(project, contract); err != nil {
-		return
-	}
-
-	if err = contractStreamAnnotations(project, contract); err != nil {
-		return
-	}
-
-	if err = contractKafkaAnnotations(project, contract); err != nil {
-		return
-	}
-
+	list = append(list, contractStreamTypeIssues(contract, project)...)
+	list = append(list, contractHTTPIssues(project, contract)...)
+	list = append(list, contractStreamIssues(project, contract)...)
+	list = append(list, contractKafkaIssues(project, contract)...)
 	return
 }
 
-func validateContractStreamTypes(contract *model.Contract, project *model.Project) (err error) {
+func contractStreamTypeIssues(contract *model.Contract, project *model.Project) (list issueList) {
 
 	hasHTTPServer := model.IsAnnotationSet(project, contract, nil, nil, tagHttpServer)
 
@@ -76,12 +68,12 @@ func validateContractStreamTypes(contract *model.Contract, project *model.Projec
 		if !hasHTTPServer {
 			for _, arg := range method.Args {
 				if arg.TypeID == typeIDIOReader {
-					return fmt.Errorf("contract %q: method %q: io.Reader в аргументах разрешён только при аннотации http-server на контракте", contract.Name, method.Name)
+					list.add(contract, method, arg.Name, fmt.Errorf("contract %q: method %q: io.Reader в аргументах разрешён только при аннотации http-server на контракте", contract.Name, method.Name))
 				}
 			}
 			for _, res := range method.Results {
 				if res.TypeID == typeIDIOReadCloser {
-					return fmt.Errorf("contract %q: method %q: io.ReadCloser в возвращаемых значениях разрешён только при аннотации http-server на контракте", contract.Name, method.Name)
+					list.add(contract, method, res.Name, fmt.Errorf("contract %q: method %q: io.ReadCloser в возвращаемых значениях разрешён только при аннотации http-server на контракте", contract.Name, method.Name))
 				}
 			}
 		}
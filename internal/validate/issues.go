@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package validate
+
+import (
+	"errors"
+
+	"tgp/internal/model"
+)
+
+const (
+	RuleContract          = "contract"
+	RuleUnknownAnnotation = "unknown-annotation"
+	RuleAnnotationValue   = "annotation-value"
+)
+
+// Issue — проблема, найденная при проверке проекта.
+// Method, Variable, TypeID/Field и Tag уточняют место проблемы, чтобы сопоставить её с исходником контракта.
+type Issue struct {
+	Rule     string
+	Warning  bool
+	Contract *model.Contract
+	Method   *model.Method
+	Variable string
+	TypeID   string
+	Field    string
+	Tag      string
+	Err      error
+}
+
+type issueList []Issue
+
+// annotationError связывает ошибку проверки с ключом аннотации @tg, значение которого её вызвало.
+type annotationError struct {
+	tag string
+	err error
+}
+
+func (e *annotationError) Error() (msg string) {
+
+	return e.err.Error()
+}
+
+func (e *annotationError) Unwrap() (err error) {
+
+	return e.err
+}
+
+func annotationErr(tag string, err error) error {
+
+	return &annotationError{tag: tag, err: err}
+}
+
+// Issues выполняет все проверки проекта и возвращает все найденные проблемы, не останавливаясь на первой.
+func Issues(project *model.Project) (issues []Issue) {
+
+	if project == nil {
+		return
+	}
+	var list issueList
+	for _, contract := range project.Contracts {
+		list = append(list, contractIssues(contract, project)...)
+	}
+	list = append(list, kafkaProjectIssues(project)...)
+	for _, issue := range annotationIssues(project) {
+		if !list.hasTag(issue.Contract, issue.Method, issue.Tag) {
+			list = append(list, issue)
+		}
+	}
+	return list
+}
+
+func (list *issueList) add(contract *model.Contract, method *model.Method, variable string, err error) {
+
+	if err == nil {
+		return
+	}
+	issue := Issue{Rule: RuleContract, Contract: contract, Method: method, Variable: variable, Err: err}
+	var tagErr *annotationError
+	if errors.As(err, &tagErr) {
+		issue.Tag = tagErr.tag
+	}
+	*list = append(*list, issue)
+}
+
+func (list issueList) hasTag(contract *model.Contract, method *model.Method, tag string) (found bool) {
+
+	for _, issue := range list {
+		if issue.Contract == contract && issue.Method == method && issue.Tag == tag {
+			return true
+		}
+	}
+	return
+}
+
+func (list issueList) err() (err error) {
+
+	if len(list) == 0 {
+		return
+	}
+	return list[0].Err
+}
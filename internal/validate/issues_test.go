@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestIssues_collectsAllProblems(t *testing.T) {
+
+	project := &model.Project{ModulePath: "example"}
+	contract := &model.Contract{
+		Name: "Http",
+		Annotations: tags.DocTags{
+			model.TagServerHTTP: "",
+			"trcae":             "",
+		},
+		Methods: []*model.Method{
+			{
+				Name:        "Get",
+				Annotations: tags.DocTags{model.TagHTTPMethod: "FETCH"},
+				Args:        []*model.Variable{{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}},
+				Results:     []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			},
+			{
+				Name: "Put",
+				Annotations: tags.DocTags{
+					model.TagHTTPMethod:  "PUT",
+					model.TagHttpSuccess: "ok",
+					"token.requird":      "",
+				},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "token", TypeRef: model.TypeRef{TypeID: "string"}},
+				},
+				Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			},
+		},
+	}
+	project.Contracts = []*model.Contract{contract}
+
+	issues := Issues(project)
+
+	var errorsCount, warnings int
+	byTag := make(map[string]Issue)
+	for _, issue := range issues {
+		if issue.Warning {
+			warnings++
+		} else {
+			errorsCount++
+		}
+		byTag[issue.Tag] = issue
+	}
+	if errorsCount != 2 || warnings != 2 {
+		t.Fatalf("want 2 errors and 2 warnings, got %d/%d: %+v", errorsCount, warnings, issues)
+	}
+	if issue := byTag[model.TagHTTPMethod]; issue.Rule != RuleContract || issue.Method != contract.Methods[0] {
+		t.Fatalf("http-method issue must come from contract checks on Get, got %+v", issue)
+	}
+	if issue := byTag[model.TagHttpSuccess]; issue.Method != contract.Methods[1] {
+		t.Fatalf("http-success issue must point to Put, got %+v", issue)
+	}
+	typo := byTag["token.requird"]
+	if typo.Rule != RuleUnknownAnnotation || typo.Variable != "token" || !strings.Contains(typo.Err.Error(), `did you mean "required"`) {
+		t.Fatalf("unexpected typo issue: %+v", typo)
+	}
+	if issue := byTag["trcae"]; issue.Method != nil || !strings.Contains(issue.Err.Error(), `did you mean "trace"`) {
+		t.Fatalf("unexpected contract annotation issue: %+v", issue)
+	}
+}
+
+func TestIssues_unknownVariableAndFieldAnnotations(t *testing.T) {
+
+	project := &model.Project{
+		ModulePath: "example",
+		Annotations: tags.DocTags{
+			"tagDesc.users": "Users",
+			"npmPrivate":    "maybe",
+		},
+		Types: map[string]*model.Type{
+			"example/dto:User": {
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "Name", Annotations: tags.DocTags{"desc": "name", "exmaple": "bob"}},
+				},
+			},
+			"github.com/ext/pkg:Ext": {
+				ImportPkgPath: "github.com/ext/pkg",
+				StructFields:  []*model.StructField{{Name: "X", Annotations: tags.DocTags{"whatever": ""}}},
+			},
+		},
+	}
+	contract := &model.Contract{
+		Name: "Users",
+		Methods: []*model.Method{{
+			Name:        "Get",
+			Annotations: tags.DocTags{"missing.desc": "x"},
+		}},
+	}
+	project.Contracts = []*model.Contract{contract}
+
+	issues := Issues(project)
+	if len(issues) != 3 {
+		t.Fatalf("want 3 issues, got %d: %+v", len(issues), issues)
+	}
+	for _, issue := range issues {
+		switch issue.Tag {
+		case "npmPrivate":
+			if issue.Warning || issue.Rule != RuleAnnotationValue || issue.Contract != nil {
+				t.Fatalf("npmPrivate must be a package-level value error, got %+v", issue)
+			}
+		case "exmaple":
+			if issue.TypeID != "example/dto:User" || issue.Field != "Name" {
+				t.Fatalf("field issue must point to example/dto:User.Name, got %+v", issue)
+			}
+		case "missing.desc":
+			if !strings.Contains(issue.Err.Error(), `unknown argument or result "missing"`) {
+				t.Fatalf("unexpected issue: %v", issue.Err)
+			}
+		default:
+			t.Fatalf("unexpected issue: %+v", issue)
+		}
+	}
+}
+
+func TestContract_returnsFirstIssue(t *testing.T) {
+
+	project := &model.Project{ModulePath: "example"}
+	contract := &model.Contract{
+		Name:        "Http",
+		Annotations: tags.DocTags{model.TagServerHTTP: ""},
+		Methods: []*model.Method{
+			{Name: "A", Annotations: tags.DocTags{model.TagHTTPMethod: "FETCH"}},
+			{Name: "B", Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpSuccess: "-1"}},
+		},
+	}
+	project.Contracts = []*model.Contract{contract}
+
+	if got := len(contractIssues(contract, project)); got != 2 {
+		t.Fatalf("want 2 contract issues, got %d", got)
+	}
+	err := Contract(contract, project)
+	if err == nil || !strings.Contains(err.Error(), `method "A"`) {
+		t.Fatalf("Contract must return the first issue, got %v", err)
+	}
+}
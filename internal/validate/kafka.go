@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"tgp/internal/kafka"
 	"tgp/internal/model"
 )
 
@@ -17,54 +18,70 @@ var kafkaAcksAllowed = map[string]struct{}{
 
 func contractKafkaAnnotations(project *model.Project, contract *model.Contract) (err error) {
 
+	return contractKafkaIssues(project, contract).err()
+}
+
+func contractKafkaIssues(project *model.Project, contract *model.Contract) (list issueList) {
+
 	if contract == nil {
-		return nil
+		return
 	}
 
 	if model.ContractHasLegacyKafkaRole(project, contract) {
-		return fmt.Errorf("contract %q: @tg kafka-consumer / kafka-publisher are removed; use @tg kafka (see kafka-pub-go / kafka-sub-go)", contract.Name)
+		list.add(contract, nil, "", fmt.Errorf("contract %q: @tg kafka-consumer / kafka-publisher are removed; use @tg kafka (see kafka-pub-go / kafka-sub-go)", contract.Name))
+		return
 	}
 	if !model.ContractIsKafka(project, contract) {
-		return nil
+		return
 	}
 
 	if model.ContractIsHTTPFamily(project, contract) {
-		return fmt.Errorf("contract %q: kafka contracts cannot combine with http-server/jsonRPC-server/ws-server/sse-server", contract.Name)
+		list.add(contract, nil, "", fmt.Errorf("contract %q: kafka contracts cannot combine with http-server/jsonRPC-server/ws-server/sse-server", contract.Name))
+		return
 	}
 	if model.IsAnnotationSet(project, contract, nil, nil, model.TagStream) {
-		return fmt.Errorf("contract %q: kafka contracts cannot use stream annotation", contract.Name)
+		list.add(contract, nil, "", annotationErr(model.TagStream, fmt.Errorf("contract %q: kafka contracts cannot use stream annotation", contract.Name)))
+		return
 	}
 
 	if len(contract.Methods) == 0 {
-		return fmt.Errorf("contract %q: kafka contract requires at least one method", contract.Name)
+		list.add(contract, nil, "", fmt.Errorf("contract %q: kafka contract requires at least one method", contract.Name))
+		return
 	}
 
 	if raw := model.ContractKafkaAcks(project, contract); raw != "" {
 		if _, ok := kafkaAcksAllowed[raw]; !ok {
-			return fmt.Errorf("contract %q: kafka-acks must be noAck, leaderAck or allISRAcks, got %q", contract.Name, raw)
+			list.add(contract, nil, "", annotationErr(model.TagKafkaAcks, fmt.Errorf("contract %q: kafka-acks must be noAck, leaderAck or allISRAcks, got %q", contract.Name, raw)))
 		}
 	}
 
 	topics := make(map[string]string)
 	for _, method := range contract.Methods {
-		if err = validateKafkaMethod(project, contract, method); err != nil {
-			return
+		if err := validateKafkaMethod(project, contract, method); err != nil {
+			list.add(contract, method, "", err)
+			continue
 		}
 		topic := model.MethodKafkaTopic(project, contract, method)
 		owner := contract.Name + "." + method.Name
 		if prev, exists := topics[topic]; exists {
-			return fmt.Errorf("contract %q: methods %q and %q share kafka-topic %q (one owner per topic)", contract.Name, prev, method.Name, topic)
+			list.add(contract, method, "", annotationErr(model.TagKafkaTopic, fmt.Errorf("contract %q: methods %q and %q share kafka-topic %q (one owner per topic)", contract.Name, prev, method.Name, topic)))
+			continue
 		}
 		topics[topic] = owner
 	}
-	return nil
+	return
 }
 
 // KafkaProject проверяет уникальность топиков среди всех @tg kafka контрактов проекта.
 func KafkaProject(project *model.Project) (err error) {
 
+	return kafkaProjectIssues(project).err()
+}
+
+func kafkaProjectIssues(project *model.Project) (list issueList) {
+
 	if project == nil {
-		return nil
+		return
 	}
 	owners := make(map[string]string)
 	for _, contract := range project.Contracts {
@@ -78,34 +95,61 @@ func KafkaProject(project *model.Project) (err error) {
 			}
 			owner := contract.Name + "." + method.Name
 			if prev, exists := owners[topic]; exists {
-				return fmt.Errorf("kafka-topic %q is owned by %s and %s (must be unique in contracts-dir)", topic, prev, owner)
+				// Дубликат внутри одного контракта уже отражён проверкой контракта.
+				if !strings.HasPrefix(prev, contract.Name+".") {
+					list.add(contract, method, "", annotationErr(model.TagKafkaTopic, fmt.Errorf("kafka-topic %q is owned by %s and %s (must be unique in contracts-dir)", topic, prev, owner)))
+				}
+				continue
 			}
 			owners[topic] = owner
 		}
 	}
-	return nil
+	for _, contract := range project.Contracts {
+		if !model.ContractIsKafka(project, contract) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			topic := model.MethodKafkaTopic(project, contract, method)
+			for attempt := 1; attempt <= model.MethodKafkaRetry(project, contract, method); attempt++ {
+				if owner, exists := owners[model.KafkaRetryTopic(topic, attempt)]; exists {
+					list.add(contract, method, "", annotationErr(model.TagKafkaRetry, fmt.Errorf("retry topic %q of %s.%s is owned by %s", model.KafkaRetryTopic(topic, attempt), contract.Name, method.Name, owner)))
+				}
+			}
+		}
+	}
+	return
 }
 
 func validateKafkaMethod(project *model.Project, contract *model.Contract, method *model.Method) (err error) {
 
 	if model.IsAnnotationSet(project, contract, method, nil, model.TagStream) {
-		return fmt.Errorf("contract %q: method %q: stream is not allowed on kafka methods", contract.Name, method.Name)
+		return annotationErr(model.TagStream, fmt.Errorf("contract %q: method %q: stream is not allowed on kafka methods", contract.Name, method.Name))
 	}
 	if model.IsAnnotationSet(project, contract, method, nil, model.TagHTTPMethod) {
-		return fmt.Errorf("contract %q: method %q: http-method is not allowed on kafka methods", contract.Name, method.Name)
+		return annotationErr(model.TagHTTPMethod, fmt.Errorf("contract %q: method %q: http-method is not allowed on kafka methods", contract.Name, method.Name))
 	}
 
 	topic := model.MethodKafkaTopic(project, contract, method)
 	if topic == "" {
-		return fmt.Errorf("contract %q: method %q: kafka-topic is required and must be non-empty after trim", contract.Name, method.Name)
+		return annotationErr(model.TagKafkaTopic, fmt.Errorf("contract %q: method %q: kafka-topic is required and must be non-empty after trim", contract.Name, method.Name))
 	}
 
 	if methodRaw := strings.TrimSpace(method.Annotations.Value(model.TagKafkaAcks, "")); methodRaw != "" {
 		if _, ok := kafkaAcksAllowed[methodRaw]; !ok {
-			return fmt.Errorf("contract %q: method %q: kafka-acks must be noAck, leaderAck or allISRAcks, got %q", contract.Name, method.Name, methodRaw)
+			return annotationErr(model.TagKafkaAcks, fmt.Errorf("contract %q: method %q: kafka-acks must be noAck, leaderAck or allISRAcks, got %q", contract.Name, method.Name, methodRaw))
+		}
+	}
+
+	if raw := strings.TrimSpace(model.GetAnnotationValue(project, contract, method, nil, model.TagKafkaRetry, "")); raw != "" {
+		if err = validateRetryValue(raw); err != nil {
+			return annotationErr(model.TagKafkaRetry, fmt.Errorf("contract %q: method %q: kafka-retry %w", contract.Name, method.Name, err))
 		}
 	}
 
+	if dlq := model.MethodKafkaDLQ(project, contract, method); dlq == topic {
+		return annotationErr(model.TagKafkaDLQ, fmt.Errorf("contract %q: method %q: kafka-dlq must differ from kafka-topic %q", contract.Name, method.Name, topic))
+	}
+
 	for _, arg := range method.Args {
 		if model.TypeRefIsChan(project, &arg.TypeRef) {
 			return fmt.Errorf("contract %q: method %q: channels are not allowed on kafka methods", contract.Name, method.Name)
@@ -155,7 +199,7 @@ func validateKafkaMessageAndBindings(project *model.Project, contract *model.Con
 	explicitName := model.MethodKafkaMessageArgName(project, contract, method)
 	message, hasMessage := model.MethodKafkaMessageArg(project, contract, method)
 	if explicitName != "" && !hasMessage {
-		return fmt.Errorf("contract %q: method %q: kafka-message argument %q not found", contract.Name, method.Name, explicitName)
+		return annotationErr(model.TagKafkaMessage, fmt.Errorf("contract %q: method %q: kafka-message argument %q not found", contract.Name, method.Name, explicitName))
 	}
 	if !hasMessage {
 		return fmt.Errorf("contract %q: method %q: cannot resolve message argument (set @tg kafka-message or leave exactly one free arg)", contract.Name, method.Name)
@@ -167,7 +211,7 @@ func validateKafkaMessageAndBindings(project *model.Project, contract *model.Con
 	keyArg := model.MethodKafkaKeyArg(project, contract, method)
 	items := model.MethodKafkaHeaderItems(project, contract, method)
 	if raw := strings.TrimSpace(model.GetAnnotationValue(project, contract, method, nil, model.TagKafkaHeaders, "")); raw != "" && len(items) == 0 {
-		return fmt.Errorf("contract %q: method %q: invalid kafka-headers format (expected arg|header pairs with non-empty names)", contract.Name, method.Name)
+		return annotationErr(model.TagKafkaHeaders, fmt.Errorf("contract %q: method %q: invalid kafka-headers format (expected arg|header pairs with non-empty names)", contract.Name, method.Name))
 	}
 
 	argByName := make(map[string]*model.Variable, len(method.Args))
@@ -178,39 +222,44 @@ func validateKafkaMessageAndBindings(project *model.Project, contract *model.Con
 	if keyArg != "" {
 		keyVar, found := argByName[keyArg]
 		if !found {
-			return fmt.Errorf("contract %q: method %q: kafka-key argument %q not found", contract.Name, method.Name, keyArg)
+			return annotationErr(model.TagKafkaKey, fmt.Errorf("contract %q: method %q: kafka-key argument %q not found", contract.Name, method.Name, keyArg))
 		}
 		if keyArg == message.Name {
-			return fmt.Errorf("contract %q: method %q: kafka-key argument %q cannot be the message", contract.Name, method.Name, keyArg)
+			return annotationErr(model.TagKafkaKey, fmt.Errorf("contract %q: method %q: kafka-key argument %q cannot be the message", contract.Name, method.Name, keyArg))
 		}
 		if !model.TypeRefIsKafkaKeyOrHeader(&keyVar.TypeRef) {
-			return fmt.Errorf("contract %q: method %q: kafka-key argument %q type must be string, []byte, []string or [][]byte", contract.Name, method.Name, keyArg)
+			return annotationErr(model.TagKafkaKey, fmt.Errorf("contract %q: method %q: kafka-key argument %q type must be string, []byte, []string or [][]byte", contract.Name, method.Name, keyArg))
 		}
 	}
 
 	for _, item := range items {
 		if strings.TrimSpace(item.Key) == "" {
-			return fmt.Errorf("contract %q: method %q: kafka-headers header name must be non-empty after trim", contract.Name, method.Name)
+			return annotationErr(model.TagKafkaHeaders, fmt.Errorf("contract %q: method %q: kafka-headers header name must be non-empty after trim", contract.Name, method.Name))
 		}
 		headerVar, found := argByName[item.Arg]
 		if !found {
-			return fmt.Errorf("contract %q: method %q: kafka-headers argument %q not found", contract.Name, method.Name, item.Arg)
+			return annotationErr(model.TagKafkaHeaders, fmt.Errorf("contract %q: method %q: kafka-headers argument %q not found", contract.Name, method.Name, item.Arg))
 		}
 		if item.Arg == message.Name {
-			return fmt.Errorf("contract %q: method %q: kafka-headers argument %q cannot be the message", contract.Name, method.Name, item.Arg)
+			return annotationErr(model.TagKafkaHeaders, fmt.Errorf("contract %q: method %q: kafka-headers argument %q cannot be the message", contract.Name, method.Name, item.Arg))
 		}
 		if keyArg != "" && item.Arg == keyArg {
-			return fmt.Errorf("contract %q: method %q: kafka-headers argument %q cannot be the key", contract.Name, method.Name, item.Arg)
+			return annotationErr(model.TagKafkaHeaders, fmt.Errorf("contract %q: method %q: kafka-headers argument %q cannot be the key", contract.Name, method.Name, item.Arg))
 		}
 		if !model.TypeRefIsKafkaKeyOrHeader(&headerVar.TypeRef) {
-			return fmt.Errorf("contract %q: method %q: kafka-headers argument %q type must be string, []byte, []string or [][]byte", contract.Name, method.Name, item.Arg)
+			return annotationErr(model.TagKafkaHeaders, fmt.Errorf("contract %q: method %q: kafka-headers argument %q type must be string, []byte, []string or [][]byte", contract.Name, method.Name, item.Arg))
 		}
 	}
 
 	codec := model.MethodKafkaCodec(project, contract, method)
 	if codec == model.KafkaCodecBytes {
 		if !model.TypeRefIsByteSlice(&message.TypeRef) && !model.TypeRefIsByteSliceSlice(&message.TypeRef) && !model.TypeRefIsByteSliceEllipsis(&message.TypeRef) {
-			return fmt.Errorf("contract %q: method %q: kafka-codec=bytes requires message []byte, [][]byte or ...[]byte", contract.Name, method.Name)
+			return annotationErr(model.TagKafkaCodec, fmt.Errorf("contract %q: method %q: kafka-codec=bytes requires message []byte, [][]byte or ...[]byte", contract.Name, method.Name))
+		}
+	}
+	if kafka.IsSchemaCodec(codec) {
+		if _, err = kafka.SchemaFor(codec, project, message.TypeRef); err != nil {
+			return annotationErr(model.TagKafkaCodec, fmt.Errorf("contract %q: method %q: kafka-codec=%s: %w", contract.Name, method.Name, codec, err))
 		}
 	}
 	return nil
@@ -207,6 +207,39 @@ func TestContractKafkaBytesCodec(t *testing.T) {
 	}
 }
 
+func TestContractKafkaSchemaCodec(t *testing.T) {
+
+	project := kafkaProject()
+	project.Types["example:Order"].StructFields = []*model.StructField{
+		{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}, "protobuf": {"1"}}},
+	}
+	for _, codec := range []string{model.KafkaCodecAvro, model.KafkaCodecProto} {
+		method := kafkaMethod("X", "t", ctxArg(), eventArg())
+		method.Annotations[model.TagKafkaCodec] = codec
+		contract := &model.Contract{
+			Name:        "Events",
+			Annotations: tags.DocTags{model.TagKafka: ""},
+			Methods:     []*model.Method{method},
+		}
+		if err := Contract(contract, project); err != nil {
+			t.Fatalf("%s struct message: %v", codec, err)
+		}
+		bad := kafkaMethod("Y", "t2", ctxArg(), &model.Variable{Name: "body", TypeRef: model.TypeRef{TypeID: "string"}})
+		bad.Annotations[model.TagKafkaCodec] = codec
+		contract.Methods = []*model.Method{bad}
+		if err := Contract(contract, project); err == nil || !strings.Contains(err.Error(), "named struct") {
+			t.Fatalf("%s string message error = %v, want named struct", codec, err)
+		}
+	}
+	project.Types["example:Order"].StructFields[0].TypeID = "uint64"
+	method := kafkaMethod("X", "t", ctxArg(), eventArg())
+	method.Annotations[model.TagKafkaCodec] = model.KafkaCodecAvro
+	contract := &model.Contract{Name: "Events", Annotations: tags.DocTags{model.TagKafka: ""}, Methods: []*model.Method{method}}
+	if err := Contract(contract, project); err == nil || !strings.Contains(err.Error(), "avro") {
+		t.Fatalf("avro uint64 error = %v", err)
+	}
+}
+
 func TestContractKafkaInvalidAcks(t *testing.T) {
 
 	project := kafkaProject()
@@ -253,3 +286,53 @@ func TestContractKafkaEmptyHeaderName(t *testing.T) {
 		t.Fatal("expected empty header name error")
 	}
 }
+
+func TestContractKafkaDeadLetter(t *testing.T) {
+
+	project := kafkaProject()
+	method := kafkaMethod("X", "orders", ctxArg(), eventArg())
+	method.Annotations[model.TagKafkaRetry] = "3"
+	method.Annotations[model.TagKafkaDLQ] = "orders.dlq"
+	contract := &model.Contract{
+		Name:        "Events",
+		Annotations: tags.DocTags{model.TagKafka: ""},
+		Methods:     []*model.Method{method},
+	}
+	if err := Contract(contract, project); err != nil {
+		t.Fatalf("retry and dlq ok: %v", err)
+	}
+
+	method.Annotations[model.TagKafkaRetry] = "-1"
+	if err := Contract(contract, project); err == nil {
+		t.Fatal("expected invalid kafka-retry error")
+	}
+
+	method.Annotations[model.TagKafkaRetry] = "1"
+	method.Annotations[model.TagKafkaDLQ] = "orders"
+	if err := Contract(contract, project); err == nil {
+		t.Fatal("expected dlq equal to topic error")
+	}
+}
+
+func TestKafkaProjectRetryTopicOwned(t *testing.T) {
+
+	project := kafkaProject()
+	retried := kafkaMethod("M1", "orders", ctxArg(), eventArg())
+	retried.Annotations[model.TagKafkaRetry] = "2"
+	project.Contracts = []*model.Contract{
+		{
+			Name:        "A",
+			Annotations: tags.DocTags{model.TagKafka: ""},
+			Methods:     []*model.Method{retried},
+		},
+		{
+			Name:        "B",
+			Annotations: tags.DocTags{model.TagKafka: ""},
+			Methods:     []*model.Method{kafkaMethod("M2", "orders.retry.2", ctxArg(), eventArg())},
+		},
+	}
+	err := KafkaProject(project)
+	if err == nil || !strings.Contains(err.Error(), "orders.retry.2") {
+		t.Fatalf("expected retry topic ownership error, got %v", err)
+	}
+}
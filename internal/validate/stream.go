@@ -11,37 +11,20 @@ import (
 
 func contractStreamAnnotations(project *model.Project, contract *model.Contract) (err error) {
 
+	return contractStreamIssues(project, contract).err()
+}
+
+func contractStreamIssues(project *model.Project, contract *model.Contract) (list issueList) {
+
 	if contract == nil {
-		return nil
+		return
 	}
 
 	hasWS := model.ContractHasWS(project, contract)
 	hasSSE := model.ContractHasSSE(project, contract)
 
 	for _, method := range contract.Methods {
-		mode := model.MethodStreamMode(project, contract, method)
-		if mode == "" {
-			if err = validateNonStreamNoChan(project, contract, method); err != nil {
-				return
-			}
-			continue
-		}
-
-		if !hasWS && !hasSSE {
-			return fmt.Errorf("contract %q: method %q: stream=%s requires ws-server and/or sse-server on the contract", contract.Name, method.Name, mode)
-		}
-		if mode != model.StreamModeServer && !hasWS {
-			return fmt.Errorf("contract %q: method %q: stream=%s requires ws-server", contract.Name, method.Name, mode)
-		}
-		if model.IsAnnotationSet(project, contract, method, nil, model.TagSSEPath) && mode != model.StreamModeServer {
-			return fmt.Errorf("contract %q: method %q: sse-path is allowed only with stream=server", contract.Name, method.Name)
-		}
-		if model.IsAnnotationSet(project, contract, method, nil, model.TagHTTPMethod) {
-			return fmt.Errorf("contract %q: method %q: stream method cannot have http-method", contract.Name, method.Name)
-		}
-		if err = validateStreamSignature(project, contract, method, mode); err != nil {
-			return
-		}
+		list.add(contract, method, "", methodStreamAnnotations(project, contract, method, hasWS, hasSSE))
 	}
 
 	if hasWS || hasSSE {
@@ -53,11 +36,39 @@ func contractStreamAnnotations(project *model.Project, contract *model.Contract)
 			}
 		}
 		if !hasStreamMethod {
-			return fmt.Errorf("contract %q: ws-server/sse-server requires at least one method with stream=server|client|bidi", contract.Name)
+			list.add(contract, nil, "", fmt.Errorf("contract %q: ws-server/sse-server requires at least one method with stream=server|client|bidi", contract.Name))
 		}
 	}
 
-	return nil
+	return
+}
+
+func methodStreamAnnotations(project *model.Project, contract *model.Contract, method *model.Method, hasWS bool, hasSSE bool) (err error) {
+
+	mode := model.MethodStreamMode(project, contract, method)
+	if mode == "" {
+		return validateNonStreamNoChan(project, contract, method)
+	}
+
+	if !hasWS && !hasSSE {
+		return annotationErr(model.TagStream, fmt.Errorf("contract %q: method %q: stream=%s requires ws-server and/or sse-server on the contract", contract.Name, method.Name, mode))
+	}
+	if mode != model.StreamModeServer && !hasWS {
+		return annotationErr(model.TagStream, fmt.Errorf("contract %q: method %q: stream=%s requires ws-server", contract.Name, method.Name, mode))
+	}
+	if model.IsAnnotationSet(project, contract, method, nil, model.TagSSEPath) && mode != model.StreamModeServer {
+		return annotationErr(model.TagSSEPath, fmt.Errorf("contract %q: method %q: sse-path is allowed only with stream=server", contract.Name, method.Name))
+	}
+	if model.IsAnnotationSet(project, contract, method, nil, model.TagHTTPMethod) {
+		return annotationErr(model.TagHTTPMethod, fmt.Errorf("contract %q: method %q: stream method cannot have http-method", contract.Name, method.Name))
+	}
+	// Лимиты проверяются только для unary-вызовов REST и JSON-RPC: на потоке аннотация метода ничего бы не ограничивала.
+	for _, tagName := range []string{model.TagRateLimit, model.TagMaxInFlight} {
+		if _, found := method.Annotations[tagName]; found {
+			return annotationErr(tagName, fmt.Errorf("contract %q: method %q: %s is not applied to stream methods", contract.Name, method.Name, tagName))
+		}
+	}
+	return validateStreamSignature(project, contract, method, mode)
 }
 
 func validateNonStreamNoChan(project *model.Project, contract *model.Contract, method *model.Method) (err error) {
@@ -118,14 +129,14 @@ func validateStreamSignature(project *model.Project, contract *model.Contract, m
 			return fmt.Errorf("contract %q: method %q: stream=bidi requires one <-chan argument and one <-chan result", contract.Name, method.Name)
 		}
 	default:
-		return fmt.Errorf("contract %q: method %q: unsupported stream mode %q", contract.Name, method.Name, mode)
+		return annotationErr(model.TagStream, fmt.Errorf("contract %q: method %q: unsupported stream mode %q", contract.Name, method.Name, mode))
 	}
 
 	if raw := strings.TrimSpace(model.GetAnnotationValue(project, contract, method, nil, model.TagStream, "")); raw != "" {
 		switch strings.ToLower(raw) {
 		case model.StreamModeServer, model.StreamModeClient, model.StreamModeBidi:
 		default:
-			return fmt.Errorf("contract %q: method %q: stream must be server|client|bidi, got %q", contract.Name, method.Name, raw)
+			return annotationErr(model.TagStream, fmt.Errorf("contract %q: method %q: stream must be server|client|bidi, got %q", contract.Name, method.Name, raw))
 		}
 	}
 
@@ -3,6 +3,7 @@
 package validate
 
 import (
+	"strings"
 	"testing"
 
 	"tgp/internal/model"
@@ -130,3 +131,36 @@ func TestContractStreamAnnotations_SSEPathOnlyServer(t *testing.T) {
 		t.Fatal("expected error: sse-path on client stream")
 	}
 }
+
+func TestContractStreamAnnotations_RejectsLimits(t *testing.T) {
+
+	project := &model.Project{Types: map[string]*model.Type{}}
+	contract := &model.Contract{
+		Name: "Live",
+		Annotations: tags.DocTags{
+			model.TagServerSSE: "",
+			model.TagRateLimit: "100/s",
+		},
+		Methods: []*model.Method{
+			{
+				Name:        "Subscribe",
+				Annotations: tags.DocTags{model.TagStream: model.StreamModeServer, model.TagRateLimit: "10/s"},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+				},
+				Results: []*model.Variable{
+					{Name: "ticks", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "string"}, ChanDirection: 2}},
+					{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+				},
+			},
+		},
+	}
+	err := contractStreamAnnotations(project, contract)
+	if err == nil || !strings.Contains(err.Error(), "rate-limit is not applied to stream methods") {
+		t.Fatalf("method rate-limit on a stream must be rejected, got %v", err)
+	}
+	delete(contract.Methods[0].Annotations, model.TagRateLimit)
+	if err = contractStreamAnnotations(project, contract); err != nil {
+		t.Fatalf("contract rate-limit must not be rejected for its stream methods: %v", err)
+	}
+}
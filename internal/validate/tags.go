@@ -0,0 +1,450 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+// Префикс ключей описаний групп swagger (@tg tagDesc.<group>=...).
+const tagDescPrefix = "tagDesc."
+
+// knownAnnotations — все ключи @tg, которые понимают astg и плагины генерации.
+// Функция проверяет значение; nil — значение не проверяется.
+var knownAnnotations = map[string]func(value string) error{
+	model.TagHTTPMethod:             validateHTTPMethodValue,
+	model.TagHttpPrefix:             nil,
+	model.TagHttpPath:               nil,
+	model.TagHttpSuccess:            validateHTTPSuccessValue,
+	model.TagHttpArg:                nil,
+	model.TagHttpHeader:             nil,
+	model.TagHttpCookies:            nil,
+	model.TagRequestContentType:     nil,
+	model.TagResponseContentType:    nil,
+	model.TagHttpMultipart:          nil,
+	model.TagHttpPartName:           nil,
+	model.TagHttpPartContent:        nil,
+	model.TagServerJsonRPC:          nil,
+	model.TagServerHTTP:             nil,
+	model.TagServerWS:               nil,
+	model.TagServerSSE:              nil,
+	model.TagStream:                 validateStreamValue,
+	model.TagWSPath:                 nil,
+	model.TagSSEPath:                nil,
+	model.TagKafka:                  nil,
+	model.TagKafkaConsumer:          nil,
+	model.TagKafkaPublisher:         nil,
+	model.TagKafkaTopic:             nil,
+	model.TagKafkaKey:               nil,
+	model.TagKafkaHeaders:           nil,
+	model.TagKafkaMessage:           nil,
+	model.TagKafkaCodec:             nil,
+	model.TagKafkaAcks:              validateKafkaAcksValue,
+	model.TagKafkaRetry:             validateRetryValue,
+	model.TagKafkaDLQ:               nil,
+	model.TagHttpEnableInlineSingle: nil,
+	model.TagParamTags:              nil,
+	model.TagRequired:               nil,
+	model.TagLogger:                 nil,
+	model.TagMetrics:                nil,
+	model.TagTrace:                  nil,
+	model.TagLogSkip:                nil,
+	model.TagPackageJSON:            nil,
+	model.TagDeprecated:             nil,
+	model.TagCompat:                 validateCompatValue,
+	model.TagCompatIgnore:           validateCompatIgnoreValue,
+	model.TagRetry:                  validateRetryValue,
+	model.TagHttpErrors:             validateHTTPErrorsValue,
+	model.TagHttpProblemBase:        nil,
+	model.TagRateLimit:              validateRateLimitValue,
+	model.TagRateLimitBurst:         validateRateLimitBurstValue,
+	model.TagRateLimitKey:           validateRateLimitKeyValue,
+	model.TagMaxInFlight:            validateMaxInFlightValue,
+	model.TagSecurity:               validateSecurityValue,
+	model.TagScopes:                 validateScopesValue,
+	model.TagIdempotent:             nil,
+	model.TagIdempotencyHeader:      validateHeaderNameValue,
+	"uuidPackage":                   nil,
+	"swaggerTags":                   nil,
+	"webhook":                       nil,
+	"servers":                       nil,
+	"version":                       nil,
+	"title":                         nil,
+	"author":                        nil,
+	"npmRegistry":                   nil,
+	"npmName":                       nil,
+	"npmPrivate":                    validateBoolValue,
+	"license":                       nil,
+	"desc":                          nil,
+	"summary":                       nil,
+	"requestBodyDesc":               nil,
+	"defaultError":                  nil,
+	"handler":                       nil,
+	"http-response":                 nil,
+	"tagOmitemptyAll":               nil,
+	"query":                         nil,
+	"nullable":                      nil,
+	"type":                          nil,
+	"enums":                         nil,
+	"format":                        nil,
+	"example":                       nil,
+}
+
+// variableAnnotations — ключи, допустимые для аргумента/результата (@tg <var>.<key>) и поля структуры.
+var variableAnnotations = map[string]struct{}{
+	model.TagRequired:        {},
+	model.TagParamTags:       {},
+	model.TagLogSkip:         {},
+	model.TagHttpPartName:    {},
+	model.TagHttpPartContent: {},
+	"desc":                   {},
+	"format":                 {},
+	"example":                {},
+	"enums":                  {},
+	"type":                   {},
+	"nullable":               {},
+}
+
+// compatFamilies — семейства транспорта, допустимые в @tg compat-ignore.
+var compatFamilies = map[string]struct{}{
+	"http":    {},
+	"jsonrpc": {},
+	"ws":      {},
+	"sse":     {},
+	"kafka":   {},
+}
+
+// annotationIssues проверяет ключи и значения аннотаций на всех уровнях: проект, контракт, метод, переменная, поле типа модуля.
+func annotationIssues(project *model.Project) (list issueList) {
+
+	list = append(list, docTagsIssues(project.Annotations, nil, nil)...)
+	for _, contract := range project.Contracts {
+		list = append(list, docTagsIssues(contract.Annotations, contract, nil)...)
+		for _, method := range contract.Methods {
+			list = append(list, methodAnnotationIssues(contract, method)...)
+		}
+	}
+
+	typeIDs := make([]string, 0, len(project.Types))
+	for typeID, typ := range project.Types {
+		if typ != nil && project.ModulePath != "" && strings.HasPrefix(typ.ImportPkgPath, project.ModulePath) {
+			typeIDs = append(typeIDs, typeID)
+		}
+	}
+	sort.Strings(typeIDs)
+	for _, typeID := range typeIDs {
+		for _, field := range project.Types[typeID].StructFields {
+			for _, key := range sortedKeys(field.Annotations) {
+				if _, ok := variableAnnotations[key]; ok {
+					continue
+				}
+				list = append(list, Issue{
+					Rule:    RuleUnknownAnnotation,
+					Warning: true,
+					TypeID:  typeID,
+					Field:   field.Name,
+					Tag:     key,
+					Err:     fmt.Errorf("type %q: field %q: unknown annotation %q%s", typeID, field.Name, key, suggestion(key, variableAnnotations)),
+				})
+			}
+		}
+	}
+	return
+}
+
+func methodAnnotationIssues(contract *model.Contract, method *model.Method) (list issueList) {
+
+	vars := append(append([]*model.Variable{}, method.Args...), method.Results...)
+	variables := make(map[string]*model.Variable, len(vars))
+	for _, variable := range vars {
+		variables[variable.Name] = variable
+	}
+
+	plain := make(tags.DocTags)
+	for _, key := range sortedKeys(method.Annotations) {
+		value := method.Annotations[key]
+		varName, subKey, dotted := strings.Cut(key, ".")
+		if !dotted || strings.HasPrefix(key, tagDescPrefix) {
+			plain[key] = value
+			continue
+		}
+		if _, found := variables[varName]; !found {
+			list = append(list, Issue{
+				Rule:     RuleUnknownAnnotation,
+				Warning:  true,
+				Contract: contract,
+				Method:   method,
+				Tag:      key,
+				Err:      fmt.Errorf("contract %q: method %q: annotation %q refers to unknown argument or result %q", contract.Name, method.Name, key, varName),
+			})
+			continue
+		}
+		if _, ok := variableAnnotations[subKey]; !ok {
+			list = append(list, Issue{
+				Rule:     RuleUnknownAnnotation,
+				Warning:  true,
+				Contract: contract,
+				Method:   method,
+				Variable: varName,
+				Tag:      key,
+				Err:      fmt.Errorf("contract %q: method %q: unknown annotation %q for %q%s", contract.Name, method.Name, subKey, varName, suggestion(subKey, variableAnnotations)),
+			})
+		}
+	}
+	list = append(list, docTagsIssues(plain, contract, method)...)
+
+	for _, variable := range vars {
+		for _, key := range sortedKeys(variable.Annotations) {
+			if _, ok := variableAnnotations[key]; ok {
+				continue
+			}
+			list = append(list, Issue{
+				Rule:     RuleUnknownAnnotation,
+				Warning:  true,
+				Contract: contract,
+				Method:   method,
+				Variable: variable.Name,
+				Tag:      key,
+				Err:      fmt.Errorf("contract %q: method %q: unknown annotation %q for %q%s", contract.Name, method.Name, key, variable.Name, suggestion(key, variableAnnotations)),
+			})
+		}
+	}
+	return
+}
+
+func docTagsIssues(docTags tags.DocTags, contract *model.Contract, method *model.Method) (list issueList) {
+
+	for _, key := range sortedKeys(docTags) {
+		if contract == nil || method == nil {
+			if strings.HasPrefix(key, tagDescPrefix) {
+				continue
+			}
+		}
+		check, known := knownAnnotations[key]
+		if !known {
+			list = append(list, Issue{
+				Rule:     RuleUnknownAnnotation,
+				Warning:  true,
+				Contract: contract,
+				Method:   method,
+				Tag:      key,
+				Err:      fmt.Errorf("%sunknown annotation %q%s", issueScope(contract, method), key, suggestion(key, knownAnnotations)),
+			})
+			continue
+		}
+		if check == nil {
+			continue
+		}
+		if err := check(strings.TrimSpace(docTags[key])); err != nil {
+			list = append(list, Issue{
+				Rule:     RuleAnnotationValue,
+				Contract: contract,
+				Method:   method,
+				Tag:      key,
+				Err:      fmt.Errorf("%s%s: %w", issueScope(contract, method), key, err),
+			})
+		}
+	}
+	return
+}
+
+func issueScope(contract *model.Contract, method *model.Method) (scope string) {
+
+	switch {
+	case contract == nil:
+		return "package: "
+	case method == nil:
+		return fmt.Sprintf("contract %q: ", contract.Name)
+	default:
+		return fmt.Sprintf("contract %q: method %q: ", contract.Name, method.Name)
+	}
+}
+
+func validateHTTPMethodValue(value string) (err error) {
+
+	if _, ok := allowedHTTPMethods[strings.ToUpper(value)]; !ok {
+		return fmt.Errorf("http method %q is not supported", value)
+	}
+	return
+}
+
+func validateHTTPSuccessValue(value string) (err error) {
+
+	if code, convErr := strconv.Atoi(value); convErr != nil || code <= 0 {
+		return fmt.Errorf("must be a positive integer, got %q", value)
+	}
+	return
+}
+
+func validateRetryValue(value string) (err error) {
+
+	if retries, convErr := strconv.Atoi(value); convErr != nil || retries < 0 {
+		return fmt.Errorf("must be a non-negative integer, got %q", value)
+	}
+	return
+}
+
+func validateStreamValue(value string) (err error) {
+
+	switch strings.ToLower(value) {
+	case model.StreamModeServer, model.StreamModeClient, model.StreamModeBidi:
+		return
+	}
+	return fmt.Errorf("must be server|client|bidi, got %q", value)
+}
+
+func validateKafkaAcksValue(value string) (err error) {
+
+	if _, ok := kafkaAcksAllowed[value]; !ok {
+		return fmt.Errorf("must be noAck, leaderAck or allISRAcks, got %q", value)
+	}
+	return
+}
+
+func validateCompatValue(value string) (err error) {
+
+	switch strings.ToLower(value) {
+	case model.CompatStrict, model.CompatWarn, model.CompatOff:
+		return
+	}
+	return fmt.Errorf("must be strict|warn|off, got %q", value)
+}
+
+func validateCompatIgnoreValue(value string) (err error) {
+
+	for _, family := range strings.Split(value, ",") {
+		if _, ok := compatFamilies[strings.ToLower(strings.TrimSpace(family))]; !ok {
+			return fmt.Errorf("must be a comma-separated list of http|jsonrpc|ws|sse|kafka, got %q", value)
+		}
+	}
+	return
+}
+
+func validateBoolValue(value string) (err error) {
+
+	if value == "" {
+		return
+	}
+	if _, parseErr := strconv.ParseBool(value); parseErr != nil {
+		return fmt.Errorf("must be true or false, got %q", value)
+	}
+	return
+}
+
+func validateHTTPErrorsValue(value string) (err error) {
+
+	if strings.EqualFold(value, model.HTTPErrorsProblem) {
+		return
+	}
+	return fmt.Errorf("must be problem, got %q", value)
+}
+
+func validateRateLimitValue(value string) (err error) {
+
+	_, err = model.ParseRateLimit(value)
+	return
+}
+
+func validateMaxInFlightValue(value string) (err error) {
+
+	_, err = model.ParseMaxInFlight(value)
+	return
+}
+
+func validateRateLimitBurstValue(value string) (err error) {
+
+	if burst, convErr := strconv.Atoi(value); convErr != nil || burst <= 0 {
+		return fmt.Errorf("must be a positive integer, got %q", value)
+	}
+	return
+}
+
+func validateRateLimitKeyValue(value string) (err error) {
+
+	if model.IsLimitKey(value) {
+		return
+	}
+	return fmt.Errorf("must be ip|client-id|header:<name>, got %q", value)
+}
+
+func validateHeaderNameValue(value string) (err error) {
+
+	if value == "" || strings.ContainsAny(value, " \t:,") {
+		return fmt.Errorf("must be an HTTP header name, got %q", value)
+	}
+	return
+}
+
+func validateScopesValue(value string) (err error) {
+
+	for _, scope := range strings.Split(value, ",") {
+		if strings.TrimSpace(scope) == "" {
+			return fmt.Errorf("must be a comma-separated list of scopes, got %q", value)
+		}
+	}
+	return
+}
+
+func validateSecurityValue(value string) (err error) {
+
+	tokens := model.SplitSecurityTokens(value)
+	for _, token := range tokens {
+		if model.IsSecurityNone(token) && len(tokens) > 1 {
+			return fmt.Errorf("none cannot be combined with other schemes, got %q", value)
+		}
+	}
+	return
+}
+
+// suggestion подбирает ближайший известный ключ для опечатки (не дальше двух правок).
+func suggestion[V any](key string, known map[string]V) (hint string) {
+
+	best, bestDistance := "", 3
+	for candidate := range known {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(candidate)); distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func editDistance(left string, right string) (distance int) {
+
+	a, b := []rune(left), []rune(right)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func sortedKeys(docTags tags.DocTags) (keys []string) {
+
+	keys = make([]string, 0, len(docTags))
+	for key := range docTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
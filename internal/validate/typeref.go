@@ -9,6 +9,8 @@ import (
 	"tgp/internal/model"
 )
 
+// Экземпляры обобщённых типов (Page[User]) парсер материализует в конкретные типы (Page_User);
+// ошибкой остаются неинстанцированные обобщённые типы и аргументы, которые не удалось разрешить.
 func validateTypeRefForGenerics(typeRef *model.TypeRef, project *model.Project, contractName, methodName, varType string) (err error) {
 
 	if typeRef == nil {
@@ -16,7 +18,15 @@ func validateTypeRefForGenerics(typeRef *model.TypeRef, project *model.Project,
 	}
 
 	if strings.Contains(typeRef.TypeID, "[") && strings.Contains(typeRef.TypeID, "]") {
-		return fmt.Errorf("contract %q: method %q: %s %q has unsupported generic type %q (generics are not supported)", contractName, methodName, varType, varType, typeRef.TypeID)
+		return fmt.Errorf("contract %q: method %q: %s %q has uninstantiated generic type %q (type arguments must be concrete)", contractName, methodName, varType, varType, typeRef.TypeID)
+	}
+
+	if typ, ok := project.Types[typeRef.TypeID]; ok && typ.GenericOf != "" {
+		for idx, typeArg := range typ.TypeArgs {
+			if typeArg == nil {
+				return fmt.Errorf("contract %q: method %q: %s %q has generic type %q with unresolved type argument #%d", contractName, methodName, varType, varType, typ.GenericOf, idx+1)
+			}
+		}
 	}
 
 	if typeRef.MapValue != nil {
@@ -31,12 +41,6 @@ func validateTypeRefForGenerics(typeRef *model.TypeRef, project *model.Project,
 		}
 	}
 
-	if typeRef.IsSlice {
-		if strings.Contains(typeRef.TypeID, "[") && strings.Contains(typeRef.TypeID, "]") {
-			return fmt.Errorf("contract %q: method %q: %s %q has unsupported generic type in slice element %q (generics are not supported)", contractName, methodName, varType, varType, typeRef.TypeID)
-		}
-	}
-
 	return
 }
 
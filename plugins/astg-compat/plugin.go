@@ -0,0 +1,93 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+
+	"tgp/core/data"
+	"tgp/core/i18n"
+	"tgp/core/plugin"
+	"tgp/internal/cdb"
+	"tgp/internal/model"
+)
+
+//go:embed plugin.md
+var docContent string
+
+const (
+	optionRefFrom = "ref-a"
+	optionRefTo   = "ref-b"
+	optionOut     = "out"
+	optionFormat  = "format"
+)
+
+// AstgCompatPlugin реализует command-плагин: проверка обратной совместимости двух версий проекта из локальной базы контрактов.
+type AstgCompatPlugin struct{}
+
+func (p *AstgCompatPlugin) Execute(request data.Storage) (response data.Storage, err error) {
+
+	response = request
+
+	var root string
+	if root, err = cdb.Root(); err != nil {
+		return response, fmt.Errorf("%s: %w", i18n.Msg("contracts db root"), err)
+	}
+	err = runCompat(request, root)
+	return
+}
+
+func runCompat(request data.Storage, root string) (err error) {
+
+	refFrom, _ := data.Get[string](request, optionRefFrom)
+	refTo, _ := data.Get[string](request, optionRefTo)
+	if refFrom == "" || refTo == "" {
+		return fmt.Errorf("%s", i18n.Msg("two refs are required: tg astg compat <ref-a> <ref-b>"))
+	}
+
+	var from, to *model.Project
+	if from, err = cdb.LoadRef(root, refFrom); err != nil {
+		return fmt.Errorf("%s %s: %w", i18n.Msg("load project from db"), refFrom, err)
+	}
+	if to, err = cdb.LoadRef(root, refTo); err != nil {
+		return fmt.Errorf("%s %s: %w", i18n.Msg("load project from db"), refTo, err)
+	}
+
+	rep := newReport(refFrom, refTo, cdb.CheckCompatibility(from, to))
+	out, _ := data.Get[string](request, optionOut)
+	format, _ := data.Get[string](request, optionFormat)
+	if err = writeReport(rep, format, out); err != nil {
+		return
+	}
+	if rep.Summary.Breaking > 0 {
+		return fmt.Errorf("%s: %d", i18n.Msg("breaking changes found"), rep.Summary.Breaking)
+	}
+	return
+}
+
+func (p *AstgCompatPlugin) Info() (info plugin.Info, err error) {
+
+	info = plugin.Info{
+		Name:          "astg-compat",
+		Description:   i18n.Msg("Backward compatibility check between two project versions stored in the contracts DB"),
+		Author:        "AlexK <seniorGolang@gmail.com>",
+		License:       "MIT",
+		Category:      "utility",
+		Doc:           docContent,
+		AllowedStdOut: true,
+		AllowedStdErr: true,
+		AllowedPaths:  map[string]string{"@tg/astg/db": "r", "@go": "w"},
+		Commands: []plugin.Command{
+			{
+				Path:        []string{"astg", "compat"},
+				Description: i18n.Msg("Classify changes between two refs as breaking or compatible per transport and fail on breaking ones"),
+				Options: []plugin.Option{
+					{Name: optionRefFrom, Type: "string", Description: i18n.Msg("Base ref (project[:contracts]@version)"), Required: true, IsPositional: true},
+					{Name: optionRefTo, Type: "string", Description: i18n.Msg("Target ref (project[:contracts]@version)"), Required: true, IsPositional: true},
+					{Name: optionFormat, Short: "f", Type: "string", Description: i18n.Msg("Output format: text or json"), Default: formatText},
+					{Name: optionOut, Short: "o", Type: "string", Description: i18n.Msg("Path to output file (default: stdout)")},
+				},
+			},
+		},
+	}
+	return
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/core/data"
+	"tgp/internal/cdb"
+	"tgp/internal/cdb/cdbtest"
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestRunCompat(t *testing.T) {
+
+	t.Parallel()
+
+	const projectKey = "example.com.app"
+
+	root := t.TempDir()
+	cdbtest.SeedVersion(t, root, projectKey, "v1", &model.Project{
+		ModulePath: "example.com/app",
+		Contracts: []*model.Contract{
+			{Name: "Users", Annotations: tags.DocTags{model.TagServerHTTP: ""}, Methods: []*model.Method{
+				{Name: "Get", Annotations: tags.DocTags{model.TagHTTPMethod: "GET"}},
+				{Name: "Delete", Annotations: tags.DocTags{model.TagHTTPMethod: "DELETE", model.TagDeprecated: ""}},
+			}},
+		},
+	})
+	cdbtest.SeedVersion(t, root, projectKey, "v2", &model.Project{
+		ModulePath: "example.com/app",
+		Contracts: []*model.Contract{
+			{Name: "Users", Annotations: tags.DocTags{model.TagServerHTTP: ""}, Methods: []*model.Method{
+				{Name: "Get", Annotations: tags.DocTags{model.TagHTTPMethod: "POST"}},
+			}},
+		},
+	})
+
+	out := filepath.Join(t.TempDir(), "compat.json")
+	err := runCompat(storage(t, map[string]string{optionRefFrom: projectKey + "@v1", optionRefTo: projectKey + "@v2", optionFormat: formatJSON, optionOut: out}), root)
+	if err == nil || !strings.Contains(err.Error(), "breaking changes found: 1") {
+		t.Fatalf("runCompat: expected one breaking change, got %v", err)
+	}
+
+	payload, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var rep report
+	if err = json.Unmarshal(payload, &rep); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if rep.Summary.Breaking != 1 || rep.Summary.Suppressed != 1 {
+		t.Fatalf("summary: got %+v", rep.Summary)
+	}
+	for _, finding := range rep.Findings {
+		if finding.Method == "Delete" && finding.Suppressed != cdb.SuppressedDeprecated {
+			t.Fatalf("deprecated method removal must be suppressed: %+v", finding)
+		}
+	}
+
+	// Сравнение версии с самой собой проходит проверку.
+	if err = runCompat(storage(t, map[string]string{optionRefFrom: projectKey + "@v2", optionRefTo: projectKey + "@v2", optionOut: filepath.Join(t.TempDir(), "compat.txt")}), root); err != nil {
+		t.Fatalf("runCompat same version: %v", err)
+	}
+}
+
+func TestRunCompatRequiresTwoRefs(t *testing.T) {
+
+	t.Parallel()
+
+	if err := runCompat(storage(t, map[string]string{optionRefFrom: "app@v1"}), t.TempDir()); err == nil {
+		t.Fatal("expected error without ref-b")
+	}
+}
+
+func TestRenderText(t *testing.T) {
+
+	t.Parallel()
+
+	text := string(renderText(newReport("app@v1", "app@v2", []cdb.Finding{
+		{Change: cdb.Change{Kind: cdb.ChangeRemoved, Entity: cdb.EntityMethod, Path: "Users.Delete"}, Family: cdb.FamilyHTTP, Breaking: true, Reason: "method removed", Suppressed: cdb.SuppressedDeprecated},
+		{Change: cdb.Change{Kind: cdb.ChangeChanged, Entity: cdb.EntityRoute, Path: "Users.Get", Name: cdb.FamilyHTTP, Before: "GET /a", After: "POST /a"}, Family: cdb.FamilyHTTP, Breaking: true, Reason: "route changed"},
+		{Change: cdb.Change{Kind: cdb.ChangeAdded, Entity: cdb.EntityMethod, Path: "Users.List"}, Family: cdb.FamilyHTTP},
+	})))
+	want := strings.Join([]string{
+		"app@v1 → app@v2",
+		"BREAKING [http] route Users.Get: route changed (GET /a → POST /a)",
+		"suppressed (deprecated) [http] method Users.Delete: method removed",
+		"breaking: 1, suppressed: 1, compatible: 1",
+		"",
+	}, "\n")
+	if text != want {
+		t.Fatalf("text:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestInfo(t *testing.T) {
+
+	t.Parallel()
+
+	plugin := &AstgCompatPlugin{}
+	info, err := plugin.Info()
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.Name != "astg-compat" {
+		t.Fatalf("name: got %q want astg-compat", info.Name)
+	}
+	wantPath := []string{"astg", "compat"}
+	if got := info.Commands[0].Path; len(got) != len(wantPath) || got[0] != wantPath[0] || got[1] != wantPath[1] {
+		t.Fatalf("path: got %v want %v", got, wantPath)
+	}
+}
+
+func storage(t *testing.T, options map[string]string) (request data.Storage) {
+
+	t.Helper()
+
+	request = data.NewStorage()
+	for key, value := range options {
+		if err := request.Set(key, value); err != nil {
+			t.Fatalf("Set %s: %v", key, err)
+		}
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"tgp/core/i18n"
+	"tgp/internal/cdb"
+	"tgp/internal/common"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// report — результат проверки совместимости двух ссылок.
+type report struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	Summary  summary       `json:"summary"`
+	Findings []cdb.Finding `json:"findings"`
+}
+
+type summary struct {
+	Breaking   int `json:"breaking"`
+	Suppressed int `json:"suppressed"`
+	Compatible int `json:"compatible"`
+}
+
+func newReport(from string, to string, findings []cdb.Finding) (rep report) {
+
+	rep = report{From: from, To: to, Findings: findings}
+	if rep.Findings == nil {
+		rep.Findings = make([]cdb.Finding, 0)
+	}
+	for _, finding := range findings {
+		switch {
+		case finding.Blocking():
+			rep.Summary.Breaking++
+		case finding.Breaking:
+			rep.Summary.Suppressed++
+		default:
+			rep.Summary.Compatible++
+		}
+	}
+	return
+}
+
+// writeReport форматирует отчёт и пишет его в stdout (пустой out) или в файл.
+func writeReport(rep report, format string, out string) (err error) {
+
+	var payload []byte
+	switch format {
+	case "", formatText:
+		payload = renderText(rep)
+	case formatJSON:
+		if payload, err = json.MarshalIndent(rep, "", "  "); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to marshal compatibility report"), err)
+		}
+		payload = append(payload, '\n')
+	default:
+		return fmt.Errorf("%s: %q", i18n.Msg("unsupported output format"), format)
+	}
+
+	if out == "" {
+		if _, err = os.Stdout.Write(payload); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to write compatibility report to stdout"), err)
+		}
+		return
+	}
+
+	path := common.NormalizeWASMPath(out)
+	if err = os.WriteFile(path, payload, 0600); err != nil {
+		return fmt.Errorf("%s: %w", i18n.Msg("failed to write compatibility report file"), err)
+	}
+	return
+}
+
+// renderText — сначала ломающие изменения, затем подавленные; совместимые учитываются только в итоге.
+func renderText(rep report) (payload []byte) {
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s → %s\n", rep.From, rep.To)
+	for _, blocking := range []bool{true, false} {
+		for _, finding := range rep.Findings {
+			if !finding.Breaking || finding.Blocking() != blocking {
+				continue
+			}
+			mark := "BREAKING"
+			if !blocking {
+				mark = "suppressed (" + finding.Suppressed + ")"
+			}
+			fmt.Fprintf(&buf, "%s [%s] %s %s: %s", mark, finding.Family, finding.Entity, finding.Path, finding.Reason)
+			if finding.Kind == cdb.ChangeChanged {
+				fmt.Fprintf(&buf, " (%s → %s)", finding.Before, finding.After)
+			}
+			buf.WriteByte('\n')
+		}
+	}
+	fmt.Fprintf(&buf, "%s: %d, %s: %d, %s: %d\n",
+		i18n.Msg("breaking"), rep.Summary.Breaking,
+		i18n.Msg("suppressed"), rep.Summary.Suppressed,
+		i18n.Msg("compatible"), rep.Summary.Compatible)
+	return buf.Bytes()
+}
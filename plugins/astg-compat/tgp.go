@@ -0,0 +1,4 @@
+package main
+
+//go:generate go run -tags pluginInfo . ../../dist/astgCompat.json
+//go:generate env GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o ../../dist/astgCompat.tgp .
@@ -0,0 +1,86 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+
+	"tgp/core/data"
+	"tgp/core/i18n"
+	"tgp/core/plugin"
+	"tgp/internal/cdb"
+	"tgp/internal/model"
+)
+
+//go:embed plugin.md
+var docContent string
+
+const (
+	optionRefFrom = "ref-a"
+	optionRefTo   = "ref-b"
+	optionOut     = "out"
+	optionFormat  = "format"
+)
+
+// AstgDiffPlugin реализует command-плагин: семантическое сравнение двух версий проекта из локальной базы контрактов.
+type AstgDiffPlugin struct{}
+
+func (p *AstgDiffPlugin) Execute(request data.Storage) (response data.Storage, err error) {
+
+	response = request
+
+	var root string
+	if root, err = cdb.Root(); err != nil {
+		return response, fmt.Errorf("%s: %w", i18n.Msg("contracts db root"), err)
+	}
+	err = runDiff(request, root)
+	return
+}
+
+func runDiff(request data.Storage, root string) (err error) {
+
+	refFrom, _ := data.Get[string](request, optionRefFrom)
+	refTo, _ := data.Get[string](request, optionRefTo)
+	if refFrom == "" || refTo == "" {
+		return fmt.Errorf("%s", i18n.Msg("two refs are required: tg astg diff <ref-a> <ref-b>"))
+	}
+
+	var from, to *model.Project
+	if from, err = cdb.LoadRef(root, refFrom); err != nil {
+		return fmt.Errorf("%s %s: %w", i18n.Msg("load project from db"), refFrom, err)
+	}
+	if to, err = cdb.LoadRef(root, refTo); err != nil {
+		return fmt.Errorf("%s %s: %w", i18n.Msg("load project from db"), refTo, err)
+	}
+
+	out, _ := data.Get[string](request, optionOut)
+	format, _ := data.Get[string](request, optionFormat)
+	return writeReport(report{From: refFrom, To: refTo, Changes: cdb.DiffProjects(from, to)}, format, out)
+}
+
+func (p *AstgDiffPlugin) Info() (info plugin.Info, err error) {
+
+	info = plugin.Info{
+		Name:          "astg-diff",
+		Description:   i18n.Msg("Semantic diff between two project versions stored in the contracts DB"),
+		Author:        "AlexK <seniorGolang@gmail.com>",
+		License:       "MIT",
+		Category:      "utility",
+		Doc:           docContent,
+		AllowedStdOut: true,
+		AllowedStdErr: true,
+		AllowedPaths:  map[string]string{"@tg/astg/db": "r", "@go": "w"},
+		Commands: []plugin.Command{
+			{
+				Path:        []string{"astg", "diff"},
+				Description: i18n.Msg("Show added, removed and changed contracts, methods, types and routes between two refs"),
+				Options: []plugin.Option{
+					{Name: optionRefFrom, Type: "string", Description: i18n.Msg("Base ref (project[:contracts]@version)"), Required: true, IsPositional: true},
+					{Name: optionRefTo, Type: "string", Description: i18n.Msg("Target ref (project[:contracts]@version)"), Required: true, IsPositional: true},
+					{Name: optionFormat, Short: "f", Type: "string", Description: i18n.Msg("Output format: text or json"), Default: formatText},
+					{Name: optionOut, Short: "o", Type: "string", Description: i18n.Msg("Path to output file (default: stdout)")},
+				},
+			},
+		},
+	}
+	return
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/core/data"
+	"tgp/internal/cdb"
+	"tgp/internal/cdb/cdbtest"
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestRunDiff(t *testing.T) {
+
+	t.Parallel()
+
+	const projectKey = "example.com.app"
+
+	root := t.TempDir()
+	cdbtest.SeedVersion(t, root, projectKey, "v1", &model.Project{
+		ModulePath: "example.com/app",
+		Contracts: []*model.Contract{
+			{Name: "Users", Annotations: tags.DocTags{model.TagServerHTTP: ""}, Methods: []*model.Method{{Name: "Get", Annotations: tags.DocTags{model.TagHTTPMethod: "GET"}}}},
+			{Name: "Billing", Methods: []*model.Method{{Name: "Pay"}}},
+		},
+	})
+	cdbtest.SeedVersion(t, root, projectKey, "v2", &model.Project{
+		ModulePath: "example.com/app",
+		Contracts: []*model.Contract{
+			{Name: "Users", Annotations: tags.DocTags{model.TagServerHTTP: ""}, Methods: []*model.Method{{Name: "Get", Annotations: tags.DocTags{model.TagHTTPMethod: "POST"}}}},
+		},
+	})
+
+	out := filepath.Join(t.TempDir(), "diff.json")
+	request := data.NewStorage()
+	for key, value := range map[string]string{optionRefFrom: projectKey + ":Users@v1", optionRefTo: projectKey + "@v2", optionFormat: formatJSON, optionOut: out} {
+		if err := request.Set(key, value); err != nil {
+			t.Fatalf("Set %s: %v", key, err)
+		}
+	}
+	if err := runDiff(request, root); err != nil {
+		t.Fatalf("runDiff: %v", err)
+	}
+
+	payload, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var rep report
+	if err = json.Unmarshal(payload, &rep); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	// Billing отфильтрован ссылкой ref-a, поэтому не попадает в отчёт как удалённый.
+	var routes []string
+	for _, change := range rep.Changes {
+		if change.Contract == "Billing" {
+			t.Fatalf("contract filtered by ref must not be reported: %+v", change)
+		}
+		if change.Entity == cdb.EntityRoute {
+			routes = append(routes, change.Before+" -> "+change.After)
+		}
+	}
+	if len(routes) != 1 || routes[0] != "GET /users/get -> POST /users/get" {
+		t.Fatalf("routes: got %v", routes)
+	}
+}
+
+func TestRunDiffRequiresTwoRefs(t *testing.T) {
+
+	t.Parallel()
+
+	request := data.NewStorage()
+	if err := request.Set(optionRefFrom, "app@v1"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := runDiff(request, t.TempDir()); err == nil {
+		t.Fatal("expected error without ref-b")
+	}
+}
+
+func TestRenderText(t *testing.T) {
+
+	t.Parallel()
+
+	text := string(renderText(report{From: "app@v1", To: "app@v2", Changes: []cdb.Change{
+		{Kind: cdb.ChangeAdded, Entity: cdb.EntityContract, Path: "Orders"},
+		{Kind: cdb.ChangeChanged, Entity: cdb.EntityRoute, Path: "Users.Get", Name: cdb.FamilyHTTP, Before: "GET /a", After: "GET /b"},
+		{Kind: cdb.ChangeRemoved, Entity: cdb.EntityField, Path: "app/dto:User.Age", Before: "int"},
+	}}))
+	want := strings.Join([]string{
+		"app@v1 → app@v2",
+		"+ contract Orders",
+		"~ route Users.Get [http]: GET /a → GET /b",
+		"- field app/dto:User.Age: int",
+		"",
+	}, "\n")
+	if text != want {
+		t.Fatalf("text:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestInfo(t *testing.T) {
+
+	t.Parallel()
+
+	plugin := &AstgDiffPlugin{}
+	info, err := plugin.Info()
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.Name != "astg-diff" {
+		t.Fatalf("name: got %q want astg-diff", info.Name)
+	}
+	wantPath := []string{"astg", "diff"}
+	if got := info.Commands[0].Path; len(got) != len(wantPath) || got[0] != wantPath[0] || got[1] != wantPath[1] {
+		t.Fatalf("path: got %v want %v", got, wantPath)
+	}
+}
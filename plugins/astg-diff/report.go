@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"tgp/core/i18n"
+	"tgp/internal/cdb"
+	"tgp/internal/common"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// report — результат сравнения двух ссылок.
+type report struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Changes []cdb.Change `json:"changes"`
+}
+
+var kindMarks = map[cdb.ChangeKind]string{
+	cdb.ChangeAdded:   "+",
+	cdb.ChangeRemoved: "-",
+	cdb.ChangeChanged: "~",
+}
+
+// writeReport форматирует отчёт и пишет его в stdout (пустой out) или в файл.
+func writeReport(rep report, format string, out string) (err error) {
+
+	if rep.Changes == nil {
+		rep.Changes = make([]cdb.Change, 0)
+	}
+
+	var payload []byte
+	switch format {
+	case "", formatText:
+		payload = renderText(rep)
+	case formatJSON:
+		if payload, err = json.MarshalIndent(rep, "", "  "); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to marshal diff"), err)
+		}
+		payload = append(payload, '\n')
+	default:
+		return fmt.Errorf("%s: %q", i18n.Msg("unsupported output format"), format)
+	}
+
+	if out == "" {
+		if _, err = os.Stdout.Write(payload); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to write diff to stdout"), err)
+		}
+		return
+	}
+
+	path := common.NormalizeWASMPath(out)
+	if err = os.WriteFile(path, payload, 0600); err != nil {
+		return fmt.Errorf("%s: %w", i18n.Msg("failed to write diff file"), err)
+	}
+	return
+}
+
+// renderText — по строке на изменение: "+|-|~ entity path [name]: before → after".
+func renderText(rep report) (payload []byte) {
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s → %s\n", rep.From, rep.To)
+	if len(rep.Changes) == 0 {
+		fmt.Fprintln(&buf, i18n.Msg("no changes"))
+		return buf.Bytes()
+	}
+	for _, change := range rep.Changes {
+		fmt.Fprintf(&buf, "%s %s %s", kindMarks[change.Kind], change.Entity, change.Path)
+		if change.Entity == cdb.EntityRoute {
+			fmt.Fprintf(&buf, " [%s]", change.Name)
+		}
+		switch change.Kind {
+		case cdb.ChangeChanged:
+			fmt.Fprintf(&buf, ": %s → %s", change.Before, change.After)
+		case cdb.ChangeAdded:
+			if change.After != "" {
+				fmt.Fprintf(&buf, ": %s", change.After)
+			}
+		case cdb.ChangeRemoved:
+			if change.Before != "" {
+				fmt.Fprintf(&buf, ": %s", change.Before)
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
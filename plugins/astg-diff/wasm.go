@@ -0,0 +1,15 @@
+package main
+
+import (
+	"tgp/core"
+)
+
+func init() {
+
+	core.InitPlugin(&AstgDiffPlugin{})
+}
+
+func main() {
+
+	// Инициализация не требуется для wasip1
+}
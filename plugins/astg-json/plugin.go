@@ -59,6 +59,9 @@ func (p *AstgJsonPlugin) Info() (info plugin.Info, err error) {
 				Options: []plugin.Option{
 					{Name: optionOut, Short: "o", Type: "string", Description: i18n.Msg("Path to output file (default: stdout)")},
 					{Name: "contracts-dir", Type: "string", Description: i18n.Msg("Path to contracts folder (relative to rootDir)"), Default: "contracts"},
+					{Name: "contracts-recursive", Type: "bool", Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"), Default: false},
+					{Name: "contracts-include", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)")},
+					{Name: "contracts-exclude", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)")},
 					{Name: optionAllContracts, Type: "bool", Description: i18n.Msg("Load full model from DB without interactive contract selection"), Default: true},
 				},
 			},
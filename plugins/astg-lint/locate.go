@@ -0,0 +1,343 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"tgp/internal/helper"
+	"tgp/internal/model"
+	"tgp/internal/validate"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// diagnostic — проблема проекта, привязанная к позиции в исходнике.
+type diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+	Contract string `json:"contract,omitempty"`
+	Method   string `json:"method,omitempty"`
+}
+
+type position struct {
+	file   string
+	line   int
+	column int
+}
+
+// locator сопоставляет проблемы валидации с позициями в исходниках, разбирая файлы контрактов и типов.
+type locator struct {
+	root    string
+	project *model.Project
+	fset    *token.FileSet
+	files   map[string]*ast.File
+}
+
+func newLocator(root string, project *model.Project) (l *locator) {
+
+	return &locator{root: root, project: project, fset: token.NewFileSet(), files: make(map[string]*ast.File)}
+}
+
+func (l *locator) diagnostics(issues []validate.Issue) (diagnostics []diagnostic) {
+
+	diagnostics = make([]diagnostic, 0, len(issues))
+	for _, issue := range issues {
+		pos := l.locate(issue)
+		diag := diagnostic{
+			File:     pos.file,
+			Line:     pos.line,
+			Column:   pos.column,
+			Severity: severityError,
+			Rule:     issue.Rule,
+			Message:  issue.Err.Error(),
+		}
+		if issue.Warning {
+			diag.Severity = severityWarning
+		}
+		if issue.Contract != nil {
+			diag.Contract = issue.Contract.Name
+		}
+		if issue.Method != nil {
+			diag.Method = issue.Method.Name
+		}
+		diagnostics = append(diagnostics, diag)
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File < diagnostics[j].File
+		}
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return
+}
+
+func (l *locator) locate(issue validate.Issue) (pos position) {
+
+	switch {
+	case issue.TypeID != "":
+		return l.locateField(issue.TypeID, issue.Field, issue.Tag)
+	case issue.Contract == nil:
+		return l.locatePackageTag(issue.Tag)
+	}
+
+	file := l.parse(issue.Contract.FilePath)
+	pos = position{file: issue.Contract.FilePath}
+	if file == nil {
+		return
+	}
+	spec := findTypeSpec(file, issue.Contract.Name)
+	if spec == nil {
+		return
+	}
+	pos = l.position(issue.Contract.FilePath, spec.Name.Pos())
+
+	if issue.Method == nil {
+		if found, ok := l.tagPosition(issue.Contract.FilePath, issue.Tag, contractDoc(file, spec)); ok {
+			return found
+		}
+		return
+	}
+
+	iface, _ := spec.Type.(*ast.InterfaceType)
+	field := findInterfaceMethod(iface, issue.Method.Name)
+	if field == nil {
+		return
+	}
+	pos = l.position(issue.Contract.FilePath, field.Names[0].Pos())
+	if found, ok := l.tagPosition(issue.Contract.FilePath, issue.Tag, field.Doc); ok {
+		return found
+	}
+
+	variable := issue.Variable
+	if variable == "" && issue.Tag != "" {
+		variable, _, _ = strings.Cut(issue.Tag, ".")
+	}
+	if funcType, ok := field.Type.(*ast.FuncType); ok && variable != "" {
+		if ident := findFuncParam(funcType, variable); ident != nil {
+			pos = l.position(issue.Contract.FilePath, ident.Pos())
+		}
+	}
+	return
+}
+
+// Аннотации проекта задаются в package-комментариях файлов контрактов.
+func (l *locator) locatePackageTag(tag string) (pos position) {
+
+	for _, contract := range l.project.Contracts {
+		file := l.parse(contract.FilePath)
+		if file == nil {
+			continue
+		}
+		if pos.file == "" {
+			pos = l.position(contract.FilePath, file.Package)
+		}
+		if found, ok := l.tagPosition(contract.FilePath, tag, file.Doc); ok {
+			return found
+		}
+	}
+	return
+}
+
+func (l *locator) locateField(typeID string, fieldName string, tag string) (pos position) {
+
+	typ := l.project.Types[typeID]
+	if typ == nil || l.project.ModulePath == "" {
+		return
+	}
+	typeName := typ.TypeName
+	if typ.GenericOf != "" {
+		_, typeName, _ = strings.Cut(typ.GenericOf, ":")
+	}
+	dir := strings.TrimPrefix(strings.TrimPrefix(typ.ImportPkgPath, l.project.ModulePath), "/")
+
+	entries, err := os.ReadDir(filepath.Join(l.root, filepath.FromSlash(dir)))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !helper.IsRelevantGoFile(entry.Name()) {
+			continue
+		}
+		relPath := filepath.ToSlash(filepath.Join(dir, entry.Name()))
+		file := l.parse(relPath)
+		if file == nil {
+			continue
+		}
+		spec := findTypeSpec(file, typeName)
+		if spec == nil {
+			continue
+		}
+		pos = l.position(relPath, spec.Name.Pos())
+		structType, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return
+		}
+		for _, field := range structType.Fields.List {
+			for _, name := range field.Names {
+				if name.Name != fieldName {
+					continue
+				}
+				pos = l.position(relPath, name.Pos())
+				if tagPos, ok := l.tagPosition(relPath, tag, field.Doc, field.Comment); ok {
+					return tagPos
+				}
+				return
+			}
+		}
+		return
+	}
+	return
+}
+
+// tagPosition ищет строку комментария "@tg ... <tag>" и возвращает позицию ключа.
+func (l *locator) tagPosition(relPath string, tag string, groups ...*ast.CommentGroup) (pos position, ok bool) {
+
+	if tag == "" {
+		return
+	}
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			marker := strings.Index(comment.Text, "@tg")
+			if marker < 0 {
+				continue
+			}
+			if offset := keyOffset(comment.Text[marker:], tag); offset >= 0 {
+				return l.position(relPath, comment.Slash+token.Pos(marker+offset)), true
+			}
+		}
+	}
+	return
+}
+
+// keyOffset возвращает смещение ключа в тексте комментария: ключ отделён пробелом слева и "=", пробелом или концом строки справа.
+func keyOffset(text string, key string) (offset int) {
+
+	for start := 0; start < len(text); {
+		idx := strings.Index(text[start:], key)
+		if idx < 0 {
+			return -1
+		}
+		idx += start
+		end := idx + len(key)
+		before := idx == 0 || text[idx-1] == ' ' || text[idx-1] == '\t'
+		after := end == len(text) || text[end] == '=' || text[end] == ' ' || text[end] == '\t'
+		if before && after {
+			return idx
+		}
+		start = idx + 1
+	}
+	return -1
+}
+
+func (l *locator) parse(relPath string) (file *ast.File) {
+
+	if relPath == "" {
+		return
+	}
+	if cached, ok := l.files[relPath]; ok {
+		return cached
+	}
+	file, _ = parser.ParseFile(l.fset, filepath.Join(l.root, filepath.FromSlash(relPath)), nil, parser.ParseComments)
+	l.files[relPath] = file
+	return
+}
+
+func (l *locator) position(relPath string, pos token.Pos) (result position) {
+
+	p := l.fset.Position(pos)
+	return position{file: relPath, line: p.Line, column: p.Column}
+}
+
+func findTypeSpec(file *ast.File, name string) (spec *ast.TypeSpec) {
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range genDecl.Specs {
+			if typeSpec, ok := s.(*ast.TypeSpec); ok && typeSpec.Name.Name == name {
+				return typeSpec
+			}
+		}
+	}
+	return
+}
+
+// Документация типа: у одиночного объявления комментарий привязан к GenDecl, а не к TypeSpec.
+func contractDoc(file *ast.File, spec *ast.TypeSpec) (doc *ast.CommentGroup) {
+
+	if spec.Doc != nil {
+		return spec.Doc
+	}
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok {
+			for _, s := range genDecl.Specs {
+				if s == spec {
+					return genDecl.Doc
+				}
+			}
+		}
+	}
+	return
+}
+
+func findInterfaceMethod(iface *ast.InterfaceType, name string) (field *ast.Field) {
+
+	if iface == nil || iface.Methods == nil {
+		return
+	}
+	for _, f := range iface.Methods.List {
+		if len(f.Names) > 0 && f.Names[0].Name == name {
+			return f
+		}
+	}
+	return
+}
+
+func findFuncParam(funcType *ast.FuncType, name string) (ident *ast.Ident) {
+
+	for _, list := range []*ast.FieldList{funcType.Params, funcType.Results} {
+		if list == nil {
+			continue
+		}
+		for _, field := range list.List {
+			for _, fieldName := range field.Names {
+				if fieldName.Name == name {
+					return fieldName
+				}
+			}
+		}
+	}
+	return
+}
+
+func countSeverity(diagnostics []diagnostic) (errorsCount int, warnings int) {
+
+	for _, diag := range diagnostics {
+		if diag.Severity == severityError {
+			errorsCount++
+			continue
+		}
+		warnings++
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+
+	"tgp/core/data"
+	"tgp/core/i18n"
+	"tgp/core/plugin"
+	"tgp/internal"
+	"tgp/internal/helper"
+	"tgp/internal/model"
+	"tgp/internal/validate"
+)
+
+//go:embed plugin.md
+var docContent string
+
+const (
+	optionOut    = "out"
+	optionFormat = "format"
+)
+
+// AstgLintPlugin реализует command-плагин: проверяет контракты и выводит все найденные проблемы с позициями в исходниках.
+type AstgLintPlugin struct{}
+
+func (p *AstgLintPlugin) Execute(request data.Storage) (response data.Storage, err error) {
+
+	response = request
+
+	var project *model.Project
+	if project, err = helper.GetProject(request); err != nil {
+		return
+	}
+
+	var out, format string
+	out, _ = data.Get[string](request, optionOut)
+	format, _ = data.Get[string](request, optionFormat)
+
+	diagnostics := newLocator(internal.ProjectRoot, project).diagnostics(validate.Issues(project))
+	if err = writeReport(diagnostics, format, out); err != nil {
+		return
+	}
+
+	errorsCount, warnings := countSeverity(diagnostics)
+	if errorsCount > 0 {
+		return response, fmt.Errorf("%s: %d %s, %d %s", i18n.Msg("contract validation failed"), errorsCount, i18n.Msg("errors"), warnings, i18n.Msg("warnings"))
+	}
+	return
+}
+
+func (p *AstgLintPlugin) Info() (info plugin.Info, err error) {
+
+	info = plugin.Info{
+		Name:          "astg-lint",
+		Description:   i18n.Msg("Validate contracts and report all problems with source positions"),
+		Author:        "AlexK <seniorGolang@gmail.com>",
+		License:       "MIT",
+		Category:      "utility",
+		Doc:           docContent,
+		Dependencies:  []string{"astg"},
+		AllowedStdOut: true,
+		AllowedStdErr: true,
+		AllowedPaths:  map[string]string{"@go": "w"},
+		Commands: []plugin.Command{
+			{
+				Path:        []string{"astg", "lint"},
+				Description: i18n.Msg("Check contracts and annotations, report diagnostics as text, JSON or SARIF"),
+				Options: []plugin.Option{
+					{Name: optionFormat, Short: "f", Type: "string", Description: i18n.Msg("Output format: text, json or sarif"), Default: formatText},
+					{Name: optionOut, Short: "o", Type: "string", Description: i18n.Msg("Path to output file (default: stdout)")},
+					{Name: "contracts-dir", Type: "string", Description: i18n.Msg("Path to contracts folder (relative to rootDir)"), Default: "contracts"},
+					{Name: "contracts-recursive", Type: "bool", Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"), Default: false},
+					{Name: "contracts-include", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)")},
+					{Name: "contracts-exclude", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)")},
+				},
+			},
+		},
+	}
+	return
+}
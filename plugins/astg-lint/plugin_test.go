@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"tgp/core/data"
+	"tgp/internal/model"
+	"tgp/internal/tags"
+	"tgp/internal/validate"
+)
+
+const usersSource = `// @tg version=1.0.0
+// @tg npmPrivate=maybe
+package contracts
+
+import "context"
+
+// @tg http-server
+// @tg trcae
+type Users interface {
+	// @tg http-method=FETCH
+	Get(ctx context.Context, id string) (err error)
+	// @tg http-method=PUT
+	// @tg token.requird
+	Put(ctx context.Context, token string) (err error)
+}
+`
+
+const dtoSource = `package dto
+
+type User struct {
+	// @tg exmaple=bob
+	Name string
+}
+`
+
+func lintProject(t *testing.T) (root string, project *model.Project) {
+
+	t.Helper()
+
+	root = t.TempDir()
+	for rel, src := range map[string]string{"contracts/users.go": usersSource, "contracts/dto/user.go": dtoSource} {
+		abs := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errRes := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	project = &model.Project{
+		ModulePath:  "example.com/app",
+		Annotations: tags.DocTags{"version": "1.0.0", "npmPrivate": "maybe"},
+		Contracts: []*model.Contract{{
+			Name:        "Users",
+			FilePath:    "contracts/users.go",
+			Annotations: tags.DocTags{model.TagServerHTTP: "", "trcae": ""},
+			Methods: []*model.Method{
+				{
+					Name:        "Get",
+					Annotations: tags.DocTags{model.TagHTTPMethod: "FETCH"},
+					Args:        []*model.Variable{ctx, {Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}},
+					Results:     []*model.Variable{errRes},
+				},
+				{
+					Name:        "Put",
+					Annotations: tags.DocTags{model.TagHTTPMethod: "PUT", "token.requird": ""},
+					Args:        []*model.Variable{ctx, {Name: "token", TypeRef: model.TypeRef{TypeID: "string"}}},
+					Results:     []*model.Variable{errRes},
+				},
+			},
+		}},
+		Types: map[string]*model.Type{
+			"example.com/app/contracts/dto:User": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "User",
+				ImportPkgPath: "example.com/app/contracts/dto",
+				StructFields:  []*model.StructField{{Name: "Name", Annotations: tags.DocTags{"exmaple": "bob"}}},
+			},
+		},
+	}
+	return
+}
+
+func TestDiagnosticsPositions(t *testing.T) {
+
+	t.Parallel()
+
+	root, project := lintProject(t)
+	diagnostics := newLocator(root, project).diagnostics(validate.Issues(project))
+
+	got := make([]string, 0, len(diagnostics))
+	for _, diag := range diagnostics {
+		got = append(got, strings.Join([]string{diag.File, strconv.Itoa(diag.Line), strconv.Itoa(diag.Column), diag.Severity, diag.Rule}, ":"))
+	}
+	want := []string{
+		"contracts/dto/user.go:4:9:warning:unknown-annotation",
+		"contracts/users.go:2:8:error:annotation-value",
+		"contracts/users.go:8:8:warning:unknown-annotation",
+		"contracts/users.go:10:9:error:contract",
+		"contracts/users.go:13:9:warning:unknown-annotation",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRenderSARIF(t *testing.T) {
+
+	t.Parallel()
+
+	root, project := lintProject(t)
+	out := filepath.Join(t.TempDir(), "lint.sarif")
+	if err := writeReport(newLocator(root, project).diagnostics(validate.Issues(project)), formatSARIF, out); err != nil {
+		t.Fatalf("writeReport: %v", err)
+	}
+
+	payload, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var log sarifLog
+	if err = json.Unmarshal(payload, &log); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != 3 {
+		t.Fatalf("unexpected SARIF header: %+v", log)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 5 {
+		t.Fatalf("results: got %d want 5", len(results))
+	}
+	region := results[3].Locations[0].PhysicalLocation.Region
+	if results[3].RuleID != validate.RuleContract || results[3].Level != severityError || region == nil || region.StartLine != 10 {
+		t.Fatalf("unexpected result: %+v", results[3])
+	}
+}
+
+func TestRenderText(t *testing.T) {
+
+	t.Parallel()
+
+	text := string(renderText([]diagnostic{
+		{File: "contracts/users.go", Line: 3, Column: 5, Severity: severityWarning, Rule: validate.RuleUnknownAnnotation, Message: "unknown"},
+		{Severity: severityError, Rule: validate.RuleContract, Message: "broken"},
+	}))
+	want := "contracts/users.go:3:5: warning: unknown (unknown-annotation)\n-: error: broken (contract)\n"
+	if text != want {
+		t.Fatalf("text:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestExecuteFailsOnErrors(t *testing.T) {
+
+	t.Parallel()
+
+	_, project := lintProject(t)
+	request := data.NewStorage()
+	if err := request.Set("project", project); err != nil {
+		t.Fatalf("Set project: %v", err)
+	}
+	if err := request.Set(optionOut, filepath.Join(t.TempDir(), "lint.json")); err != nil {
+		t.Fatalf("Set out: %v", err)
+	}
+	if err := request.Set(optionFormat, formatJSON); err != nil {
+		t.Fatalf("Set format: %v", err)
+	}
+
+	plugin := &AstgLintPlugin{}
+	if _, err := plugin.Execute(request); err == nil {
+		t.Fatal("expected error when contracts have errors")
+	}
+}
+
+func TestInfo(t *testing.T) {
+
+	t.Parallel()
+
+	plugin := &AstgLintPlugin{}
+	info, err := plugin.Info()
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.Name != "astg-lint" {
+		t.Fatalf("name: got %q want astg-lint", info.Name)
+	}
+	wantPath := []string{"astg", "lint"}
+	if got := info.Commands[0].Path; len(got) != len(wantPath) || got[0] != wantPath[0] || got[1] != wantPath[1] {
+		t.Fatalf("path: got %v want %v", got, wantPath)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"tgp/core/i18n"
+	"tgp/internal/common"
+	"tgp/internal/validate"
+)
+
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolURI      = "https://github.com/seniorGolang/tgp-go"
+)
+
+// Описания правил для SARIF (tool.driver.rules).
+var ruleDescriptions = map[string]string{
+	validate.RuleContract:          "Contract does not satisfy tgp generation rules",
+	validate.RuleUnknownAnnotation: "Unknown @tg annotation key",
+	validate.RuleAnnotationValue:   "Invalid value of @tg annotation",
+}
+
+// writeReport форматирует диагностики и пишет их в stdout (пустой out) или в файл.
+func writeReport(diagnostics []diagnostic, format string, out string) (err error) {
+
+	var payload []byte
+	switch format {
+	case "", formatText:
+		payload = renderText(diagnostics)
+	case formatJSON:
+		if payload, err = json.MarshalIndent(diagnostics, "", "  "); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to marshal diagnostics"), err)
+		}
+		payload = append(payload, '\n')
+	case formatSARIF:
+		if payload, err = json.MarshalIndent(renderSARIF(diagnostics), "", "  "); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to marshal diagnostics"), err)
+		}
+		payload = append(payload, '\n')
+	default:
+		return fmt.Errorf("%s: %q", i18n.Msg("unsupported output format"), format)
+	}
+
+	if out == "" {
+		if _, err = os.Stdout.Write(payload); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to write diagnostics to stdout"), err)
+		}
+		return
+	}
+
+	path := common.NormalizeWASMPath(out)
+	if err = os.WriteFile(path, payload, 0600); err != nil {
+		return fmt.Errorf("%s: %w", i18n.Msg("failed to write diagnostics file"), err)
+	}
+	return
+}
+
+// renderText формирует строки в формате компилятора: file:line:col: severity: message (rule).
+func renderText(diagnostics []diagnostic) (payload []byte) {
+
+	var buf bytes.Buffer
+	for _, diag := range diagnostics {
+		location := diag.File
+		if location == "" {
+			location = "-"
+		}
+		if diag.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", diag.File, diag.Line, diag.Column)
+		}
+		fmt.Fprintf(&buf, "%s: %s: %s (%s)\n", location, diag.Severity, diag.Message, diag.Rule)
+	}
+	return buf.Bytes()
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func renderSARIF(diagnostics []diagnostic) (log sarifLog) {
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "tg astg lint",
+			InformationURI: toolURI,
+		}},
+		Results: make([]sarifResult, 0, len(diagnostics)),
+	}
+	for _, rule := range []string{validate.RuleContract, validate.RuleUnknownAnnotation, validate.RuleAnnotationValue} {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: rule, ShortDescription: sarifMessage{Text: ruleDescriptions[rule]}})
+	}
+	for _, diag := range diagnostics {
+		result := sarifResult{RuleID: diag.Rule, Level: diag.Severity, Message: sarifMessage{Text: diag.Message}}
+		if diag.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: diag.File}}}
+			if diag.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: diag.Line, StartColumn: diag.Column}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}
+}
@@ -22,7 +22,7 @@ const (
 	BaseDir = "/tg/astg/cache"
 )
 
-func GetProject(rootDir string, contractsDir string, excludeDirs []string) (project *model.Project, fromCache bool, projectID string) {
+func GetProject(rootDir string, contractsDir string, scope marker.ContractsScope, excludeDirs []string) (project *model.Project, fromCache bool, projectID string) {
 
 	var err error
 	if projectID, err = GetProjectID(rootDir); err != nil {
@@ -33,7 +33,7 @@ func GetProject(rootDir string, contractsDir string, excludeDirs []string) (proj
 	branch := getGitBranchForCache(rootDir)
 	cacheFile := GetCachePath(projectID, branch)
 
-	project, fromCache = loadEntry(rootDir, cacheFile, projectID, contractsDir, excludeDirs)
+	project, fromCache = loadEntry(rootDir, cacheFile, projectID, contractsDir, scope, excludeDirs)
 	if fromCache {
 		slog.Debug(i18n.Msg("using cached project"), slog.String("cacheFile", cacheFile))
 	}
@@ -41,7 +41,7 @@ func GetProject(rootDir string, contractsDir string, excludeDirs []string) (proj
 	return
 }
 
-func SaveProject(projectID string, project *model.Project, rootDir string, contractsDir string, excludeDirs []string) {
+func SaveProject(projectID string, project *model.Project, rootDir string, contractsDir string, scope marker.ContractsScope, excludeDirs []string) {
 
 	project.ProjectID = projectID
 
@@ -58,11 +58,19 @@ func SaveProject(projectID string, project *model.Project, rootDir string, contr
 		return
 	}
 
+	var contractsRoot string
+	if contractsRoot, err = contractsMerkleRoot(rootDir, contractsDir, scope, excludeDirs); err != nil {
+		slog.Debug(i18n.Msg("failed to compute contracts tree hash, skipping cache"), slog.Any("error", err))
+		return
+	}
+
 	entry := cacheEntry{
-		Project:      project,
-		Files:        files,
-		ContractsDir: contractsDir,
-		ExcludeDirs:  excludeDirs,
+		Project:        project,
+		Files:          files,
+		ContractsDir:   contractsDir,
+		ContractsScope: scope,
+		ContractsRoot:  contractsRoot,
+		ExcludeDirs:    excludeDirs,
 	}
 	branch := ""
 	if project.Git != nil && project.Git.Branch != "" {
@@ -150,7 +158,7 @@ func readGitFile(gitDir string, fileName string) (content []byte, err error) {
 	return os.ReadFile(targetAbsPath)
 }
 
-func loadEntry(rootDir string, cacheFile string, projectID string, contractsDir string, excludeDirs []string) (project *model.Project, valid bool) {
+func loadEntry(rootDir string, cacheFile string, projectID string, contractsDir string, scope marker.ContractsScope, excludeDirs []string) (project *model.Project, valid bool) {
 
 	var err error
 	var info os.FileInfo
@@ -216,6 +224,13 @@ func loadEntry(rootDir string, cacheFile string, projectID string, contractsDir
 			slog.String("cacheFile", cacheFile))
 		return
 	}
+	if !entry.ContractsScope.Equal(scope) {
+		slog.Debug(i18n.Msg("contracts scope mismatch"),
+			slog.Any("expected", scope),
+			slog.Any("got", entry.ContractsScope),
+			slog.String("cacheFile", cacheFile))
+		return
+	}
 	if !stringSlicesEqual(entry.ExcludeDirs, excludeDirs) {
 		slog.Debug(i18n.Msg("excludeDirs mismatch"),
 			slog.Any("expected", excludeDirs),
@@ -246,9 +261,25 @@ func loadEntry(rootDir string, cacheFile string, projectID string, contractsDir
 		}
 	}
 
+	// Дерево контрактов пересчитывается целиком, чтобы учесть добавленные и удалённые файлы в поддиректориях.
+	var contractsRoot string
+	if contractsRoot, err = contractsMerkleRoot(rootDir, contractsDir, scope, excludeDirs); err != nil || contractsRoot != entry.ContractsRoot {
+		slog.Debug(i18n.Msg("contracts tree hash mismatch"), slog.String("contractsDir", contractsDir))
+		return
+	}
+
 	return entry.Project, true
 }
 
+func contractsMerkleRoot(rootDir string, contractsDir string, scope marker.ContractsScope, excludeDirs []string) (root string, err error) {
+
+	var paths []string
+	if paths, err = marker.DiscoverContractFiles(rootDir, contractsDir, scope, excludeDirs); err != nil {
+		return
+	}
+	return merkle.Root(rootDir, paths)
+}
+
 func stringSlicesEqual(left []string, right []string) (ok bool) {
 
 	if len(left) != len(right) {
@@ -5,11 +5,14 @@ package cache
 
 import (
 	"tgp/internal/model"
+	"tgp/plugins/astg/marker"
 )
 
 type cacheEntry struct {
-	Project      *model.Project    `json:"project"`
-	ContractsDir string            `json:"contractsDir"`
-	ExcludeDirs  []string          `json:"excludeDirs"`
-	Files        map[string]string `json:"files"`
+	Project        *model.Project        `json:"project"`
+	ContractsDir   string                `json:"contractsDir"`
+	ContractsScope marker.ContractsScope `json:"contractsScope"`
+	ContractsRoot  string                `json:"contractsRoot"`
+	ExcludeDirs    []string              `json:"excludeDirs"`
+	Files          map[string]string     `json:"files"`
 }
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+
+package marker
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"tgp/internal/helper"
+)
+
+// ContractsScope задаёт набор файлов директории контрактов, в которых ищутся контракты.
+// Шаблоны include/exclude сопоставляются с путём относительно contracts-dir (через "/", "**" — любое число сегментов).
+type ContractsScope struct {
+	Recursive bool     `json:"recursive,omitempty"`
+	Include   []string `json:"include,omitempty"`
+	Exclude   []string `json:"exclude,omitempty"`
+}
+
+func (scope ContractsScope) Equal(other ContractsScope) (ok bool) {
+
+	return scope.Recursive == other.Recursive &&
+		stringSlicesEqual(scope.Include, other.Include) &&
+		stringSlicesEqual(scope.Exclude, other.Exclude)
+}
+
+// DiscoverContractFiles возвращает отсортированные пути файлов контрактов относительно rootDir (через "/").
+func DiscoverContractFiles(rootDir string, contractsDir string, scope ContractsScope, excludeDirs []string) (paths []string, err error) {
+
+	rootDir = filepath.Clean(rootDir)
+	contractsDirAbs := filepath.Join(rootDir, contractsDir)
+	paths = make([]string, 0)
+
+	err = filepath.WalkDir(contractsDirAbs, func(absPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, relErr := filepath.Rel(contractsDirAbs, absPath)
+		if relErr != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			if !scope.Recursive || helper.IsDirNameExcluded(entry.Name()) || discoverShouldExcludeDir(absPath, rootDir, excludeDirs) {
+				return filepath.SkipDir
+			}
+			if matchAnyGlob(scope.Exclude, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !helper.IsRelevantGoFile(entry.Name()) {
+			return nil
+		}
+		if len(scope.Include) > 0 && !matchAnyGlob(scope.Include, rel) {
+			return nil
+		}
+		if matchAnyGlob(scope.Exclude, rel) {
+			return nil
+		}
+		rootRel, relErr := filepath.Rel(rootDir, absPath)
+		if relErr != nil {
+			return nil
+		}
+		paths = append(paths, filepath.ToSlash(rootRel))
+		return nil
+	})
+	sort.Strings(paths)
+	return
+}
+
+// MatchGlob сопоставляет путь с шаблоном: сегменты — по правилам path.Match, "**" — ноль или более сегментов.
+func MatchGlob(pattern string, relPath string) (ok bool) {
+
+	pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+func matchSegments(pattern []string, parts []string) (ok bool) {
+
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(parts); skip++ {
+				if matchSegments(pattern[1:], parts[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], parts[0]); err != nil || !matched {
+			return false
+		}
+		pattern = pattern[1:]
+		parts = parts[1:]
+	}
+	return len(parts) == 0
+}
+
+func matchAnyGlob(patterns []string, relPath string) (ok bool) {
+
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringSlicesEqual(left []string, right []string) (ok bool) {
+
+	if len(left) != len(right) {
+		return false
+	}
+	for idx := range left {
+		if left[idx] != right[idx] {
+			return false
+		}
+	}
+	return true
+}
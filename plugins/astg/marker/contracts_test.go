@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+
+package marker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.go", path: "orders.go", want: true},
+		{pattern: "*.go", path: "billing/invoices.go", want: false},
+		{pattern: "**/*.go", path: "billing/invoices.go", want: true},
+		{pattern: "**/*.go", path: "orders.go", want: true},
+		{pattern: "billing/**", path: "billing", want: true},
+		{pattern: "billing/**", path: "billing/v2/invoices.go", want: true},
+		{pattern: "billing/**", path: "users/users.go", want: false},
+		{pattern: "**/internal/**", path: "users/internal/x.go", want: true},
+		{pattern: "users/*_gen.go", path: "users/users_gen.go", want: true},
+	}
+	for _, tc := range cases {
+		if got := MatchGlob(tc.pattern, tc.path); got != tc.want {
+			t.Fatalf("MatchGlob(%q, %q)=%v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestDiscoverContractFiles(t *testing.T) {
+
+	root := t.TempDir()
+	for _, rel := range []string{
+		"contracts/orders.go",
+		"contracts/orders_test.go",
+		"contracts/billing/invoices.go",
+		"contracts/billing/v2/invoices.go",
+		"contracts/users/users.go",
+		"contracts/users/internal/hidden.go",
+		"contracts/dto/dto.go",
+	} {
+		abs := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte("package x\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		name  string
+		scope ContractsScope
+		want  []string
+	}{
+		{
+			name: "flat",
+			want: []string{"contracts/orders.go"},
+		},
+		{
+			name:  "recursive",
+			scope: ContractsScope{Recursive: true},
+			want: []string{
+				"contracts/billing/invoices.go",
+				"contracts/billing/v2/invoices.go",
+				"contracts/dto/dto.go",
+				"contracts/orders.go",
+				"contracts/users/internal/hidden.go",
+				"contracts/users/users.go",
+			},
+		},
+		{
+			name:  "include and exclude",
+			scope: ContractsScope{Recursive: true, Include: []string{"*.go", "billing/**", "users/**"}, Exclude: []string{"**/internal/**", "billing/v2"}},
+			want: []string{
+				"contracts/billing/invoices.go",
+				"contracts/orders.go",
+				"contracts/users/users.go",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DiscoverContractFiles(root, "contracts", tc.scope, nil)
+			if err != nil {
+				t.Fatalf("DiscoverContractFiles: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
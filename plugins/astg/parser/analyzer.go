@@ -26,6 +26,10 @@ func analyzeProject(project *model.Project, loader *AutonomousPackageLoader) (er
 		return fmt.Errorf("failed to expand types recursively: %w", err)
 	}
 
+	if err = loader.typeIDConflictsError(); err != nil {
+		return
+	}
+
 	attachContractEnums(project, loader)
 	return
 }
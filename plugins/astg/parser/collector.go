@@ -20,9 +20,10 @@ import (
 	"tgp/internal"
 	"tgp/internal/model"
 	"tgp/internal/tags"
+	"tgp/plugins/astg/marker"
 )
 
-func CollectWithExcludeDirs(version string, svcDir string, excludeDirs []string) (project *model.Project, err error) {
+func CollectWithExcludeDirs(version string, svcDir string, scope marker.ContractsScope, excludeDirs []string) (project *model.Project, err error) {
 
 	project = &model.Project{
 		Version:      version,
@@ -63,19 +64,16 @@ func CollectWithExcludeDirs(version string, svcDir string, excludeDirs []string)
 
 	svcDirAbs := filepath.Join(internal.ProjectRoot, svcDir)
 
-	var files []os.DirEntry
-	if files, err = os.ReadDir(svcDirAbs); err != nil {
+	var files []string
+	if files, err = marker.DiscoverContractFiles(internal.ProjectRoot, svcDir, scope, excludeDirs); err != nil {
 		return nil, fmt.Errorf("failed to read service directory: %w", err)
 	}
 
 	contractsMap := make(map[string]*model.Contract)
+	pkgAnnotations := make(map[string]tags.DocTags)
 
 	for _, file := range files {
-		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") {
-			continue
-		}
-
-		filePathAbs := filepath.Join(svcDirAbs, file.Name())
+		filePathAbs := filepath.Join(internal.ProjectRoot, filepath.FromSlash(file))
 
 		dir := filepath.Dir(filePathAbs)
 		var pkgPath string
@@ -151,7 +149,9 @@ func CollectWithExcludeDirs(version string, svcDir string, excludeDirs []string)
 
 		imports := collectImports([]*ast.File{astFile}, loader.resolver)
 
-		if astFile.Doc != nil {
+		if astFile.Doc != nil && filepath.Dir(filePathAbs) != svcDirAbs {
+			pkgAnnotations[pkgPath] = pkgAnnotations[pkgPath].Merge(tags.ParseTags(extractComments(astFile.Doc)))
+		} else if astFile.Doc != nil {
 			packageLines := extractComments(astFile.Doc)
 			if len(project.Docs) == 0 {
 				project.Docs, project.Directives = splitDocsAndDirectives(packageLines)
@@ -219,11 +219,14 @@ func CollectWithExcludeDirs(version string, svcDir string, excludeDirs []string)
 						}
 						pkgInfo.TypeInfo = typeInfo
 					}
+					var embeds []ast.Expr
 					for _, methodField := range interfaceType.Methods.List {
 						if _, ok := methodField.Type.(*ast.Ident); ok {
+							embeds = append(embeds, methodField.Type)
 							continue
 						}
 						if _, ok := methodField.Type.(*ast.SelectorExpr); ok {
+							embeds = append(embeds, methodField.Type)
 							continue
 						}
 
@@ -245,6 +248,7 @@ func CollectWithExcludeDirs(version string, svcDir string, excludeDirs []string)
 							contract.Methods = append(contract.Methods, method)
 						}
 					}
+					flattenEmbeddedInterfaces(contract, embeds, typeInfo, project, loader)
 				}
 
 				contractsMap[contractID] = contract
@@ -252,7 +256,21 @@ func CollectWithExcludeDirs(version string, svcDir string, excludeDirs []string)
 		}
 	}
 
+	contractNames := make(map[string]string, len(contractsMap))
 	for _, contract := range contractsMap {
+		if prevID, exists := contractNames[contract.Name]; exists {
+			first, second := prevID, contract.ID
+			if second < first {
+				first, second = second, first
+			}
+			return nil, fmt.Errorf("contract name %s is declared by %s and %s (must be unique across contracts-dir packages)", contract.Name, first, second)
+		}
+		contractNames[contract.Name] = contract.ID
+		for key, value := range pkgAnnotations[contract.PkgPath] {
+			if !contract.Annotations.IsSet(key) {
+				contract.Annotations[key] = value
+			}
+		}
 		project.Contracts = append(project.Contracts, contract)
 	}
 
@@ -3,7 +3,6 @@
 package parser
 
 import (
-	"fmt"
 	"go/types"
 	"log/slog"
 
@@ -24,12 +23,13 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 		processingSet = make(map[string]bool)
 	}
 
-	typeID := generateTypeIDFromGoTypes(typ)
+	typeID := generateTypeIDFromGoTypes(typ, loader)
 	if typeID == "" {
 		if basic, ok := typ.(*types.Basic); ok {
 			typeID = basic.Name()
 		}
 	}
+	loader.claimTypeID(typeID, typ)
 
 	// Если тип уже существует в project.Types, возвращаем его
 	if typeID != "" && !isBuiltinTypeName(typeID) {
@@ -65,7 +65,7 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 			var named *types.Named
 			if named, ok = typ.(*types.Named); ok {
 				if named.Obj() != nil {
-					coreType.TypeName = named.Obj().Name()
+					coreType.TypeName = loader.namedTypeName(named)
 					if named.Obj().Pkg() != nil {
 						coreType.ImportPkgPath = named.Obj().Pkg().Path()
 						coreType.PkgName = named.Obj().Pkg().Name()
@@ -97,7 +97,7 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 	if typeID != "" && !isBuiltinTypeName(typeID) {
 		if named, ok := typ.(*types.Named); ok {
 			if named.Obj() != nil {
-				coreType.TypeName = named.Obj().Name()
+				coreType.TypeName = loader.namedTypeName(named)
 				if named.Obj().Pkg() != nil {
 					coreType.ImportPkgPath = named.Obj().Pkg().Path()
 					coreType.PkgName = named.Obj().Pkg().Name()
@@ -124,7 +124,7 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 
 	case *types.Named:
 		if t.Obj() != nil {
-			coreType.TypeName = t.Obj().Name()
+			coreType.TypeName = loader.namedTypeName(t)
 			if t.Obj().Pkg() != nil {
 				coreType.ImportPkgPath = t.Obj().Pkg().Path()
 				coreType.PkgName = t.Obj().Pkg().Name()
@@ -136,6 +136,8 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 				}
 			}
 		}
+		// Экземпляр обобщённого типа: underlying уже содержит подставленные аргументы (Page[User] → поля с User)
+		fillGenericInstance(t, typeID, imports, project, coreType, loader)
 
 		underlying := t.Underlying()
 
@@ -211,7 +213,7 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 
 		if named, ok := underlying.(*types.Named); ok {
 			if named.Obj() != nil {
-				coreType.AliasOf = fmt.Sprintf("%s:%s", named.Obj().Pkg().Path(), named.Obj().Name())
+				coreType.AliasOf = generateTypeIDFromGoTypes(named, loader)
 				baseTypeID := coreType.AliasOf
 				if _, exists := project.Types[baseTypeID]; !exists {
 					basePkgPath := named.Obj().Pkg().Path()
@@ -272,7 +274,7 @@ func convertTypeFromGoTypes(typ types.Type, pkgPath string, imports map[string]s
 	}
 
 	if loader != nil && coreType.ImportPkgPath != "" && coreType.TypeName != "" {
-		coreType.Docs, coreType.Directives = getTypeDocs(loader, coreType.ImportPkgPath, coreType.TypeName)
+		coreType.Docs, coreType.Directives = getTypeDocs(loader, coreType.ImportPkgPath, declTypeName(coreType))
 	}
 
 	return
@@ -121,7 +121,7 @@ func detectParseFromString(typ types.Type, coreType *model.Type, project *model.
 		} else {
 			res0ValueType = res0
 		}
-		if generateTypeIDFromGoTypes(res0ValueType) != targetTypeID {
+		if generateTypeIDFromGoTypes(res0ValueType, loader) != targetTypeID {
 			continue
 		}
 
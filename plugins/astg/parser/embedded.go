@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"log/slog"
+
+	"tgp/core/i18n"
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+// embeddedInterface — объявление встроенного в контракт интерфейса вместе с контекстом его пакета.
+type embeddedInterface struct {
+	id          string
+	pkgPath     string
+	iface       *ast.InterfaceType
+	annotations tags.DocTags
+	imports     map[string]string
+	typeInfo    *types.Info
+}
+
+// Методы встроенных интерфейсов добавляются после собственных методов контракта; одноимённые методы контракта имеют приоритет.
+// Аннотации встроенного интерфейса дополняют аннотации контракта, не перезаписывая заданные в контракте значения.
+func flattenEmbeddedInterfaces(contract *model.Contract, embeds []ast.Expr, typeInfo *types.Info, project *model.Project, loader *AutonomousPackageLoader) {
+
+	seenMethods := make(map[string]bool, len(contract.Methods))
+	for _, method := range contract.Methods {
+		seenMethods[method.Name] = true
+	}
+	visited := map[string]bool{contract.ID: true}
+	for _, expr := range embeds {
+		appendEmbeddedMethods(contract, expr, typeInfo, project, loader, seenMethods, visited)
+	}
+}
+
+func appendEmbeddedMethods(contract *model.Contract, expr ast.Expr, typeInfo *types.Info, project *model.Project, loader *AutonomousPackageLoader, seenMethods map[string]bool, visited map[string]bool) {
+
+	embedded, ok := resolveEmbeddedInterface(expr, typeInfo, loader)
+	if !ok {
+		slog.Debug(i18n.Msg("Embedded interface not resolved, skipping"),
+			slog.String("contract", contract.ID),
+			slog.String("expr", types.ExprString(expr)))
+		return
+	}
+	if visited[embedded.id] {
+		return
+	}
+	visited[embedded.id] = true
+
+	if contract.Annotations == nil {
+		contract.Annotations = make(tags.DocTags)
+	}
+	for key, value := range embedded.annotations {
+		if !contract.Annotations.IsSet(key) {
+			contract.Annotations[key] = value
+		}
+	}
+
+	if embedded.iface.Methods == nil {
+		return
+	}
+
+	var nested []ast.Expr
+	for _, methodField := range embedded.iface.Methods.List {
+		funcType, isFunc := methodField.Type.(*ast.FuncType)
+		if !isFunc {
+			nested = append(nested, methodField.Type)
+			continue
+		}
+		if len(methodField.Names) == 0 || methodField.Names[0] == nil {
+			continue
+		}
+		methodName := methodField.Names[0].Name
+		if seenMethods[methodName] {
+			continue
+		}
+		method := convertMethod(methodName, funcType, extractComments(methodField.Doc, methodField.Comment), contract.ID, embedded.pkgPath, embedded.imports, embedded.typeInfo, project, loader)
+		if method != nil {
+			seenMethods[methodName] = true
+			contract.Methods = append(contract.Methods, method)
+		}
+	}
+	for _, nestedExpr := range nested {
+		appendEmbeddedMethods(contract, nestedExpr, embedded.typeInfo, project, loader, seenMethods, visited)
+	}
+}
+
+// Находит объявление встроенного интерфейса по go/types объекту идентификатора (локальный или из другого пакета).
+func resolveEmbeddedInterface(expr ast.Expr, typeInfo *types.Info, loader *AutonomousPackageLoader) (embedded *embeddedInterface, ok bool) {
+
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return
+	}
+	if typeInfo == nil || loader == nil {
+		return
+	}
+
+	typeName, isTypeName := typeInfo.Uses[ident].(*types.TypeName)
+	if !isTypeName || typeName.Pkg() == nil {
+		return
+	}
+	if _, isIface := typeName.Type().Underlying().(*types.Interface); !isIface {
+		return
+	}
+
+	pkgPath := typeName.Pkg().Path()
+	pkgInfo, err := loader.LoadPackageLazy(pkgPath)
+	if err != nil || pkgInfo == nil {
+		return
+	}
+
+	for _, file := range pkgInfo.Files {
+		for _, decl := range file.Decls {
+			genDecl, isGen := decl.(*ast.GenDecl)
+			if !isGen || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, isType := spec.(*ast.TypeSpec)
+				if !isType || typeSpec.Name.Name != typeName.Name() {
+					continue
+				}
+				iface, isIface := typeSpec.Type.(*ast.InterfaceType)
+				if !isIface {
+					return
+				}
+				embedded = &embeddedInterface{
+					id:          fmt.Sprintf("%s:%s", pkgPath, typeName.Name()),
+					pkgPath:     pkgPath,
+					iface:       iface,
+					annotations: tags.ParseTags(extractComments(genDecl.Doc, typeSpec.Doc, typeSpec.Comment)),
+					imports:     collectImports([]*ast.File{file}, loader.resolver),
+					typeInfo:    pkgInfo.TypeInfo,
+				}
+				return embedded, true
+			}
+		}
+	}
+	return
+}
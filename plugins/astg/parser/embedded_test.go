@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestFlattenEmbeddedInterfaces_methodsAndAnnotations(t *testing.T) {
+
+	const sharedSrc = `package shared
+
+// @tg http-prefix=shared log
+type Health interface {
+	// @tg http-method=GET
+	Ping() (status string, err error)
+}
+`
+
+	const contractsSrc = `package contracts
+
+import "example/shared"
+
+// @tg metrics
+type Admin interface {
+	Reload() (err error)
+	Ping() (status string, err error)
+}
+
+// @tg jsonRPC-server http-prefix=api/v1
+type Orders interface {
+	shared.Health
+	Admin
+	Create(name string) (id string, err error)
+}
+`
+
+	fset := token.NewFileSet()
+	sharedFile, err := parser.ParseFile(fset, "/shared/health.go", sharedSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse shared: %v", err)
+	}
+	contractsFile, err := parser.ParseFile(fset, "/contracts/orders.go", contractsSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse contracts: %v", err)
+	}
+
+	loader, err := NewAutonomousPackageLoader(testModuleFile(t, "example"))
+	if err != nil {
+		t.Fatalf("loader: %v", err)
+	}
+
+	sharedTypeInfo := createTypeInfo()
+	sharedPkg, err := (&types.Config{}).Check("example/shared", fset, []*ast.File{sharedFile}, sharedTypeInfo)
+	if err != nil {
+		t.Fatalf("type check shared: %v", err)
+	}
+
+	contractsTypeInfo := createTypeInfo()
+	contractsCfg := &types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+		return sharedPkg, nil
+	})}
+	contractsPkg, err := contractsCfg.Check("example/contracts", fset, []*ast.File{contractsFile}, contractsTypeInfo)
+	if err != nil {
+		t.Fatalf("type check contracts: %v", err)
+	}
+
+	loader.mu.Lock()
+	loader.cache["example/shared"] = &PackageInfo{PkgPath: "example/shared", Files: []*ast.File{sharedFile}, Types: sharedPkg, TypeInfo: sharedTypeInfo, Fset: fset}
+	loader.cache["example/contracts"] = &PackageInfo{PkgPath: "example/contracts", Files: []*ast.File{contractsFile}, Types: contractsPkg, TypeInfo: contractsTypeInfo, Fset: fset}
+	loader.mu.Unlock()
+
+	ordersType := findInterfaceInFile(t, contractsFile, "Orders")
+	project := &model.Project{Types: make(map[string]*model.Type)}
+	contract := &model.Contract{
+		ID:          "example/contracts:Orders",
+		Name:        "Orders",
+		PkgPath:     "example/contracts",
+		Annotations: tags.DocTags{"jsonRPC-server": "", "http-prefix": "api/v1"},
+	}
+
+	var embeds []ast.Expr
+	for _, field := range ordersType.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			embeds = append(embeds, field.Type)
+			continue
+		}
+		contract.Methods = append(contract.Methods, convertMethod(field.Names[0].Name, funcType, nil, contract.ID, contract.PkgPath, nil, contractsTypeInfo, project, loader))
+	}
+
+	flattenEmbeddedInterfaces(contract, embeds, contractsTypeInfo, project, loader)
+
+	var names []string
+	for _, method := range contract.Methods {
+		names = append(names, method.Name)
+		if method.ContractID != contract.ID {
+			t.Fatalf("method %s has ContractID=%q, want %q", method.Name, method.ContractID, contract.ID)
+		}
+	}
+	if got, want := len(names), 3; got != want {
+		t.Fatalf("methods=%v, want 3 (Create, Ping, Reload)", names)
+	}
+	if names[0] != "Create" || names[1] != "Ping" || names[2] != "Reload" {
+		t.Fatalf("methods order=%v, want [Create Ping Reload]", names)
+	}
+	if got := contract.Methods[1].Annotations.Value("http-method"); got != "GET" {
+		t.Fatalf("Ping http-method=%q, want GET (from shared.Health)", got)
+	}
+	if got := contract.Annotations.Value("http-prefix"); got != "api/v1" {
+		t.Fatalf("http-prefix=%q, embedding contract must take priority", got)
+	}
+	if !contract.Annotations.IsSet("log") || !contract.Annotations.IsSet("metrics") {
+		t.Fatalf("annotations=%v, want log and metrics merged from embedded interfaces", contract.Annotations)
+	}
+}
+
+func TestResolveEmbeddedInterface_builtinError(t *testing.T) {
+
+	const src = `package contracts
+
+type Service interface {
+	error
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "service.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	typeInfo := createTypeInfo()
+	if _, err = (&types.Config{}).Check("example/contracts", fset, []*ast.File{file}, typeInfo); err != nil {
+		t.Fatalf("type check: %v", err)
+	}
+
+	iface := findInterfaceInFile(t, file, "Service")
+	if _, ok := resolveEmbeddedInterface(iface.Methods.List[0].Type, typeInfo, &AutonomousPackageLoader{}); ok {
+		t.Fatalf("universe error must not resolve to an embedded contract interface")
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+func findInterfaceInFile(t *testing.T, file *ast.File, name string) (iface *ast.InterfaceType) {
+
+	t.Helper()
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || typeSpec.Name.Name != name {
+				continue
+			}
+			if iface, ok = typeSpec.Type.(*ast.InterfaceType); ok {
+				return
+			}
+		}
+	}
+	t.Fatalf("interface %s not found", name)
+	return
+}
@@ -64,6 +64,13 @@ func collectTypeFromID(typeID string, project *model.Project, seenTypes *typeuti
 		return
 	}
 
+	if typ.GenericOf != "" {
+		if instance, found := loader.instance(typeID); found {
+			forEachReachableType(instance, project, seenTypes, msets, loader)
+		}
+		return
+	}
+
 	var ok bool
 	var pkgInfo *PackageInfo
 	if pkgInfo, ok = loader.GetPackage(typ.ImportPkgPath); !ok || pkgInfo == nil || pkgInfo.Types == nil {
@@ -182,7 +189,7 @@ func forEachReachableType(t types.Type, project *model.Project, seenTypes *typeu
 
 func saveTypeFromGoTypes(t types.Type, project *model.Project, loader *AutonomousPackageLoader) {
 
-	typeID := generateTypeIDFromGoTypes(t)
+	typeID := generateTypeIDFromGoTypes(t, loader)
 	if typeID == "" {
 		return
 	}
@@ -193,6 +200,7 @@ func saveTypeFromGoTypes(t types.Type, project *model.Project, loader *Autonomou
 		}
 	}
 
+	loader.claimTypeID(typeID, t)
 	if _, exists := project.Types[typeID]; exists {
 		return
 	}
@@ -203,7 +211,7 @@ func saveTypeFromGoTypes(t types.Type, project *model.Project, loader *Autonomou
 	switch t := t.(type) {
 	case *types.Named:
 		if t.Obj() != nil {
-			typeName = t.Obj().Name()
+			typeName = loader.namedTypeName(t)
 			if t.Obj().Pkg() != nil {
 				importPkgPath = t.Obj().Pkg().Path()
 			}
@@ -241,7 +249,7 @@ func saveTypeFromGoTypes(t types.Type, project *model.Project, loader *Autonomou
 	project.Types[typeID] = coreType
 }
 
-func generateTypeIDFromGoTypes(t types.Type) (typeID string) {
+func generateTypeIDFromGoTypes(t types.Type, loader *AutonomousPackageLoader) (typeID string) {
 
 	if t == nil {
 		return
@@ -259,7 +267,7 @@ func generateTypeIDFromGoTypes(t types.Type) (typeID string) {
 
 	case *types.Named:
 		if t.Obj() != nil {
-			typeName := t.Obj().Name()
+			typeName := loader.namedTypeName(t)
 			if t.Obj().Pkg() != nil {
 				importPkgPath := t.Obj().Pkg().Path()
 				typeID = fmt.Sprintf("%s:%s", importPkgPath, typeName)
@@ -271,7 +279,7 @@ func generateTypeIDFromGoTypes(t types.Type) (typeID string) {
 		underlying := t.Underlying()
 		if underlying != nil {
 			var underlyingID string
-			if underlyingID = generateTypeIDFromGoTypes(underlying); underlyingID != "" {
+			if underlyingID = generateTypeIDFromGoTypes(underlying, loader); underlyingID != "" {
 				typeID = underlyingID
 				return
 			}
@@ -292,7 +300,7 @@ func generateTypeIDFromGoTypes(t types.Type) (typeID string) {
 		underlying := types.Unalias(t)
 		if underlying != nil {
 			var underlyingID string
-			if underlyingID = generateTypeIDFromGoTypes(underlying); underlyingID != "" {
+			if underlyingID = generateTypeIDFromGoTypes(underlying, loader); underlyingID != "" {
 				typeID = underlyingID
 				return
 			}
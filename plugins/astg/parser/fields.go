@@ -25,7 +25,7 @@ func fillStructFields(structType *types.Struct, pkgPath string, imports map[stri
 	var astStructType *ast.StructType
 	if pkgInfo, ok := loader.GetPackage(pkgPath); ok && pkgInfo != nil {
 		for _, file := range pkgInfo.Files {
-			astStructType = findASTStructType(file, coreType.TypeName, pkgInfo.TypeInfo)
+			astStructType = findASTStructType(file, declTypeName(coreType), pkgInfo.TypeInfo)
 			if astStructType != nil {
 				break
 			}
@@ -66,6 +66,7 @@ func fillStructFields(structType *types.Struct, pkgPath string, imports map[stri
 		structField := &model.StructField{
 			TypeRef:     *fieldTypeInfoToTypeRef(typeInfo),
 			Name:        fieldName,
+			Embedded:    field.Embedded(),
 			Tags:        fieldTags,
 			Docs:        docs,
 			Directives:  directives,
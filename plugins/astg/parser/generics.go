@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"tgp/internal/model"
+)
+
+// Имя типа для model.Type: для экземпляров обобщённых типов аргументы дописываются через "_" (Page[User] → Page_User,
+// Page[dto.User] → Page_DtoUser, Page[map[string]int] → Page_MapStringInt). Короткий хеш полного имени экземпляра
+// добавляется только при совпадении: имя занято объявленным типом пакета или другим экземпляром
+// (Page[map[string][]int] и Page[[]map[string]int]); читаемое имя остаётся за экземпляром, встреченным первым.
+func (loader *AutonomousPackageLoader) namedTypeName(named *types.Named) (name string) {
+
+	if name = readableTypeName(named); name == "" || named.TypeArgs().Len() == 0 {
+		return
+	}
+	pkgPath := ""
+	declared := false
+	if pkg := named.Obj().Pkg(); pkg != nil {
+		pkgPath = pkg.Path()
+		declared = pkg.Scope().Lookup(name) != nil
+	}
+	if loader == nil {
+		if declared {
+			name += "_" + shortTypeHash(named)
+		}
+		return
+	}
+	instance := types.TypeString(named, nil)
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+	if chosen, found := loader.instanceNames[instance]; found {
+		return chosen
+	}
+	if loader.instanceNames == nil {
+		loader.instanceNames = make(map[string]string)
+		loader.instanceOwners = make(map[string]string)
+	}
+	if owner, taken := loader.instanceOwners[pkgPath+":"+name]; declared || taken && owner != instance {
+		name += "_" + shortTypeHash(named)
+	}
+	loader.instanceOwners[pkgPath+":"+name] = instance
+	loader.instanceNames[instance] = name
+	return
+}
+
+func readableTypeName(named *types.Named) (name string) {
+
+	if named == nil || named.Obj() == nil {
+		return
+	}
+	name = named.Obj().Name()
+	typeArgs := named.TypeArgs()
+	if typeArgs == nil || typeArgs.Len() == 0 {
+		return
+	}
+	parts := make([]string, 0, typeArgs.Len()+1)
+	parts = append(parts, name)
+	for typ := range typeArgs.Types() {
+		parts = append(parts, typeArgName(typ, named.Obj().Pkg()))
+	}
+	return strings.Join(parts, "_")
+}
+
+// Первые 8 hex-символов SHA-256 полного имени экземпляра с путями пакетов.
+func shortTypeHash(named *types.Named) (hash string) {
+
+	sum := sha256.Sum256([]byte(types.TypeString(named, nil)))
+	return hex.EncodeToString(sum[:4])
+}
+
+func typeArgName(typ types.Type, pkg *types.Package) (name string) {
+
+	switch t := typ.(type) {
+	case *types.Pointer:
+		return "Ptr" + typeArgName(t.Elem(), pkg)
+	case *types.Slice:
+		return typeArgName(t.Elem(), pkg) + "List"
+	case *types.Array:
+		return fmt.Sprintf("%sArray%d", typeArgName(t.Elem(), pkg), t.Len())
+	case *types.Map:
+		return "Map" + typeArgName(t.Key(), pkg) + typeArgName(t.Elem(), pkg)
+	case *types.Named:
+		if t.Obj() != nil && t.Obj().Pkg() != nil && t.Obj().Pkg() != pkg {
+			return capitalize(t.Obj().Pkg().Name()) + readableTypeName(t)
+		}
+		return readableTypeName(t)
+	case *types.Alias:
+		if t.Obj() != nil {
+			return capitalize(t.Obj().Name())
+		}
+		return typeArgName(types.Unalias(t), pkg)
+	case *types.Basic:
+		return capitalize(t.Name())
+	case *types.Interface:
+		if t.Empty() {
+			return "Any"
+		}
+	}
+	return capitalize(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, types.TypeString(typ, func(*types.Package) string { return "" })))
+}
+
+func capitalize(s string) (out string) {
+
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// Заполняет GenericOf и TypeArgs для экземпляра обобщённого типа и запоминает его go/types представление в загрузчике.
+func fillGenericInstance(named *types.Named, typeID string, imports map[string]string, project *model.Project, coreType *model.Type, loader *AutonomousPackageLoader) {
+
+	typeArgs := named.TypeArgs()
+	if typeArgs == nil || typeArgs.Len() == 0 {
+		return
+	}
+	origin := named.Origin()
+	if origin.Obj() == nil || origin.Obj().Pkg() == nil {
+		return
+	}
+	coreType.GenericOf = fmt.Sprintf("%s:%s", origin.Obj().Pkg().Path(), origin.Obj().Name())
+	coreType.TypeArgs = make([]*model.TypeRef, 0, typeArgs.Len())
+	for typ := range typeArgs.Types() {
+		argInfo, _ := typeRefFromTypes(typ, coreType.ImportPkgPath, imports, project, loader)
+		coreType.TypeArgs = append(coreType.TypeArgs, conversionInfoToTypeRef(argInfo))
+	}
+	loader.rememberInstance(typeID, named)
+}
+
+// Имя объявления типа в исходном коде: для экземпляра обобщённого типа — имя обобщённого типа.
+func declTypeName(coreType *model.Type) (name string) {
+
+	if coreType.GenericOf != "" {
+		if parts := splitTypeID(coreType.GenericOf); len(parts) == 2 {
+			return parts[1]
+		}
+	}
+	return coreType.TypeName
+}
+
+func (loader *AutonomousPackageLoader) rememberInstance(typeID string, named *types.Named) {
+
+	if loader == nil {
+		return
+	}
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+	if loader.instances == nil {
+		loader.instances = make(map[string]*types.Named)
+	}
+	loader.instances[typeID] = named
+}
+
+// claimTypeID закрепляет ID модели за именованным типом; другой тип с тем же ID запоминается как конфликт.
+func (loader *AutonomousPackageLoader) claimTypeID(typeID string, typ types.Type) {
+
+	named, ok := typ.(*types.Named)
+	if loader == nil || !ok || typeID == "" {
+		return
+	}
+	owner := types.TypeString(named, nil)
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+	if loader.typeOwners == nil {
+		loader.typeOwners = make(map[string]string)
+	}
+	prev, found := loader.typeOwners[typeID]
+	if !found {
+		loader.typeOwners[typeID] = owner
+		return
+	}
+	if conflict := fmt.Sprintf("%s and %s both map to type ID %s", prev, owner, typeID); prev != owner && !slices.Contains(loader.typeConflicts, conflict) {
+		loader.typeConflicts = append(loader.typeConflicts, conflict)
+	}
+}
+
+// typeIDConflictsError — ошибка разбора, если разные типы получили один ID модели (например, Page[User] и объявленный Page_User).
+func (loader *AutonomousPackageLoader) typeIDConflictsError() (err error) {
+
+	if loader == nil {
+		return
+	}
+	loader.mu.RLock()
+	defer loader.mu.RUnlock()
+	if len(loader.typeConflicts) == 0 {
+		return
+	}
+	return fmt.Errorf("ambiguous type IDs: %s", strings.Join(loader.typeConflicts, "; "))
+}
+
+func (loader *AutonomousPackageLoader) instance(typeID string) (named *types.Named, ok bool) {
+
+	if loader == nil {
+		return
+	}
+	loader.mu.RLock()
+	defer loader.mu.RUnlock()
+	named, ok = loader.instances[typeID]
+	return
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+)
+
+func TestConvertMethod_genericInstances(t *testing.T) {
+
+	const src = `package contracts
+
+// Page — страница результатов.
+type Page[T any] struct {
+	Items []T ` + "`json:\"items\"`" + `
+	Total int ` + "`json:\"total\"`" + `
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type User struct {
+	Name string
+}
+
+type Users interface {
+	List(limit int) (page Page[User], err error)
+	Index() (index Pair[string, []*User], err error)
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "/contracts/users.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	loader, err := NewAutonomousPackageLoader(testModuleFile(t, "example"))
+	if err != nil {
+		t.Fatalf("loader: %v", err)
+	}
+	typeInfo := createTypeInfo()
+	pkg, err := (&types.Config{}).Check("example/contracts", fset, []*ast.File{file}, typeInfo)
+	if err != nil {
+		t.Fatalf("type check: %v", err)
+	}
+	loader.mu.Lock()
+	loader.cache["example/contracts"] = &PackageInfo{PkgPath: "example/contracts", Files: []*ast.File{file}, Types: pkg, TypeInfo: typeInfo, Fset: fset}
+	loader.mu.Unlock()
+
+	project := &model.Project{Types: make(map[string]*model.Type)}
+	iface := findInterfaceInFile(t, file, "Users")
+	var methods []*model.Method
+	for _, field := range iface.Methods.List {
+		methods = append(methods, convertMethod(field.Names[0].Name, field.Type.(*ast.FuncType), nil, "example/contracts:Users", "example/contracts", nil, typeInfo, project, loader))
+	}
+
+	if got := methods[0].Results[0].TypeID; got != "example/contracts:Page_User" {
+		t.Fatalf("List result TypeID=%q, want example/contracts:Page_User", got)
+	}
+	page := project.Types["example/contracts:Page_User"]
+	if page == nil {
+		t.Fatalf("Page_User not in project types")
+	}
+	if page.TypeName != "Page_User" || page.GenericOf != "example/contracts:Page" || page.Kind != model.TypeKindStruct {
+		t.Fatalf("Page_User=%+v, want struct instance of example/contracts:Page", page)
+	}
+	if len(page.TypeArgs) != 1 || page.TypeArgs[0].TypeID != "example/contracts:User" {
+		t.Fatalf("Page_User type args=%+v, want [example/contracts:User]", page.TypeArgs)
+	}
+	if len(page.StructFields) != 2 || page.StructFields[0].TypeID != "example/contracts:User" || !page.StructFields[0].IsSlice {
+		t.Fatalf("Page_User fields=%+v, want Items []User", page.StructFields)
+	}
+	if got := page.StructFields[0].Tags["json"]; len(got) == 0 || got[0] != "items" {
+		t.Fatalf("Items json tag=%v, want items", got)
+	}
+	if len(page.Docs) == 0 {
+		t.Fatalf("Page_User docs must come from generic declaration Page")
+	}
+	if _, ok := project.Types["example/contracts:User"]; !ok {
+		t.Fatalf("type argument User must be added to project types")
+	}
+
+	if got := methods[1].Results[0].TypeID; got != "example/contracts:Pair_String_PtrUserList" {
+		t.Fatalf("Index result TypeID=%q, want example/contracts:Pair_String_PtrUserList", got)
+	}
+	if err = loader.typeIDConflictsError(); err != nil {
+		t.Fatalf("unexpected type ID conflict: %v", err)
+	}
+}
+
+func TestConvertMethod_genericInstanceConflictsWithDeclaredType(t *testing.T) {
+
+	const src = `package contracts
+
+type Page[T any] struct {
+	Items []T
+}
+
+type User struct {
+	Name string
+}
+
+type Page_User struct {
+	Legacy bool
+}
+
+type Users interface {
+	List() (page Page[User], err error)
+	Legacy() (page Page_User, err error)
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "/contracts/users.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	loader, err := NewAutonomousPackageLoader(testModuleFile(t, "example"))
+	if err != nil {
+		t.Fatalf("loader: %v", err)
+	}
+	typeInfo := createTypeInfo()
+	pkg, err := (&types.Config{}).Check("example/contracts", fset, []*ast.File{file}, typeInfo)
+	if err != nil {
+		t.Fatalf("type check: %v", err)
+	}
+	loader.mu.Lock()
+	loader.cache["example/contracts"] = &PackageInfo{PkgPath: "example/contracts", Files: []*ast.File{file}, Types: pkg, TypeInfo: typeInfo, Fset: fset}
+	loader.mu.Unlock()
+
+	project := &model.Project{Types: make(map[string]*model.Type)}
+	iface := findInterfaceInFile(t, file, "Users")
+	var methods []*model.Method
+	for _, field := range iface.Methods.List {
+		methods = append(methods, convertMethod(field.Names[0].Name, field.Type.(*ast.FuncType), nil, "example/contracts:Users", "example/contracts", nil, typeInfo, project, loader))
+	}
+	if err = loader.typeIDConflictsError(); err != nil {
+		t.Fatalf("Page[User] must not take the name of declared Page_User: %v", err)
+	}
+	instance, legacy := methods[0].Results[0].TypeID, methods[1].Results[0].TypeID
+	if legacy != "example/contracts:Page_User" || !strings.HasPrefix(instance, "example/contracts:Page_User_") {
+		t.Fatalf("Page[User]=%q, Page_User=%q: the instance must get a disambiguating suffix", instance, legacy)
+	}
+}
+
+func TestNamedTypeName(t *testing.T) {
+
+	pkg := types.NewPackage("example/dto", "dto")
+	tparam := types.NewTypeParam(types.NewTypeName(token.NoPos, pkg, "T", nil), types.Universe.Lookup("any").Type())
+	origin := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Result", nil), nil, nil)
+	origin.SetTypeParams([]*types.TypeParam{tparam})
+	origin.SetUnderlying(types.NewStruct([]*types.Var{types.NewField(token.NoPos, pkg, "Value", tparam, false)}, nil))
+
+	cases := []struct {
+		arg  types.Type
+		want string
+	}{
+		{arg: types.Typ[types.String], want: "Result_String"},
+		{arg: types.NewMap(types.Typ[types.String], types.Typ[types.Int64]), want: "Result_MapStringInt64"},
+		{arg: types.NewArray(types.Universe.Lookup("byte").Type(), 16), want: "Result_ByteArray16"},
+		{arg: types.Universe.Lookup("any").Type(), want: "Result_Any"},
+	}
+	loader := &AutonomousPackageLoader{}
+	for _, tc := range cases {
+		instance, err := types.Instantiate(nil, origin, []types.Type{tc.arg}, true)
+		if err != nil {
+			t.Fatalf("instantiate: %v", err)
+		}
+		if got := loader.namedTypeName(instance.(*types.Named)); got != tc.want {
+			t.Fatalf("namedTypeName(Result[%s])=%q, want %q", tc.arg, got, tc.want)
+		}
+	}
+	if got := loader.namedTypeName(origin); got != "Result" {
+		t.Fatalf("namedTypeName(Result)=%q, want Result", got)
+	}
+
+	instantiate := func(arg types.Type) (name string) {
+		instance, err := types.Instantiate(nil, origin, []types.Type{arg}, true)
+		if err != nil {
+			t.Fatalf("instantiate: %v", err)
+		}
+		return loader.namedTypeName(instance.(*types.Named))
+	}
+	intList := types.NewSlice(types.Typ[types.Int])
+	mapOfList := instantiate(types.NewMap(types.Typ[types.String], intList))
+	listOfMap := instantiate(types.NewSlice(types.NewMap(types.Typ[types.String], types.Typ[types.Int])))
+	if mapOfList != "Result_MapStringIntList" || !strings.HasPrefix(listOfMap, "Result_MapStringIntList_") {
+		t.Fatalf("Result[map[string][]int]=%q and Result[[]map[string]int]=%q: only the colliding second name gets a suffix", mapOfList, listOfMap)
+	}
+	if again := instantiate(types.NewSlice(types.NewMap(types.Typ[types.String], types.Typ[types.Int]))); again != listOfMap {
+		t.Fatalf("the same instance must keep its name: %q, want %q", again, listOfMap)
+	}
+	userOf := func(path, name string) (typ types.Type) {
+		return types.NewNamed(types.NewTypeName(token.NoPos, types.NewPackage(path, name), "User", nil), types.NewStruct(nil, nil), nil)
+	}
+	userA, userB := instantiate(userOf("example/a", "a")), instantiate(userOf("example/b", "b"))
+	if userA != "Result_AUser" || userB != "Result_BUser" {
+		t.Fatalf("Result[a.User]=%q and Result[b.User]=%q must be package-qualified without suffix", userA, userB)
+	}
+	dtoA, dtoB := instantiate(userOf("example/a/dto", "dto")), instantiate(userOf("example/b/dto", "dto"))
+	if dtoA != "Result_DtoUser" || !strings.HasPrefix(dtoB, "Result_DtoUser_") {
+		t.Fatalf("Result[a/dto.User]=%q and Result[b/dto.User]=%q: same package names collide and get a suffix", dtoA, dtoB)
+	}
+}
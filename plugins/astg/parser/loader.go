@@ -34,6 +34,11 @@ type AutonomousPackageLoader struct {
 	mu                 sync.RWMutex
 	gcImporter         types.Importer
 	exportIndex        map[string]string
+	instances          map[string]*types.Named
+	instanceNames      map[string]string
+	instanceOwners     map[string]string
+	typeOwners         map[string]string
+	typeConflicts      []string
 }
 
 func NewAutonomousPackageLoader(modFile *modfile.File) (loader *AutonomousPackageLoader, err error) {
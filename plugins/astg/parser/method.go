@@ -106,6 +106,7 @@ func convertMethod(methodName string, funcType *ast.FuncType, docs []string, con
 
 func ensureTypeInProject(typeID string, typ types.Type, pkgPath string, imports map[string]string, project *model.Project, loader *AutonomousPackageLoader) (coreType *model.Type, err error) {
 
+	loader.claimTypeID(typeID, typ)
 	if existing, exists := project.Types[typeID]; exists {
 		return existing, nil
 	}
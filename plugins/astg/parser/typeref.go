@@ -138,7 +138,7 @@ func typeRefFromTypes(typ types.Type, pkgPath string, imports map[string]string,
 		return
 
 	default:
-		typeID := generateTypeIDFromGoTypes(typ)
+		typeID := generateTypeIDFromGoTypes(typ, loader)
 		if typeID == "" || typeID == "invalid type" {
 			return
 		}
@@ -21,6 +21,7 @@ import (
 	"tgp/plugins/astg/cache"
 	"tgp/plugins/astg/descref"
 	"tgp/plugins/astg/generator"
+	"tgp/plugins/astg/marker"
 	"tgp/plugins/astg/parser"
 )
 
@@ -54,6 +55,17 @@ func (p *AstgPlugin) Execute(request data.Storage) (response data.Storage, err e
 		return
 	}
 
+	var scope marker.ContractsScope
+	scope.Recursive, _ = data.Get[bool](request, "contracts-recursive")
+	if scope.Include, err = helper.ParseStringList(request, "contracts-include"); err != nil {
+		err = fmt.Errorf("%s: %w", i18n.Msg("failed to parse contracts-include"), err)
+		return
+	}
+	if scope.Exclude, err = helper.ParseStringList(request, "contracts-exclude"); err != nil {
+		err = fmt.Errorf("%s: %w", i18n.Msg("failed to parse contracts-exclude"), err)
+		return
+	}
+
 	contractsDisplay := "all"
 	if len(contractsFilter) > 0 {
 		contractsDisplay = strings.Join(contractsFilter, ", ")
@@ -65,6 +77,7 @@ func (p *AstgPlugin) Execute(request data.Storage) (response data.Storage, err e
 	slog.Info(i18n.Msg("analyzing project"),
 		slog.String("contractsDir", contractsDir),
 		slog.String("contracts", contractsDisplay),
+		slog.Bool("contracts-recursive", scope.Recursive),
 		slog.Bool("no-cache", noCache),
 	)
 
@@ -78,11 +91,11 @@ func (p *AstgPlugin) Execute(request data.Storage) (response data.Storage, err e
 			slog.Debug(i18n.Msg("failed to compute project ID"), slog.String("error", err.Error()))
 		}
 	} else {
-		project, fromCache, projectID = cache.GetProject(internal.ProjectRoot, contractsDir, excludeDirs)
+		project, fromCache, projectID = cache.GetProject(internal.ProjectRoot, contractsDir, scope, excludeDirs)
 	}
 
 	if !fromCache {
-		if project, err = parser.CollectWithExcludeDirs(internal.Version, contractsDir, excludeDirs); err != nil || project == nil {
+		if project, err = parser.CollectWithExcludeDirs(internal.Version, contractsDir, scope, excludeDirs); err != nil || project == nil {
 			err = fmt.Errorf("%s: %w", i18n.Msg("failed to collect project"), err)
 			return
 		}
@@ -97,7 +110,7 @@ func (p *AstgPlugin) Execute(request data.Storage) (response data.Storage, err e
 
 		descref.ResolveFileRefsInProject(project, internal.ProjectRoot)
 		if projectID != "" {
-			cache.SaveProject(projectID, project, internal.ProjectRoot, contractsDir, excludeDirs)
+			cache.SaveProject(projectID, project, internal.ProjectRoot, contractsDir, scope, excludeDirs)
 		}
 	}
 
@@ -90,6 +90,16 @@ func (g *generator) generate(docOpts DocOptions) (err error) {
 				return
 			}
 		}
+		if g.renderer.HasJsonRPC() || g.renderer.HasHTTP() {
+			if err = g.renderer.RenderClientRetry(); err != nil {
+				return
+			}
+		}
+		if g.renderer.HasSecurity() {
+			if err = g.renderer.RenderClientCredentials(); err != nil {
+				return
+			}
+		}
 	}
 
 	contractsForClient := make([]*model.Contract, 0, len(g.project.Contracts))
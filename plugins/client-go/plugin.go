@@ -123,6 +123,25 @@ func (p *ClientGoPlugin) Info() (info plugin.Info, err error) {
 						Required:    false,
 						Default:     "contracts",
 					},
+					{
+						Name:        "contracts-recursive",
+						Type:        "bool",
+						Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"),
+						Required:    false,
+						Default:     false,
+					},
+					{
+						Name:        "contracts-include",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)"),
+						Required:    false,
+					},
+					{
+						Name:        "contracts-exclude",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)"),
+						Required:    false,
+					},
 					{
 						Name:        "out",
 						Type:        "string",
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -106,6 +107,44 @@ func (r *ClientRenderer) HasHTTP() (ok bool) {
 	return false
 }
 
+// HasSecurity — пакет объявляет @tg security, которую клиент может заполнить через Credentials.
+func (r *ClientRenderer) HasSecurity() (ok bool) {
+	return (r.HasJsonRPC() || r.HasHTTP()) && len(model.ProjectSecuritySchemes(r.project)) > 0
+}
+
+// HasProblemErrors — хотя бы один REST-метод отдаёт ошибки в формате RFC 9457 (@tg http-errors=problem).
+func (r *ClientRenderer) HasProblemErrors() (ok bool) {
+
+	for _, contract := range r.project.Contracts {
+		if model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) && model.ContractHasProblemErrors(r.project, contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIdempotency — хотя бы один метод помечен @tg idempotent и клиент прикладывает к нему ключ идемпотентности.
+func (r *ClientRenderer) HasIdempotency() (ok bool) {
+	return len(r.idempotencyHeaders()) > 0
+}
+
+// idempotencyHeaders — отсортированные имена заголовков ключа идемпотентности всех @tg idempotent методов.
+func (r *ClientRenderer) idempotencyHeaders() (headers []string) {
+
+	for _, contract := range r.project.Contracts {
+		for _, method := range contract.Methods {
+			if !model.MethodIsIdempotent(r.project, contract, method) {
+				continue
+			}
+			if header := model.MethodIdempotencyHeader(r.project, contract, method); !slices.Contains(headers, header) {
+				headers = append(headers, header)
+			}
+		}
+	}
+	slices.Sort(headers)
+	return
+}
+
 func (r *ClientRenderer) HasWS() (ok bool) {
 
 	for _, contract := range r.project.Contracts {
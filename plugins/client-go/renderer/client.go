@@ -96,6 +96,9 @@ func (r *ClientRenderer) RenderClient() (err error) {
 			if r.HasJsonRPC() {
 				bg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ClientHTTP").Call(Id("cli").Dot("httpClient")))
 				bg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ClientID").Call(Id("cli").Dot("name")))
+				if r.hasIdempotentJsonRPC() {
+					bg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "IdempotencyKeys").Call(Id("rpcIdempotencyHeaders")))
+				}
 				bg.Id("cli").Dot("rpc").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewClient").Call(Id("endpoint"), Id("cli").Dot("rpcOpts").Op("..."))
 			}
 
@@ -150,6 +153,12 @@ func (r *ClientRenderer) clientStructFunc(outDir string) Code {
 			sg.Id("beforeRequest").Func().Params(Qual(PackageContext, "Context"), Op("*").Qual(PackageHttp, "Request")).Params(Qual(PackageContext, "Context"))
 			sg.Id("afterRequest").Func().Params(Qual(PackageContext, "Context"), Op("*").Qual(PackageHttp, "Response")).Params(Err().Error())
 		}
+		if r.HasJsonRPC() || r.HasHTTP() {
+			sg.Id("retry").Op("*").Id("RetryPolicy")
+		}
+		if r.HasSecurity() {
+			sg.Id("credentials").Id("CredentialProvider")
+		}
 		if r.HasMetrics() {
 			sg.Line().Id("metrics").Op("*").Id("Metrics")
 			sg.Id("metricsReg").Op("*").Qual(PackagePrometheus, "Registry")
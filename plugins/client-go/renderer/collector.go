@@ -137,6 +137,12 @@ func (r *ClientRenderer) collectTypeIDRecursive(typeID string, collectedTypeIDs
 		slog.Debug(i18n.Msg("collectTypeIDRecursive: added typeID"), slog.String("typeID", typeID), slog.String("kind", string(typ.Kind)), slog.String("typeName", typ.TypeName), slog.String("importPkgPath", typ.ImportPkgPath))
 	}
 
+	for _, typeArg := range typ.TypeArgs {
+		if typeArg != nil {
+			r.collectTypeIDFromTypeRef(typeArg, collectedTypeIDs, processedTypes)
+		}
+	}
+
 	// Рекурсивно обходим зависимые типы (используя уже собранные данные Core)
 	switch typ.Kind {
 	case model.TypeKindArray:
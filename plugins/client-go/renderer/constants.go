@@ -19,6 +19,7 @@ const (
 	PackageErrors         = "errors"
 	PackageReflect        = "reflect"
 	PackageSort           = "sort"
+	PackageRand           = "math/rand/v2"
 	PackageUUID           = "github.com/google/uuid"
 	PackageFiber          = "github.com/gofiber/fiber/v2"
 	PackageSlog           = "log/slog"
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/generated"
+	"tgp/internal/model"
+)
+
+// RenderClientCredentials генерирует credentials.go: провайдеры учётных данных для схем @tg security и опцию Credentials.
+func (r *ClientRenderer) RenderClientCredentials() (err error) {
+
+	outDir := r.outDir
+	jsonrpcPkg := fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir))
+	schemes := model.ProjectSecuritySchemes(r.project)
+
+	srcFile := NewSrcFile(filepath.Base(outDir))
+	srcFile.PackageComment(generated.ByToolGateway)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageHttp, "http")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(jsonrpcPkg, "jsonrpc")
+
+	srcFile.Line().Comment("CredentialProvider добавляет учётные данные в запрос; вызывается перед каждой попыткой, включая повторы.")
+	srcFile.Type().Id("CredentialProvider").Func().Params(Id("ctx").Qual(PackageContext, "Context"), Id("req").Op("*").Qual(PackageHttp, "Request")).Params(Err().Error())
+
+	srcFile.Line().Comment("Credentials подключает провайдера учётных данных ко всем HTTP и JSON-RPC вызовам клиента.")
+	srcFile.Func().Id("Credentials").Params(Id("provider").Id("CredentialProvider")).Params(Id("Option")).BlockFunc(func(bg *Group) {
+		bg.Return(Func().Params(Id("cli").Op("*").Id("Client"))).BlockFunc(func(returnBg *Group) {
+			returnBg.Id("cli").Dot("credentials").Op("=").Id("provider")
+			if r.HasJsonRPC() {
+				returnBg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(jsonrpcPkg, "Credentials").Call(Id("provider")))
+			}
+		})
+	})
+
+	if model.HasSecurityKind(schemes, model.SecurityBearer) {
+		srcFile.Line().Add(r.credentialsBearerFuncs())
+	}
+	if model.HasSecurityKind(schemes, model.SecurityBasic) {
+		srcFile.Line().Comment("BasicAuth передаёт логин и пароль в заголовке Authorization: Basic.")
+		srcFile.Func().Id("BasicAuth").Params(Id("username"), Id("password").String()).Params(Id("CredentialProvider")).Block(
+			Return(Func().Params(Id("_").Qual(PackageContext, "Context"), Id("req").Op("*").Qual(PackageHttp, "Request")).Params(Err().Error())).Block(
+				Id("req").Dot("SetBasicAuth").Call(Id("username"), Id("password")),
+				Return(),
+			),
+		)
+	}
+	for _, scheme := range schemes {
+		if scheme.Kind == model.SecurityAPIKey {
+			srcFile.Line().Add(r.credentialsAPIKeyFunc(scheme))
+			break
+		}
+	}
+
+	return srcFile.Save(path.Join(outDir, "credentials.go"))
+}
+
+func (r *ClientRenderer) credentialsBearerFuncs() (c Code) {
+
+	setBearer := func(token Code) Code {
+		return Id("req").Dot("Header").Dot("Set").Call(Lit("Authorization"), Lit("Bearer ").Op("+").Add(token))
+	}
+	return Comment("StaticToken передаёт неизменный токен в заголовке Authorization: Bearer.").
+		Line().
+		Func().Id("StaticToken").Params(Id("token").String()).Params(Id("CredentialProvider")).Block(
+		Return(Func().Params(Id("_").Qual(PackageContext, "Context"), Id("req").Op("*").Qual(PackageHttp, "Request")).Params(Err().Error())).Block(
+			setBearer(Id("token")),
+			Return(),
+		),
+	).
+		Line().
+		Line().
+		Comment("TokenFunc получает новый токен и момент его истечения; нулевой expiresAt — токен не кешируется.").
+		Line().
+		Type().Id("TokenFunc").Func().Params(Id("ctx").Qual(PackageContext, "Context")).Params(Id("token").String(), Id("expiresAt").Qual(PackageTime, "Time"), Err().Error()).
+		Line().
+		Line().
+		Comment("tokenRefreshMargin — за сколько до истечения RefreshingToken запрашивает новый токен.").
+		Line().
+		Const().Id("tokenRefreshMargin").Op("=").Lit(10).Op("*").Qual(PackageTime, "Second").
+		Line().
+		Line().
+		Comment("RefreshingToken передаёт токен от fetch в заголовке Authorization: Bearer и обновляет его перед истечением;").
+		Line().
+		Comment("одновременные вызовы ждут одно обновление.").
+		Line().
+		Func().Id("RefreshingToken").Params(Id("fetch").Id("TokenFunc")).Params(Id("CredentialProvider")).Block(
+		Id("source").Op(":=").Op("&").Id("tokenSource").Values(Dict{Id("fetch"): Id("fetch")}),
+		Return(Func().Params(Id("ctx").Qual(PackageContext, "Context"), Id("req").Op("*").Qual(PackageHttp, "Request")).Params(Err().Error())).Block(
+			Var().Id("token").String(),
+			If(List(Id("token"), Err()).Op("=").Id("source").Dot("get").Call(Id("ctx")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			setBearer(Id("token")),
+			Return(),
+		),
+	).
+		Line().
+		Line().
+		Type().Id("tokenSource").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("fetch").Id("TokenFunc"),
+		Id("token").String(),
+		Id("expiresAt").Qual(PackageTime, "Time"),
+	).
+		Line().
+		Line().
+		Func().Params(Id("source").Op("*").Id("tokenSource")).Id("get").Params(Id("ctx").Qual(PackageContext, "Context")).Params(Id("token").String(), Err().Error()).Block(
+		Id("source").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("source").Dot("mu").Dot("Unlock").Call(),
+		If(Id("source").Dot("token").Op("!=").Lit("").Op("&&").Qual(PackageTime, "Now").Call().Dot("Add").Call(Id("tokenRefreshMargin")).Dot("Before").Call(Id("source").Dot("expiresAt"))).Block(
+			Return(Id("source").Dot("token"), Nil()),
+		),
+		Var().Id("expiresAt").Qual(PackageTime, "Time"),
+		If(List(Id("token"), Id("expiresAt"), Err()).Op("=").Id("source").Dot("fetch").Call(Id("ctx")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		List(Id("source").Dot("token"), Id("source").Dot("expiresAt")).Op("=").List(Id("token"), Id("expiresAt")),
+		Return(),
+	)
+}
+
+func (r *ClientRenderer) credentialsAPIKeyFunc(scheme model.SecurityScheme) (c Code) {
+
+	return Comment(fmt.Sprintf("APIKey передаёт ключ API в %s %s.", map[string]string{
+		model.SecurityInHeader: "заголовке",
+		model.SecurityInQuery:  "параметре запроса",
+		model.SecurityInCookie: "cookie",
+	}[scheme.In], scheme.Name)).
+		Line().
+		Func().Id("APIKey").Params(Id("key").String()).Params(Id("CredentialProvider")).Block(
+		Return(Func().Params(Id("_").Qual(PackageContext, "Context"), Id("req").Op("*").Qual(PackageHttp, "Request")).Params(Err().Error())).BlockFunc(func(bg *Group) {
+			switch scheme.In {
+			case model.SecurityInQuery:
+				bg.Id("query").Op(":=").Id("req").Dot("URL").Dot("Query").Call()
+				bg.Id("query").Dot("Set").Call(Lit(scheme.Name), Id("key"))
+				bg.Id("req").Dot("URL").Dot("RawQuery").Op("=").Id("query").Dot("Encode").Call()
+			case model.SecurityInCookie:
+				bg.Id("cookies").Op(":=").Id("req").Dot("Cookies").Call()
+				bg.Id("req").Dot("Header").Dot("Del").Call(Lit("Cookie"))
+				bg.For(List(Id("_"), Id("cookie")).Op(":=").Range().Id("cookies")).Block(
+					If(Id("cookie").Dot("Name").Op("!=").Lit(scheme.Name)).Block(
+						Id("req").Dot("AddCookie").Call(Id("cookie")),
+					),
+				)
+				bg.Id("req").Dot("AddCookie").Call(Op("&").Qual(PackageHttp, "Cookie").Values(Dict{Id("Name"): Lit(scheme.Name), Id("Value"): Id("key")}))
+			default:
+				bg.Id("req").Dot("Header").Dot("Set").Call(Lit(scheme.Name), Id("key"))
+			}
+			bg.Return()
+		}),
+	)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestRenderClientCredentials(t *testing.T) {
+
+	project := retryTestProject()
+	project.Annotations = tags.DocTags{model.TagSecurity: "http:bearer,apiKey:query:api_key"}
+	dir := filepath.Join(t.TempDir(), "client")
+	renderer := NewClientRenderer(project, dir, "example", "client")
+	if !renderer.HasSecurity() {
+		t.Fatalf("HasSecurity must be true for @tg security")
+	}
+	if err := renderer.RenderClientCredentials(); err != nil {
+		t.Fatalf("RenderClientCredentials: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "credentials.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	source := string(content)
+
+	for _, want := range []string{
+		"type CredentialProvider func(ctx context.Context, req *http.Request) (err error)",
+		"cli.rpcOpts = append(cli.rpcOpts, jsonrpc.Credentials(provider))",
+		"func StaticToken(token string) CredentialProvider",
+		"func RefreshingToken(fetch TokenFunc) CredentialProvider",
+		`query.Set("api_key", key)`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("credentials.go must contain %q:\n%s", want, source)
+		}
+	}
+	if strings.Contains(source, "func BasicAuth(") {
+		t.Fatalf("BasicAuth must be generated only for http:basic:\n%s", source)
+	}
+}
+
+func TestRenderClient_AppliesCredentialsPerAttempt(t *testing.T) {
+
+	project := retryTestProject()
+	project.Annotations = tags.DocTags{model.TagSecurity: "http:basic"}
+	dir := filepath.Join(t.TempDir(), "client")
+	renderer := NewClientRenderer(project, dir, "example", "client")
+	if err := renderer.RenderJsonRPCPackage(dir); err != nil {
+		t.Fatalf("RenderJsonRPCPackage: %v", err)
+	}
+	if err := renderer.RenderHTTP(); err != nil {
+		t.Fatalf("RenderHTTP: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "http.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	source := string(content)
+	loop := strings.Index(source, "for attempt = 0; ; attempt++ {")
+	if loop < 0 || !strings.Contains(source[loop:], "if err = cli.credentials(ctx, httpReq); err != nil") {
+		t.Fatalf("doRoundTrip must apply credentials inside the retry loop:\n%s", source)
+	}
+
+	rpcInternal, err := os.ReadFile(filepath.Join(dir, "jsonrpc", "internal.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	if !strings.Contains(string(rpcInternal), "client.options.credentials(ctx, httpRequest)") {
+		t.Fatalf("JSON-RPC calls must apply the credentials option:\n%s", rpcInternal)
+	}
+}
@@ -5,10 +5,13 @@ package renderer
 import (
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
 	"tgp/internal/generated"
+	"tgp/internal/model"
 )
 
 func (r *ClientRenderer) RenderClientError() (err error) {
@@ -25,6 +28,10 @@ func (r *ClientRenderer) RenderClientError() (err error) {
 	if r.HasHTTP() {
 		srcFile.ImportName(PackageFmt, "fmt")
 	}
+	if r.HasProblemErrors() {
+		srcFile.ImportName(jsonPkg, "json")
+		srcFile.ImportName(PackageMime, "mime")
+	}
 
 	srcFile.Line().Const().Id("internalError").Op("=").Lit(-32603) // JSON-RPC: Internal error
 
@@ -52,6 +59,11 @@ func (r *ClientRenderer) RenderClientError() (err error) {
 		).Params(Id("err").Error())
 	}
 
+	if r.HasProblemErrors() {
+		srcFile.Line().Add(r.problemTypeConsts())
+		srcFile.Line().Add(r.problemType(jsonPkg))
+	}
+
 	if r.HasJsonRPC() {
 		srcFile.Line().Add(r.errorJsonRPCErrorMethod()).Line()
 	}
@@ -89,18 +101,106 @@ func (r *ClientRenderer) RenderClientError() (err error) {
 			Id("statusCode").Int(),
 			Id("contentType").String(),
 			Id("body").Index().Byte(),
-		).Params(Id("err").Error()).Block(
-			Return(Op("&").Id("ResponseError").Values(Dict{
+		).Params(Id("err").Error()).BlockFunc(func(bg *Group) {
+			if r.HasProblemErrors() {
+				bg.If(List(Id("mediaType"), Id("_"), Id("_")).Op(":=").Qual(PackageMime, "ParseMediaType").Call(Id("contentType")).Op(";").Id("mediaType").Op("==").Lit(model.ProblemContentType)).Block(
+					If(List(Id("problem"), Id("ok")).Op(":=").Id("decodeProblem").Call(Id("statusCode"), Id("body")).Op(";").Id("ok")).Block(
+						Return(Id("problem")),
+					),
+				)
+			}
+			bg.Return(Op("&").Id("ResponseError").Values(Dict{
 				Id("StatusCode"):  Id("statusCode"),
 				Id("ContentType"): Id("contentType"),
 				Id("Body"):        Id("append").Call(Index().Byte().Call(Nil()), Id("body").Op("...")),
-			})),
-		)
+			}))
+		})
 	}
 
 	return srcFile.Save(path.Join(outDir, "error.go"))
 }
 
+// problemTypeConsts — URI типов проблем RFC 9457 для ошибок методов с @tg http-errors=problem.
+func (r *ClientRenderer) problemTypeConsts() (c Code) {
+
+	uris := make(map[string]string)
+	for _, contract := range r.project.Contracts {
+		if !model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if !model.HTTPErrorsAsProblem(r.project, contract, method) {
+				continue
+			}
+			for _, errInfo := range method.Errors {
+				if errInfo.TypeName == "" {
+					continue
+				}
+				name := "ProblemType" + strings.TrimPrefix(errInfo.TypeName, "Err")
+				if _, found := uris[name]; !found {
+					uris[name] = model.ProblemType(r.project, contract, errInfo)
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(uris))
+	for name := range uris {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return Const().DefsFunc(func(dg *Group) {
+		dg.Id("ProblemTypeBlank").Op("=").Lit(model.ProblemTypeBlank)
+		for _, name := range names {
+			dg.Id(name).Op("=").Lit(uris[name])
+		}
+	})
+}
+
+// problemType — ошибка REST в формате RFC 9457 и её разбор из тела ответа application/problem+json.
+func (r *ClientRenderer) problemType(jsonPkg string) (c Code) {
+
+	return Comment("Problem — ошибка REST в формате RFC 9457 (application/problem+json); члены-расширения — в Extensions.").
+		Line().
+		Type().Id("Problem").Struct(
+		Id("Type").String().Tag(map[string]string{"json": "type"}),
+		Id("Title").String().Tag(map[string]string{"json": "title"}),
+		Id("Status").Int().Tag(map[string]string{"json": "status"}),
+		Id("Detail").String().Tag(map[string]string{"json": "detail,omitempty"}),
+		Id("Instance").String().Tag(map[string]string{"json": "instance,omitempty"}),
+		Id("Extensions").Map(String()).Qual(jsonPkg, "RawMessage").Tag(map[string]string{"json": "-"}),
+	).
+		Line().Line().
+		Func().Params(Id("p").Op("*").Id("Problem")).Id("Error").Params().Params(Id("msg").String()).Block(
+		If(Id("p").Dot("Detail").Op("==").Lit("")).Block(
+			Return(Qual(PackageFmt, "Sprintf").Call(Lit("HTTP error: %d: %s"), Id("p").Dot("Status"), Id("p").Dot("Title"))),
+		),
+		Return(Qual(PackageFmt, "Sprintf").Call(Lit("HTTP error: %d: %s: %s"), Id("p").Dot("Status"), Id("p").Dot("Title"), Id("p").Dot("Detail"))),
+	).
+		Line().Line().
+		Func().Params(Id("p").Op("*").Id("Problem")).Id("Code").Params().Params(Id("code").Int()).Block(
+		Return(Id("p").Dot("Status")),
+	).
+		Line().Line().
+		Func().Id("decodeProblem").Params(Id("statusCode").Int(), Id("body").Index().Byte()).Params(Id("problem").Op("*").Id("Problem"), Id("ok").Bool()).Block(
+		Id("problem").Op("=").Op("&").Id("Problem").Values(),
+		If(Qual(jsonPkg, "Unmarshal").Call(Id("body"), Id("problem")).Op("!=").Nil()).Block(
+			Return(Nil(), False()),
+		),
+		If(Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("problem").Dot("Extensions")).Op("==").Nil()).Block(
+			For(List(Id("_"), Id("member")).Op(":=").Range().Index().String().Values(Lit("type"), Lit("title"), Lit("status"), Lit("detail"), Lit("instance"))).Block(
+				Delete(Id("problem").Dot("Extensions"), Id("member")),
+			),
+		),
+		If(Id("problem").Dot("Type").Op("==").Lit("")).Block(
+			Id("problem").Dot("Type").Op("=").Id("ProblemTypeBlank"),
+		),
+		If(Id("problem").Dot("Status").Op("==").Lit(0)).Block(
+			Id("problem").Dot("Status").Op("=").Id("statusCode"),
+		),
+		Return(Id("problem"), True()),
+	)
+}
+
 func (r *ClientRenderer) errorJsonRPCType() (c Code) {
 
 	return Type().Id("errorJsonRPC").Struct(
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+)
+
+func TestRenderClientTypes_GenericInstances(t *testing.T) {
+
+	const (
+		modulePath = "example.com/svc"
+		dtoPkg     = modulePath + "/contracts/dto"
+		userID     = dtoPkg + ":User"
+		pageID     = dtoPkg + ":Page_User"
+		optionID   = "github.com/samber/mo:Option_String"
+	)
+
+	project := &model.Project{
+		ModulePath: modulePath,
+		Types: map[string]*model.Type{
+			userID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "User",
+				ImportPkgPath: dtoPkg,
+				PkgName:       "dto",
+				StructFields: []*model.StructField{
+					{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}},
+				},
+			},
+			pageID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Page_User",
+				ImportPkgPath: dtoPkg,
+				PkgName:       "dto",
+				GenericOf:     dtoPkg + ":Page",
+				TypeArgs:      []*model.TypeRef{{TypeID: userID}},
+				StructFields: []*model.StructField{
+					{Name: "Items", TypeRef: model.TypeRef{TypeID: userID, IsSlice: true}, Tags: map[string][]string{"json": {"items"}}},
+					{Name: "Cursor", TypeRef: model.TypeRef{TypeID: optionID}, Tags: map[string][]string{"json": {"cursor"}}},
+				},
+			},
+			optionID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Option_String",
+				ImportPkgPath: "github.com/samber/mo",
+				PkgName:       "mo",
+				GenericOf:     "github.com/samber/mo:Option",
+				TypeArgs:      []*model.TypeRef{{TypeID: "string"}},
+			},
+		},
+	}
+
+	dir := filepath.Join(t.TempDir(), "client")
+	renderer := NewClientRenderer(project, dir, modulePath, "client")
+	if err := renderer.RenderClientTypes(map[string]bool{userID: true, pageID: true, optionID: true}); err != nil {
+		t.Fatalf("RenderClientTypes: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "dto", "page_user.go"))
+	if err != nil {
+		t.Fatalf("read page_user.go: %v", err)
+	}
+	source := string(content)
+
+	if !strings.Contains(source, "type Page_User struct") {
+		t.Fatalf("project generic instance must be generated as concrete type Page_User:\n%s", source)
+	}
+	if !strings.Contains(source, "[]User") {
+		t.Fatalf("expected Items []User, got:\n%s", source)
+	}
+	if !strings.Contains(source, "mo.Option[string]") {
+		t.Fatalf("external generic instance must be referenced as mo.Option[string]:\n%s", source)
+	}
+}
@@ -167,12 +167,21 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *mod
 				}
 			}
 
+			if model.MethodIsIdempotent(r.project, contract, method) {
+				header := model.MethodIdempotencyHeader(r.project, contract, method)
+				bg.Comment("Запрос переиспользуется между повторами, поэтому ключ идемпотентности у всех попыток один.")
+				bg.If(Id("httpReq").Dot("Header").Dot("Get").Call(Lit(header)).Op("==").Lit("")).Block(
+					Id("httpReq").Dot("Header").Dot("Set").Call(Lit(header), Qual(PackageUUID, "NewString").Call()),
+				)
+			}
 			bg.Var().Id("httpResp").Op("*").Qual(PackageHttp, "Response")
 			bg.If(List(Id("httpResp"), Err()).Op("=").Id("cli").Dot("doRoundTrip").Call(
 				Id(_ctx_),
+				Lit(r.contractNameToLowerCamel(contract)),
 				Lit(r.methodNameToLowerCamel(method)),
 				Id("httpReq"),
 				Lit(successStatusCode),
+				r.methodRetries(contract, method),
 			).Op(";").Err().Op("!=").Nil()).Block(Return())
 			if responseStreamResult == nil && !responseMultipart {
 				bg.Add(r.httpDeferBodyClose())
@@ -78,9 +78,11 @@ func (r *ClientRenderer) httpDoRoundTripFunc(outDir string) (c Code) {
 	return Func().Params(Id("cli").Op("*").Id("Client")).
 		Id("doRoundTrip").Params(
 		Id("ctx").Qual(PackageContext, "Context"),
+		Id("serviceName").String(),
 		Id("methodName").String(),
 		Id("httpReq").Op("*").Qual(PackageHttp, "Request"),
 		Id("successCode").Int(),
+		Id("retries").Int(),
 	).Params(Id("httpResp").Op("*").Qual(PackageHttp, "Response"), Err().Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("X-Client-Id"), Id("cli").Dot("name"))
@@ -97,12 +99,38 @@ func (r *ClientRenderer) httpDoRoundTripFunc(outDir string) (c Code) {
 					),
 				),
 			)
+			bg.Var().Id("attempt").Int()
 			bg.Defer().Func().Params().Block(
 				If(Err().Op("!=").Nil().Op("&&").Id("cli").Dot("logOnError")).Block(
-					Qual(PackageSlog, "ErrorContext").Call(Id("ctx"), Lit("HTTP request failed"), Qual(PackageSlog, "String").Call(Lit("method"), Id("httpReq").Dot("Method")), Qual(PackageSlog, "String").Call(Lit("curl"), Id("curlCmd")), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+					Qual(PackageSlog, "ErrorContext").Call(Id("ctx"), Lit("HTTP request failed"), Qual(PackageSlog, "String").Call(Lit("method"), Id("httpReq").Dot("Method")), Qual(PackageSlog, "String").Call(Lit("curl"), Id("curlCmd")), Qual(PackageSlog, "Int").Call(Lit("attempts"), Id("attempt").Op("+").Lit(1)), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
 				),
 			).Call()
-			bg.If(List(Id("httpResp"), Err()).Op("=").Id("cli").Dot("httpClient").Dot("Do").Call(Id("httpReq")).Op(";").Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
+			bg.For(Id("attempt").Op("=").Lit(0), Empty(), Id("attempt").Op("++")).BlockFunc(func(fg *Group) {
+				if r.HasSecurity() {
+					fg.If(Id("cli").Dot("credentials").Op("!=").Nil()).Block(
+						If(Err().Op("=").Id("cli").Dot("credentials").Call(Id("ctx"), Id("httpReq")).Op(";").Err().Op("!=").Nil()).Block(
+							Return(Nil(), Qual(PackageFmt, "Errorf").Call(Lit("credentials: %w"), Err())),
+						),
+					)
+				}
+				fg.List(Id("httpResp"), Err()).Op("=").Id("cli").Dot("httpClient").Dot("Do").Call(Id("httpReq"))
+				fg.If(Err().Op("==").Nil().Op("&&").Id("httpResp").Dot("StatusCode").Op("==").Id("successCode")).Block(
+					Break(),
+				)
+				fg.If(Op("!").Id("cli").Dot("retryAttempt").Call(Id("ctx"), Id("serviceName"), Id("methodName"), Id("retries"), Id("attempt"), Id("httpReq"), Id("httpResp"), Err())).Block(
+					Break(),
+				)
+				fg.If(Id("httpResp").Op("!=").Nil()).Block(
+					List(Id("_"), Id("_")).Op("=").Qual(PackageIO, "Copy").Call(Qual(PackageIO, "Discard"), Id("httpResp").Dot("Body")),
+					Id("_").Op("=").Id("httpResp").Dot("Body").Dot("Close").Call(),
+				)
+				fg.If(Id("httpReq").Dot("GetBody").Op("!=").Nil()).Block(
+					If(List(Id("httpReq").Dot("Body"), Err()).Op("=").Id("httpReq").Dot("GetBody").Call().Op(";").Err().Op("!=").Nil()).Block(
+						Return(Nil(), Err()),
+					),
+				)
+			})
+			bg.If(Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
 			bg.If(Id("cli").Dot("afterRequest").Op("!=").Nil()).Block(
 				If(Err().Op("=").Id("cli").Dot("afterRequest").Call(Id("ctx"), Id("httpResp")).Op(";").Err().Op("!=").Nil()).Block(
 					Id("_").Op("=").Id("httpResp").Dot("Body").Dot("Close").Call(),
@@ -40,6 +40,9 @@ func TestRenderHTTP_GeneratesSharedHelpersOnce(t *testing.T) {
 	if !strings.Contains(source, "serviceLabel string") {
 		t.Fatalf("recordHTTPMetrics must accept serviceLabel parameter:\n%s", source)
 	}
+	if !strings.Contains(source, "cli.retryAttempt(ctx, serviceName, methodName, retries, attempt, httpReq, httpResp, err)") {
+		t.Fatalf("doRoundTrip must consult the retry policy:\n%s", source)
+	}
 }
 
 func TestRenderServiceClient_DoesNotDuplicateHTTPHelpers(t *testing.T) {
@@ -294,3 +297,43 @@ func httpClientTestProject() (project *model.Project) {
 	}
 	return project
 }
+
+func TestRenderClientError_ProblemDecoder(t *testing.T) {
+
+	project := httpClientTestProject()
+	contract := project.Contracts[0]
+	contract.Annotations[model.TagHttpErrors] = model.HTTPErrorsProblem
+	contract.Methods[0].Errors = []*model.ErrorInfo{{PkgPath: "example/errs", TypeName: "ErrNotFound", HTTPCode: 404}}
+	dir := filepath.Join(t.TempDir(), "client")
+
+	if err := NewClientRenderer(project, dir, "example", "client").RenderClientError(); err != nil {
+		t.Fatalf("RenderClientError: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "error.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	source := string(content)
+	for _, want := range []string{
+		`ProblemTypeNotFound = "urn:problem-type:not-found"`,
+		"type Problem struct",
+		"Extensions map[string]json.RawMessage `json:\"-\"`",
+		`mediaType == "application/problem+json"`,
+		"if problem, ok := decodeProblem(statusCode, body); ok {",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("error.go must contain %q:\n%s", want, source)
+		}
+	}
+
+	project = httpClientTestProject()
+	if err = NewClientRenderer(project, dir, "example", "client").RenderClientError(); err != nil {
+		t.Fatalf("RenderClientError: %v", err)
+	}
+	if content, err = os.ReadFile(filepath.Join(dir, "error.go")); err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	if strings.Contains(string(content), "Problem") {
+		t.Fatalf("problem decoding must be opt-in:\n%s", content)
+	}
+}
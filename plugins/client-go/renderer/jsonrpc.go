@@ -330,6 +330,7 @@ func (gen *jsonrpcGenerator) renderInternal(outDir, jsonPkg string) (err error)
 	srcFile.ImportName(PackageSlog, "slog")
 	srcFile.ImportName(PackageHttp, "http")
 	srcFile.ImportName(jsonPkg, "json")
+	srcFile.ImportName(PackageUUID, "uuid")
 
 	srcFile.Line().Func().Params(Id("client").Op("*").Id("ClientRPC")).Id("newRequest").Params(Id("ctx").Qual(PackageContext, "Context"), Id("reqBody").Any()).Params(Id("request").Op("*").Qual(PackageHttp, "Request"), Id("err").Error()).BlockFunc(
 		func(bg *Group) {
@@ -379,6 +380,10 @@ func (gen *jsonrpcGenerator) renderInternal(outDir, jsonPkg string) (err error)
 				Id("err").Op("=").Qual(PackageFmt, "Errorf").Call(Lit("rpc call %v() on %v: %v"), Id("request").Dot("Method"), Id("client").Dot("endpoint"), Id("err").Dot("Error").Call()),
 				Return(),
 			)
+			bg.Comment("Ключ идемпотентности создаётся один раз на вызов и не меняется между повторами.")
+			bg.If(List(Id("header"), Id("found")).Op(":=").Id("client").Dot("options").Dot("idempotencyHeaders").Index(Id("request").Dot("Method")).Op(";").Id("found").Op("&&").Id("httpRequest").Dot("Header").Dot("Get").Call(Id("header")).Op("==").Lit("")).Block(
+				Id("httpRequest").Dot("Header").Dot("Set").Call(Id("header"), Qual(PackageUUID, "NewString").Call()),
+			)
 			bg.If(Id("client").Dot("options").Dot("before").Op("!=").Nil()).Block(
 				Id("ctx").Op("=").Id("client").Dot("options").Dot("before").Call(Id("ctx"), Id("httpRequest")),
 			)
@@ -393,15 +398,41 @@ func (gen *jsonrpcGenerator) renderInternal(outDir, jsonPkg string) (err error)
 					)
 				},
 			)
+			bg.Var().Id("attempt").Int()
 			bg.Defer().Func().Params().BlockFunc(
 				func(dg *Group) {
 					dg.If(Id("err").Op("!=").Nil().Op("&&").Id("client").Dot("options").Dot("logOnError")).Block(
-						Qual(PackageSlog, "ErrorContext").Call(Id("ctx"), Lit("call"), Qual(PackageSlog, "String").Call(Lit("method"), Id("request").Dot("Method")), Qual(PackageSlog, "String").Call(Lit("curl"), Id("curlCmd")), Qual(PackageSlog, "Any").Call(Lit("error"), Id("err"))),
+						Qual(PackageSlog, "ErrorContext").Call(Id("ctx"), Lit("call"), Qual(PackageSlog, "String").Call(Lit("method"), Id("request").Dot("Method")), Qual(PackageSlog, "String").Call(Lit("curl"), Id("curlCmd")), Qual(PackageSlog, "Int").Call(Lit("attempts"), Id("attempt").Op("+").Lit(1)), Qual(PackageSlog, "Any").Call(Lit("error"), Id("err"))),
 					)
 				},
 			).Call()
 			bg.Var().Id("httpResponse").Op("*").Qual(PackageHttp, "Response")
-			bg.If(List(Id("httpResponse"), Id("err")).Op("=").Id("client").Dot("httpClient").Dot("Do").Call(Id("httpRequest")).Op(";").Id("err").Op("!=").Nil()).Block(
+			bg.For(Id("attempt").Op("=").Lit(0), Empty(), Id("attempt").Op("++")).BlockFunc(
+				func(fg *Group) {
+					fg.If(Id("client").Dot("options").Dot("credentials").Op("!=").Nil()).Block(
+						If(Id("err").Op("=").Id("client").Dot("options").Dot("credentials").Call(Id("ctx"), Id("httpRequest")).Op(";").Id("err").Op("!=").Nil()).Block(
+							Id("err").Op("=").Qual(PackageFmt, "Errorf").Call(Lit("rpc call %v() on %v: credentials: %w"), Id("request").Dot("Method"), Id("client").Dot("endpoint"), Id("err")),
+							Return(),
+						),
+					)
+					fg.List(Id("httpResponse"), Id("err")).Op("=").Id("client").Dot("httpClient").Dot("Do").Call(Id("httpRequest"))
+					fg.If(Id("err").Op("==").Nil().Op("&&").Id("httpResponse").Dot("StatusCode").Op("==").Qual(PackageHttp, "StatusOK")).Block(
+						Break(),
+					)
+					fg.If(Id("client").Dot("options").Dot("retry").Op("==").Nil().Op("||").Op("!").Id("client").Dot("options").Dot("retry").Call(Id("ctx"), Id("request").Dot("Method"), Id("attempt"), Id("httpRequest"), Id("httpResponse"), Id("err"))).Block(
+						Break(),
+					)
+					fg.If(Id("httpResponse").Op("!=").Nil()).Block(
+						List(Id("_"), Id("_")).Op("=").Qual(PackageIO, "Copy").Call(Qual(PackageIO, "Discard"), Id("httpResponse").Dot("Body")),
+						Id("_").Op("=").Id("httpResponse").Dot("Body").Dot("Close").Call(),
+					)
+					fg.If(List(Id("httpRequest").Dot("Body"), Id("err")).Op("=").Id("httpRequest").Dot("GetBody").Call().Op(";").Id("err").Op("!=").Nil()).Block(
+						Id("err").Op("=").Qual(PackageFmt, "Errorf").Call(Lit("rpc call %v() on %v: %v"), Id("request").Dot("Method"), Id("client").Dot("endpoint"), Id("err").Dot("Error").Call()),
+						Return(),
+					)
+				},
+			)
+			bg.If(Id("err").Op("!=").Nil()).Block(
 				Id("err").Op("=").Qual(PackageFmt, "Errorf").Call(Lit("rpc call %v() on %v: %v"), Id("request").Dot("Method"), Id("httpRequest").Dot("URL").Dot("String").Call(), Id("err").Dot("Error").Call()),
 				Return(),
 			)
@@ -491,6 +522,12 @@ func (gen *jsonrpcGenerator) renderInternal(outDir, jsonPkg string) (err error)
 					)
 				},
 			).Call()
+			bg.If(Id("client").Dot("options").Dot("credentials").Op("!=").Nil()).Block(
+				If(Id("err").Op("=").Id("client").Dot("options").Dot("credentials").Call(Id("ctx"), Id("httpRequest")).Op(";").Id("err").Op("!=").Nil()).Block(
+					Id("err").Op("=").Qual(PackageFmt, "Errorf").Call(Lit("rpc batch call on %v: credentials: %w"), Id("client").Dot("endpoint"), Id("err")),
+					Return(),
+				),
+			)
 			bg.Var().Id("httpResponse").Op("*").Qual(PackageHttp, "Response")
 			bg.If(List(Id("httpResponse"), Id("err")).Op("=").Id("client").Dot("httpClient").Dot("Do").Call(Id("httpRequest")).Op(";").Id("err").Op("!=").Nil()).Block(
 				Id("err").Op("=").Qual(PackageFmt, "Errorf").Call(Lit("rpc batch call on %v: %v"), Id("httpRequest").Dot("URL").Dot("String").Call(), Id("err").Dot("Error").Call()),
@@ -566,10 +603,23 @@ func (gen *jsonrpcGenerator) renderOption(outDir string) (err error) {
 		Id("customHeaders").Map(String()).String(),
 		Id("before").Func().Params(Qual(PackageContext, "Context"), Op("*").Qual(PackageHttp, "Request")).Params(Qual(PackageContext, "Context")),
 		Id("after").Func().Params(Qual(PackageContext, "Context"), Op("*").Qual(PackageHttp, "Response")).Params(Err().Error()),
+		Id("retry").Id("RetryFunc"),
+		Id("credentials").Func().Params(Qual(PackageContext, "Context"), Op("*").Qual(PackageHttp, "Request")).Params(Error()),
+		Id("idempotencyHeaders").Map(String()).String(),
 	)
 
 	srcFile.Line().Type().Id("Option").Op("=").Func().Params(Id("ops").Op("*").Id("options"))
 
+	srcFile.Line().Comment("RetryFunc решает, отправлять ли вызов method повторно после неудачной попытки attempt (с нуля), и выжидает паузу перед повтором.")
+	srcFile.Type().Id("RetryFunc").Func().Params(
+		Id("ctx").Qual(PackageContext, "Context"),
+		Id("method").String(),
+		Id("attempt").Int(),
+		Id("httpReq").Op("*").Qual(PackageHttp, "Request"),
+		Id("httpResp").Op("*").Qual(PackageHttp, "Response"),
+		Err().Error(),
+	).Params(Id("retry").Bool())
+
 	srcFile.Line().Func().Id("prepareOpts").Params(Id("opts").Index().Id("Option")).Params(Id("options").Id("options")).BlockFunc(
 		func(bg *Group) {
 			bg.Id("options").Dot("customHeaders").Op("=").Make(Map(String()).String())
@@ -591,6 +641,26 @@ func (gen *jsonrpcGenerator) renderOption(outDir string) (err error) {
 		)),
 	)
 
+	srcFile.Line().Func().Id("Retry").Params(Id("retry").Id("RetryFunc")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("ops").Op("*").Id("options")).Block(
+			Id("ops").Dot("retry").Op("=").Id("retry"),
+		)),
+	)
+
+	srcFile.Line().Comment("Credentials добавляет учётные данные в запрос перед каждой попыткой, включая повторы.")
+	srcFile.Func().Id("Credentials").Params(Id("credentials").Func().Params(Qual(PackageContext, "Context"), Op("*").Qual(PackageHttp, "Request")).Params(Error())).Params(Id("Option")).Block(
+		Return(Func().Params(Id("ops").Op("*").Id("options")).Block(
+			Id("ops").Dot("credentials").Op("=").Id("credentials"),
+		)),
+	)
+
+	srcFile.Line().Comment("IdempotencyKeys задаёт заголовки ключа идемпотентности по методам: одиночный вызов такого метода получает новый ключ, общий для всех его повторов.")
+	srcFile.Func().Id("IdempotencyKeys").Params(Id("headers").Map(String()).String()).Params(Id("Option")).Block(
+		Return(Func().Params(Id("ops").Op("*").Id("options")).Block(
+			Id("ops").Dot("idempotencyHeaders").Op("=").Id("headers"),
+		)),
+	)
+
 	srcFile.Line().Func().Id("HeaderFromCtx").Params(Id("headers").Op("...").Any()).Params(Id("Option")).Block(
 		Return(Func().Params(Id("ops").Op("*").Id("options")).Block(
 			Id("ops").Dot("headersFromCtx").Op("=").Append(Id("ops").Dot("headersFromCtx"), Id("headers").Op("...")),
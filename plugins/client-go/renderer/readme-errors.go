@@ -118,6 +118,10 @@ func main() {
 		md.LF()
 		md.PlainText(markdown.Bold("Дефолтный HTTPErrorDecoder.") + " Возвращает " + markdown.Code("*ResponseError") + " (status, Content-Type, тело). " + markdown.Code("Error()") + " — status и сырое тело; " + markdown.Code("Code()") + " — HTTP status для метрик. Свой тип — " + markdown.Code("DecodeHTTPError") + " и разбор " + markdown.Code("e.Body") + ".")
 		md.LF()
+		if r.HasProblemErrors() {
+			md.PlainText(markdown.Bold("RFC 9457.") + " Методы с " + markdown.Code("@tg http-errors=problem") + " отвечают " + markdown.Code(model.ProblemContentType) + "; дефолтный декодер возвращает " + markdown.Code("*Problem") + " (" + markdown.Code("Type") + ", " + markdown.Code("Title") + ", " + markdown.Code("Status") + ", " + markdown.Code("Detail") + ", " + markdown.Code("Instance") + ", расширения в " + markdown.Code("Extensions") + "). Тип проблемы сравнивайте с константами " + markdown.Code("ProblemType*") + ".")
+			md.LF()
+		}
 		md.PlainText(markdown.Bold("Свой тип.") + " Опция " + markdown.Code("DecodeHTTPError") + ": " + markdown.Code("func(statusCode int, contentType string, body []byte) error") + ".")
 		md.LF()
 		md.CodeBlocks(markdown.SyntaxHighlightGo, fmt.Sprintf(`cli := %s.New("http://localhost:9000",
@@ -605,6 +605,16 @@ client := %s.New("http://localhost:9000",
     }),
 )`, pkgName, pkgName),
 			},
+			{
+				name:        "Retry",
+				description: "Включает повторную отправку запросов при 429, 502, 503, 504 и транспортных ошибках: экспоненциальная задержка с разбросом, учёт заголовка Retry-After. POST, PATCH и JSON-RPC вызовы повторяются только при AllowNonIdempotent, ключе идемпотентности или аннотации @tg retry=N на самом методе. Без этой опции аннотация @tg retry не действует",
+				signature:   "func Retry(policy RetryPolicy) Option",
+				example: fmt.Sprintf(`policy := %s.DefaultRetryPolicy()
+policy.MaxRetries = 5
+client := %s.New("http://localhost:9000",
+    %s.Retry(policy),
+)`, pkgName, pkgName, pkgName),
+			},
 		}...)
 	}
 
@@ -688,7 +698,7 @@ func main() {
 		},
 		{
 			name:        "client_requests_all_count",
-			description: "Общее количество всех запросов",
+			description: "Общее количество всех запросов, включая каждую повторённую попытку (опция Retry) с success=false",
 			labels:      "service, method, success, errCode, client_id",
 		},
 		{
@@ -825,6 +835,8 @@ func (r *ClientRenderer) renderLoggingSection(md *markdown.Markdown, outDir stri
 		"`method` - HTTP метод или имя JSON-RPC метода",
 		"`curl` - команда curl для воспроизведения запроса (включает URL, заголовки и тело запроса)",
 		"`error` - информация об ошибке (только для LogOnError)",
+		"`attempts` - число попыток, сделанных до ошибки (только для LogOnError)",
+		"`attempt`, `delay` - номер повтора и пауза перед ним (предупреждение `request retry` при опции Retry)",
 		"`count` - количество запросов в batch (только для JSON-RPC batch запросов)",
 	)
 	md.LF()
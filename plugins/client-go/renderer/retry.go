@@ -0,0 +1,387 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/generated"
+	"tgp/internal/model"
+)
+
+// retryByPolicy — значение аргумента retries в doRoundTrip, когда у метода нет аннотации retry.
+const retryByPolicy = "retryByPolicy"
+
+// RenderClientRetry генерирует retry.go: политику повторов, опцию Retry и решение о повторе для HTTP и JSON-RPC.
+func (r *ClientRenderer) RenderClientRetry() (err error) {
+
+	outDir := r.outDir
+	jsonrpcPkg := fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir))
+
+	srcFile := NewSrcFile(filepath.Base(outDir))
+	srcFile.PackageComment(generated.ByToolGateway)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageErrors, "errors")
+	srcFile.ImportName(PackageSlog, "slog")
+	srcFile.ImportName(PackageHttp, "http")
+	srcFile.ImportName(PackageRand, "rand")
+	srcFile.ImportName(PackageStrconv, "strconv")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(jsonrpcPkg, "jsonrpc")
+
+	srcFile.Line().Add(r.retryPolicyType())
+	srcFile.Line().Add(r.retryOptionFunc(jsonrpcPkg))
+	srcFile.Line().Add(r.retryPolicyMethods())
+	srcFile.Line().Add(r.retryAttemptFunc())
+	if r.HasJsonRPC() {
+		srcFile.Line().Add(r.rpcRetryFunc())
+	}
+	if r.HasMetrics() {
+		srcFile.Line().Add(r.retryRecordMetricsFunc())
+	}
+	if r.HasIdempotency() {
+		srcFile.Line().Add(r.retryIdempotencyFuncs())
+	}
+
+	return srcFile.Save(path.Join(outDir, "retry.go"))
+}
+
+func (r *ClientRenderer) retryPolicyType() (c Code) {
+
+	return Comment("RetryPolicy — политика повторной отправки запросов: экспоненциальная задержка с разбросом и учётом Retry-After.").
+		Line().
+		Type().Id("RetryPolicy").Struct(
+		Comment("MaxRetries — число повторов после первой попытки; 0 — без повторов.").Line().Id("MaxRetries").Int(),
+		Comment("BaseDelay — задержка перед первым повтором, далее удваивается.").Line().Id("BaseDelay").Qual(PackageTime, "Duration"),
+		Comment("MaxDelay — верхняя граница задержки; 0 — без ограничения. Retry-After сервера не ограничивается.").Line().Id("MaxDelay").Qual(PackageTime, "Duration"),
+		Comment("Jitter — доля случайного разброса задержки от 0 до 1.").Line().Id("Jitter").Float64(),
+		Comment("RetryOnStatus решает, повторять ли ответ с кодом статуса; nil — 429, 502, 503 и 504.").Line().Id("RetryOnStatus").Func().Params(Id("statusCode").Int()).Bool(),
+		Comment("RetryOnError решает, повторять ли транспортную ошибку; nil — любую, кроме отмены контекста.").Line().Id("RetryOnError").Func().Params(Err().Error()).Bool(),
+		Comment("AllowNonIdempotent разрешает повтор POST, PATCH и JSON-RPC вызовов без ключа идемпотентности.").Line().Id("AllowNonIdempotent").Bool(),
+	).
+		Line().
+		Line().
+		Comment("DefaultRetryPolicy — три повтора с задержкой от 100ms до 5s и разбросом 20%.").
+		Line().
+		Func().Id("DefaultRetryPolicy").Params().Params(Id("policy").Id("RetryPolicy")).Block(
+		Return(Id("RetryPolicy").Values(Dict{
+			Id("MaxRetries"): Lit(3),
+			Id("BaseDelay"):  Lit(100).Op("*").Qual(PackageTime, "Millisecond"),
+			Id("MaxDelay"):   Lit(5).Op("*").Qual(PackageTime, "Second"),
+			Id("Jitter"):     Lit(0.2),
+		})),
+	)
+}
+
+func (r *ClientRenderer) retryOptionFunc(jsonrpcPkg string) (c Code) {
+
+	return Func().Id("Retry").Params(Id("policy").Id("RetryPolicy")).Params(Id("Option")).BlockFunc(func(bg *Group) {
+		bg.Return(Func().Params(Id("cli").Op("*").Id("Client"))).BlockFunc(func(returnBg *Group) {
+			returnBg.Id("cli").Dot("retry").Op("=").Op("&").Id("policy")
+			if r.HasJsonRPC() {
+				returnBg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(jsonrpcPkg, "Retry").Call(Id("cli").Dot("rpcRetry")))
+			}
+		})
+	}).
+		Line().
+		Line().
+		Const().Id(retryByPolicy).Op("=").Lit(-1)
+}
+
+func (r *ClientRenderer) retryPolicyMethods() (c Code) {
+
+	return Func().Params(Id("policy").Op("*").Id("RetryPolicy")).Id("retryOnStatus").Params(Id("statusCode").Int()).Params(Bool()).Block(
+		If(Id("policy").Dot("RetryOnStatus").Op("!=").Nil()).Block(
+			Return(Id("policy").Dot("RetryOnStatus").Call(Id("statusCode"))),
+		),
+		Switch(Id("statusCode")).Block(
+			Case(Qual(PackageHttp, "StatusTooManyRequests"), Qual(PackageHttp, "StatusBadGateway"), Qual(PackageHttp, "StatusServiceUnavailable"), Qual(PackageHttp, "StatusGatewayTimeout")).Block(
+				Return(True()),
+			),
+		),
+		Return(False()),
+	).
+		Line().
+		Line().
+		Func().Params(Id("policy").Op("*").Id("RetryPolicy")).Id("retryOnError").Params(Err().Error()).Params(Bool()).Block(
+		If(Qual(PackageErrors, "Is").Call(Err(), Qual(PackageContext, "Canceled")).Op("||").Qual(PackageErrors, "Is").Call(Err(), Qual(PackageContext, "DeadlineExceeded"))).Block(
+			Return(False()),
+		),
+		If(Id("policy").Dot("RetryOnError").Op("!=").Nil()).Block(
+			Return(Id("policy").Dot("RetryOnError").Call(Err())),
+		),
+		Return(True()),
+	).
+		Line().
+		Line().
+		Func().Params(Id("policy").Op("*").Id("RetryPolicy")).Id("backoff").Params(Id("attempt").Int(), Id("retryAfter").Qual(PackageTime, "Duration")).Params(Id("delay").Qual(PackageTime, "Duration")).Block(
+		Id("delay").Op("=").Id("policy").Dot("BaseDelay"),
+		For(Range().Id("attempt")).Block(
+			If(Id("policy").Dot("MaxDelay").Op(">").Lit(0).Op("&&").Id("delay").Op(">=").Id("policy").Dot("MaxDelay")).Block(
+				Break(),
+			),
+			Id("delay").Op("*=").Lit(2),
+		),
+		If(Id("policy").Dot("MaxDelay").Op(">").Lit(0).Op("&&").Id("delay").Op(">").Id("policy").Dot("MaxDelay")).Block(
+			Id("delay").Op("=").Id("policy").Dot("MaxDelay"),
+		),
+		If(Id("policy").Dot("Jitter").Op(">").Lit(0).Op("&&").Id("delay").Op(">").Lit(0)).Block(
+			Id("spread").Op(":=").Float64().Call(Id("delay")).Op("*").Min(Id("policy").Dot("Jitter"), Lit(1.0)),
+			Id("delay").Op("+=").Qual(PackageTime, "Duration").Call(Id("spread").Op("*").Parens(Lit(2).Op("*").Qual(PackageRand, "Float64").Call().Op("-").Lit(1))),
+		),
+		Return(Max(Id("delay"), Id("retryAfter"))),
+	).
+		Line().
+		Line().
+		Func().Id("parseRetryAfter").Params(Id("value").String()).Params(Id("delay").Qual(PackageTime, "Duration")).Block(
+		If(Id("value").Op("==").Lit("")).Block(
+			Return(),
+		),
+		If(List(Id("seconds"), Err()).Op(":=").Qual(PackageStrconv, "Atoi").Call(Id("value")).Op(";").Err().Op("==").Nil()).Block(
+			Return(Qual(PackageTime, "Duration").Call(Max(Id("seconds"), Lit(0))).Op("*").Qual(PackageTime, "Second")),
+		),
+		If(List(Id("at"), Err()).Op(":=").Qual(PackageHttp, "ParseTime").Call(Id("value")).Op(";").Err().Op("==").Nil()).Block(
+			Return(Max(Qual(PackageTime, "Until").Call(Id("at")), Lit(0))),
+		),
+		Return(),
+	).
+		Line().
+		Line().
+		Func().Id("idempotentMethod").Params(Id("method").String()).Params(Bool()).Block(
+		Switch(Id("method")).Block(
+			Case(Qual(PackageHttp, "MethodGet"), Qual(PackageHttp, "MethodHead"), Qual(PackageHttp, "MethodOptions"), Qual(PackageHttp, "MethodTrace"), Qual(PackageHttp, "MethodPut"), Qual(PackageHttp, "MethodDelete")).Block(
+				Return(True()),
+			),
+		),
+		Return(False()),
+	)
+}
+
+// retryAttemptFunc генерирует решение о повторе: лимит, идемпотентность, предикаты, лог, метрика и пауза.
+func (r *ClientRenderer) retryAttemptFunc() (c Code) {
+
+	return Func().Params(Id("cli").Op("*").Id("Client")).Id("retryAttempt").Params(
+		Id("ctx").Qual(PackageContext, "Context"),
+		Id("service").String(),
+		Id("method").String(),
+		Id("retries").Int(),
+		Id("attempt").Int(),
+		Id("httpReq").Op("*").Qual(PackageHttp, "Request"),
+		Id("httpResp").Op("*").Qual(PackageHttp, "Response"),
+		Err().Error(),
+	).Params(Id("retry").Bool()).BlockFunc(func(bg *Group) {
+		bg.If(Id("cli").Dot("retry").Op("==").Nil().Op("||").Id("ctx").Dot("Err").Call().Op("!=").Nil()).Block(
+			Return(False()),
+		)
+		bg.Comment("Тело без GetBody (поток, multipart) нельзя отправить повторно.")
+		bg.If(Id("httpReq").Dot("Body").Op("!=").Nil().Op("&&").Id("httpReq").Dot("Body").Op("!=").Qual(PackageHttp, "NoBody").Op("&&").Id("httpReq").Dot("GetBody").Op("==").Nil()).Block(
+			Return(False()),
+		)
+		bg.Id("limit").Op(":=").Id("cli").Dot("retry").Dot("MaxRetries")
+		bg.Comment("Аннотация retry задаёт число повторов: на методе она явно разрешает повтор неидемпотентного вызова,")
+		bg.Comment("на контракте или пакете передаётся только идемпотентным методам (см. retryAnnotation).")
+		nonIdempotent := Op("!").Id("cli").Dot("retry").Dot("AllowNonIdempotent").Op("&&").Op("!").Id("idempotentMethod").Call(Id("httpReq").Dot("Method"))
+		if r.HasIdempotency() {
+			nonIdempotent = nonIdempotent.Op("&&").Op("!").Id("hasIdempotencyKey").Call(Id("httpReq"))
+		}
+		bg.If(Id("retries").Op(">=").Lit(0)).Block(
+			Id("limit").Op("=").Id("retries"),
+		).Else().If(nonIdempotent).Block(
+			Return(False()),
+		)
+		bg.If(Id("attempt").Op(">=").Id("limit")).Block(
+			Return(False()),
+		)
+		bg.Var().Id("retryAfter").Qual(PackageTime, "Duration")
+		bg.Var().Id("reason").Qual(PackageSlog, "Attr")
+		if r.HasMetrics() {
+			bg.Id("errCode").Op(":=").Qual(PackageHttp, "StatusInternalServerError")
+		}
+		bg.If(Err().Op("!=").Nil()).Block(
+			If(Op("!").Id("cli").Dot("retry").Dot("retryOnError").Call(Err())).Block(
+				Return(False()),
+			),
+			Id("reason").Op("=").Qual(PackageSlog, "Any").Call(Lit("error"), Err()),
+		).Else().BlockFunc(func(eg *Group) {
+			eg.If(Op("!").Id("cli").Dot("retry").Dot("retryOnStatus").Call(Id("httpResp").Dot("StatusCode"))).Block(
+				Return(False()),
+			)
+			if r.HasMetrics() {
+				eg.Id("errCode").Op("=").Id("httpResp").Dot("StatusCode")
+			}
+			eg.Id("reason").Op("=").Qual(PackageSlog, "Int").Call(Lit("status"), Id("httpResp").Dot("StatusCode"))
+			eg.Id("retryAfter").Op("=").Id("parseRetryAfter").Call(Id("httpResp").Dot("Header").Dot("Get").Call(Lit("Retry-After")))
+		})
+		bg.Id("delay").Op(":=").Id("cli").Dot("retry").Dot("backoff").Call(Id("attempt"), Id("retryAfter"))
+		bg.If(Id("cli").Dot("logRequests").Op("||").Id("cli").Dot("logOnError")).Block(
+			Qual(PackageSlog, "WarnContext").Call(
+				Id("ctx"),
+				Lit("request retry"),
+				Qual(PackageSlog, "String").Call(Lit("method"), Id("httpReq").Dot("Method")),
+				Qual(PackageSlog, "String").Call(Lit("call"), Id("service").Op("+").Lit(".").Op("+").Id("method")),
+				Qual(PackageSlog, "Int").Call(Lit("attempt"), Id("attempt").Op("+").Lit(1)),
+				Qual(PackageSlog, "Duration").Call(Lit("delay"), Id("delay")),
+				Id("reason"),
+			),
+		)
+		if r.HasMetrics() {
+			bg.Id("cli").Dot("recordRetryMetrics").Call(Id("service"), Id("method"), Id("errCode"))
+		}
+		bg.Id("timer").Op(":=").Qual(PackageTime, "NewTimer").Call(Id("delay"))
+		bg.Defer().Id("timer").Dot("Stop").Call()
+		bg.Select().Block(
+			Case(Op("<-").Id("ctx").Dot("Done").Call()).Block(
+				Return(False()),
+			),
+			Case(Op("<-").Id("timer").Dot("C")).Block(
+				Return(True()),
+			),
+		)
+	})
+}
+
+// rpcRetryFunc генерирует адаптер решения о повторе для подпакета jsonrpc и таблицу аннотаций retry JSON-RPC методов.
+func (r *ClientRenderer) rpcRetryFunc() (c Code) {
+
+	return Var().Id("rpcMethodRetries").Op("=").Map(String()).Int().Values(DictFunc(func(d Dict) {
+		for _, contract := range r.project.Contracts {
+			for _, method := range contract.Methods {
+				if !r.methodIsJsonRPC(contract, method) {
+					continue
+				}
+				if retries, found := r.retryAnnotation(contract, method, http.MethodPost); found {
+					d[Lit(r.jsonRPCWireMethod(contract, method))] = Lit(retries)
+				}
+			}
+		}
+	})).
+		Line().
+		Line().
+		Func().Params(Id("cli").Op("*").Id("Client")).Id("rpcRetry").Params(
+		Id("ctx").Qual(PackageContext, "Context"),
+		Id("method").String(),
+		Id("attempt").Int(),
+		Id("httpReq").Op("*").Qual(PackageHttp, "Request"),
+		Id("httpResp").Op("*").Qual(PackageHttp, "Response"),
+		Err().Error(),
+	).Params(Id("retry").Bool()).Block(
+		List(Id("retries"), Id("found")).Op(":=").Id("rpcMethodRetries").Index(Id("method")),
+		If(Op("!").Id("found")).Block(
+			Id("retries").Op("=").Id(retryByPolicy),
+		),
+		List(Id("service"), Id("name"), Id("_")).Op(":=").Qual(PackageStrings, "Cut").Call(Id("method"), Lit(".")),
+		Return(Id("cli").Dot("retryAttempt").Call(Id("ctx"), Id("service"), Id("name"), Id("retries"), Id("attempt"), Id("httpReq"), Id("httpResp"), Err())),
+	)
+}
+
+func (r *ClientRenderer) retryRecordMetricsFunc() (c Code) {
+
+	return Func().Params(Id("cli").Op("*").Id("Client")).Id("recordRetryMetrics").Params(
+		Id("service").String(),
+		Id("method").String(),
+		Id("errCode").Int(),
+	).Block(
+		If(Id("cli").Dot("metrics").Op("==").Nil()).Block(
+			Return(),
+		),
+		Comment("Повторённая попытка учитывается в client_requests_all_count как неуспешная, с кодом, вызвавшим повтор."),
+		Id("cli").Dot("metrics").Dot("RequestCountAll").Dot("WithLabelValues").Call(
+			Id("service"),
+			Id("method"),
+			Lit("false"),
+			Qual(PackageStrconv, "Itoa").Call(Id("errCode")),
+			Id("cli").Dot("name")).
+			Dot("Add").Call(Lit(1)),
+	)
+}
+
+// retryIdempotencyFuncs генерирует проверку ключа идемпотентности запроса и таблицу заголовков ключа JSON-RPC методов @tg idempotent.
+func (r *ClientRenderer) retryIdempotencyFuncs() (c Code) {
+
+	stmt := Var().Id("idempotencyKeyHeaders").Op("=").Index().String().ValuesFunc(func(g *Group) {
+		for _, header := range r.idempotencyHeaders() {
+			g.Lit(header)
+		}
+	}).
+		Line().
+		Line().
+		Comment("hasIdempotencyKey — запрос несёт ключ идемпотентности: сервер не выполнит его повтор дважды.").
+		Line().
+		Func().Id("hasIdempotencyKey").Params(Id("httpReq").Op("*").Qual(PackageHttp, "Request")).Params(Bool()).Block(
+		For(List(Id("_"), Id("header")).Op(":=").Range().Id("idempotencyKeyHeaders")).Block(
+			If(Id("httpReq").Dot("Header").Dot("Get").Call(Id("header")).Op("!=").Lit("")).Block(
+				Return(True()),
+			),
+		),
+		Return(False()),
+	)
+	if r.hasIdempotentJsonRPC() {
+		stmt.Line().
+			Line().
+			Var().Id("rpcIdempotencyHeaders").Op("=").Map(String()).String().Values(DictFunc(func(d Dict) {
+			for _, contract := range r.project.Contracts {
+				for _, method := range contract.Methods {
+					if r.methodIsJsonRPC(contract, method) && model.MethodIsIdempotent(r.project, contract, method) {
+						d[Lit(r.jsonRPCWireMethod(contract, method))] = Lit(model.MethodIdempotencyHeader(r.project, contract, method))
+					}
+				}
+			}
+		}))
+	}
+	return stmt
+}
+
+// hasIdempotentJsonRPC — хотя бы один JSON-RPC метод помечен @tg idempotent.
+func (r *ClientRenderer) hasIdempotentJsonRPC() (ok bool) {
+
+	for _, contract := range r.project.Contracts {
+		for _, method := range contract.Methods {
+			if r.methodIsJsonRPC(contract, method) && model.MethodIsIdempotent(r.project, contract, method) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// methodRetries возвращает литерал числа повторов из аннотации retry или retryByPolicy.
+func (r *ClientRenderer) methodRetries(contract *model.Contract, method *model.Method) (c Code) {
+
+	retries, found := r.retryAnnotation(contract, method, strings.ToUpper(model.GetHTTPMethod(r.project, contract, method)))
+	if !found {
+		return Id(retryByPolicy)
+	}
+	return Lit(retries)
+}
+
+// retryAnnotation — число повторов из аннотации retry. Аннотация на самом методе — явное разрешение повторять
+// и неидемпотентный вызов. Унаследованная от контракта или пакета действует только на идемпотентные методы
+// (GET, HEAD, OPTIONS, TRACE, PUT, DELETE или @tg idempotent); остальные повторяются по правилам политики.
+func (r *ClientRenderer) retryAnnotation(contract *model.Contract, method *model.Method, httpMethod string) (retries int, found bool) {
+
+	if !model.IsAnnotationSet(r.project, contract, method, nil, model.TagRetry) {
+		return 0, false
+	}
+	if _, onMethod := method.Annotations[model.TagRetry]; !onMethod && !idempotentHTTPMethods[httpMethod] && !model.MethodIsIdempotent(r.project, contract, method) {
+		return 0, false
+	}
+	return model.GetAnnotationValueInt(r.project, contract, method, nil, model.TagRetry, 0), true
+}
+
+// idempotentHTTPMethods — методы, которые сгенерированный idempotentMethod считает безопасными для повтора.
+var idempotentHTTPMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func retryTestProject() (project *model.Project) {
+
+	method := func(name string, annotations tags.DocTags) (m *model.Method) {
+		return &model.Method{
+			Name:        name,
+			Annotations: annotations,
+			Args: []*model.Variable{
+				{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+				{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}},
+			},
+			Results: []*model.Variable{
+				{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+			},
+		}
+	}
+	return &model.Project{
+		ModulePath: "example",
+		Types:      map[string]*model.Type{},
+		Contracts: []*model.Contract{
+			{
+				Name:        "Jobs",
+				PkgPath:     "example/contracts",
+				ID:          "Jobs",
+				Annotations: tags.DocTags{model.TagServerHTTP: "", TagMetrics: ""},
+				Methods: []*model.Method{
+					method("Get", tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/jobs/:id"}),
+					method("Run", tags.DocTags{model.TagHTTPMethod: "POST", model.TagHttpPath: "/jobs/:id/run", model.TagRetry: "2"}),
+				},
+			},
+			{
+				Name:        "Queue",
+				PkgPath:     "example/contracts",
+				ID:          "Queue",
+				Annotations: tags.DocTags{model.TagServerJsonRPC: ""},
+				Methods: []*model.Method{
+					method("Push", tags.DocTags{model.TagRetry: "0"}),
+					method("Pop", nil),
+				},
+			},
+		},
+	}
+}
+
+func TestRenderClientRetry_PolicyAndMethodOverrides(t *testing.T) {
+
+	project := retryTestProject()
+	dir := filepath.Join(t.TempDir(), "client")
+	if err := NewClientRenderer(project, dir, "example", "client").RenderClientRetry(); err != nil {
+		t.Fatalf("RenderClientRetry: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "retry.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	source := string(content)
+
+	for _, want := range []string{
+		"type RetryPolicy struct",
+		"func DefaultRetryPolicy() (policy RetryPolicy)",
+		"func Retry(policy RetryPolicy) Option",
+		"cli.rpcOpts = append(cli.rpcOpts, jsonrpc.Retry(cli.rpcRetry))",
+		`parseRetryAfter(httpResp.Header.Get("Retry-After"))`,
+		"!cli.retry.AllowNonIdempotent && !idempotentMethod(httpReq.Method)",
+		`var rpcMethodRetries = map[string]int{"queue.push": 0}`,
+		"cli.recordRetryMetrics(service, method, errCode)",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("retry.go must contain %q:\n%s", want, source)
+		}
+	}
+	if strings.Contains(source, `"queue.pop"`) {
+		t.Fatalf("methods without retry annotation must follow the policy:\n%s", source)
+	}
+}
+
+func TestRenderServiceClient_PassesRetryAnnotation(t *testing.T) {
+
+	project := retryTestProject()
+	dir := filepath.Join(t.TempDir(), "client")
+	renderer := NewClientRenderer(project, dir, "example", "client")
+	if err := renderer.RenderJsonRPCPackage(dir); err != nil {
+		t.Fatalf("RenderJsonRPCPackage: %v", err)
+	}
+	if err := renderer.RenderServiceClient(project.Contracts[0]); err != nil {
+		t.Fatalf("RenderServiceClient: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "jobs-client.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	source := string(content)
+	if !strings.Contains(source, `cli.doRoundTrip(ctx, "jobs", "get", httpReq, 200, retryByPolicy)`) {
+		t.Fatalf("method without annotation must retry by policy:\n%s", source)
+	}
+	if !strings.Contains(source, `cli.doRoundTrip(ctx, "jobs", "run", httpReq, 200, 2)`) {
+		t.Fatalf("retry annotation must be passed to doRoundTrip:\n%s", source)
+	}
+
+	rpcInternal, err := os.ReadFile(filepath.Join(dir, "jsonrpc", "internal.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	if !strings.Contains(string(rpcInternal), "client.options.retry(ctx, request.Method, attempt, httpRequest, httpResponse, err)") {
+		t.Fatalf("JSON-RPC call must consult the retry option:\n%s", rpcInternal)
+	}
+}
+
+func TestRenderClient_ContractRetryDoesNotAllowUnsafeMethods(t *testing.T) {
+
+	project := retryTestProject()
+	project.Contracts[0].Annotations[model.TagRetry] = "4"
+	delete(project.Contracts[0].Methods[1].Annotations, model.TagRetry)
+	project.Contracts[1].Annotations[model.TagRetry] = "4"
+	project.Contracts[1].Methods[1].Annotations = tags.DocTags{model.TagIdempotent: ""}
+	dir := filepath.Join(t.TempDir(), "client")
+	renderer := NewClientRenderer(project, dir, "example", "client")
+	if err := renderer.RenderJsonRPCPackage(dir); err != nil {
+		t.Fatalf("RenderJsonRPCPackage: %v", err)
+	}
+	if err := renderer.RenderServiceClient(project.Contracts[0]); err != nil {
+		t.Fatalf("RenderServiceClient: %v", err)
+	}
+	if err := renderer.RenderClientRetry(); err != nil {
+		t.Fatalf("RenderClientRetry: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "jobs-client.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	client := string(content)
+	if !strings.Contains(client, `cli.doRoundTrip(ctx, "jobs", "get", httpReq, 200, 4)`) {
+		t.Fatalf("contract retry must apply to idempotent GET:\n%s", client)
+	}
+	if !strings.Contains(client, `cli.doRoundTrip(ctx, "jobs", "run", httpReq, 200, retryByPolicy)`) {
+		t.Fatalf("contract retry must not opt POST into retries:\n%s", client)
+	}
+	if content, err = os.ReadFile(filepath.Join(dir, "retry.go")); err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	if !strings.Contains(string(content), "\"queue.pop\":  4,\n\t\"queue.push\": 0,") {
+		t.Fatalf("contract retry must reach only @tg idempotent JSON-RPC methods; method-level retry wins:\n%s", content)
+	}
+}
+
+func TestRenderClient_IdempotencyKeyStableAcrossRetries(t *testing.T) {
+
+	project := retryTestProject()
+	project.Contracts[0].Methods[1].Annotations[model.TagIdempotent] = ""
+	project.Contracts[1].Methods[1].Annotations = tags.DocTags{model.TagIdempotent: "", model.TagIdempotencyHeader: "X-Request-Key"}
+	dir := filepath.Join(t.TempDir(), "client")
+	renderer := NewClientRenderer(project, dir, "example", "client")
+	if err := renderer.RenderJsonRPCPackage(dir); err != nil {
+		t.Fatalf("RenderJsonRPCPackage: %v", err)
+	}
+	if err := renderer.RenderServiceClient(project.Contracts[0]); err != nil {
+		t.Fatalf("RenderServiceClient: %v", err)
+	}
+	if err := renderer.RenderClientRetry(); err != nil {
+		t.Fatalf("RenderClientRetry: %v", err)
+	}
+
+	checks := map[string][]string{
+		"jobs-client.go": {
+			`if httpReq.Header.Get("Idempotency-Key") == "" {`,
+			`httpReq.Header.Set("Idempotency-Key", uuid.NewString())`,
+		},
+		"retry.go": {
+			"!cli.retry.AllowNonIdempotent && !idempotentMethod(httpReq.Method) && !hasIdempotencyKey(httpReq)",
+			`var idempotencyKeyHeaders = []string{"Idempotency-Key", "X-Request-Key"}`,
+			`var rpcIdempotencyHeaders = map[string]string{"queue.pop": "X-Request-Key"}`,
+		},
+		filepath.Join("jsonrpc", "internal.go"): {
+			"client.options.idempotencyHeaders[request.Method]; found && httpRequest.Header.Get(header) == \"\"",
+		},
+	}
+	for file, wants := range checks {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("read generated file: %v", err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Fatalf("%s must contain %q:\n%s", file, want, content)
+			}
+		}
+	}
+}
@@ -13,6 +13,10 @@ func (r *ClientRenderer) clientRPCResultValue(contract *model.Contract, method *
 
 	if model.ResultFieldEmbedded(r.project, contract, method, ret) {
 		embedName := model.TypeNameFromTypeID(r.project, ret.TypeID)
+		// Экземпляр обобщённого типа проекта генерируется в dto как конкретный тип (Page_User)
+		if typ, ok := r.project.Types[ret.TypeID]; ok && typ.GenericOf != "" && r.isTypeFromCurrentProject(typ.ImportPkgPath) {
+			embedName = typ.TypeName
+		}
 		value = Id(responseVar).Dot(embedName)
 	} else {
 		value = Id(responseVar).Dot(ToCamel(field.name))
@@ -503,9 +503,9 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 					packageName = filepath.Base(typ.ImportPkgPath)
 				}
 				srcFile.ImportName(typ.ImportPkgPath, packageName)
-				return c.Qual(typ.ImportPkgPath, typ.TypeName)
+				return c.Add(r.externalType(ctx, typ, true))
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, true))
 		}
 	}
 
@@ -601,9 +601,9 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 					packageName = filepath.Base(typ.ImportPkgPath)
 				}
 				srcFile.ImportName(typ.ImportPkgPath, packageName)
-				return c.Qual(typ.ImportPkgPath, typ.TypeName)
+				return c.Add(r.externalType(ctx, typ, true))
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, true))
 		}
 		// Если TypeName пустой, это может быть анонимный интерфейс (any)
 		if strings.Contains(typeID, ":interface:anonymous") || typ.Kind == model.TypeKindAny {
@@ -742,7 +742,7 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 				}
 				srcFile.ImportName(typ.ImportPkgPath, packageName)
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, true))
 		}
 		// Встроенный базовый тип - используем Kind как имя типа
 		return c.Id(string(typ.Kind))
@@ -806,9 +806,9 @@ func (r *ClientRenderer) fieldTypeFromTypeRefForClient(ctx context.Context, type
 							packageName = filepath.Base(typ.ImportPkgPath)
 						}
 						srcFile.ImportName(typ.ImportPkgPath, packageName)
-						return c.Qual(typ.ImportPkgPath, typ.TypeName)
+						return c.Add(r.externalType(ctx, typ, true))
 					}
-					return c.Qual(typ.ImportPkgPath, typ.TypeName)
+					return c.Add(r.externalType(ctx, typ, true))
 				}
 			}
 		}
@@ -131,12 +131,12 @@ func (r *ClientRenderer) fieldTypeFromTypeRef(ctx context.Context, typeRef *mode
 						}
 						if typ.ImportAlias != "" && typ.ImportAlias != packageName {
 							srcFile.ImportName(typ.ImportPkgPath, typ.ImportAlias)
-							return c.Qual(typ.ImportPkgPath, typ.TypeName)
+							return c.Add(r.externalType(ctx, typ, false))
 						}
 						srcFile.ImportName(typ.ImportPkgPath, packageName)
-						return c.Qual(typ.ImportPkgPath, typ.TypeName)
+						return c.Add(r.externalType(ctx, typ, false))
 					}
-					return c.Qual(typ.ImportPkgPath, typ.TypeName)
+					return c.Add(r.externalType(ctx, typ, false))
 				}
 			}
 		}
@@ -231,13 +231,13 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 				// Если ImportAlias установлен и отличается от PkgName, используем алиас
 				if typ.ImportAlias != "" && typ.ImportAlias != packageName {
 					srcFile.ImportName(typ.ImportPkgPath, typ.ImportAlias)
-					return c.Qual(typ.ImportPkgPath, typ.TypeName)
+					return c.Add(r.externalType(ctx, typ, false))
 				}
 				// Иначе используем реальное имя пакета
 				srcFile.ImportName(typ.ImportPkgPath, packageName)
-				return c.Qual(typ.ImportPkgPath, typ.TypeName)
+				return c.Add(r.externalType(ctx, typ, false))
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, false))
 		}
 	}
 
@@ -308,13 +308,13 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 				// Если ImportAlias установлен и отличается от PkgName, используем алиас
 				if typ.ImportAlias != "" && typ.ImportAlias != packageName {
 					srcFile.ImportName(typ.ImportPkgPath, typ.ImportAlias)
-					return c.Qual(typ.ImportPkgPath, typ.TypeName)
+					return c.Add(r.externalType(ctx, typ, false))
 				}
 				// Иначе используем реальное имя пакета
 				srcFile.ImportName(typ.ImportPkgPath, packageName)
-				return c.Qual(typ.ImportPkgPath, typ.TypeName)
+				return c.Add(r.externalType(ctx, typ, false))
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, false))
 		}
 		return c.Id(typ.TypeName)
 
@@ -339,13 +339,13 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 				// Если ImportAlias установлен и отличается от PkgName, используем алиас
 				if typ.ImportAlias != "" && typ.ImportAlias != packageName {
 					srcFile.ImportName(typ.ImportPkgPath, typ.ImportAlias)
-					return c.Qual(typ.ImportPkgPath, typ.TypeName)
+					return c.Add(r.externalType(ctx, typ, false))
 				}
 				// Иначе используем реальное имя пакета
 				srcFile.ImportName(typ.ImportPkgPath, packageName)
-				return c.Qual(typ.ImportPkgPath, typ.TypeName)
+				return c.Add(r.externalType(ctx, typ, false))
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, false))
 		}
 		return c.Id(typ.TypeName)
 
@@ -399,9 +399,9 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 				default:
 					srcFile.ImportName(typ.ImportPkgPath, baseName)
 				}
-				return c.Qual(typ.ImportPkgPath, typ.TypeName)
+				return c.Add(r.externalType(ctx, typ, false))
 			}
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+			return c.Add(r.externalType(ctx, typ, false))
 		}
 		// Встроенный базовый тип - используем Kind как имя типа
 		return c.Id(string(typ.Kind))
@@ -849,3 +849,21 @@ func (r *ClientRenderer) varsToFields(vars []*model.Variable, methodTags tags.Do
 	}
 	return fields
 }
+
+// externalType — именованный тип внешнего пакета; экземпляр обобщённого типа рендерится как Page[Arg, ...].
+func (r *ClientRenderer) externalType(ctx context.Context, typ *model.Type, forClient bool) (st *Statement) {
+
+	if typ.GenericOf == "" {
+		return Qual(typ.ImportPkgPath, typ.TypeName)
+	}
+	typeArgs := make([]Code, 0, len(typ.TypeArgs))
+	for _, typeArg := range typ.TypeArgs {
+		if forClient {
+			typeArgs = append(typeArgs, r.fieldTypeFromTypeRefForClient(ctx, typeArg, false))
+			continue
+		}
+		typeArgs = append(typeArgs, r.fieldTypeFromTypeRef(ctx, typeArg, false))
+	}
+	originName := typ.GenericOf[strings.LastIndex(typ.GenericOf, ":")+1:]
+	return Qual(typ.ImportPkgPath, originName).Types(typeArgs...)
+}
@@ -13,9 +13,12 @@ import (
 )
 
 type Options struct {
-	Doc             DocOptions
-	PackageJSONPath string
-	ClientIdentity  bool
+	Doc              DocOptions
+	PackageJSONPath  string
+	ClientIdentity   bool
+	ReactQuery       bool // Генерировать react-query.ts с хуками TanStack Query
+	Validate         bool // Проверять ответы по схемам validate.ts
+	ValidateRequests bool // Проверять и запросы перед отправкой (включает Validate)
 }
 
 type DocOptions struct {
@@ -86,6 +89,9 @@ func (g *generator) generate() (err error) {
 		if err = g.renderer.RenderHeaders(); err != nil {
 			return
 		}
+		if err = g.renderer.RenderTransport(); err != nil {
+			return
+		}
 		if g.renderer.HasJsonRPC() {
 			if err = g.renderer.RenderJsonRPCLibrary(); err != nil {
 				return
@@ -102,6 +108,11 @@ func (g *generator) generate() (err error) {
 				return
 			}
 		}
+		if g.opts.Validate || g.opts.ValidateRequests {
+			if err = g.renderer.RenderValidation(g.opts.ValidateRequests); err != nil {
+				return
+			}
+		}
 	}
 
 	contractsForClient := make([]*model.Contract, 0, len(g.project.Contracts))
@@ -137,6 +148,11 @@ func (g *generator) generate() (err error) {
 	}
 
 	if g.renderer.HasJsonRPC() || g.renderer.HasHTTP() || g.renderer.HasWS() || g.renderer.HasSSE() {
+		if g.opts.ReactQuery {
+			if err = g.renderer.RenderReactQuery(); err != nil {
+				return
+			}
+		}
 		if err = g.renderer.RenderTsConfig(); err != nil {
 			return
 		}
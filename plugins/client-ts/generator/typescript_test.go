@@ -123,6 +123,18 @@ func TestGenerateClient_passesTypeScriptCheck_withoutClientIdentity(t *testing.T
 	runTscCheck(t, dir)
 }
 
+func TestGenerateClient_passesTypeScriptCheck_withValidation(t *testing.T) {
+
+	dir := t.TempDir()
+	if err := GenerateClient(tscTestProject(), dir, Options{Validate: true, ValidateRequests: true}); err != nil {
+		t.Fatalf("GenerateClient: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "validate.ts")); err != nil {
+		t.Fatalf("validate.ts must exist when validation enabled: %v", err)
+	}
+	runTscCheck(t, dir)
+}
+
 func TestGenerateClient_httpGetOmitsContentTypeWithoutBody(t *testing.T) {
 
 	dir := t.TempDir()
@@ -180,3 +192,116 @@ func TestGenerateClient_resultHeaderBodyModeReadsJSONAndHeader(t *testing.T) {
 		t.Fatalf("expected body assign then unconditional header assign:\n%s", block)
 	}
 }
+
+func tscGenericProject() (project *model.Project) {
+
+	const (
+		dtoPkg = "example/contracts/dto"
+		userID = dtoPkg + ":User"
+		pageID = dtoPkg + ":Page_User"
+		pairID = dtoPkg + ":Pair_String_PtrUserList_7cf70d12"
+	)
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context.Context"}}
+	return &model.Project{
+		ModulePath: "example",
+		Version:    "1.0.0",
+		Types: map[string]*model.Type{
+			userID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "User",
+				ImportPkgPath: dtoPkg,
+				PkgName:       "dto",
+				StructFields: []*model.StructField{
+					{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"name"}}},
+				},
+			},
+			pageID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Page_User",
+				ImportPkgPath: dtoPkg,
+				PkgName:       "dto",
+				GenericOf:     dtoPkg + ":Page",
+				TypeArgs:      []*model.TypeRef{{TypeID: userID}},
+				StructFields: []*model.StructField{
+					{Name: "Items", TypeRef: model.TypeRef{TypeID: userID, IsSlice: true}, Tags: map[string][]string{"json": {"items"}}},
+					{Name: "Total", TypeRef: model.TypeRef{TypeID: "int"}, Tags: map[string][]string{"json": {"total"}}},
+				},
+			},
+			pairID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Pair_String_PtrUserList_7cf70d12",
+				ImportPkgPath: dtoPkg,
+				PkgName:       "dto",
+				GenericOf:     dtoPkg + ":Pair",
+				TypeArgs:      []*model.TypeRef{{TypeID: "string"}, {TypeID: userID, IsSlice: true, ElementPointers: 1}},
+				StructFields: []*model.StructField{
+					{Name: "Key", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"key"}}},
+					{Name: "Value", TypeRef: model.TypeRef{TypeID: userID, IsSlice: true, ElementPointers: 1}, Tags: map[string][]string{"json": {"value"}}},
+				},
+			},
+		},
+		Contracts: []*model.Contract{{
+			Name:        "Users",
+			PkgPath:     "example/contracts",
+			Annotations: tags.DocTags{model.TagServerJsonRPC: ""},
+			Methods: []*model.Method{{
+				Name:    "List",
+				Args:    []*model.Variable{ctx, {Name: "filter", TypeRef: model.TypeRef{TypeID: pageID}}},
+				Results: []*model.Variable{{Name: "page", TypeRef: model.TypeRef{TypeID: pageID}}},
+			}, {
+				Name:    "Index",
+				Args:    []*model.Variable{ctx},
+				Results: []*model.Variable{{Name: "index", TypeRef: model.TypeRef{TypeID: pairID}}},
+			}},
+		}, {
+			Name:        "Pages",
+			PkgPath:     "example/contracts",
+			Annotations: tags.DocTags{model.TagServerHTTP: ""},
+			Methods: []*model.Method{{
+				Name:        "Get",
+				Annotations: tags.DocTags{model.TagHTTPMethod: "POST", model.TagHttpPath: "/pages"},
+				Args:        []*model.Variable{ctx, {Name: "page", TypeRef: model.TypeRef{TypeID: pageID}}},
+				Results:     []*model.Variable{{Name: "index", TypeRef: model.TypeRef{TypeID: pairID}}},
+			}},
+		}},
+	}
+}
+
+func TestGenerateClient_genericInstancesAreNamedTypes(t *testing.T) {
+
+	dir := t.TempDir()
+	if err := GenerateClient(tscGenericProject(), dir, Options{}); err != nil {
+		t.Fatalf("GenerateClient: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "users-exchange.ts"))
+	if err != nil {
+		t.Fatalf("read users-exchange.ts: %v", err)
+	}
+	source := string(content)
+	for _, want := range []string{
+		"export interface Page_User {",
+		"items?:dto.User[];",
+		"export interface Pair_String_PtrUserList_7cf70d12 {",
+		"filter:dto.Page_User;",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("users-exchange.ts must contain %q:\n%s", want, source)
+		}
+	}
+	content, err = os.ReadFile(filepath.Join(dir, "pages-http.ts"))
+	if err != nil {
+		t.Fatalf("read pages-http.ts: %v", err)
+	}
+	if !strings.Contains(string(content), "Promise<dto.Pair_String_PtrUserList_7cf70d12>") {
+		t.Fatalf("REST method must return the generic instance type:\n%s", content)
+	}
+}
+
+func TestGenerateClient_passesTypeScriptCheck_withGenericInstances(t *testing.T) {
+
+	dir := t.TempDir()
+	if err := GenerateClient(tscGenericProject(), dir, Options{}); err != nil {
+		t.Fatalf("GenerateClient: %v", err)
+	}
+	runTscCheck(t, dir)
+}
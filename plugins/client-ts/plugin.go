@@ -53,6 +53,21 @@ func (p *ClientTsPlugin) Execute(request data.Storage) (response data.Storage, e
 		opts.ClientIdentity = false
 	}
 
+	var reactQuery bool
+	if reactQuery, err = data.Get[bool](request, "react-query"); err == nil {
+		opts.ReactQuery = reactQuery
+	}
+
+	var validateResponses bool
+	if validateResponses, err = data.Get[bool](request, "validate"); err == nil {
+		opts.Validate = validateResponses
+	}
+
+	var validateRequests bool
+	if validateRequests, err = data.Get[bool](request, "validate-requests"); err == nil {
+		opts.ValidateRequests = validateRequests
+	}
+
 	var docFile string
 	if docFile, err = data.Get[string](request, "doc-file"); err != nil {
 		docFile = ""
@@ -126,6 +141,25 @@ func (p *ClientTsPlugin) Info() (info plugin.Info, err error) {
 						Required:    false,
 						Default:     "contracts",
 					},
+					{
+						Name:        "contracts-recursive",
+						Type:        "bool",
+						Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"),
+						Required:    false,
+						Default:     false,
+					},
+					{
+						Name:        "contracts-include",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)"),
+						Required:    false,
+					},
+					{
+						Name:        "contracts-exclude",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)"),
+						Required:    false,
+					},
 					{
 						Name:        "out",
 						Type:        "string",
@@ -164,6 +198,27 @@ func (p *ClientTsPlugin) Info() (info plugin.Info, err error) {
 						Required:    false,
 						Default:     false,
 					},
+					{
+						Name:        "react-query",
+						Type:        "bool",
+						Description: i18n.Msg("Generate react-query.ts with TanStack Query hooks per contract method"),
+						Required:    false,
+						Default:     false,
+					},
+					{
+						Name:        "validate",
+						Type:        "bool",
+						Description: i18n.Msg("Validate JSON-RPC and HTTP responses against schemas generated from the contract types"),
+						Required:    false,
+						Default:     false,
+					},
+					{
+						Name:        "validate-requests",
+						Type:        "bool",
+						Description: i18n.Msg("Also validate request arguments before sending (implies validate)"),
+						Required:    false,
+						Default:     false,
+					},
 				},
 			},
 		},
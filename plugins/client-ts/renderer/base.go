@@ -23,6 +23,9 @@ type ClientRenderer struct {
 	typeAnchorsSet           map[string]bool
 	needParseFormValueHelper bool
 	npmRuntimeDeps           map[string]string
+	reactQuery               bool
+	validateResponses        bool
+	validateRequests         bool
 }
 
 func NewClientRenderer(project *model.Project, outDir string, emitDist bool, packageJSONPath string, clientIdentity bool) (r *ClientRenderer) {
@@ -74,6 +77,17 @@ func (r *ClientRenderer) HasHTTP() (ok bool) {
 	return false
 }
 
+// HasProblemErrors — хотя бы один REST-метод отдаёт ошибки в формате RFC 9457 (@tg http-errors=problem).
+func (r *ClientRenderer) HasProblemErrors() (ok bool) {
+
+	for _, contract := range r.project.Contracts {
+		if model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) && model.ContractHasProblemErrors(r.project, contract) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *ClientRenderer) HasWS() (ok bool) {
 
 	for _, contract := range r.project.Contracts {
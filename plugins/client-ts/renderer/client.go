@@ -27,6 +27,10 @@ func (r *ClientRenderer) RenderClient() (err error) {
 	}
 	if r.HasHTTP() {
 		file.ImportNamed("./error", "defaultHTTPErrorDecoder")
+		file.ImportNamed("./transport", "fetchWithPolicy")
+	}
+	if r.HasJsonRPC() || r.HasHTTP() {
+		file.ImportType("./transport", "CallOptions")
 	}
 	if r.HasJsonRPC() {
 		file.ImportNamed("./jsonrpc/client", "JsonRpcClient")
@@ -152,6 +156,12 @@ func (r *ClientRenderer) renderBaseClientClass() *tsg.Statement {
 			}))
 		grp.Line()
 
+		// Общий fetch HTTP-клиентов: перехватчики, таймаут и повторы из опций клиента
+		if r.HasHTTP() {
+			grp.Add(r.renderFetchMethod())
+			grp.Line()
+		}
+
 		// Метод Batch для выполнения batch запросов
 		if r.HasJsonRPC() {
 			grp.Add(r.renderBatchMethod())
@@ -245,6 +255,7 @@ func (r *ClientRenderer) renderBatchMethod() *tsg.Statement {
 	methodParams.Params(func(pg *tsg.Group) {
 		// Параметр: requests: BatchRequest[]
 		pg.Add(tsg.NewStatement().Id("requests").Colon().Id("BatchRequest").Array(nil))
+		pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
 	})
 	returnType := tsg.NewStatement()
 	returnType.Void()
@@ -267,7 +278,7 @@ func (r *ClientRenderer) renderBatchMethod() *tsg.Statement {
 		responseType := tsg.NewStatement()
 		responseType.Nullable(tsg.NewStatement().Id("Map").Generic("ID", "ResponseRPC"))
 		bg.Add(tsg.NewStatement().Const("rpcResponses").Colon().Add(responseType).Op("=").Await(
-			tsg.NewStatement().This().Dot("rpcClient").Dot("callBatch").Call(tsg.NewStatement().Id("rpcRequests")),
+			tsg.NewStatement().This().Dot("rpcClient").Dot("callBatch").Call(tsg.NewStatement().Id("rpcRequests"), tsg.NewStatement().Id("callOptions")),
 		).Semicolon())
 
 		// Обработка ошибки или null
@@ -319,6 +330,29 @@ func (r *ClientRenderer) renderBatchMethod() *tsg.Statement {
 	return stmt
 }
 
+func (r *ClientRenderer) renderFetchMethod() *tsg.Statement {
+
+	stmt := tsg.NewStatement()
+	stmt.Comment("Sends an HTTP request with the client interceptors, timeout and retry policy")
+	methodParams := tsg.NewStatement()
+	methodParams.Params(func(pg *tsg.Group) {
+		pg.Add(tsg.NewStatement().Id("url").Colon().Id("string"))
+		pg.Add(tsg.NewStatement().Id("init").Colon().Id("RequestInit"))
+		pg.Add(tsg.NewStatement().Id("idempotent").Colon().Id("boolean"))
+		pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
+	})
+	stmt.AsyncMethodWithParams("fetch", methodParams, tsg.NewStatement().Id("Response"), func(mg *tsg.Group) {
+		mg.Return(tsg.NewStatement().Id("fetchWithPolicy").Call(
+			tsg.NewStatement().This().Dot("options"),
+			tsg.NewStatement().Id("url"),
+			tsg.NewStatement().Id("init"),
+			tsg.NewStatement().Id("idempotent"),
+			tsg.NewStatement().Id("callOptions"),
+		))
+	})
+	return stmt
+}
+
 func (r *ClientRenderer) renderDecodeRPCErrorMethod() *tsg.Statement {
 
 	stmt := tsg.NewStatement()
@@ -12,6 +12,7 @@ const (
 	tagAuthor          = "author"
 	tagVersion         = "version"
 	tagDesc            = "desc"
+	tagQuery           = "query"
 	TypeIDIOReader     = "io:Reader"
 	TypeIDIOReadCloser = "io:ReadCloser"
 )
@@ -4,8 +4,11 @@ package renderer
 
 import (
 	"path"
+	"sort"
+	"strings"
 
 	"tgp/internal/generated"
+	"tgp/internal/model"
 	"tgp/plugins/client-ts/tsg"
 )
 
@@ -46,6 +49,12 @@ func (r *ClientRenderer) RenderClientError() (err error) {
 	if r.HasHTTP() {
 		file.Add(r.renderResponseErrorClass())
 		file.Line()
+		if r.HasProblemErrors() {
+			file.Add(r.renderProblemTypesConst())
+			file.Line()
+			file.Add(r.renderProblemErrorClass())
+			file.Line()
+		}
 		file.Add(r.renderHTTPErrorDecoderType())
 		file.Line()
 		file.Add(r.renderDefaultHTTPErrorDecoderFunc())
@@ -144,6 +153,23 @@ func (r *ClientRenderer) renderDefaultHTTPErrorDecoderFunc() *tsg.Statement {
 	})
 	stmt.Colon().Id("Error")
 	stmt.Block(func(bg *tsg.Group) {
+		if r.HasProblemErrors() {
+			bg.If(tsg.TypeFromString("contentType.split(\";\")[0].trim().toLowerCase() === "+`"`+model.ProblemContentType+`"`), func(ig *tsg.Group) {
+				ig.Try(func(tg *tsg.Group) {
+					tg.Add(tsg.NewStatement().Const("problem").Colon().Id("unknown").Op("=").Id("JSON.parse").Call(tsg.NewStatement().Id("body")).Semicolon())
+					tg.If(tsg.TypeFromString("problem !== null && typeof problem === \"object\" && !Array.isArray(problem)"), func(pg *tsg.Group) {
+						pg.Return(tsg.NewStatement().New("ProblemError").Call(
+							tsg.NewStatement().Id("statusCode"),
+							tsg.NewStatement().Id("contentType"),
+							tsg.NewStatement().Id("body"),
+							tsg.NewStatement().Id("problem").Op("as").Id("Record<string, unknown>"),
+						))
+					})
+				}, func(cg *tsg.Group) {
+					cg.Comment("not a problem document: fall back to ResponseError")
+				})
+			})
+		}
 		bg.Return(tsg.NewStatement().New("ResponseError").Call(
 			tsg.NewStatement().Id("statusCode"),
 			tsg.NewStatement().Id("contentType"),
@@ -152,3 +178,88 @@ func (r *ClientRenderer) renderDefaultHTTPErrorDecoderFunc() *tsg.Statement {
 	})
 	return stmt
 }
+
+// renderProblemTypesConst — URI типов проблем RFC 9457 для ошибок методов с @tg http-errors=problem.
+func (r *ClientRenderer) renderProblemTypesConst() *tsg.Statement {
+
+	uris := make(map[string]string)
+	for _, contract := range r.project.Contracts {
+		if !model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if !model.HTTPErrorsAsProblem(r.project, contract, method) {
+				continue
+			}
+			for _, errInfo := range method.Errors {
+				if errInfo.TypeName == "" {
+					continue
+				}
+				name := strings.TrimPrefix(errInfo.TypeName, "Err")
+				if _, found := uris[name]; !found {
+					uris[name] = model.ProblemType(r.project, contract, errInfo)
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(uris))
+	for name := range uris {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stmt := tsg.NewStatement()
+	stmt.Comment("RFC 9457 problem type URIs of contract errors")
+	stmt.Export().Const("ProblemTypes").Op("=").ObjectLiteral(func(og *tsg.Group) {
+		og.Add(tsg.NewStatement().ObjectField("Blank", tsg.NewStatement().Lit(model.ProblemTypeBlank)))
+		for _, name := range names {
+			og.Add(tsg.NewStatement().ObjectField(name, tsg.NewStatement().Lit(uris[name])))
+		}
+	}).Op("as").Id("const").Semicolon()
+	return stmt
+}
+
+// renderProblemErrorClass — ошибка REST в формате RFC 9457: стандартные члены и расширения.
+func (r *ClientRenderer) renderProblemErrorClass() *tsg.Statement {
+
+	stmt := tsg.NewStatement()
+	stmt.Comment("RFC 9457 problem details (application/problem+json); extension members are kept in `extensions`")
+	stmt.Export()
+	stmt.Add(tsg.TypeFromString("class ProblemError extends ResponseError"))
+	stmt.Block(func(grp *tsg.Group) {
+		grp.Add(tsg.NewStatement().Id("type").Colon().Id("string").Semicolon())
+		grp.Add(tsg.NewStatement().Id("title").Colon().Id("string").Semicolon())
+		grp.Add(tsg.NewStatement().Id("status").Colon().Id("number").Semicolon())
+		grp.Add(tsg.NewStatement().Id("detail").Optional().Colon().Id("string").Semicolon())
+		grp.Add(tsg.NewStatement().Id("instance").Optional().Colon().Id("string").Semicolon())
+		grp.Add(tsg.NewStatement().Id("extensions").Colon().Id("Record<string, unknown>").Semicolon())
+		grp.Line()
+
+		ctor := tsg.NewStatement()
+		ctor.Id("constructor")
+		ctor.Params(func(pg *tsg.Group) {
+			pg.Add(tsg.NewStatement().Id("statusCode").Colon().Id("number"))
+			pg.Add(tsg.NewStatement().Id("contentType").Colon().Id("string"))
+			pg.Add(tsg.NewStatement().Id("body").Colon().Id("string"))
+			pg.Add(tsg.NewStatement().Id("problem").Colon().Id("Record<string, unknown>"))
+		})
+		ctor.Block(func(bg *tsg.Group) {
+			bg.Add(tsg.TypeFromString("super(statusCode, contentType, body);"))
+			bg.Add(tsg.TypeFromString("const { type, title, status, detail, instance, ...extensions } = problem;"))
+			bg.Add(tsg.TypeFromString("this.type = typeof type === \"string\" && type !== \"\" ? type : ProblemTypes.Blank;"))
+			bg.Add(tsg.TypeFromString("this.title = typeof title === \"string\" ? title : \"\";"))
+			bg.Add(tsg.TypeFromString("this.status = typeof status === \"number\" ? status : statusCode;"))
+			bg.Add(tsg.TypeFromString("this.detail = typeof detail === \"string\" ? detail : undefined;"))
+			bg.Add(tsg.TypeFromString("this.instance = typeof instance === \"string\" ? instance : undefined;"))
+			bg.Add(tsg.NewStatement().This().Dot("extensions").Op("=").Id("extensions").Semicolon())
+			bg.Add(tsg.TypeFromString("this.message = this.detail ? `HTTP error: ${this.status}: ${this.title}: ${this.detail}` : `HTTP error: ${this.status}: ${this.title}`;"))
+		})
+		grp.Add(ctor)
+		grp.Line()
+
+		grp.Add(tsg.NewStatement().Id("code").Call().Colon().Id("number").Block(func(mg *tsg.Group) {
+			mg.Return(tsg.NewStatement().This().Dot("status"))
+		}))
+	})
+	return stmt
+}
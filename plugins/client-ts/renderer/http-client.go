@@ -37,6 +37,12 @@ func (r *ClientRenderer) RenderHTTPClientClass(contract *model.Contract) (err er
 	file.Comment(generated.ByToolGatewayComment)
 
 	file.ImportNamed("./client", "Client")
+	for _, method := range contract.Methods {
+		if r.isHTTP(method, contract) {
+			file.ImportType("./transport", "CallOptions")
+			break
+		}
+	}
 
 	file.Line()
 
@@ -76,6 +82,9 @@ func (r *ClientRenderer) RenderHTTPClientClass(contract *model.Contract) (err er
 	if len(exchangeTypes) > 0 {
 		file.ImportType(exchangePath, exchangeTypes...)
 	}
+	if validators := r.contractValidators(contract, false); len(validators) > 0 {
+		file.ImportNamed("./validate", validators...)
+	}
 	kindsUsed := make(map[string]struct{})
 	for _, method := range contract.Methods {
 		if !r.isHTTP(method, contract) {
@@ -207,3 +207,44 @@ func TestRenderHTTPClient_xmlResponseUnwrapsRoot(t *testing.T) {
 		t.Fatalf("expected XML request wrapped in Go exchange root, got:\n%s", source)
 	}
 }
+
+func TestRenderClientError_ProblemError(t *testing.T) {
+
+	project := &model.Project{
+		ModulePath:  "example",
+		Annotations: tags.DocTags{model.TagHttpProblemBase: "https://api.example.com/problems"},
+	}
+	project.Contracts = []*model.Contract{{
+		Name:        "Orders",
+		PkgPath:     "example/contracts",
+		Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagHttpErrors: model.HTTPErrorsProblem},
+		Methods: []*model.Method{{
+			Name:    "Get",
+			Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			Errors: []*model.ErrorInfo{
+				{PkgPath: "example/errs", TypeName: "ErrNotFound", HTTPCode: 404, HTTPCodeText: "Not Found"},
+			},
+		}},
+	}}
+
+	dir := t.TempDir()
+	if err := NewClientRenderer(project, dir, false, "", true).RenderClientError(); err != nil {
+		t.Fatalf("RenderClientError: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "error.ts"))
+	if err != nil {
+		t.Fatalf("read error.ts: %v", err)
+	}
+	source := string(content)
+
+	for _, want := range []string{
+		"class ProblemError extends ResponseError",
+		`NotFound: "https://api.example.com/problems/not-found"`,
+		`=== "application/problem+json"`,
+		"new ProblemError(statusCode, contentType, body, problem as Record<string, unknown>)",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("error.ts must contain %q, got:\n%s", want, source)
+		}
+	}
+}
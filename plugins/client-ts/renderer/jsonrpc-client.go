@@ -42,6 +42,7 @@ func (r *ClientRenderer) RenderJsonRPCClientClass(contract *model.Contract) (err
 		file.ImportType("./jsonrpc/utils/jsonrpc", "JsonRpcCall", "JsonRpcParams", "ResponseRPC")
 		file.ImportType("./jsonrpc/utils/ts", "MapBatchResult")
 		file.ImportType("./batch", "BatchRequest")
+		file.ImportType("./transport", "CallOptions")
 	}
 
 	for _, method := range contract.Methods {
@@ -101,6 +102,9 @@ func (r *ClientRenderer) RenderJsonRPCClientClass(contract *model.Contract) (err
 	for _, pkg := range common.SortedKeys(usedPkgs) {
 		file.ImportAll(exchangePath, pkg)
 	}
+	if validators := r.contractValidators(contract, true); len(validators) > 0 {
+		file.ImportNamed("./validate", validators...)
+	}
 
 	file.GenerateImports()
 	file.Line()
@@ -352,6 +356,7 @@ func (r *ClientRenderer) renderJsonRPCMethod(grp *tsg.Group, contract *model.Con
 				pg.Add(paramStmt)
 			}
 		}
+		pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
 	})
 
 	returnType := r.resultToTypeStatement(method, results)
@@ -374,12 +379,16 @@ func (r *ClientRenderer) renderJsonRPCMethod(grp *tsg.Group, contract *model.Con
 		} else {
 			mg.Add(tsg.NewStatement().Const("params").Colon().Id("Record").Generic("string", "never").Op("=").Values(nil).Semicolon())
 		}
+		if _, ok := r.validatedRequestArgs(contract, method); ok {
+			mg.Add(tsg.NewStatement().Id(r.validateRequestName(contract, method)).Call(tsg.NewStatement().Id("params")).Semicolon())
+		}
 		methodName := model.JsonRPCWireMethod(contract.Name, method.Name)
 		execCall := tsg.NewStatement()
 		execCall.This().Dot("client").Dot("exec")
 		execCall.Call(
 			tsg.NewStatement().Lit(methodName),
 			tsg.NewStatement().Id("params"),
+			tsg.NewStatement().Id("callOptions"),
 		)
 
 		mg.Add(
@@ -406,6 +415,9 @@ func (r *ClientRenderer) renderJsonRPCMethod(grp *tsg.Group, contract *model.Con
 		if len(results) == 0 {
 			mg.Return()
 		} else {
+			if _, ok := r.validatedResponseResults(contract, method); ok {
+				mg.Add(tsg.NewStatement().Id(r.validateResponseName(contract, method)).Call(tsg.NewStatement().Id("execResult").Dot("result")).Semicolon())
+			}
 			mg.Add(tsg.NewStatement().Const("result").Colon().Id(responseTypeName).Op("=").Id("execResult").Dot("result").Op("as").Id(responseTypeName).Semicolon())
 
 			if len(results) == 1 {
@@ -484,6 +496,9 @@ func (r *ClientRenderer) renderJsonRPCRequestMethod(grp *tsg.Group, contract *mo
 		} else {
 			bg.Add(tsg.NewStatement().Const("params").Colon().Id("Record").Generic("string", "never").Op("=").Values(nil).Semicolon())
 		}
+		if _, ok := r.validatedRequestArgs(contract, method); ok {
+			bg.Add(tsg.NewStatement().Id(r.validateRequestName(contract, method)).Call(tsg.NewStatement().Id("params")).Semicolon())
+		}
 
 		requestStmt := tsg.NewStatement()
 		requestStmt.Const("_request").Colon().Id("BatchRequest").Op("=")
@@ -545,6 +560,19 @@ func (r *ClientRenderer) renderJsonRPCRequestMethod(grp *tsg.Group, contract *mo
 											tsg.NewStatement().Id("null"),
 										).Semicolon())
 									} else {
+										if _, ok := r.validatedResponseResults(contract, method); ok {
+											sg.Add(tsg.NewStatement().Try(func(tg *tsg.Group) {
+												tg.Add(tsg.NewStatement().Id(r.validateResponseName(contract, method)).Call(tsg.NewStatement().Id("rpcResponse.result")).Semicolon())
+											}, func(cg *tsg.Group) {
+												callbackArgs := make([]*tsg.Statement, 0, len(results)+1)
+												for range results {
+													callbackArgs = append(callbackArgs, tsg.NewStatement().Id("null"))
+												}
+												callbackArgs = append(callbackArgs, tsg.NewStatement().Id("e").Op("as").Id("Error"))
+												cg.Add(tsg.NewStatement().Id("callback").Call(callbackArgs...).Semicolon())
+												cg.Return()
+											}))
+										}
 										sg.Add(tsg.NewStatement().Const("result").Colon().Id(responseTypeName).Op("=").Id("rpcResponse.result").Op("as").Id(responseTypeName).Semicolon())
 
 										callbackArgs := []*tsg.Statement{}
@@ -596,6 +624,7 @@ func (r *ClientRenderer) renderJsonRPCBatchMethod(grp *tsg.Group, contract *mode
 	methodParams := tsg.NewStatement()
 	methodParams.Params(func(pg *tsg.Group) {
 		pg.Add(tsg.NewStatement().Id("calls").Colon().Id("Calls"))
+		pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
 	})
 
 	returnType := tsg.NewStatement()
@@ -608,7 +637,7 @@ func (r *ClientRenderer) renderJsonRPCBatchMethod(grp *tsg.Group, contract *mode
 				This().
 				Dot("client").
 				Dot("callBatch").
-				Call(tsg.NewStatement().Id("calls")).
+				Call(tsg.NewStatement().Id("calls"), tsg.NewStatement().Id("callOptions")).
 				Op("as").
 				Id("MapBatchResult").
 				Generic("Calls"),
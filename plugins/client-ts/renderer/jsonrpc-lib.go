@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
+	"strings"
 
 	"tgp/internal/generated"
+	"tgp/internal/model"
 	"tgp/plugins/client-ts/tsg"
 )
 
@@ -48,6 +51,25 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 	file.ImportType("./utils/jsonrpc", "RequestRPC", "ResponseRPC", "ID")
 	file.ImportType("../options", "ClientOptions")
 	file.ImportNamed("../headers", "buildClientHeaders")
+	file.ImportNamed("../transport", "fetchWithPolicy")
+	file.ImportType("../transport", "CallOptions")
+
+	// Заголовки ключа идемпотентности методов @tg idempotent: только такие вызовы повторяются без callOptions.idempotent.
+	headersStmt := tsg.NewStatement().
+		Comment("Idempotency key headers of @tg idempotent methods; only these calls are retried by default").
+		Const("idempotencyHeaders").
+		Colon().
+		Id("Readonly").
+		Generic("Record<string, string>").
+		Op("=").
+		ObjectLiteral(func(og *tsg.Group) {
+			for _, entry := range r.jsonRPCIdempotencyHeaders() {
+				og.Add(tsg.NewStatement().ObjectField(entry[0], tsg.NewStatement().Lit(entry[1])))
+			}
+		}).
+		Semicolon()
+	file.Add(headersStmt)
+	file.Line()
 
 	classStmt := tsg.NewStatement().
 		Comment("JSON-RPC 2.0 client").
@@ -100,6 +122,7 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 			Params(func(pg *tsg.Group) {
 				pg.Add(tsg.NewStatement().Id("method").Colon().Id("string"))
 				pg.Add(tsg.NewStatement().Id("params").Optional().Colon().Id("any"))
+				pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
 			})
 		callReturnType := tsg.NewStatement().
 			ObjectLiteral(func(og *tsg.Group) {
@@ -151,7 +174,13 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 					Semicolon()
 				bg.Add(headersVar)
 
-				// Выполняем запрос
+				// Ключ создаётся один раз на вызов и переживает повторы: сервер выполнит метод не больше одного раза.
+				bg.Add(tsg.NewStatement().Const("idempotencyHeader").Colon().Id("string | undefined").Op("=").Id("idempotencyHeaders").Index(tsg.NewStatement().Id("method")).Semicolon())
+				bg.If(tsg.NewStatement().Id("idempotencyHeader").Op("!==").Id("undefined").Op("&&").Id("headers").Index(tsg.NewStatement().Id("idempotencyHeader")).Op("===").Id("undefined"), func(ig *tsg.Group) {
+					ig.Add(tsg.NewStatement().Id("headers").Index(tsg.NewStatement().Id("idempotencyHeader")).Op("=").Id("crypto").Dot("randomUUID").Call().Semicolon())
+				})
+
+				// Выполняем запрос через общий fetch: повторяются методы @tg idempotent, остальные — только при callOptions.idempotent
 				fetchCall := tsg.NewStatement().
 					Const("response").
 					Colon().
@@ -159,8 +188,9 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 					Op("=").
 					Await(
 						tsg.NewStatement().
-							Id("fetch").
+							Id("fetchWithPolicy").
 							Call(
+								tsg.NewStatement().This().Dot("options"),
 								tsg.NewStatement().Id("this.options.url"),
 								tsg.NewStatement().ObjectLiteral(func(og *tsg.Group) {
 									og.Add(tsg.NewStatement().ObjectField("method", tsg.NewStatement().Lit("POST")))
@@ -170,6 +200,8 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 									})))
 									og.Add(tsg.NewStatement().ObjectField("body", tsg.NewStatement().Id("JSON.stringify").Call(tsg.NewStatement().Id("request"))))
 								}),
+								tsg.NewStatement().Id("idempotencyHeader").Op("!==").Id("undefined"),
+								tsg.NewStatement().Id("callOptions"),
 							),
 					).
 					Semicolon()
@@ -215,6 +247,7 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 		batchParams := tsg.NewStatement().
 			Params(func(pg *tsg.Group) {
 				pg.Add(tsg.NewStatement().Id("requests").Colon().ReadonlyArray(tsg.NewStatement().Id("RequestRPC")))
+				pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
 			})
 		batchReturnType := tsg.NewStatement().
 			Nullable(tsg.NewStatement().Id("Map").Generic("ID", "ResponseRPC"))
@@ -232,7 +265,18 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 					Semicolon()
 				bg.Add(headersVar)
 
-				// Выполняем запрос
+				// Batch повторяется, только если все его методы @tg idempotent; сервер выводит из ключа отдельный ключ для каждого элемента.
+				bg.Add(tsg.NewStatement().Const("batchHeaders").Op("=").Id("requests").Dot("map").Call(tsg.NewStatement().Id("(request) => idempotencyHeaders[request.method] as string | undefined")).Semicolon())
+				bg.Add(tsg.NewStatement().Const("idempotent").Op("=").Id("batchHeaders").Dot("length").Op(">").Lit(0).Op("&&").Id("batchHeaders").Dot("every").Call(tsg.NewStatement().Id("(header) => header !== undefined")).Semicolon())
+				bg.If(tsg.NewStatement().Id("idempotent"), func(ig *tsg.Group) {
+					ig.Add(tsg.NewStatement().ForOf("header", "batchHeaders", func(fg *tsg.Group) {
+						fg.If(tsg.NewStatement().Id("header").Op("!==").Id("undefined").Op("&&").Id("headers").Index(tsg.NewStatement().Id("header")).Op("===").Id("undefined"), func(hg *tsg.Group) {
+							hg.Add(tsg.NewStatement().Id("headers").Index(tsg.NewStatement().Id("header")).Op("=").Id("crypto").Dot("randomUUID").Call().Semicolon())
+						})
+					}))
+				})
+
+				// Выполняем запрос через общий fetch: повторяется batch из методов @tg idempotent, остальные — только при callOptions.idempotent
 				fetchCall := tsg.NewStatement().
 					Const("response").
 					Colon().
@@ -240,8 +284,9 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 					Op("=").
 					Await(
 						tsg.NewStatement().
-							Id("fetch").
+							Id("fetchWithPolicy").
 							Call(
+								tsg.NewStatement().This().Dot("options"),
 								tsg.NewStatement().Id("this.options.url"),
 								tsg.NewStatement().ObjectLiteral(func(og *tsg.Group) {
 									og.Add(tsg.NewStatement().ObjectField("method", tsg.NewStatement().Lit("POST")))
@@ -251,6 +296,8 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 									})))
 									og.Add(tsg.NewStatement().ObjectField("body", tsg.NewStatement().Id("JSON.stringify").Call(tsg.NewStatement().Id("requests"))))
 								}),
+								tsg.NewStatement().Id("idempotent"),
+								tsg.NewStatement().Id("callOptions"),
 							),
 					).
 					Semicolon()
@@ -309,6 +356,20 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) (err error) {
 	return file.Save(path.Join(jsonrpcDir, "client.ts"))
 }
 
+// jsonRPCIdempotencyHeaders — пары «метод JSON-RPC — заголовок ключа идемпотентности» методов @tg idempotent, по имени метода.
+func (r *ClientRenderer) jsonRPCIdempotencyHeaders() (entries [][2]string) {
+
+	for _, contract := range r.project.Contracts {
+		for _, method := range contract.Methods {
+			if r.methodIsJsonRPC(contract, method) && model.MethodIsIdempotent(r.project, contract, method) {
+				entries = append(entries, [2]string{model.JsonRPCWireMethod(contract.Name, method.Name), model.MethodIdempotencyHeader(r.project, contract, method)})
+			}
+		}
+	}
+	slices.SortFunc(entries, func(a, b [2]string) int { return strings.Compare(a[0], b[0]) })
+	return
+}
+
 func (r *ClientRenderer) renderJsonRPCUtils(utilsDir string) (err error) {
 
 	file := tsg.NewFile()
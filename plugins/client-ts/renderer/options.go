@@ -22,6 +22,7 @@ func (r *ClientRenderer) RenderClientOptions() (err error) {
 	if r.HasHTTP() {
 		file.ImportType("./error", "HTTPErrorDecoder")
 	}
+	file.ImportType("./transport", "CredentialProvider", "Interceptors", "RetryPolicy")
 	stmt.Export().Type("ClientOptions")
 	stmt.Op("=")
 	stmt.Block(func(grp *tsg.Group) {
@@ -50,6 +51,12 @@ func (r *ClientRenderer) RenderClientOptions() (err error) {
 		fnType := tsg.NewStatement()
 		fnType.Params(func(fg *tsg.Group) {}).Op("=>").Id("string")
 		grp.Add(tsg.NewStatement().Id("idGeneratorFn").Optional().Colon().Add(fnType).Semicolon())
+		// Таймаут по умолчанию (мс), политика повторов и перехватчики общего fetch
+		grp.Add(tsg.NewStatement().Id("timeout").Optional().Colon().Id("number").Semicolon())
+		grp.Add(tsg.NewStatement().Id("retry").Optional().Colon().Id("RetryPolicy").Semicolon())
+		grp.Add(tsg.NewStatement().Id("interceptors").Optional().Colon().Id("Interceptors").Semicolon())
+		// Учётные данные для @tg security, добавляются перед каждой попыткой
+		grp.Add(tsg.NewStatement().Id("credentials").Optional().Colon().Id("CredentialProvider").Semicolon())
 	})
 	file.Add(stmt)
 	file.Line()
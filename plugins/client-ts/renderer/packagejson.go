@@ -65,6 +65,58 @@ func (r *ClientRenderer) RenderPackageJSON() (err error) {
 		},
 	}
 
+	// transport.ts отдельной точкой входа: CallOptions, RetryPolicy, типы перехватчиков и TimeoutError.
+	var transportPath, transportTypesPath string
+	if transportPath, err = npmRelativePath(packageDir, filepath.Join(r.outDir, "dist", "transport.js")); err != nil {
+		return fmt.Errorf("package-json transport path: %w", err)
+	}
+	if transportTypesPath, err = npmRelativePath(packageDir, filepath.Join(r.outDir, "dist", "transport.d.ts")); err != nil {
+		return fmt.Errorf("package-json transport types path: %w", err)
+	}
+	pkg["exports"].(map[string]any)["./transport"] = map[string]any{
+		"types":  transportTypesPath,
+		"import": transportPath,
+	}
+
+	if r.reactQuery {
+		var hooksPath, hooksTypesPath string
+		if hooksPath, err = npmRelativePath(packageDir, filepath.Join(r.outDir, "dist", "react-query.js")); err != nil {
+			return fmt.Errorf("package-json react-query path: %w", err)
+		}
+		if hooksTypesPath, err = npmRelativePath(packageDir, filepath.Join(r.outDir, "dist", "react-query.d.ts")); err != nil {
+			return fmt.Errorf("package-json react-query types path: %w", err)
+		}
+		pkg["exports"].(map[string]any)["./react-query"] = map[string]any{
+			"types":  hooksTypesPath,
+			"import": hooksPath,
+		}
+		pkg["peerDependencies"] = map[string]string{
+			"@tanstack/react-query": "^5.0.0",
+			"react":                 ">=18.0.0",
+		}
+		pkg["peerDependenciesMeta"] = map[string]any{
+			"@tanstack/react-query": map[string]bool{"optional": true},
+			"react":                 map[string]bool{"optional": true},
+		}
+		devDeps := pkg["devDependencies"].(map[string]string)
+		devDeps["@tanstack/react-query"] = "^5.0.0"
+		devDeps["@types/react"] = "^18.0.0"
+	}
+
+	if r.validateResponses {
+		var validatePath, validateTypesPath string
+		if validatePath, err = npmRelativePath(packageDir, filepath.Join(r.outDir, "dist", "validate.js")); err != nil {
+			return fmt.Errorf("package-json validate path: %w", err)
+		}
+		if validateTypesPath, err = npmRelativePath(packageDir, filepath.Join(r.outDir, "dist", "validate.d.ts")); err != nil {
+			return fmt.Errorf("package-json validate types path: %w", err)
+		}
+		pkg["exports"].(map[string]any)["./validate"] = map[string]any{
+			"types":  validateTypesPath,
+			"import": validatePath,
+		}
+	}
+
 	if len(r.npmRuntimeDeps) > 0 {
 		deps := make(map[string]string, len(r.npmRuntimeDeps))
 		for name, version := range r.npmRuntimeDeps {
@@ -0,0 +1,290 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"tgp/internal/generated"
+	"tgp/internal/model"
+	"tgp/plugins/client-ts/tsg"
+)
+
+const (
+	reactQueryKindQuery        = "query"
+	reactQueryKindMutation     = "mutation"
+	reactQueryKindSubscription = "subscription"
+)
+
+// reactQueryHook — хук метода контракта: класс и аксессор клиента, имя метода класса и число аргументов.
+type reactQueryHook struct {
+	kind      string
+	contract  *model.Contract
+	method    *model.Method
+	classType string
+	classFile string
+	accessor  string
+	tsMethod  string
+	argsCount int
+}
+
+// RenderReactQuery записывает react-query.ts с хуками TanStack Query поверх сгенерированных клиентов.
+func (r *ClientRenderer) RenderReactQuery() (err error) {
+
+	hooks := r.collectReactQueryHooks()
+	kinds := make(map[string]bool)
+	for _, hook := range hooks {
+		kinds[hook.kind] = true
+	}
+	// package.json публикует react-query.ts отдельной точкой входа с peer-зависимостями.
+	r.reactQuery = true
+
+	file := tsg.NewFile()
+	file.Comment(generated.ByToolGatewayComment)
+
+	file.ImportNamed("./client", "newClient")
+	file.ImportType("./client", "Client")
+	file.ImportType("./options", "ClientOptions")
+	if kinds[reactQueryKindSubscription] {
+		file.ImportNamed("react", "useEffect", "useState")
+	} else {
+		file.ImportNamed("react", "useState")
+	}
+	if kinds[reactQueryKindQuery] {
+		file.ImportNamed("@tanstack/react-query", "useQuery")
+		file.ImportType("@tanstack/react-query", "UseQueryOptions", "UseQueryResult")
+	}
+	if kinds[reactQueryKindMutation] {
+		file.ImportNamed("@tanstack/react-query", "useMutation")
+		file.ImportType("@tanstack/react-query", "UseMutationOptions", "UseMutationResult")
+	}
+	imported := make(map[string]bool)
+	for _, hook := range hooks {
+		if imported[hook.classType] {
+			continue
+		}
+		imported[hook.classType] = true
+		file.ImportType("./"+hook.classFile, hook.classType)
+	}
+
+	file.GenerateImports()
+	file.Line()
+
+	blocks := []string{reactQueryUseClient}
+	if kinds[reactQueryKindQuery] {
+		blocks = append(blocks, reactQueryQueryOptions)
+	}
+	if kinds[reactQueryKindMutation] {
+		blocks = append(blocks, reactQueryMutationOptions)
+	}
+	if kinds[reactQueryKindSubscription] {
+		blocks = append(blocks, reactQuerySubscriptionTypes)
+	}
+	for _, contractName := range r.ContractKeys() {
+		var contractHooks []reactQueryHook
+		for _, hook := range hooks {
+			if hook.contract.Name == contractName {
+				contractHooks = append(contractHooks, hook)
+			}
+		}
+		if len(contractHooks) == 0 {
+			continue
+		}
+		blocks = append(blocks, r.reactQueryKeysSource(contractHooks))
+		for _, hook := range contractHooks {
+			blocks = append(blocks, r.reactQueryHookSource(hook))
+		}
+	}
+	file.Add(tsg.TypeFromString(strings.Join(blocks, "\n\n")))
+	file.Line()
+	return file.Save(path.Join(r.outDir, "react-query.ts"))
+}
+
+// collectReactQueryHooks собирает хуки в порядке контрактов и методов; WS-потоки хуков не получают.
+func (r *ClientRenderer) collectReactQueryHooks() (hooks []reactQueryHook) {
+
+	for _, contractName := range r.ContractKeys() {
+		contract := r.FindContract(contractName)
+		if contract == nil || !model.ContractIsHTTPFamily(r.project, contract) {
+			continue
+		}
+		fileName := r.tsFileName(contract)
+		accessor := r.fileNameToMethodName(fileName)
+		for _, method := range contract.Methods {
+			hook := reactQueryHook{contract: contract, method: method, tsMethod: r.lcName(method.Name)}
+			var args []*model.Variable
+			switch {
+			case model.MethodIsSSE(r.project, contract, method):
+				if model.MethodStreamMode(r.project, contract, method) != model.StreamModeServer {
+					continue
+				}
+				if model.MethodIsWS(r.project, contract, method) {
+					hook.tsMethod = r.lcName(method.Name + "SSE")
+				}
+				hook.kind = reactQueryKindSubscription
+				hook.classType, hook.classFile, hook.accessor = contract.Name+"Client", fileName, accessor
+				args = r.streamClientArgs(contract, method)
+			case r.methodIsJsonRPC(contract, method):
+				hook.kind = r.reactQueryUnaryKind(contract, method, false)
+				hook.classType, hook.classFile, hook.accessor = contract.Name+"Client", fileName, accessor
+				args = r.argsForExchangeRequest(contract, method)
+			case r.methodIsHTTP(method, contract):
+				hook.kind = r.reactQueryUnaryKind(contract, method, true)
+				hook.classType, hook.classFile, hook.accessor = contract.Name+"HTTPClient", fileName+"-http", accessor+"HTTP"
+				args = r.argsForClient(contract, method)
+			default:
+				continue
+			}
+			hook.argsCount = len(args)
+			hooks = append(hooks, hook)
+		}
+	}
+	return
+}
+
+// reactQueryUnaryKind: GET/HEAD, идемпотентные (@tg idempotent) и методы с @tg query становятся запросами, остальные — мутациями.
+// Метод без результатов всегда мутация: TanStack Query не допускает undefined в данных запроса.
+func (r *ClientRenderer) reactQueryUnaryKind(contract *model.Contract, method *model.Method, http bool) (kind string) {
+
+	if len(r.resultsWithoutError(method)) == 0 {
+		return reactQueryKindMutation
+	}
+	if model.IsAnnotationSet(r.project, contract, method, nil, tagQuery) || model.MethodIsIdempotent(r.project, contract, method) {
+		return reactQueryKindQuery
+	}
+	if http {
+		switch strings.ToUpper(model.GetHTTPMethod(r.project, contract, method)) {
+		case "GET", "HEAD":
+			return reactQueryKindQuery
+		}
+	}
+	return reactQueryKindMutation
+}
+
+func (r *ClientRenderer) reactQueryKeysSource(hooks []reactQueryHook) (source string) {
+
+	contract := hooks[0].contract
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Query keys for %s: [contract, method, ...args]; %s.all matches every query of the contract.\n", contract.Name, r.reactQueryKeysName(contract))
+	fmt.Fprintf(&b, "export const %s = {\n    all: [%q] as const,\n", r.reactQueryKeysName(contract), contract.Name)
+	for _, hook := range hooks {
+		if hook.kind == reactQueryKindMutation {
+			continue
+		}
+		fmt.Fprintf(&b, "    %s: (...args: %s) => [%q, %q, ...args] as const,\n", hook.tsMethod, r.reactQueryArgsType(hook), contract.Name, hook.method.Name)
+	}
+	b.WriteString("};")
+	return b.String()
+}
+
+func (r *ClientRenderer) reactQueryHookSource(hook reactQueryHook) (source string) {
+
+	name := "use" + hook.contract.Name + hook.method.Name
+	methodRef := fmt.Sprintf("%s[%q]", hook.classType, hook.tsMethod)
+	call := fmt.Sprintf("client.%s().%s", hook.accessor, hook.tsMethod)
+	keys := r.reactQueryKeysName(hook.contract)
+
+	switch hook.kind {
+	case reactQueryKindQuery:
+		dataType := fmt.Sprintf("Awaited<ReturnType<%s>>", methodRef)
+		if hook.argsCount == 0 {
+			return fmt.Sprintf("// Calls %s.%s with useQuery.\nexport function %s(client: Client, options?: QueryHookOptions<%s>): UseQueryResult<%s, Error> {\n    return useQuery<%s, Error, %s, readonly unknown[]>({\n        ...options,\n        queryKey: %s.%s(),\n        queryFn: ({signal}) => %s({signal}),\n    });\n}",
+				hook.contract.Name, hook.method.Name, name, dataType, dataType, dataType, dataType, keys, hook.tsMethod, call)
+		}
+		return fmt.Sprintf("// Calls %s.%s with useQuery; args is the argument list of %s.\nexport function %s(client: Client, args: %s, options?: QueryHookOptions<%s>): UseQueryResult<%s, Error> {\n    return useQuery<%s, Error, %s, readonly unknown[]>({\n        ...options,\n        queryKey: %s.%s(...args),\n        queryFn: ({signal}) => %s(...args, {signal}),\n    });\n}",
+			hook.contract.Name, hook.method.Name, hook.tsMethod, name, r.reactQueryArgsType(hook), dataType, dataType, dataType, dataType, keys, hook.tsMethod, call)
+	case reactQueryKindMutation:
+		dataType := fmt.Sprintf("Awaited<ReturnType<%s>>", methodRef)
+		if hook.argsCount == 0 {
+			return fmt.Sprintf("// Calls %s.%s with useMutation; mutate() takes no variables.\nexport function %s(client: Client, options?: MutationHookOptions<%s, void>): UseMutationResult<%s, Error, void> {\n    return useMutation<%s, Error, void>({\n        ...options,\n        mutationFn: () => %s(),\n    });\n}",
+				hook.contract.Name, hook.method.Name, name, dataType, dataType, dataType, call)
+		}
+		varsType := r.reactQueryArgsType(hook)
+		return fmt.Sprintf("// Calls %s.%s with useMutation; variables are the argument list of %s.\nexport function %s(client: Client, options?: MutationHookOptions<%s, %s>): UseMutationResult<%s, Error, %s> {\n    return useMutation<%s, Error, %s>({\n        ...options,\n        mutationFn: (args) => %s(...args),\n    });\n}",
+			hook.contract.Name, hook.method.Name, hook.tsMethod, name, dataType, varsType, dataType, varsType, dataType, varsType, call)
+	}
+
+	itemType := fmt.Sprintf("StreamItem<ReturnType<%s>>", methodRef)
+	argsParam, argsSpread, keyArgs := "", "", ""
+	if hook.argsCount > 0 {
+		argsParam = ", args: " + r.reactQueryArgsType(hook)
+		argsSpread = "...args, "
+		keyArgs = "...args"
+	}
+	return fmt.Sprintf(`// Subscribes to %s.%s while mounted and enabled; data holds the last received item.
+// The stream restarts when args change and is cancelled through AbortSignal on unmount.
+export function %s(client: Client%s, options?: SubscriptionHookOptions): SubscriptionResult<%s> {
+    const enabled = options?.enabled ?? true;
+    const key = JSON.stringify(%s.%s(%s));
+    const [state, setState] = useState<SubscriptionResult<%s>>({data: undefined, error: undefined, done: false});
+    useEffect(() => {
+        if (!enabled) {
+            return;
+        }
+        const controller = new AbortController();
+        setState({data: undefined, error: undefined, done: false});
+        void (async () => {
+            try {
+                for await (const item of %s(%scontroller.signal)) {
+                    if (controller.signal.aborted) {
+                        return;
+                    }
+                    setState({data: item, error: undefined, done: false});
+                }
+                if (!controller.signal.aborted) {
+                    setState((prev) => ({...prev, done: true}));
+                }
+            } catch (err) {
+                if (!controller.signal.aborted) {
+                    setState((prev) => ({...prev, error: err instanceof Error ? err : new Error(String(err)), done: true}));
+                }
+            }
+        })();
+        return () => controller.abort();
+    }, [client, enabled, key]);
+    return state;
+}`, hook.contract.Name, hook.method.Name, name, argsParam, itemType, keys, hook.tsMethod, keyArgs, itemType, call, argsSpread)
+}
+
+// reactQueryArgsType — кортеж аргументов метода клиента без завершающих callOptions или signal.
+func (r *ClientRenderer) reactQueryArgsType(hook reactQueryHook) (typeName string) {
+
+	methodRef := fmt.Sprintf("%s[%q]", hook.classType, hook.tsMethod)
+	elements := make([]string, 0, hook.argsCount)
+	for i := 0; i < hook.argsCount; i++ {
+		elements = append(elements, fmt.Sprintf("Parameters<%s>[%d]", methodRef, i))
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (r *ClientRenderer) reactQueryKeysName(contract *model.Contract) (name string) {
+
+	return r.fileNameToMethodName(r.tsFileName(contract)) + "Keys"
+}
+
+const reactQueryUseClient = `// Creates the base client once per component; later changes of endpoint and opts are ignored.
+export function useClient(endpoint: string, opts?: Partial<ClientOptions>): Client {
+    const [client] = useState(() => newClient(endpoint, opts));
+    return client;
+}`
+
+const reactQueryQueryOptions = `// useQuery options without queryKey and queryFn, which the hooks derive from the method.
+export type QueryHookOptions<TData> = Omit<UseQueryOptions<TData, Error, TData, readonly unknown[]>, "queryKey" | "queryFn">;`
+
+const reactQueryMutationOptions = `// useMutation options without mutationFn, which the hooks bind to the method.
+export type MutationHookOptions<TData, TVariables> = Omit<UseMutationOptions<TData, Error, TVariables>, "mutationFn">;`
+
+const reactQuerySubscriptionTypes = `// State of a stream subscription: the last item, the stream error and whether the stream has finished.
+export interface SubscriptionResult<T> {
+    data: T | undefined;
+    error: Error | undefined;
+    done: boolean;
+}
+
+export interface SubscriptionHookOptions {
+    enabled?: boolean;
+}
+
+type StreamItem<T> = T extends AsyncGenerator<infer I> ? I : never;`
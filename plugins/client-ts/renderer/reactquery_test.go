@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func reactQueryTestProject() (project *model.Project) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Annotations: tags.DocTags{
+			tagNpmName: "@test/api-client",
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:        "Users",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerJsonRPC: ""},
+				Methods: []*model.Method{
+					{
+						Name:        "Find",
+						Annotations: tags.DocTags{tagQuery: ""},
+						Args:        []*model.Variable{ctx, {Name: "name", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results:     []*model.Variable{{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}, errResult},
+					},
+					{
+						Name:    "Rename",
+						Args:    []*model.Variable{ctx, {Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}, {Name: "name", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results: []*model.Variable{errResult},
+					},
+					{
+						Name:        "Resolve",
+						Annotations: tags.DocTags{model.TagIdempotent: ""},
+						Args:        []*model.Variable{ctx, {Name: "name", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results:     []*model.Variable{{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}, errResult},
+					},
+				},
+			},
+			{
+				Name:        "Items",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagHttpPrefix: "api/v1"},
+				Methods: []*model.Method{
+					{
+						Name:        "List",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/items"},
+						Args:        []*model.Variable{ctx},
+						Results:     []*model.Variable{{Name: "items", TypeRef: model.TypeRef{TypeID: "string", IsSlice: true}}, errResult},
+					},
+					{
+						Name:        "Create",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "POST", model.TagHttpPath: "/items"},
+						Args:        []*model.Variable{ctx, {Name: "name", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results:     []*model.Variable{{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}, errResult},
+					},
+				},
+			},
+			{
+				Name:        "Live",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerSSE: "", model.TagHttpPrefix: "api/v1"},
+				Methods: []*model.Method{
+					{
+						Name:        "Subscribe",
+						Annotations: tags.DocTags{model.TagStream: model.StreamModeServer, model.TagSSEPath: "/sse/live/subscribe"},
+						Args:        []*model.Variable{ctx, {Name: "symbol", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results: []*model.Variable{
+							{Name: "ticks", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "string"}, ChanDirection: 2}},
+							errResult,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderReactQuery_hooksPerMethodKind(t *testing.T) {
+
+	dir := t.TempDir()
+	renderer := NewClientRenderer(reactQueryTestProject(), dir, false, "", true)
+	if err := renderer.RenderReactQuery(); err != nil {
+		t.Fatalf("RenderReactQuery: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "react-query.ts"))
+	if err != nil {
+		t.Fatalf("read react-query.ts: %v", err)
+	}
+	source := string(content)
+	for _, want := range []string{
+		`import {useQuery, useMutation, type UseQueryOptions, type UseQueryResult, type UseMutationOptions, type UseMutationResult} from '@tanstack/react-query';`,
+		`import {type ItemsHTTPClient} from './items-http';`,
+		`export function useClient(endpoint: string, opts?: Partial<ClientOptions>): Client {`,
+		`find: (...args: [Parameters<UsersClient["find"]>[0]]) => ["Users", "Find", ...args] as const,`,
+		`export function useUsersFind(client: Client, args: [Parameters<UsersClient["find"]>[0]], options?: QueryHookOptions<`,
+		`queryFn: ({signal}) => client.users().find(...args, {signal}),`,
+		`export function useUsersRename(client: Client, options?: MutationHookOptions<`,
+		`mutationFn: (args) => client.users().rename(...args),`,
+		`queryFn: ({signal}) => client.users().resolve(...args, {signal}),`,
+		`queryKey: itemsKeys.list(),`,
+		`queryFn: ({signal}) => client.itemsHTTP().list({signal}),`,
+		`mutationFn: (args) => client.itemsHTTP().create(...args),`,
+		`export function useLiveSubscribe(client: Client, args: [Parameters<LiveClient["subscribe"]>[0]], options?: SubscriptionHookOptions)`,
+		`for await (const item of client.live().subscribe(...args, controller.signal)) {`,
+		`return () => controller.abort();`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("react-query.ts missing %q:\n%s", want, source)
+		}
+	}
+	for _, unwanted := range []string{"rename:", "create:"} {
+		if strings.Contains(source, unwanted) {
+			t.Fatalf("mutations must not get query keys, found %q:\n%s", unwanted, source)
+		}
+	}
+}
+
+func TestRenderReactQuery_packageJSONEntry(t *testing.T) {
+
+	outDir := t.TempDir()
+	packagePath := filepath.Join(outDir, "package.json")
+	renderer := NewClientRenderer(reactQueryTestProject(), outDir, true, packagePath, true)
+	if err := renderer.RenderReactQuery(); err != nil {
+		t.Fatalf("RenderReactQuery: %v", err)
+	}
+	if err := renderer.RenderPackageJSON(); err != nil {
+		t.Fatalf("RenderPackageJSON: %v", err)
+	}
+
+	content, err := os.ReadFile(packagePath)
+	if err != nil {
+		t.Fatalf("read package.json: %v", err)
+	}
+	source := string(content)
+	for _, want := range []string{
+		`"./react-query": {`,
+		`"import": "./dist/react-query.js"`,
+		`"types": "./dist/react-query.d.ts"`,
+		`"peerDependencies": {`,
+		`"@tanstack/react-query": "^5.0.0"`,
+		`"optional": true`,
+		`"@types/react": "^18.0.0"`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("package.json missing %q:\n%s", want, source)
+		}
+	}
+}
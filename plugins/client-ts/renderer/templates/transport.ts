@@ -0,0 +1,255 @@
+import {type ClientOptions} from "./options";
+
+// Per-call settings accepted as the last argument of every generated method.
+export type CallOptions = {
+    // Cancels the call; retries stop as soon as the signal aborts.
+    signal?: AbortSignal;
+    // Overrides ClientOptions.timeout for this call, in milliseconds; covers reading the response body too.
+    timeout?: number;
+    // Overrides whether the call may be retried (JSON-RPC calls without @tg idempotent are not retried by default).
+    idempotent?: boolean;
+};
+
+// Retry policy for idempotent calls: exponential backoff with full jitter between attempts.
+export type RetryPolicy = {
+    // Total number of attempts, including the first one.
+    attempts: number;
+    // Base delay in milliseconds, doubled after every attempt (200 by default).
+    baseDelay?: number;
+    // Upper bound of a single delay in milliseconds (30000 by default); also caps Retry-After.
+    maxDelay?: number;
+    // HTTP statuses that trigger a retry (408, 429, 502, 503 and 504 by default).
+    retryOn?: number[];
+};
+
+// Request about to be sent; interceptors may change url and init, including init.headers.
+export type RequestContext = {
+    url: string;
+    init: RequestInit & { headers: Headers };
+    attempt: number;
+};
+
+export type RequestInterceptor = (context: RequestContext) => void | Promise<void>;
+
+// Returning a Response replaces the one passed in.
+export type ResponseInterceptor = (response: Response, context: RequestContext) => Response | void | Promise<Response | void>;
+
+// Returning an Error replaces the one passed in.
+export type ErrorInterceptor = (error: unknown, context: RequestContext) => Error | void | Promise<Error | void>;
+
+export type Interceptors = {
+    // Run before every attempt.
+    request?: RequestInterceptor[];
+    // Run once on the final response, before its status is checked.
+    response?: ResponseInterceptor[];
+    // Run once when the request fails without a response: network error, timeout or abort.
+    error?: ErrorInterceptor[];
+};
+
+// Adds credentials required by @tg security to the request; runs before every attempt, ahead of request interceptors.
+export type CredentialProvider = (context: RequestContext) => void | Promise<void>;
+
+// Token and its expiry in milliseconds since the epoch; without expiresAt the token is fetched for every attempt.
+export type Token = {
+    token: string;
+    expiresAt?: number;
+};
+
+// Sends a fixed token as Authorization: Bearer.
+export function staticToken(token: string): CredentialProvider {
+    return (context) => {
+        context.init.headers.set("Authorization", "Bearer " + token);
+    };
+}
+
+const tokenRefreshMargin = 10000;
+
+// Sends the token from fetchToken as Authorization: Bearer and refreshes it shortly before expiresAt;
+// concurrent requests wait for a single refresh.
+export function refreshingToken(fetchToken: () => Promise<Token>): CredentialProvider {
+    let cached: Token | undefined;
+    let pending: Promise<Token> | undefined;
+    return async (context) => {
+        if (cached === undefined || cached.expiresAt === undefined || cached.expiresAt - tokenRefreshMargin <= Date.now()) {
+            if (pending === undefined) {
+                pending = fetchToken().finally(() => {
+                    pending = undefined;
+                });
+            }
+            cached = await pending;
+        }
+        context.init.headers.set("Authorization", "Bearer " + cached.token);
+    };
+}
+
+// Sends username and password as Authorization: Basic.
+export function basicAuth(username: string, password: string): CredentialProvider {
+    const bytes = new TextEncoder().encode(username + ":" + password);
+    const encoded = btoa(String.fromCharCode(...bytes));
+    return (context) => {
+        context.init.headers.set("Authorization", "Basic " + encoded);
+    };
+}
+
+// Sends an API key in a header, a query parameter or a cookie, as declared by apiKey:<in>:<name> in @tg security.
+// Browsers do not let scripts set the Cookie header; there the cookie has to come from the browser itself.
+export function apiKey(key: string, location: "header" | "query" | "cookie", name: string): CredentialProvider {
+    return (context) => {
+        switch (location) {
+            case "query": {
+                const url = new URL(context.url, typeof window !== "undefined" ? window.location.href : undefined);
+                url.searchParams.set(name, key);
+                context.url = url.toString();
+                break;
+            }
+            case "cookie": {
+                const cookies = (context.init.headers.get("Cookie") ?? "").split(";").map((cookie) => cookie.trim()).filter((cookie) => cookie !== "" && !cookie.startsWith(name + "="));
+                context.init.headers.set("Cookie", [...cookies, name + "=" + encodeURIComponent(key)].join("; "));
+                break;
+            }
+            default:
+                context.init.headers.set(name, key);
+        }
+    };
+}
+
+export class TimeoutError extends Error {
+    readonly timeout: number;
+
+    constructor(timeout: number) {
+        super("request timed out after " + String(timeout) + "ms");
+        this.name = "TimeoutError";
+        this.timeout = timeout;
+    }
+}
+
+const defaultRetryOn = [408, 429, 502, 503, 504];
+
+// Sends a request with credentials, interceptors, timeout and retries from the client options and the call options.
+export async function fetchWithPolicy(options: ClientOptions, url: string, init: RequestInit, idempotent: boolean, call?: CallOptions): Promise<Response> {
+    const policy = options.retry;
+    const streamed = typeof ReadableStream !== "undefined" && init.body instanceof ReadableStream;
+    const attempts = policy && (call?.idempotent ?? idempotent) && !streamed ? Math.max(1, policy.attempts) : 1;
+    const timeout = call?.timeout ?? options.timeout;
+    const signal = call?.signal;
+    for (let attempt = 1; ; attempt++) {
+        const context: RequestContext = {url, init: {...init, headers: new Headers(init.headers)}, attempt};
+        await options.credentials?.(context);
+        for (const interceptor of options.interceptors?.request ?? []) {
+            await interceptor(context);
+        }
+        const controller = new AbortController();
+        const abort = () => controller.abort(signal?.reason);
+        if (signal?.aborted) {
+            abort();
+        } else {
+            signal?.addEventListener("abort", abort, {once: true});
+        }
+        let timedOut = false;
+        const timer = timeout !== undefined && timeout > 0 ? setTimeout(() => {
+            timedOut = true;
+            controller.abort();
+        }, timeout) : undefined;
+        const release = () => {
+            clearTimeout(timer);
+            signal?.removeEventListener("abort", abort);
+        };
+        let response: Response;
+        try {
+            response = await fetch(context.url, {...context.init, signal: controller.signal});
+        } catch (cause) {
+            release();
+            if (attempt < attempts && !signal?.aborted) {
+                await sleep(backoff(policy!, attempt, null), signal);
+                continue;
+            }
+            throw await intercept(options, timedOut ? new TimeoutError(timeout!) : cause, context);
+        }
+        if (attempt < attempts && (policy!.retryOn ?? defaultRetryOn).includes(response.status)) {
+            release();
+            await response.body?.cancel();
+            await sleep(backoff(policy!, attempt, response.headers.get("Retry-After")), signal);
+            continue;
+        }
+        // The timeout and the caller's signal also cover reading the body: both are released once it is read, cancelled or fails.
+        response = guardBody(response, release, () => timedOut ? new TimeoutError(timeout!) : undefined);
+        for (const interceptor of options.interceptors?.response ?? []) {
+            response = (await interceptor(response, context)) ?? response;
+        }
+        return response;
+    }
+}
+
+// Wraps the body so that release runs when it is read to the end, cancelled or fails; a read cut by the timeout fails with TimeoutError.
+function guardBody(response: Response, release: () => void, timeoutError: () => Error | undefined): Response {
+    if (response.body === null) {
+        release();
+        return response;
+    }
+    const reader = response.body.getReader();
+    const body = new ReadableStream<Uint8Array>({
+        async pull(controller) {
+            try {
+                const {done, value} = await reader.read();
+                if (done) {
+                    release();
+                    controller.close();
+                    return;
+                }
+                controller.enqueue(value);
+            } catch (cause) {
+                release();
+                controller.error(timeoutError() ?? cause);
+            }
+        },
+        cancel(reason) {
+            release();
+            return reader.cancel(reason);
+        },
+    });
+    const guarded = new Response(body, {status: response.status, statusText: response.statusText, headers: response.headers});
+    Object.defineProperties(guarded, {url: {value: response.url}, redirected: {value: response.redirected}});
+    return guarded;
+}
+
+async function intercept(options: ClientOptions, error: unknown, context: RequestContext): Promise<unknown> {
+    let result = error;
+    for (const interceptor of options.interceptors?.error ?? []) {
+        result = (await interceptor(result, context)) ?? result;
+    }
+    return result;
+}
+
+function backoff(policy: RetryPolicy, attempt: number, retryAfter: string | null): number {
+    const maxDelay = policy.maxDelay ?? 30000;
+    if (retryAfter !== null && retryAfter.trim() !== "") {
+        const seconds = Number(retryAfter);
+        if (!Number.isNaN(seconds)) {
+            return Math.min(Math.max(0, seconds) * 1000, maxDelay);
+        }
+        const date = Date.parse(retryAfter);
+        if (!Number.isNaN(date)) {
+            return Math.min(Math.max(0, date - Date.now()), maxDelay);
+        }
+    }
+    const baseDelay = policy.baseDelay ?? 200;
+    return Math.random() * Math.min(maxDelay, baseDelay * 2 ** (attempt - 1));
+}
+
+function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+    return new Promise((resolve, reject) => {
+        if (signal?.aborted) {
+            reject(signal.reason);
+            return;
+        }
+        const onAbort = () => {
+            clearTimeout(timer);
+            reject(signal?.reason);
+        };
+        const timer = setTimeout(() => {
+            signal?.removeEventListener("abort", onAbort);
+            resolve();
+        }, ms);
+        signal?.addEventListener("abort", onAbort, {once: true});
+    });
+}
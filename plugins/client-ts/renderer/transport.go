@@ -0,0 +1,22 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	_ "embed"
+	"os"
+	"path"
+
+	"tgp/internal/generated"
+)
+
+//go:embed templates/transport.ts
+var transportTS []byte
+
+// RenderTransport записывает transport.ts: общий fetch с перехватчиками, таймаутом и повторами.
+func (r *ClientRenderer) RenderTransport() (err error) {
+
+	content := append([]byte(generated.ByToolGatewayComment+"\n"), transportTS...)
+	err = os.WriteFile(path.Join(r.outDir, "transport.ts"), content, 0600)
+	return
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestRenderTransport_optionsAndHelper(t *testing.T) {
+
+	dir := t.TempDir()
+	renderer := NewClientRenderer(validationTestProject(), dir, false, "", true)
+	if err := renderer.RenderTransport(); err != nil {
+		t.Fatalf("RenderTransport: %v", err)
+	}
+	if err := renderer.RenderClientOptions(); err != nil {
+		t.Fatalf("RenderClientOptions: %v", err)
+	}
+
+	for file, wants := range map[string][]string{
+		"transport.ts": {
+			"export type CallOptions = {",
+			"export type RetryPolicy = {",
+			"export class TimeoutError extends Error {",
+			"export async function fetchWithPolicy(options: ClientOptions, url: string, init: RequestInit, idempotent: boolean, call?: CallOptions): Promise<Response> {",
+			"await options.credentials?.(context);",
+			"export function refreshingToken(fetchToken: () => Promise<Token>): CredentialProvider {",
+		},
+		"options.ts": {
+			"import {type CredentialProvider, type Interceptors, type RetryPolicy} from './transport';",
+			"credentials?:CredentialProvider;",
+			"timeout?:number;",
+			"retry?:RetryPolicy;",
+			"interceptors?:Interceptors;",
+		},
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Fatalf("%s missing %q:\n%s", file, want, content)
+			}
+		}
+	}
+}
+
+func TestRenderTransport_methodsAcceptCallOptions(t *testing.T) {
+
+	dir := t.TempDir()
+	project := validationTestProject()
+	renderer := NewClientRenderer(project, dir, false, "", true)
+	if err := renderer.RenderJsonRPCLibrary(); err != nil {
+		t.Fatalf("RenderJsonRPCLibrary: %v", err)
+	}
+	if err := renderer.RenderClient(); err != nil {
+		t.Fatalf("RenderClient: %v", err)
+	}
+	if err := renderer.RenderJsonRPCClientClass(project.Contracts[0]); err != nil {
+		t.Fatalf("RenderJsonRPCClientClass: %v", err)
+	}
+	if err := renderer.RenderHTTPClientClass(project.Contracts[1]); err != nil {
+		t.Fatalf("RenderHTTPClientClass: %v", err)
+	}
+
+	for file, wants := range map[string][]string{
+		"jsonrpc/client.ts": {
+			"import {fetchWithPolicy, type CallOptions} from '../transport';",
+			"async exec(method:string, params?:any, callOptions?:CallOptions)",
+			"async callBatch(requests:readonly RequestRPC[], callOptions?:CallOptions)",
+			"const idempotencyHeaders:Readonly<Record<string, string>> = {",
+			"}, idempotencyHeader !== undefined, callOptions);",
+			"}, idempotent, callOptions);",
+		},
+		"client.ts": {
+			"async fetch(url:string, init:RequestInit, idempotent:boolean, callOptions?:CallOptions): Promise<Response> {",
+			"return fetchWithPolicy(this.options, url, init, idempotent, callOptions);",
+			"async batch(requests:BatchRequest[], callOptions?:CallOptions)",
+		},
+		"users.ts": {
+			"public async get(id:string, callOptions?:CallOptions): Promise<dto.User>",
+			`this.client.exec("users.get", params, callOptions);`,
+		},
+		"items-http.ts": {
+			"public async create(name:string, callOptions?:CallOptions)",
+			"public async export(callOptions?:CallOptions)",
+			"}, false, callOptions);",
+			"}, true, callOptions);",
+		},
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Fatalf("%s missing %q:\n%s", file, want, content)
+			}
+		}
+	}
+}
+
+func TestRenderTransport_idempotentMethodsRetry(t *testing.T) {
+
+	dir := t.TempDir()
+	project := validationTestProject()
+	project.Contracts[0].Methods[0].Annotations = tags.DocTags{model.TagIdempotent: ""}
+	project.Contracts[1].Methods[0].Annotations[model.TagIdempotent] = ""
+	project.Contracts[1].Methods[0].Annotations[model.TagIdempotencyHeader] = "X-Request-Key"
+	renderer := NewClientRenderer(project, dir, false, "", true)
+	if err := renderer.RenderJsonRPCLibrary(); err != nil {
+		t.Fatalf("RenderJsonRPCLibrary: %v", err)
+	}
+	if err := renderer.RenderHTTPClientClass(project.Contracts[1]); err != nil {
+		t.Fatalf("RenderHTTPClientClass: %v", err)
+	}
+
+	for file, wants := range map[string][]string{
+		"jsonrpc/client.ts": {
+			`"users.get": "Idempotency-Key"`,
+			"const idempotencyHeader:string | undefined = idempotencyHeaders[method];",
+			"headers[idempotencyHeader] = crypto.randomUUID();",
+			"const idempotent = batchHeaders.length > 0 && batchHeaders.every((header) => header !== undefined);",
+		},
+		"items-http.ts": {
+			`.set("X-Request-Key", crypto.randomUUID());`,
+		},
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Fatalf("%s missing %q:\n%s", file, want, content)
+			}
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "items-http.ts"))
+	if err != nil {
+		t.Fatalf("read items-http.ts: %v", err)
+	}
+	if strings.Contains(string(content), "}, false, callOptions);") {
+		t.Fatalf("idempotent POST must be retried:\n%s", content)
+	}
+}
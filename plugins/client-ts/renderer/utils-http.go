@@ -79,6 +79,7 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *model.Method,
 				pg.Add(paramStmt)
 			}
 		}
+		pg.Add(tsg.NewStatement().Id("callOptions").Optional().Colon().Id("CallOptions"))
 	})
 
 	returnType := r.resultToTypeStatement(method, results)
@@ -101,6 +102,9 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *model.Method,
 			})
 			mg.Add(paramsObj.Semicolon())
 		}
+		if _, ok := r.validatedRequestArgs(contract, method); ok {
+			mg.Add(tsg.NewStatement().Id(r.validateRequestName(contract, method)).Call(tsg.NewStatement().Id(tsLocalVar("params"))).Semicolon())
+		}
 
 		urlStmt := tsg.NewStatement()
 		urlStmt.Const(tsLocalVar("baseURL")).Op("=").Id("this").Dot("baseClient").Dot("getEndpoint").Call().Semicolon()
@@ -226,6 +230,15 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *model.Method,
 			mg.Add(tsg.NewStatement().Id(tsLocalVar("headers")).Dot("set").Call(tsg.NewStatement().Lit("Cookie"), tsg.NewStatement().Id(tsLocalVar("cookieParts")).Dot("join").Call(tsg.NewStatement().Lit("; "))).Semicolon())
 		}
 
+		idempotent := model.MethodIsIdempotent(r.project, contract, method)
+		if idempotent {
+			header := model.MethodIdempotencyHeader(r.project, contract, method)
+			// Ключ создаётся один раз на вызов: fetchWithPolicy копирует заголовки в каждую попытку, и сервер узнаёт повтор.
+			mg.If(tsg.NewStatement().Op("!").Id(tsLocalVar("headers")).Dot("has").Call(tsg.NewStatement().Lit(header)), func(ig *tsg.Group) {
+				ig.Add(tsg.NewStatement().Id(tsLocalVar("headers")).Dot("set").Call(tsg.NewStatement().Lit(header), tsg.NewStatement().Id("crypto").Dot("randomUUID").Call()).Semicolon())
+			})
+		}
+
 		if requestMultipart && (httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH") {
 			mg.Add(tsg.NewStatement().Const(tsLocalVar("multipartReq")).Op("=").Id("new Request").Call(tsg.NewStatement().Id(tsLocalVar("url")), tsg.NewStatement().Values(func(vg *tsg.Group) {
 				vg.Add(tsg.NewStatement().Id("method").Colon().Lit(httpMethod))
@@ -256,7 +269,12 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *model.Method,
 			fetchOptsArg = fetchOptions
 		}
 		fetchStmt := tsg.NewStatement()
-		fetchStmt.Const(tsLocalVar("response")).Op("=").Await(tsg.NewStatement().Id("fetch").Call(tsg.NewStatement().Id(tsLocalVar("url")), fetchOptsArg))
+		fetchStmt.Const(tsLocalVar("response")).Op("=").Await(tsg.NewStatement().Id("this").Dot("baseClient").Dot("fetch").Call(
+			tsg.NewStatement().Id(tsLocalVar("url")),
+			fetchOptsArg,
+			tsg.NewStatement().Lit(idempotent || httpMethodIdempotent(httpMethod)),
+			tsg.NewStatement().Id("callOptions"),
+		))
 		mg.Add(fetchStmt.Semicolon())
 
 		successCode := 200
@@ -286,6 +304,16 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *model.Method,
 	grp.Line()
 }
 
+// httpMethodIdempotent: повторять при сбоях можно только идемпотентные HTTP-методы (RFC 9110).
+func httpMethodIdempotent(httpMethod string) (ok bool) {
+
+	switch httpMethod {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
 func (r *ClientRenderer) httpPath(method *model.Method, contract *model.Contract) (s string) {
 
 	return model.MethodHTTPPathValue(r.project, contract, method)
@@ -617,7 +645,13 @@ func (r *ClientRenderer) renderHTTPResponse(mg *tsg.Group, contract *model.Contr
 			mg.Add(tsg.NewStatement().Const(tsLocalVar("text")).Op("=").Await(tsg.NewStatement().Id(tsLocalVar("response")).Dot("text").Call()).Semicolon())
 			mg.Add(tsg.NewStatement().Var(tsLocalVar("responseData")).Colon().Id(responseTypeName).Op("=").Id("YAML").Dot("parse").Call(tsg.NewStatement().Id(tsLocalVar("text"))).Op("as").Id(responseTypeName).Semicolon())
 		default:
-			mg.Add(tsg.NewStatement().Var(tsLocalVar("responseData")).Colon().Id(responseTypeName).Op("=").Await(tsg.NewStatement().Id(tsLocalVar("response")).Dot("json").Call()).Op("as").Id(responseTypeName).Semicolon())
+			if _, ok := r.validatedResponseResults(contract, method); ok {
+				mg.Add(tsg.NewStatement().Const(tsLocalVar("decoded")).Colon().Id("unknown").Op("=").Await(tsg.NewStatement().Id(tsLocalVar("response")).Dot("json").Call()).Semicolon())
+				mg.Add(tsg.NewStatement().Id(r.validateResponseName(contract, method)).Call(tsg.NewStatement().Id(tsLocalVar("decoded"))).Semicolon())
+				mg.Add(tsg.NewStatement().Var(tsLocalVar("responseData")).Colon().Id(responseTypeName).Op("=").Id(tsLocalVar("decoded")).Op("as").Id(responseTypeName).Semicolon())
+			} else {
+				mg.Add(tsg.NewStatement().Var(tsLocalVar("responseData")).Colon().Id(responseTypeName).Op("=").Await(tsg.NewStatement().Id(tsLocalVar("response")).Dot("json").Call()).Op("as").Id(responseTypeName).Semicolon())
+			}
 		}
 	}
 	r.renderHTTPResponseMergeHeadersAndCookies(mg, contract, method, results, responseTypeName)
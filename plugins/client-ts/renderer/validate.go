@@ -0,0 +1,655 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"tgp/internal/content"
+	"tgp/internal/generated"
+	"tgp/internal/model"
+	"tgp/internal/tags"
+	"tgp/plugins/client-ts/tsg"
+)
+
+// validatorBuilder собирает функции проверки типов проекта: typeID -> имя функции, исходники в порядке регистрации.
+type validatorBuilder struct {
+	r       *ClientRenderer
+	names   map[string]string
+	taken   map[string]bool
+	sources []string
+}
+
+// RenderValidation записывает validate.ts с проверками ответов (и запросов при requests) и включает их вызов в клиентах.
+// Вызывается до RenderJsonRPCClientClass и RenderHTTPClientClass.
+func (r *ClientRenderer) RenderValidation(requests bool) (err error) {
+
+	r.validateResponses = true
+	r.validateRequests = requests
+
+	builder := &validatorBuilder{r: r, names: make(map[string]string), taken: make(map[string]bool)}
+	var methods []string
+	for _, contractName := range r.ContractKeys() {
+		contract := r.FindContract(contractName)
+		if contract == nil || !model.ContractIsHTTPFamily(r.project, contract) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if !r.methodIsJsonRPC(contract, method) && !r.methodIsHTTP(method, contract) {
+				continue
+			}
+			if args, ok := r.validatedRequestArgs(contract, method); ok {
+				methods = append(methods, builder.requestSource(contract, method, args))
+			}
+			if results, ok := r.validatedResponseResults(contract, method); ok {
+				methods = append(methods, builder.responseSource(contract, method, results))
+			}
+		}
+	}
+
+	file := tsg.NewFile()
+	file.Comment(generated.ByToolGatewayComment)
+	file.Line()
+	blocks := append([]string{validatorRuntime}, builder.sources...)
+	blocks = append(blocks, methods...)
+	file.Add(tsg.TypeFromString(strings.Join(blocks, "\n\n")))
+	file.Line()
+	return file.Save(path.Join(r.outDir, "validate.ts"))
+}
+
+func (r *ClientRenderer) validateRequestName(contract *model.Contract, method *model.Method) (s string) {
+	return "validate" + r.requestTypeName(contract, method)
+}
+
+func (r *ClientRenderer) validateResponseName(contract *model.Contract, method *model.Method) (s string) {
+	return "validate" + r.responseTypeName(contract, method)
+}
+
+// contractValidators — имена функций validate.ts, которые вызывают методы JSON-RPC (jsonRPC) или HTTP-клиента контракта.
+func (r *ClientRenderer) contractValidators(contract *model.Contract, jsonRPC bool) (names []string) {
+
+	for _, method := range contract.Methods {
+		if jsonRPC && !r.methodIsJsonRPC(contract, method) || !jsonRPC && !r.methodIsHTTP(method, contract) {
+			continue
+		}
+		if _, ok := r.validatedRequestArgs(contract, method); ok {
+			names = append(names, r.validateRequestName(contract, method))
+		}
+		if _, ok := r.validatedResponseResults(contract, method); ok {
+			names = append(names, r.validateResponseName(contract, method))
+		}
+	}
+	return
+}
+
+// validatedRequestArgs возвращает аргументы, которые клиент проверяет перед отправкой; ok=false — проверки нет.
+func (r *ClientRenderer) validatedRequestArgs(contract *model.Contract, method *model.Method) (args []*model.Variable, ok bool) {
+
+	if !r.validateRequests {
+		return nil, false
+	}
+	if r.methodIsJsonRPC(contract, method) {
+		args = r.argsForExchangeRequest(contract, method)
+	} else {
+		for _, arg := range r.argsForClient(contract, method) {
+			if arg.TypeID == TypeIDIOReader || arg.TypeID == TypeIDIOReadCloser || model.TypeRefIsChan(r.project, &arg.TypeRef) {
+				continue
+			}
+			args = append(args, arg)
+		}
+	}
+	return args, len(args) > 0
+}
+
+// validatedResponseResults возвращает результаты из тела ответа, которые клиент проверяет после декодирования.
+// HTTP-ответы проверяются только в JSON: multipart, потоки тела и другие форматы декодируются без схемы.
+func (r *ClientRenderer) validatedResponseResults(contract *model.Contract, method *model.Method) (results []*model.Variable, ok bool) {
+
+	if !r.validateResponses {
+		return nil, false
+	}
+	results = r.resultsWithoutError(method)
+	if r.methodIsJsonRPC(contract, method) {
+		return results, len(results) > 0
+	}
+	if len(results) == 0 || r.methodResponseMultipart(contract, method) || r.methodResponseBodyStreamResult(method) != nil {
+		return nil, false
+	}
+	if content.Kind(model.GetAnnotationValue(r.project, contract, method, nil, model.TagResponseContentType, "application/json")) != content.KindJSON {
+		return nil, false
+	}
+	results = model.HTTPResultsForExchangeBody(r.project, contract, method)
+	return results, len(results) > 0
+}
+
+func (b *validatorBuilder) requestSource(contract *model.Contract, method *model.Method, args []*model.Variable) (source string) {
+
+	jsonRPC := b.r.methodIsJsonRPC(contract, method)
+	nullable := model.IsAnnotationSet(b.r.project, contract, method, nil, "nullable")
+	fields := make([]string, 0, len(args))
+	for _, arg := range args {
+		key := tsSafeName(arg.Name)
+		if jsonRPC {
+			key = arg.Name
+		}
+		check := b.valueCheck(&arg.TypeRef, methodVarTags(method, arg))
+		fields = append(fields, validatorField(key, check, arg.NumberOfPointers == 0 && !nullable))
+	}
+	return fmt.Sprintf("// Validates the request of %s.%s before it is sent.\nexport function %s(value: unknown): void {\n    validate(%q, \"request\", %s, value);\n}",
+		contract.Name, method.Name, b.r.validateRequestName(contract, method), contract.Name+"."+method.Name, validatorObject(fields, nil))
+}
+
+func (b *validatorBuilder) responseSource(contract *model.Contract, method *model.Method, results []*model.Variable) (source string) {
+
+	return fmt.Sprintf("// Validates the decoded response of %s.%s.\nexport function %s(value: unknown): void {\n    validate(%q, \"response\", %s, value);\n}",
+		contract.Name, method.Name, b.r.validateResponseName(contract, method), contract.Name+"."+method.Name, b.responseCheck(contract, method, results))
+}
+
+// responseCheck повторяет форму тела ответа из renderExchangeResponseType: значение единственного inline-результата
+// или объект по именам результатов, в который inline-результаты встраивают свои поля.
+func (b *validatorBuilder) responseCheck(contract *model.Contract, method *model.Method, results []*model.Variable) (expr string) {
+
+	inlineSingle := model.IsAnnotationSet(b.r.project, contract, method, nil, model.TagHttpEnableInlineSingle)
+	if len(results) == 1 {
+		ret := results[0]
+		if inlineSingle || model.ResultFieldEmbedded(b.r.project, contract, method, ret) || (b.r.methodIsHTTP(method, contract) && b.r.resultHasJsonInline(method, ret)) {
+			return b.valueCheck(&ret.TypeRef, methodVarTags(method, ret))
+		}
+	}
+	nullable := model.IsAnnotationSet(b.r.project, contract, method, nil, "nullable")
+	var fields, embedded []string
+	for _, ret := range results {
+		varTags := methodVarTags(method, ret)
+		if len(results) > 1 && b.r.resultHasJsonInline(method, ret) {
+			embedded = append(embedded, b.valueCheck(&ret.TypeRef, varTags))
+			continue
+		}
+		required := ret.NumberOfPointers == 0 && !nullable && !jsonTagHasOmitempty(varTags["json"])
+		fields = append(fields, validatorField(ret.Name, b.valueCheck(&ret.TypeRef, varTags), required))
+	}
+	return validatorObject(fields, embedded)
+}
+
+// valueCheck возвращает выражение проверки значения; указатели, slice и map допускают null.
+func (b *validatorBuilder) valueCheck(typeRef *model.TypeRef, varTags map[string]string) (expr string) {
+
+	expr, nullable := b.typeCheck(typeRef, varTags)
+	if expr != "v.any()" && (nullable || typeRef.NumberOfPointers > 0) {
+		return "v.nullable(" + expr + ")"
+	}
+	return expr
+}
+
+func (b *validatorBuilder) typeCheck(typeRef *model.TypeRef, varTags map[string]string) (expr string, nullable bool) {
+
+	if typeRef.IsSlice || typeRef.ArrayLen > 0 {
+		return b.listCheck(typeRef.TypeID, typeRef.ElementPointers), typeRef.IsSlice
+	}
+	if typeRef.MapKey != nil && typeRef.MapValue != nil {
+		return "v.record(" + b.valueCheck(typeRef.MapValue, nil) + ")", true
+	}
+	return b.namedCheck(typeRef.TypeID, varTags)
+}
+
+func (b *validatorBuilder) listCheck(itemTypeID string, itemPointers int) (expr string) {
+
+	// []byte кодируется строкой base64, а в TS описан массивом чисел — форму не проверяем.
+	if itemTypeID == "byte" || itemTypeID == "uint8" {
+		return "v.any()"
+	}
+	item := b.valueCheck(&model.TypeRef{TypeID: itemTypeID, NumberOfPointers: itemPointers}, nil)
+	return "v.array(" + item + ")"
+}
+
+func (b *validatorBuilder) namedCheck(typeID string, varTags map[string]string) (expr string, nullable bool) {
+
+	typ, ok := b.r.project.Types[typeID]
+	if !ok {
+		return b.builtinCheck(typeID, varTags), false
+	}
+	if len(typ.Enums) > 0 && typ.TypeName != "" {
+		return b.enumCheck(typeID, typ), false
+	}
+	if _, okBuiltin := b.r.goBuiltinTSType(typeID, typ); okBuiltin {
+		base := b.r.followAliasChain(typ)
+		return b.builtinCheck(base.ImportPkgPath+":"+base.TypeName, varTags), false
+	}
+	if b.r.isExplicitlyExcludedType(typ) || b.r.hasMarshaler(typ, true) || b.r.hasMarshaler(typ, false) {
+		return "v.any()", false
+	}
+	switch typ.Kind {
+	case model.TypeKindAlias:
+		if typ.AliasOf == "" {
+			return "v.any()", true
+		}
+		return b.namedCheck(typ.AliasOf, varTags)
+	case model.TypeKindString:
+		return stringCheck(varTags), false
+	case model.TypeKindInt, model.TypeKindInt8, model.TypeKindInt16, model.TypeKindInt32, model.TypeKindInt64,
+		model.TypeKindUint, model.TypeKindUint8, model.TypeKindUint16, model.TypeKindUint32, model.TypeKindUint64,
+		model.TypeKindByte, model.TypeKindRune:
+		return numberCheck("v.integer()", varTags), false
+	case model.TypeKindFloat32, model.TypeKindFloat64:
+		return numberCheck("v.number()", varTags), false
+	case model.TypeKindBool:
+		return "v.boolean()", false
+	case model.TypeKindStruct:
+		return b.structCheck(typeID, typ), false
+	case model.TypeKindArray:
+		return b.listCheck(typ.ArrayOfID, typ.ElementPointers), typ.IsSlice || typ.ArrayLen == 0
+	case model.TypeKindMap:
+		valueRef := typ.MapValue
+		if valueRef == nil {
+			valueRef = &model.TypeRef{TypeID: "any"}
+		}
+		return "v.record(" + b.valueCheck(valueRef, nil) + ")", true
+	default:
+		return "v.any()", true
+	}
+}
+
+// builtinCheck проверяет встроенные типы Go и внешние типы с известным форматом JSON (time.Time, uuid).
+func (b *validatorBuilder) builtinCheck(typeID string, varTags map[string]string) (expr string) {
+
+	switch strings.TrimPrefix(typeID, "builtin:") {
+	case "string":
+		return stringCheck(varTags)
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "rune":
+		return numberCheck("v.integer()", varTags)
+	case "float32", "float64":
+		return numberCheck("v.number()", varTags)
+	case "bool":
+		return "v.boolean()"
+	}
+	pkgSeg, typeName := keyFromTypeID(typeID)
+	tsType, ok := lookupBuiltinTS("/"+pkgSeg, typeName)
+	if !ok {
+		return "v.any()"
+	}
+	switch {
+	case tsType == "Date":
+		return stringCheck(withDefaultFormat(varTags, "date-time"))
+	case pkgSeg == "time" && typeName == "Duration":
+		return "v.integer()"
+	case tsType == "string":
+		return stringCheck(withDefaultFormat(varTags, "uuid"))
+	}
+	return "v.any()"
+}
+
+func (b *validatorBuilder) enumCheck(typeID string, typ *model.Type) (name string) {
+
+	if name, ok := b.names[typeID]; ok {
+		return name
+	}
+	name = b.checkName(typ, typeID)
+	b.names[typeID] = name
+	numeric := isTSEnumNumeric(typ)
+	values := make([]string, 0, len(typ.Enums))
+	for _, item := range typ.Enums {
+		if item == nil {
+			continue
+		}
+		if numeric {
+			values = append(values, item.Value)
+			continue
+		}
+		values = append(values, strconv.Quote(item.Value))
+	}
+	b.sources = append(b.sources, fmt.Sprintf("// Checks %s: one of the enum values.\nexport function %s(value: unknown, path: string): void {\n    v.oneOf([%s])(value, path);\n}",
+		validatorTitle(typ), name, strings.Join(values, ", ")))
+	return name
+}
+
+// structCheck регистрирует функцию проверки структуры до обхода полей, чтобы рекурсивные типы ссылались на неё.
+func (b *validatorBuilder) structCheck(typeID string, typ *model.Type) (name string) {
+
+	if name, ok := b.names[typeID]; ok {
+		return name
+	}
+	name = b.checkName(typ, typeID)
+	b.names[typeID] = name
+
+	var fields, embedded []string
+	seen := make(map[string]bool)
+	for _, field := range typ.StructFields {
+		fieldName, inline := b.r.jsonName(field)
+		if fieldName == "" || fieldName == "-" {
+			continue
+		}
+		fieldTags := parseTagsFromDocs(field.Docs)
+		for key, value := range field.Annotations {
+			fieldTags[key] = value
+		}
+		if inline {
+			embedded = append(embedded, b.valueCheck(&field.TypeRef, fieldTags))
+			continue
+		}
+		if seen[fieldName] {
+			continue
+		}
+		seen[fieldName] = true
+		_, required := fieldTags[tagRequired]
+		fields = append(fields, validatorField(fieldName, b.valueCheck(&field.TypeRef, fieldTags), required))
+	}
+	b.sources = append(b.sources, fmt.Sprintf("// Checks %s.\nexport function %s(value: unknown, path: string): void {\n    %s(value, path);\n}",
+		validatorTitle(typ), name, validatorObject(fields, embedded)))
+	return name
+}
+
+func validatorTitle(typ *model.Type) (title string) {
+
+	if typ.ImportPkgPath == "" {
+		return typ.TypeName
+	}
+	return fmt.Sprintf("%s (%s)", typ.TypeName, typ.ImportPkgPath)
+}
+
+// checkName — check<Пакет><Тип>; совпадающие имена типов разных пакетов получают числовой суффикс.
+func (b *validatorBuilder) checkName(typ *model.Type, typeID string) (name string) {
+
+	pkgName := typ.PkgName
+	if pkgName == "" {
+		pkgName = typ.ImportAlias
+	}
+	if pkgName == "" {
+		pkgName = lastSegment(typ.ImportPkgPath)
+	}
+	typeName := typ.TypeName
+	if typeName == "" {
+		_, typeName = keyFromTypeID(typeID)
+	}
+	base := "check" + tsIdentifier(pkgName) + tsIdentifier(typeName)
+	name = base
+	for i := 2; b.taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	b.taken[name] = true
+	return name
+}
+
+// tsIdentifier оставляет буквы, цифры и _ и поднимает регистр первых букв слов: "go.uuid" -> "GoUuid", "Page[T]" -> "PageT".
+func tsIdentifier(s string) (out string) {
+
+	var b strings.Builder
+	upper := true
+	for _, ch := range s {
+		isLetter := ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+		if !isLetter && ch != '_' && (ch < '0' || ch > '9') {
+			upper = true
+			continue
+		}
+		if upper && ch >= 'a' && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+func stringCheck(varTags map[string]string) (expr string) {
+
+	if enums := varTags["enums"]; enums != "" {
+		values := strings.Split(enums, ",")
+		for i, value := range values {
+			values[i] = strconv.Quote(strings.TrimSpace(value))
+		}
+		return "v.oneOf([" + strings.Join(values, ", ") + "])"
+	}
+	if format := varTags["format"]; format != "" {
+		return fmt.Sprintf("v.string(%q)", format)
+	}
+	return "v.string()"
+}
+
+func numberCheck(base string, varTags map[string]string) (expr string) {
+
+	enums := varTags["enums"]
+	if enums == "" {
+		return base
+	}
+	values := strings.Split(enums, ",")
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return base
+		}
+		values[i] = value
+	}
+	return "v.oneOf([" + strings.Join(values, ", ") + "])"
+}
+
+func withDefaultFormat(varTags map[string]string, format string) (out map[string]string) {
+
+	out = map[string]string{"format": format}
+	for key, value := range varTags {
+		out[key] = value
+	}
+	return
+}
+
+// methodVarTags объединяет теги переменной из аннотаций метода (json, format, enums) с её собственными аннотациями.
+func methodVarTags(method *model.Method, variable *model.Variable) (out map[string]string) {
+
+	out = tags.ParseMethodVarTags(method.Annotations, variable.Name)
+	for key, value := range variable.Annotations {
+		out[key] = value
+	}
+	return
+}
+
+func jsonTagHasOmitempty(jsonTag string) (ok bool) {
+
+	for _, option := range strings.Split(jsonTag, ",")[1:] {
+		if strings.TrimSpace(option) == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
+
+func validatorField(key string, check string, required bool) (line string) {
+
+	wrapper := "v.optional"
+	if required {
+		wrapper = "v.required"
+	}
+	return fmt.Sprintf("%s: %s(%s)", tsPropertyKey(key), wrapper, check)
+}
+
+// validatorObject — v.object с полями по строке; выражение стоит в теле функции с отступом в четыре пробела.
+func validatorObject(fields []string, embedded []string) (expr string) {
+
+	var b strings.Builder
+	b.WriteString("v.object({")
+	if len(fields) > 0 {
+		b.WriteString("\n")
+		for _, field := range fields {
+			b.WriteString("        " + field + ",\n")
+		}
+		b.WriteString("    ")
+	}
+	b.WriteString("}")
+	for _, check := range embedded {
+		b.WriteString(", ")
+		b.WriteString(check)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// tsPropertyKey оставляет ключ-идентификатор без кавычек, остальные ключи JSON берёт в кавычки.
+func tsPropertyKey(key string) (out string) {
+
+	if key == "" {
+		return `""`
+	}
+	for i, ch := range key {
+		isLetter := ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+		if !isLetter && ch != '_' && ch != '$' && (i == 0 || ch < '0' || ch > '9') {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
+
+const validatorRuntime = `// Check validates a decoded value found at path and throws on mismatch.
+export type Check = (value: unknown, path: string) => void;
+
+type Field = { check: Check; required: boolean };
+
+// ValidationError reports a request or response that does not match the contract schema.
+export class ValidationError extends Error {
+    readonly method: string;
+    readonly direction: "request" | "response";
+    readonly path: string;
+    readonly reason: string;
+
+    constructor(method: string, direction: "request" | "response", path: string, reason: string) {
+        super(` + "`${method} ${direction}: ${path}: ${reason}`" + `);
+        this.name = "ValidationError";
+        this.method = method;
+        this.direction = direction;
+        this.path = path;
+        this.reason = reason;
+    }
+}
+
+class ValidationFailure {
+    readonly path: string;
+    readonly reason: string;
+
+    constructor(path: string, reason: string) {
+        this.path = path;
+        this.reason = reason;
+    }
+}
+
+function fail(path: string, reason: string): never {
+    throw new ValidationFailure(path, reason);
+}
+
+function describe(value: unknown): string {
+    if (value === null) {
+        return "null";
+    }
+    if (Array.isArray(value)) {
+        return "array";
+    }
+    if (typeof value === "number" || typeof value === "boolean") {
+        return String(value);
+    }
+    return typeof value;
+}
+
+function member(path: string, key: string): string {
+    return /^[A-Za-z_$][A-Za-z0-9_$]*$/.test(key) ? ` + "`${path}.${key}`" + ` : ` + "`${path}[${JSON.stringify(key)}]`" + `;
+}
+
+function isObject(value: unknown): value is Record<string, unknown> {
+    return typeof value === "object" && value !== null && !Array.isArray(value);
+}
+
+const formats: Record<string, RegExp> = {
+    "date-time": /^\d{4}-\d{2}-\d{2}[Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})$/,
+    "date": /^\d{4}-\d{2}-\d{2}$/,
+    "uuid": /^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$/,
+    "email": /^[^\s@]+@[^\s@]+$/,
+    "uri": /^[A-Za-z][A-Za-z0-9+.-]*:\S*$/,
+};
+
+// Checks for every shape the generator emits; unknown formats only check the string type.
+const v = {
+    any: (): Check => () => undefined,
+    string: (format?: string): Check => (value, path) => {
+        if (format === "date-time" && value instanceof Date) {
+            if (Number.isNaN(value.getTime())) {
+                fail(path, "expected a valid date");
+            }
+            return;
+        }
+        if (typeof value !== "string") {
+            fail(path, ` + "`expected string, got ${describe(value)}`" + `);
+        }
+        const pattern = format === undefined ? undefined : formats[format];
+        if (pattern !== undefined && !pattern.test(value)) {
+            fail(path, ` + "`expected ${format} string, got ${JSON.stringify(value)}`" + `);
+        }
+    },
+    integer: (): Check => (value, path) => {
+        if (typeof value !== "number" || !Number.isInteger(value)) {
+            fail(path, ` + "`expected integer, got ${describe(value)}`" + `);
+        }
+    },
+    number: (): Check => (value, path) => {
+        if (typeof value !== "number") {
+            fail(path, ` + "`expected number, got ${describe(value)}`" + `);
+        }
+    },
+    boolean: (): Check => (value, path) => {
+        if (typeof value !== "boolean") {
+            fail(path, ` + "`expected boolean, got ${describe(value)}`" + `);
+        }
+    },
+    oneOf: (values: readonly (string | number)[]): Check => (value, path) => {
+        if (!values.includes(value as string | number)) {
+            fail(path, ` + "`expected one of ${values.map((item) => JSON.stringify(item)).join(\", \")}, got ${JSON.stringify(value) ?? describe(value)}`" + `);
+        }
+    },
+    nullable: (check: Check): Check => (value, path) => {
+        if (value !== null && value !== undefined) {
+            check(value, path);
+        }
+    },
+    array: (item: Check): Check => (value, path) => {
+        if (!Array.isArray(value)) {
+            fail(path, ` + "`expected array, got ${describe(value)}`" + `);
+        }
+        value.forEach((element, index) => item(element, ` + "`${path}[${index}]`" + `));
+    },
+    record: (item: Check): Check => (value, path) => {
+        if (!isObject(value)) {
+            fail(path, ` + "`expected object, got ${describe(value)}`" + `);
+        }
+        for (const [key, element] of Object.entries(value)) {
+            item(element, member(path, key));
+        }
+    },
+    required: (check: Check): Field => ({ check, required: true }),
+    optional: (check: Check): Field => ({ check, required: false }),
+    // Unknown keys are allowed; embedded checks validate fields of inlined structs on the same object.
+    object: (fields: Record<string, Field>, ...embedded: Check[]): Check => (value, path) => {
+        if (!isObject(value)) {
+            fail(path, ` + "`expected object, got ${describe(value)}`" + `);
+        }
+        for (const [key, field] of Object.entries(fields)) {
+            const fieldValue = value[key];
+            if (fieldValue === undefined) {
+                if (field.required) {
+                    fail(member(path, key), "required field is missing");
+                }
+                continue;
+            }
+            field.check(fieldValue, member(path, key));
+        }
+        for (const check of embedded) {
+            check(value, path);
+        }
+    },
+};
+
+// Runs check on value and reports the first mismatch as ValidationError with the method name and JSON path.
+export function validate(method: string, direction: "request" | "response", check: Check, value: unknown): void {
+    try {
+        check(value, "$");
+    } catch (e) {
+        if (e instanceof ValidationFailure) {
+            throw new ValidationError(method, direction, e.path, e.reason);
+        }
+        throw e;
+    }
+}`
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func validationTestProject() (project *model.Project) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Types: map[string]*model.Type{
+			"example/dto:User": {
+				Kind: model.TypeKindStruct, TypeName: "User", ImportPkgPath: "example/dto", PkgName: "dto",
+				StructFields: []*model.StructField{
+					{Name: "ID", TypeRef: model.TypeRef{TypeID: "github.com/google/uuid:UUID"}, Tags: map[string][]string{"json": {"id"}}},
+					{Name: "Age", TypeRef: model.TypeRef{TypeID: "int", NumberOfPointers: 1}, Tags: map[string][]string{"json": {"age", "omitempty"}}},
+					{Name: "Role", TypeRef: model.TypeRef{TypeID: "example/dto:Role"}, Tags: map[string][]string{"json": {"role"}}},
+					{Name: "CreatedAt", TypeRef: model.TypeRef{TypeID: "time:Time"}, Tags: map[string][]string{"json": {"created-at"}}},
+					{Name: "Email", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"email", "omitempty"}}, Annotations: tags.DocTags{"format": "email", "required": ""}},
+					{Name: "Friends", TypeRef: model.TypeRef{TypeID: "example/dto:User", IsSlice: true}, Tags: map[string][]string{"json": {"friends"}}},
+					{Name: "secret", TypeRef: model.TypeRef{TypeID: "string"}},
+				},
+			},
+			"example/dto:Role": {
+				Kind: model.TypeKindString, TypeName: "Role", ImportPkgPath: "example/dto", PkgName: "dto",
+				Enums: []*model.EnumValue{{Name: "RoleAdmin", Value: "admin"}, {Name: "RoleUser", Value: "user"}},
+			},
+			"time:Time": {Kind: model.TypeKindStruct, TypeName: "Time", ImportPkgPath: "time"},
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:        "Users",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerJsonRPC: ""},
+				Methods: []*model.Method{
+					{
+						Name:    "Get",
+						Args:    []*model.Variable{ctx, {Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results: []*model.Variable{{Name: "user", TypeRef: model.TypeRef{TypeID: "example/dto:User"}}, errResult},
+					},
+				},
+			},
+			{
+				Name:        "Items",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagHttpPrefix: "api/v1"},
+				Methods: []*model.Method{
+					{
+						Name:        "Create",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "POST", model.TagHttpPath: "/items"},
+						Args:        []*model.Variable{ctx, {Name: "name", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results:     []*model.Variable{{Name: "id", TypeRef: model.TypeRef{TypeID: "int64"}}, errResult},
+					},
+					{
+						Name:        "Export",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/items/export", model.TagResponseContentType: "application/xml"},
+						Args:        []*model.Variable{ctx},
+						Results:     []*model.Variable{{Name: "names", TypeRef: model.TypeRef{TypeID: "string", IsSlice: true}}, errResult},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderValidation_checksPerTypeAndMethod(t *testing.T) {
+
+	dir := t.TempDir()
+	renderer := NewClientRenderer(validationTestProject(), dir, false, "", true)
+	if err := renderer.RenderValidation(true); err != nil {
+		t.Fatalf("RenderValidation: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "validate.ts"))
+	if err != nil {
+		t.Fatalf("read validate.ts: %v", err)
+	}
+	source := string(content)
+	for _, want := range []string{
+		`export class ValidationError extends Error {`,
+		`export function checkDtoRole(value: unknown, path: string): void {`,
+		`v.oneOf(["admin", "user"])(value, path);`,
+		`export function checkDtoUser(value: unknown, path: string): void {`,
+		`id: v.optional(v.string("uuid")),`,
+		`age: v.optional(v.nullable(v.integer())),`,
+		`role: v.optional(checkDtoRole),`,
+		`"created-at": v.optional(v.string("date-time")),`,
+		`email: v.required(v.string("email")),`,
+		`friends: v.optional(v.nullable(v.array(checkDtoUser))),`,
+		`validate("Users.Get", "request", v.object({`,
+		`validate("Users.Get", "response", v.object({`,
+		`user: v.required(checkDtoUser),`,
+		`export function validateRequestItemsCreate(value: unknown): void {`,
+		`id: v.required(v.integer()),`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("validate.ts missing %q:\n%s", want, source)
+		}
+	}
+	for _, unwanted := range []string{"secret", "validateResponseItemsExport"} {
+		if strings.Contains(source, unwanted) {
+			t.Fatalf("validate.ts must not contain %q:\n%s", unwanted, source)
+		}
+	}
+}
+
+func TestRenderValidation_clientsCallValidators(t *testing.T) {
+
+	dir := t.TempDir()
+	project := validationTestProject()
+	renderer := NewClientRenderer(project, dir, false, "", true)
+	if err := renderer.RenderValidation(false); err != nil {
+		t.Fatalf("RenderValidation: %v", err)
+	}
+	if err := renderer.RenderJsonRPCClientClass(project.Contracts[0]); err != nil {
+		t.Fatalf("RenderJsonRPCClientClass: %v", err)
+	}
+	if err := renderer.RenderHTTPClientClass(project.Contracts[1]); err != nil {
+		t.Fatalf("RenderHTTPClientClass: %v", err)
+	}
+
+	for file, wants := range map[string][]string{
+		"users.ts": {
+			`import {validateResponseUsersGet} from './validate';`,
+			`validateResponseUsersGet(execResult.result);`,
+			`validateResponseUsersGet(rpcResponse.result);`,
+		},
+		"items-http.ts": {
+			`import {validateResponseItemsCreate} from './validate';`,
+			`const _decoded_:unknown = await _response_.json();`,
+			`validateResponseItemsCreate(_decoded_);`,
+		},
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		source := string(content)
+		for _, want := range wants {
+			if !strings.Contains(source, want) {
+				t.Fatalf("%s missing %q:\n%s", file, want, source)
+			}
+		}
+		if strings.Contains(source, "validateRequest") {
+			t.Fatalf("%s must not validate requests without validate-requests:\n%s", file, source)
+		}
+	}
+}
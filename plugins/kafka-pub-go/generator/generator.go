@@ -12,7 +12,7 @@ import (
 )
 
 // Generate проверяет модель и генерирует Kafka-издатель.
-func Generate(project *model.Project, outDir string, targetModulePath string, outputRelPath string) (err error) {
+func Generate(project *model.Project, outDir string, targetModulePath string, outputRelPath string, kafkaTest bool) (err error) {
 
 	if err = validate.Project(project); err != nil {
 		return fmt.Errorf("invalid project: %w", err)
@@ -42,6 +42,11 @@ func Generate(project *model.Project, outDir string, targetModulePath string, ou
 	if err = r.Render(); err != nil {
 		return fmt.Errorf("render kafka publisher: %w", err)
 	}
+	if kafkaTest {
+		if err = r.RenderKafkaTest(); err != nil {
+			return fmt.Errorf("render kafkatest: %w", err)
+		}
+	}
 	return nil
 }
 
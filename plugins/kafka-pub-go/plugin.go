@@ -61,12 +61,13 @@ func (p *KafkaPubGoPlugin) Execute(request data.Storage) (response data.Storage,
 	if filter, err = helper.ParseStringList(request, "contracts"); err != nil {
 		return nil, fmt.Errorf("failed to parse contracts: %w", err)
 	}
+	kafkaTest, _ := data.Get[bool](request, "kafkatest")
 	filtered := *project
 	filtered.Contracts = helper.FilterContracts(project, filter)
 	if err = cleanup.GeneratedFiles(output); err != nil {
 		return nil, fmt.Errorf("cleanup generated files: %w", err)
 	}
-	if err = generator.Generate(&filtered, output, targetModulePath, outputRelPath); err != nil {
+	if err = generator.Generate(&filtered, output, targetModulePath, outputRelPath, kafkaTest); err != nil {
 		return nil, fmt.Errorf("generate kafka-pub-go: %w", err)
 	}
 	return response, nil
@@ -87,8 +88,12 @@ func (p *KafkaPubGoPlugin) Info() (info plugin.Info, err error) {
 			Description: i18n.Msg("Generate Kafka Go publisher"),
 			Options: []plugin.Option{
 				{Name: "contracts-dir", Type: "string", Description: i18n.Msg("Path to contracts folder (relative to rootDir)"), Default: "contracts"},
+				{Name: "contracts-recursive", Type: "bool", Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"), Default: false},
+				{Name: "contracts-include", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)")},
+				{Name: "contracts-exclude", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)")},
 				{Name: "out", Type: "string", Description: i18n.Msg("Path to output directory (package name = basename, e.g. internal/kafka)"), Required: true},
 				{Name: "contracts", Type: "string", Description: i18n.Msg("Comma-separated list of contracts for filtering (e.g., \"Contract1,Contract2\")")},
+				{Name: "kafkatest", Type: "bool", Description: i18n.Msg("Generate kafkatest package with in-memory broker for tests"), Default: false},
 			},
 		}},
 		AllowedEnvVars: []string{"GOPATH", "GOROOT", "GOMODCACHE"},
@@ -64,6 +64,8 @@ func (r *Renderer) batchAdapterBody(contract *model.Contract, method *model.Meth
 			r.addRecordBuild(group, contract, method, jen.Id("message"), jen.Id("index"), jen.Len(jen.Id(messageName)))
 			group.Id("records").Op("=").Append(jen.Id("records"), jen.Id("record"))
 		}),
+		r.outboxCode(jen.Id("records").Op("...")),
+		r.producerCode(jen.Id("records")),
 		jen.Var().Id("client").Op("*").Qual(kgoPath, "Client"),
 		jen.If(jen.List(jen.Id("client"), jen.Id("err")).Op("=").Id("adapter").Dot("client").Dot("kafkaClient").Call(jen.Lit(model.MethodKafkaAcks(r.project, contract, method))), jen.Id("err").Op("!=").Nil()).Block(jen.Return()),
 	)
@@ -83,6 +85,8 @@ func (r *Renderer) singleAdapterBody(contract *model.Contract, method *model.Met
 	body = append(body, jen.Id("codec").Op(":=").Id("adapter").Dot("client").Dot("codecs").Index(jen.Lit(model.MethodKafkaCodec(r.project, contract, method))))
 	body = append(body, r.recordBuildCodes(contract, method, jen.Id(messageName), jen.Lit(0), jen.Lit(1))...)
 	body = append(body,
+		r.outboxCode(jen.Id("record")),
+		r.producerCode(jen.Index().Op("*").Qual(kgoPath, "Record").Values(jen.Id("record"))),
 		jen.Var().Id("client").Op("*").Qual(kgoPath, "Client"),
 		jen.If(jen.List(jen.Id("client"), jen.Id("err")).Op("=").Id("adapter").Dot("client").Dot("kafkaClient").Call(jen.Lit(model.MethodKafkaAcks(r.project, contract, method))), jen.Id("err").Op("!=").Nil()).Block(jen.Return()),
 	)
@@ -96,11 +100,27 @@ func (r *Renderer) singleAdapterBody(contract *model.Contract, method *model.Met
 	return body
 }
 
+// outboxCode сохраняет готовые записи в outbox, если адаптер вызван через Client.Outbox.
+func (r *Renderer) outboxCode(records jen.Code) (code jen.Code) {
+
+	return jen.If(jen.Id("adapter").Dot("client").Dot("outboxTx").Op("!=").Nil()).Block(
+		jen.Return(jen.Id("outboxInsert").Call(jen.Id("ctx"), jen.Id("adapter").Dot("client").Dot("outboxTx"), jen.Id("adapter").Dot("client").Dot("outbox"), records)),
+	)
+}
+
+// producerCode передаёт готовые записи функции Producer вместо клиента franz-go.
+func (r *Renderer) producerCode(records jen.Code) (code jen.Code) {
+
+	return jen.If(jen.Id("adapter").Dot("client").Dot("producer").Op("!=").Nil()).Block(
+		jen.Return(jen.Id("adapter").Dot("client").Dot("producer").Call(jen.Id("ctx"), records)),
+	)
+}
+
 func (r *Renderer) recordBuildCodes(contract *model.Contract, method *model.Method, value jen.Code, index jen.Code, records jen.Code) (codes []jen.Code) {
 
 	codes = append(codes,
 		jen.Var().Id("data").Index().Byte(),
-		jen.If(jen.List(jen.Id("data"), jen.Id("err")).Op("=").Id("codec").Dot("Marshal").Call(value), jen.Id("err").Op("!=").Nil()).BlockFunc(func(block *jen.Group) {
+		jen.If(jen.List(jen.Id("data"), jen.Id("err")).Op("=").Add(r.marshalCode(contract, method, value)), jen.Id("err").Op("!=").Nil()).BlockFunc(func(block *jen.Group) {
 			r.addEncodeFailure(block, contract, method, records)
 			block.Return()
 		}),
@@ -201,6 +221,13 @@ func (r *Renderer) typeCode(reference *model.TypeRef, ellipsis bool) (result jen
 		return prefix.Index().Byte()
 	}
 	if typ := r.project.Types[reference.TypeID]; typ != nil && typ.ImportPkgPath != "" {
+		if _, originName, found := strings.Cut(typ.GenericOf, ":"); found {
+			typeArgs := make([]jen.Code, 0, len(typ.TypeArgs))
+			for _, typeArg := range typ.TypeArgs {
+				typeArgs = append(typeArgs, r.typeCode(typeArg, false))
+			}
+			return prefix.Qual(typ.ImportPkgPath, originName).Types(typeArgs...)
+		}
 		return prefix.Qual(typ.ImportPkgPath, typ.TypeName)
 	}
 	if path, name, found := strings.Cut(reference.TypeID, ":"); found {
@@ -208,3 +235,13 @@ func (r *Renderer) typeCode(reference *model.TypeRef, ellipsis bool) (result jen
 	}
 	return prefix.Id(reference.TypeID)
 }
+
+func (r *Renderer) marshalCode(contract *model.Contract, method *model.Method, value jen.Code) (code jen.Code) {
+
+	switch model.MethodKafkaCodec(r.project, contract, method) {
+	case model.KafkaCodecAvro, model.KafkaCodecProto:
+		return jen.Id("marshalTopic").Call(jen.Id("ctx"), jen.Id("codec"), jen.Lit(model.MethodKafkaTopic(r.project, contract, method)), value)
+	default:
+		return jen.Id("codec").Dot("Marshal").Call(value)
+	}
+}
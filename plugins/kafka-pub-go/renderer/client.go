@@ -29,12 +29,20 @@ func (r *Renderer) renderClient() (err error) {
 		if r.hasAnnotation(model.TagTrace) {
 			group.Id("tracer").Qual(tracePath, "Tracer")
 		}
+		group.Id("transactional").Op("*").Qual(kgoPath, "Client")
+		group.Id("txMu").Qual("sync", "Mutex")
+		group.Id("txClient").Op("*").Qual(kgoPath, "Client")
+		group.Id("outbox").Id("outboxConfig")
+		group.Id("outboxTx").Op("*").Qual("database/sql", "Tx")
+		group.Id("producer").Id("producer")
 	})
 	source.Line()
 	r.addNewClient(source, acks)
 	r.addClose(source, acks)
 	r.addKafkaClientGetter(source, acks)
 	r.addKafkaClientConstructor(source)
+	r.addTransaction(source)
+	r.addOutbox(source)
 	r.addRequiredCodecs(source)
 	return source.Save(filepath.Join(r.outDir, "client.go"))
 }
@@ -48,10 +56,13 @@ func (r *Renderer) addNewClient(source *GoFile, acks []string) {
 			jen.If(jen.Id("option").Op("==").Nil()).Block(jen.Continue()),
 			jen.If(jen.Id("err").Op("=").Id("option").Call(jen.Op("&").Id("setup")), jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
 		),
-		jen.If(jen.Len(jen.Id("setup").Dot("brokers")).Op("==").Lit(0)).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka publisher brokers are required")))),
+		jen.If(jen.Len(jen.Id("setup").Dot("brokers")).Op("==").Lit(0).Op("&&").Id("setup").Dot("producer").Op("==").Nil()).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka publisher brokers are required")))),
 		jen.If(jen.Id("err").Op("=").Id("validateSecurity").Call(jen.Id("setup")), jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
 		jen.If(jen.Len(jen.Id("setup").Dot("compression")).Op("==").Lit(0)).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka publisher compression requires at least one codec")))),
 		jen.Id("codecs").Op(":=").Id("defaultCodecs").Call(),
+	}
+	body = append(body, r.schemaCodecs()...)
+	body = append(body,
 		jen.For(jen.List(jen.Id("name"), jen.Id("value")).Op(":=").Range().Id("setup").Dot("codecs")).Block(
 			jen.If(jen.Id("value").Op("==").Nil()).Block(jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("kafka codec %q is nil"), jen.Id("name")))),
 			jen.Id("codecs").Index(jen.Id("name")).Op("=").Id("value"),
@@ -60,7 +71,7 @@ func (r *Renderer) addNewClient(source *GoFile, acks []string) {
 			jen.If(jen.Id("codecs").Index(jen.Id("name")).Op("==").Nil()).Block(jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("kafka codec %q is not registered"), jen.Id("name")))),
 		),
 		jen.Id("client").Op("=").Op("&").Id("Client").Values(jen.Dict{jen.Id("log"): jen.Id("log"), jen.Id("codecs"): jen.Id("codecs")}),
-	}
+	)
 	if r.hasAnnotation(model.TagMetrics) {
 		body = append(body, jen.If(jen.Id("setup").Dot("metrics").Op("!=").Nil()).Block(
 			jen.If(jen.List(jen.Id("client").Dot("metrics"), jen.Id("err")).Op("=").Id("newMetrics").Call(jen.Id("setup").Dot("metrics")), jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
@@ -69,24 +80,56 @@ func (r *Renderer) addNewClient(source *GoFile, acks []string) {
 	if r.hasAnnotation(model.TagTrace) {
 		body = append(body, jen.Id("client").Dot("tracer").Op("=").Id("setup").Dot("tracer"))
 	}
-	body = append(body, jen.Var().Id("kafkaClient").Op("*").Qual(kgoPath, "Client"))
+	body = append(body,
+		jen.Id("client").Dot("outbox").Op("=").Id("setup").Dot("outbox"),
+		jen.If(jen.Id("setup").Dot("producer").Op("!=").Nil()).Block(
+			jen.Id("client").Dot("producer").Op("=").Id("setup").Dot("producer"),
+			jen.Return(jen.Id("client"), jen.Nil()),
+		),
+		jen.Var().Id("kafkaClient").Op("*").Qual(kgoPath, "Client"),
+	)
 	for _, ack := range acks {
 		body = append(body,
 			jen.If(jen.List(jen.Id("kafkaClient"), jen.Id("err")).Op("=").Id("newKafkaClient").Call(jen.Id("setup"), ackExpression(ack), jen.Lit(ack != model.KafkaAcksAllISR)), jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
 			jen.Id("client").Dot(ackField(ack)).Op("=").Id("kafkaClient"),
 		)
 	}
-	body = append(body, jen.Return(jen.Id("client"), jen.Nil()))
+	body = append(body,
+		jen.If(jen.Id("setup").Dot("transactionalID").Op("!=").Lit("")).Block(
+			jen.If(jen.List(jen.Id("client").Dot("transactional"), jen.Id("err")).Op("=").Id("newKafkaClient").Call(jen.Id("setup"), jen.Qual(kgoPath, "AllISRAcks").Call(), jen.False(), jen.Qual(kgoPath, "TransactionalID").Call(jen.Id("setup").Dot("transactionalID"))), jen.Id("err").Op("!=").Nil()).Block(
+				jen.Id("client").Dot("Close").Call(),
+				jen.Return(jen.Nil(), jen.Id("err")),
+			),
+		),
+		jen.Return(jen.Id("client"), jen.Nil()),
+	)
 	source.Func().Id("New").Params(jen.Id("log").Op("*").Qual("log/slog", "Logger"), jen.Id("options").Op("...").Id("Option")).Params(jen.Id("client").Op("*").Id("Client"), jen.Id("err").Error()).Block(body...)
 	source.Line()
 }
 
+// schemaCodecs регистрирует кодеки schema registry, если задан SchemaRegistry; Codec может их переопределить.
+func (r *Renderer) schemaCodecs() (codes []jen.Code) {
+
+	if !r.hasCodec(model.KafkaCodecAvro, model.KafkaCodecProto) {
+		return nil
+	}
+	return []jen.Code{jen.If(jen.Id("setup").Dot("registry").Op("!=").Nil()).BlockFunc(func(group *jen.Group) {
+		if r.hasCodec(model.KafkaCodecAvro) {
+			group.Id("codecs").Index(jen.Lit(model.KafkaCodecAvro)).Op("=").Id("newAvroCodec").Call(jen.Id("setup").Dot("registry"), jen.Id("avroSchemas").Call())
+		}
+		if r.hasCodec(model.KafkaCodecProto) {
+			group.Id("codecs").Index(jen.Lit(model.KafkaCodecProto)).Op("=").Id("newProtobufCodec").Call(jen.Id("setup").Dot("registry"), jen.Id("protobufSchemas").Call())
+		}
+	})}
+}
+
 func (r *Renderer) addClose(source *GoFile, acks []string) {
 
-	clients := make([]jen.Code, 0, len(acks))
+	clients := make([]jen.Code, 0, len(acks)+1)
 	for _, ack := range acks {
 		clients = append(clients, jen.Id("client").Dot(ackField(ack)))
 	}
+	clients = append(clients, jen.Id("client").Dot("transactional"))
 	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("Close").Params().Block(
 		jen.If(jen.Id("client").Op("==").Nil()).Block(jen.Return()),
 		jen.Id("client").Dot("mu").Dot("Lock").Call(),
@@ -107,21 +150,24 @@ func (r *Renderer) addKafkaClientGetter(source *GoFile, acks []string) {
 
 	cases := make([]jen.Code, 0, len(acks)+1)
 	for _, ack := range acks {
-		cases = append(cases, jen.Case(jen.Lit(ack)).Block(jen.Return(jen.Id("client").Dot(ackField(ack)), jen.Nil())))
+		cases = append(cases, jen.Case(jen.Lit(ack)).Block(jen.Id("result").Op("=").Id("client").Dot(ackField(ack))))
 	}
 	cases = append(cases, jen.Default().Block(jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("unknown kafka acks %q"), jen.Id("acks")))))
 	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("kafkaClient").Params(jen.Id("acks").String()).Params(jen.Id("result").Op("*").Qual(kgoPath, "Client"), jen.Id("err").Error()).Block(
 		jen.Id("client").Dot("mu").Dot("RLock").Call(),
 		jen.Defer().Id("client").Dot("mu").Dot("RUnlock").Call(),
 		jen.If(jen.Id("client").Dot("closed")).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka publisher is closed")))),
+		jen.If(jen.Id("client").Dot("txClient").Op("!=").Nil()).Block(jen.Return(jen.Id("client").Dot("txClient"), jen.Nil())),
 		jen.Switch(jen.Id("acks")).Block(cases...),
+		jen.If(jen.Id("result").Op("==").Nil()).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka outbox transaction is nil")))),
+		jen.Return(jen.Id("result"), jen.Nil()),
 	)
 	source.Line()
 }
 
 func (r *Renderer) addKafkaClientConstructor(source *GoFile) {
 
-	source.Func().Id("newKafkaClient").Params(jen.Id("setup").Id("setup"), jen.Id("acks").Qual(kgoPath, "Acks"), jen.Id("disableIdempotence").Bool()).Params(jen.Id("client").Op("*").Qual(kgoPath, "Client"), jen.Id("err").Error()).Block(
+	source.Func().Id("newKafkaClient").Params(jen.Id("setup").Id("setup"), jen.Id("acks").Qual(kgoPath, "Acks"), jen.Id("disableIdempotence").Bool(), jen.Id("extra").Op("...").Qual(kgoPath, "Opt")).Params(jen.Id("client").Op("*").Qual(kgoPath, "Client"), jen.Id("err").Error()).Block(
 		jen.Id("options").Op(":=").Index().Qual(kgoPath, "Opt").Values(
 			jen.Qual(kgoPath, "SeedBrokers").Call(jen.Id("setup").Dot("brokers").Op("...")),
 			jen.Qual(kgoPath, "RequiredAcks").Call(jen.Id("acks")),
@@ -141,6 +187,7 @@ func (r *Renderer) addKafkaClientConstructor(source *GoFile) {
 			),
 			jen.Id("options").Op("=").Append(jen.Id("options"), jen.Qual(kgoPath, "SASL").Call(jen.Id("mechanism"))),
 		),
+		jen.Id("options").Op("=").Append(jen.Id("options"), jen.Id("extra").Op("...")),
 		jen.Id("options").Op("=").Append(jen.Id("options"), jen.Id("setup").Dot("clientOptions").Op("...")),
 		jen.Return(jen.Qual(kgoPath, "NewClient").Call(jen.Id("options").Op("..."))),
 	)
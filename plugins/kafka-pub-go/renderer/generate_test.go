@@ -58,7 +58,7 @@ type OrderEvents interface {
 	if err := renderer.Render(); err != nil {
 		t.Fatalf("Render: %v", err)
 	}
-	for _, name := range []string{"client.go", "options.go", "adapters.go", "codec.go", "produce.go", "security.go", "version.go", "metrics.go", "tracing.go"} {
+	for _, name := range []string{"client.go", "options.go", "adapters.go", "codec.go", "produce.go", "security.go", "transaction.go", "outbox.go", "version.go", "metrics.go", "tracing.go"} {
 		if _, err := os.Stat(filepath.Join(outDir, name)); err != nil {
 			t.Fatalf("missing %s: %v", name, err)
 		}
@@ -130,6 +130,17 @@ type OrderEvents interface {
 	if !strings.Contains(string(body), "_ = kafkaClient.Flush(context.Background())") {
 		t.Fatal("client.go must check Flush error via blank assign")
 	}
+	for _, expected := range []string{
+		"func (client *Client) Tx(ctx context.Context, fn func(tx *Client) (err error)) (err error)",
+		"kgo.TransactionalID(setup.transactionalID)",
+		"func (client *Client) Outbox(tx *sql.Tx) (outbox *Client)",
+		"func (client *Client) RunOutbox(ctx context.Context, db *sql.DB) (err error)",
+		`"orders.bulk":    "noAck"`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("client.go misses %q", expected)
+		}
+	}
 	adaptersBody, err := os.ReadFile(filepath.Join(outDir, "adapters.go"))
 	if err != nil {
 		t.Fatal(err)
@@ -140,6 +151,9 @@ type OrderEvents interface {
 	if !strings.Contains(string(adaptersBody), "outcome := produceAndWait(") {
 		t.Fatal("adapters.go must assign produceAndWait with :=")
 	}
+	if !strings.Contains(string(adaptersBody), "return outboxInsert(ctx, adapter.client.outboxTx, adapter.client.outbox, records...)") {
+		t.Fatal("adapters.go must store batch records in outbox")
+	}
 	tracing, err := os.ReadFile(filepath.Join(outDir, "tracing.go"))
 	if err != nil {
 		t.Fatal(err)
@@ -162,3 +176,198 @@ type OrderEvents interface {
 		t.Fatalf("generated package does not build: %v\n%s", err, output)
 	}
 }
+
+func TestRenderPublisherWithSchemaRegistry(t *testing.T) {
+
+	root := t.TempDir()
+	outDir := filepath.Join(root, "kafka")
+	contractsDir := filepath.Join(root, "contracts")
+	if err := os.MkdirAll(contractsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	goMod := "module example.com/app\n\ngo 1.26\n\nrequire (\n\tgithub.com/hamba/avro/v2 v2.31.0\n\tgithub.com/prometheus/client_golang v1.23.2\n\tgithub.com/twmb/franz-go v1.21.5\n)\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	contractSource := `package contracts
+import "context"
+type Order struct { ID string ` + "`json:\"id\"`" + ` }
+type OrderEvents interface {
+	OrderCreated(ctx context.Context, event Order) (err error)
+	OrderBulk(ctx context.Context, events []Order) (err error)
+}
+`
+	if err := os.WriteFile(filepath.Join(contractsDir, "contracts.go"), []byte(contractSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	orderArg := func(name string, isSlice bool) (arg *model.Variable) {
+		return &model.Variable{Name: name, TypeRef: model.TypeRef{TypeID: "example.com/app/contracts:Order", IsSlice: isSlice}}
+	}
+	project := &model.Project{
+		Types: map[string]*model.Type{
+			"example.com/app/contracts:Order": {Kind: model.TypeKindStruct, TypeName: "Order", ImportPkgPath: "example.com/app/contracts", StructFields: []*model.StructField{
+				{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}, "protobuf": {"1"}}},
+			}},
+		},
+		Contracts: []*model.Contract{{
+			Name: "OrderEvents", PkgPath: "example.com/app/contracts", Annotations: tags.DocTags{"kafka": "", "metrics": ""},
+			Methods: []*model.Method{
+				{Name: "OrderCreated", Annotations: tags.DocTags{"kafka-topic": "orders.created", "kafka-codec": "avro"}, Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}, orderArg("event", false),
+				}, Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}}},
+				{Name: "OrderBulk", Annotations: tags.DocTags{"kafka-topic": "orders.bulk", "kafka-codec": "protobuf"}, Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}, orderArg("events", true),
+				}, Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}}},
+			},
+		}},
+	}
+	if err := renderer.New(project, outDir, "example.com/app", "kafka").Render(); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	for name, expected := range map[string]string{
+		"registry.go": "func NewSchemaRegistry",
+		"avro.go":     "func newAvroCodec",
+		"protobuf.go": "func newProtobufCodec",
+		"schemas.go":  `"orders.bulk": {`,
+		"options.go":  "func Registry(registry *SchemaRegistry) (option Option)",
+		"client.go":   `codecs["protobuf"] = newProtobufCodec(setup.registry, protobufSchemas())`,
+		"adapters.go": `marshalTopic(ctx, codec, "orders.created", event)`,
+	} {
+		body, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("%s does not contain %q", name, expected)
+		}
+	}
+	command := exec.Command("go", "build", "-mod=mod", "./kafka")
+	command.Dir = root
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("generated package does not build: %v\n%s", err, output)
+	}
+}
+
+func TestRenderPublisherKafkaTest(t *testing.T) {
+
+	root := t.TempDir()
+	outDir := filepath.Join(root, "kafka")
+	contractsDir := filepath.Join(root, "contracts")
+	if err := os.MkdirAll(contractsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	goMod := "module example.com/app\n\ngo 1.26\n\nrequire (\n\tgithub.com/prometheus/client_golang v1.23.2\n\tgithub.com/twmb/franz-go v1.21.5\n)\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	contractSource := `package contracts
+import "context"
+type Order struct { ID string }
+type OrderEvents interface {
+	OrderCreated(ctx context.Context, orderID string, event Order) (err error)
+	OrderBulk(ctx context.Context, events ...Order) (err error)
+}
+`
+	if err := os.WriteFile(filepath.Join(contractsDir, "contracts.go"), []byte(contractSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &model.Project{
+		Types: map[string]*model.Type{
+			"example.com/app/contracts:Order": {TypeName: "Order", ImportPkgPath: "example.com/app/contracts"},
+		},
+		Contracts: []*model.Contract{{
+			Name: "OrderEvents", PkgPath: "example.com/app/contracts", Annotations: tags.DocTags{"kafka": "", "metrics": ""},
+			Methods: []*model.Method{
+				{Name: "OrderCreated", Annotations: tags.DocTags{"kafka-topic": "orders.created", "kafka-key": "orderID", "kafka-message": "event"}, Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}, {Name: "orderID", TypeRef: model.TypeRef{TypeID: "string"}}, {Name: "event", TypeRef: model.TypeRef{TypeID: "example.com/app/contracts:Order"}},
+				}, Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}}},
+				{Name: "OrderBulk", Annotations: tags.DocTags{"kafka-topic": "orders.bulk", "kafka-message": "events"}, Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}, {Name: "events", TypeRef: model.TypeRef{TypeID: "example.com/app/contracts:Order", IsEllipsis: true}},
+				}, Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}}},
+			},
+		}},
+	}
+	render := renderer.New(project, outDir, "example.com/app", "kafka")
+	if err := render.Render(); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if err := render.RenderKafkaTest(); err != nil {
+		t.Fatalf("RenderKafkaTest: %v", err)
+	}
+	brokerTest := `package app_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"example.com/app/contracts"
+	"example.com/app/kafka"
+	"example.com/app/kafka/kafkatest"
+)
+
+func TestBroker(t *testing.T) {
+
+	ctx := context.Background()
+	broker, err := kafkatest.New(slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer broker.Close()
+	if err = broker.OrderEvents().OrderCreated(ctx, "o-1", contracts.Order{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	records := broker.Records("orders.created")
+	if len(records) != 1 || string(records[0].Key) != "o-1" || string(records[0].Value) != ` + "`" + `{"ID":"1"}` + "`" + ` || records[0].Offset != 0 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	err = broker.Tx(ctx, func(tx *kafka.Client) (err error) {
+		if err = tx.OrderEvents().OrderBulk(ctx, contracts.Order{ID: "2"}, contracts.Order{ID: "3"}); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	if err == nil || len(broker.Records("orders.bulk")) != 0 {
+		t.Fatalf("aborted transaction must not publish: %v", err)
+	}
+	err = broker.Tx(ctx, func(tx *kafka.Client) (err error) {
+		return tx.OrderEvents().OrderBulk(ctx, contracts.Order{ID: "2"}, contracts.Order{ID: "3"})
+	})
+	if records = broker.Records("orders.bulk"); err != nil || len(records) != 2 || records[1].Offset != 1 {
+		t.Fatalf("committed transaction must publish both records: %v %+v", err, records)
+	}
+	failure := errors.New("broker down")
+	broker.Fail(failure)
+	if err = broker.OrderEvents().OrderCreated(ctx, "o-2", contracts.Order{}); !errors.Is(err, failure) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	broker.Reset()
+	if len(broker.Records()) != 0 {
+		t.Fatal("Reset must drop records")
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(root, "broker_test.go"), []byte(brokerTest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mustContainFile(t, filepath.Join(outDir, "options.go"), "func Producer(produce func(ctx context.Context, records []*kgo.Record) (err error)) (option Option)")
+	mustContainFile(t, filepath.Join(outDir, "kafkatest", "broker.go"), "kafka.Producer(broker.produce)")
+	command := exec.Command("go", "test", "-mod=mod", ".")
+	command.Dir = root
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("kafkatest broker test failed: %v\n%s", err, output)
+	}
+}
+
+func mustContainFile(t *testing.T, filePath string, expected string) {
+
+	t.Helper()
+	body, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), expected) {
+		t.Fatalf("%s does not contain %q", filePath, expected)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path/filepath"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// RenderKafkaTest создаёт пакет kafkatest с in-memory брокером для тестов издателя.
+func (r *Renderer) RenderKafkaTest() (err error) {
+
+	pkgPath := r.targetModulePath + "/" + filepath.ToSlash(r.outputRelPath)
+	source := newSrcFile("kafkatest")
+	source.Comment("Broker — издатель, который вместо Kafka сохраняет записи в памяти для проверок в тестах.")
+	source.Comment("Записи получают partition 0 и последовательный offset своего топика.")
+	source.Type().Id("Broker").Struct(
+		jen.Op("*").Qual(pkgPath, "Client"),
+		jen.Line(),
+		jen.Id("mu").Qual("sync", "Mutex"),
+		jen.Id("records").Index().Op("*").Qual(kgoPath, "Record"),
+		jen.Id("offsets").Map(jen.String()).Int64(),
+		jen.Id("fail").Error(),
+	)
+	source.Line()
+	source.Comment("New создаёт издателя с опциями options поверх in-memory брокера; Brokers не требуется.")
+	source.Func().Id("New").Params(jen.Id("log").Op("*").Qual("log/slog", "Logger"), jen.Id("options").Op("...").Qual(pkgPath, "Option")).Params(jen.Id("broker").Op("*").Id("Broker"), jen.Id("err").Error()).Block(
+		jen.Id("broker").Op("=").Op("&").Id("Broker").Values(jen.Dict{jen.Id("offsets"): jen.Make(jen.Map(jen.String()).Int64())}),
+		jen.Id("options").Op("=").Append(jen.Qual("slices", "Clip").Call(jen.Id("options")), jen.Qual(pkgPath, "Producer").Call(jen.Id("broker").Dot("produce"))),
+		jen.If(jen.List(jen.Id("broker").Dot("Client"), jen.Id("err")).Op("=").Qual(pkgPath, "New").Call(jen.Id("log"), jen.Id("options").Op("...")), jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
+		jen.Return(jen.Id("broker"), jen.Nil()),
+	)
+	source.Line()
+	source.Comment("Records возвращает отправленные записи в порядке отправки; topics ограничивает выборку.")
+	source.Func().Params(jen.Id("broker").Op("*").Id("Broker")).Id("Records").Params(jen.Id("topics").Op("...").String()).Params(jen.Id("records").Index().Op("*").Qual(kgoPath, "Record")).Block(
+		jen.Id("broker").Dot("mu").Dot("Lock").Call(),
+		jen.Defer().Id("broker").Dot("mu").Dot("Unlock").Call(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("record")).Op(":=").Range().Id("broker").Dot("records")).Block(
+			jen.If(jen.Len(jen.Id("topics")).Op("==").Lit(0).Op("||").Qual("slices", "Contains").Call(jen.Id("topics"), jen.Id("record").Dot("Topic"))).Block(
+				jen.Id("records").Op("=").Append(jen.Id("records"), jen.Id("record")),
+			),
+		),
+		jen.Return(jen.Id("records")),
+	)
+	source.Line()
+	source.Comment("Reset удаляет сохранённые записи и сбрасывает offset топиков.")
+	source.Func().Params(jen.Id("broker").Op("*").Id("Broker")).Id("Reset").Params().Block(
+		jen.Id("broker").Dot("mu").Dot("Lock").Call(),
+		jen.Defer().Id("broker").Dot("mu").Dot("Unlock").Call(),
+		jen.Id("broker").Dot("records").Op("=").Nil(),
+		jen.Id("broker").Dot("offsets").Op("=").Make(jen.Map(jen.String()).Int64()),
+	)
+	source.Line()
+	source.Comment("Fail задаёт ошибку, которую возвращает каждая следующая отправка; nil возвращает брокер в рабочий режим.")
+	source.Func().Params(jen.Id("broker").Op("*").Id("Broker")).Id("Fail").Params(jen.Id("err").Error()).Block(
+		jen.Id("broker").Dot("mu").Dot("Lock").Call(),
+		jen.Defer().Id("broker").Dot("mu").Dot("Unlock").Call(),
+		jen.Id("broker").Dot("fail").Op("=").Id("err"),
+	)
+	source.Line()
+	source.Func().Params(jen.Id("broker").Op("*").Id("Broker")).Id("produce").Params(jen.Id("_").Qual("context", "Context"), jen.Id("records").Index().Op("*").Qual(kgoPath, "Record")).Params(jen.Id("err").Error()).Block(
+		jen.Id("broker").Dot("mu").Dot("Lock").Call(),
+		jen.Defer().Id("broker").Dot("mu").Dot("Unlock").Call(),
+		jen.If(jen.Id("broker").Dot("fail").Op("!=").Nil()).Block(jen.Return(jen.Id("broker").Dot("fail"))),
+		jen.For(jen.List(jen.Id("_"), jen.Id("record")).Op(":=").Range().Id("records")).Block(
+			jen.Id("record").Dot("Partition").Op("=").Lit(0),
+			jen.Id("record").Dot("Offset").Op("=").Id("broker").Dot("offsets").Index(jen.Id("record").Dot("Topic")),
+			jen.Id("broker").Dot("offsets").Index(jen.Id("record").Dot("Topic")).Op("++"),
+			jen.If(jen.Id("record").Dot("Timestamp").Dot("IsZero").Call()).Block(
+				jen.Id("record").Dot("Timestamp").Op("=").Qual("time", "Now").Call(),
+			),
+			jen.Id("broker").Dot("records").Op("=").Append(jen.Id("broker").Dot("records"), jen.Id("record")),
+		),
+		jen.Return(jen.Nil()),
+	)
+	return source.Save(filepath.Join(r.outDir, "kafkatest", "broker.go"))
+}
@@ -31,6 +31,12 @@ func (r *Renderer) renderOptions() (err error) {
 		group.Id("authPassword").String()
 		group.Id("saslName").String()
 		group.Id("clientOptions").Index().Qual(kgoPath, "Opt")
+		group.Id("transactionalID").String()
+		group.Id("outbox").Id("outboxConfig")
+		group.Id("producer").Id("producer")
+		if r.hasCodec(model.KafkaCodecAvro, model.KafkaCodecProto) {
+			group.Id("registry").Op("*").Id("SchemaRegistry")
+		}
 		if r.hasAnnotation(model.TagMetrics) {
 			group.Id("metrics").Qual(prometheusPath, "Registerer")
 		}
@@ -41,6 +47,8 @@ func (r *Renderer) renderOptions() (err error) {
 	source.Line()
 	source.Type().Id("Option").Func().Params(jen.Id("setup").Op("*").Id("setup")).Params(jen.Id("err").Error())
 	source.Line()
+	source.Type().Id("producer").Func().Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("records").Index().Op("*").Qual(kgoPath, "Record")).Params(jen.Id("err").Error())
+	source.Line()
 	source.Func().Id("defaultSetup").Params().Params(jen.Id("result").Id("setup")).Block(
 		jen.Return(jen.Id("setup").Values(jen.Dict{
 			jen.Id("batchMaxLinger"):     jen.Lit(10).Op("*").Qual("time", "Millisecond"),
@@ -48,6 +56,12 @@ func (r *Renderer) renderOptions() (err error) {
 			jen.Id("maxBufferedRecords"): jen.Lit(10000),
 			jen.Id("compression"):        jen.Index().Qual(kgoPath, "CompressionCodec").Values(jen.Qual(kgoPath, "NoCompression").Call()),
 			jen.Id("codecs"):             jen.Make(jen.Map(jen.String()).Id("codec")),
+			jen.Id("outbox"): jen.Id("outboxConfig").Values(jen.Dict{
+				jen.Id("table"):     jen.Lit("kafka_outbox"),
+				jen.Id("dialect"):   jen.Id("OutboxPostgres"),
+				jen.Id("batchSize"): jen.Lit(100),
+				jen.Id("interval"):  jen.Qual("time", "Second"),
+			}),
 		})),
 	)
 	source.Line()
@@ -109,6 +123,41 @@ func (r *Renderer) renderOptions() (err error) {
 		)),
 	)
 	source.Line()
+	if r.hasCodec(model.KafkaCodecAvro, model.KafkaCodecProto) {
+		r.addRequiredOption(source, "Registry", "задаёт клиент schema registry кодеков avro и protobuf.", "registry", jen.Id("registry").Op("*").Id("SchemaRegistry"), "kafka schema registry is nil", jen.Id("setup").Dot("registry").Op("=").Id("registry"))
+	}
+	source.Comment("Transactional включает транзакции Kafka (Tx) с заданным transactional.id, уникальным для экземпляра издателя.")
+	source.Func().Id("Transactional").Params(jen.Id("id").String()).Params(jen.Id("option").Id("Option")).Block(
+		jen.Return(jen.Func().Params(jen.Id("setup").Op("*").Id("setup")).Params(jen.Id("err").Error()).Block(
+			jen.If(jen.Id("id").Op("==").Lit("")).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka transactional id is required")))),
+			jen.Id("setup").Dot("transactionalID").Op("=").Id("id"),
+			jen.Return(jen.Nil()),
+		)),
+	)
+	source.Line()
+	source.Comment("OutboxTable задаёт таблицу outbox и её SQL-диалект (по умолчанию kafka_outbox, OutboxPostgres).")
+	source.Func().Id("OutboxTable").Params(jen.Id("table").String(), jen.Id("dialect").Id("OutboxDialect")).Params(jen.Id("option").Id("Option")).Block(
+		jen.Return(jen.Func().Params(jen.Id("setup").Op("*").Id("setup")).Params(jen.Id("err").Error()).Block(
+			jen.If(jen.Id("table").Op("==").Lit("")).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka outbox table is required")))),
+			jen.If(jen.Id("dialect").Dot("placeholder").Op("==").Nil()).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka outbox dialect is required")))),
+			jen.Id("setup").Dot("outbox").Dot("table").Op("=").Id("table"),
+			jen.Id("setup").Dot("outbox").Dot("dialect").Op("=").Id("dialect"),
+			jen.Return(jen.Nil()),
+		)),
+	)
+	source.Line()
+	source.Comment("OutboxBatch задаёт размер батча relay outbox и паузу опроса пустой таблицы (по умолчанию 100 и 1s).")
+	source.Func().Id("OutboxBatch").Params(jen.Id("size").Int(), jen.Id("interval").Qual("time", "Duration")).Params(jen.Id("option").Id("Option")).Block(
+		jen.Return(jen.Func().Params(jen.Id("setup").Op("*").Id("setup")).Params(jen.Id("err").Error()).Block(
+			jen.If(jen.Id("size").Op("<=").Lit(0)).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka outbox batch size must be positive")))),
+			jen.If(jen.Id("interval").Op("<=").Lit(0)).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka outbox interval must be positive")))),
+			jen.Id("setup").Dot("outbox").Dot("batchSize").Op("=").Id("size"),
+			jen.Id("setup").Dot("outbox").Dot("interval").Op("=").Id("interval"),
+			jen.Return(jen.Nil()),
+		)),
+	)
+	source.Line()
+	r.addRequiredOption(source, "Producer", "заменяет отправку в Kafka функцией produce: New не требует Brokers и не создаёт клиентов franz-go (in-memory тесты, kafkatest).", "produce", jen.Id("produce").Func().Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("records").Index().Op("*").Qual(kgoPath, "Record")).Params(jen.Id("err").Error()), "kafka producer is nil", jen.Id("setup").Dot("producer").Op("=").Id("produce"))
 	r.addOption(source, "ClientOpt", "передаёт дополнительную franz-go опцию всем клиентам отправки. Не для TLS/SASL/Auth.", jen.Id("clientOption").Qual(kgoPath, "Opt"), jen.Id("setup").Dot("clientOptions").Op("=").Append(jen.Id("setup").Dot("clientOptions"), jen.Id("clientOption")))
 	if r.hasAnnotation(model.TagMetrics) {
 		r.addRequiredOption(source, "Metrics", "включает сбор Prometheus-метрик в заданном реестре.", "registerer", jen.Id("registerer").Qual(prometheusPath, "Registerer"), "kafka metrics registerer is nil", jen.Id("setup").Dot("metrics").Op("=").Id("registerer"))
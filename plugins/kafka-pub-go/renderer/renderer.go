@@ -7,6 +7,7 @@ import (
 	"go/format"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -51,6 +52,15 @@ func (r *Renderer) Render() (err error) {
 	if err = kafkaruntime.WriteSecurity(r.outDir, filepath.Base(r.outDir)); err != nil {
 		return err
 	}
+	if err = kafkaruntime.WriteTransaction(r.outDir, filepath.Base(r.outDir)); err != nil {
+		return err
+	}
+	if err = kafkaruntime.WriteOutbox(r.outDir, filepath.Base(r.outDir)); err != nil {
+		return err
+	}
+	if err = r.renderSchemas(); err != nil {
+		return err
+	}
 	if err = r.renderVersion(); err != nil {
 		return err
 	}
@@ -96,6 +106,18 @@ func (r *Renderer) codecOptions() (options kafkaruntime.WriteCodecOptions) {
 	return options
 }
 
+func (r *Renderer) hasCodec(codecs ...string) (found bool) {
+
+	for _, contract := range r.contracts {
+		for _, method := range contract.Methods {
+			if slices.Contains(codecs, model.MethodKafkaCodec(r.project, contract, method)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *Renderer) hasAnnotation(tag string) (found bool) {
 
 	for _, contract := range r.contracts {
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dave/jennifer/jen"
+
+	kafkaruntime "tgp/internal/kafka"
+	"tgp/internal/model"
+)
+
+func (r *Renderer) renderSchemas() (err error) {
+
+	if !r.hasCodec(model.KafkaCodecAvro, model.KafkaCodecProto) {
+		return nil
+	}
+	pkgName := filepath.Base(r.outDir)
+	if err = kafkaruntime.WriteSchemaRegistry(r.outDir, pkgName); err != nil {
+		return err
+	}
+	source := newSrcFile(pkgName)
+	for _, codec := range []string{model.KafkaCodecAvro, model.KafkaCodecProto} {
+		if !r.hasCodec(codec) {
+			continue
+		}
+		var definitions jen.Dict
+		if definitions, err = r.schemaDefinitions(codec); err != nil {
+			return err
+		}
+		switch codec {
+		case model.KafkaCodecAvro:
+			err = kafkaruntime.WriteAvro(r.outDir, pkgName)
+		default:
+			err = kafkaruntime.WriteProtobuf(r.outDir, pkgName)
+		}
+		if err != nil {
+			return err
+		}
+		name := codec + "Schemas"
+		source.Comment(name + " возвращает схемы тел сообщений кодека " + codec + " по топикам.")
+		source.Func().Id(name).Params().Params(jen.Id("schemas").Map(jen.String()).Id("schemaDefinition")).Block(
+			jen.Return(jen.Map(jen.String()).Id("schemaDefinition").Values(definitions)),
+		)
+		source.Line()
+	}
+	return source.Save(filepath.Join(r.outDir, "schemas.go"))
+}
+
+func (r *Renderer) schemaDefinitions(codec string) (definitions jen.Dict, err error) {
+
+	definitions = make(jen.Dict)
+	for _, contract := range r.contracts {
+		for _, method := range contract.Methods {
+			if model.MethodKafkaCodec(r.project, contract, method) != codec {
+				continue
+			}
+			message, _ := model.MethodKafkaMessageArg(r.project, contract, method)
+			var schema kafkaruntime.MessageSchema
+			if schema, err = kafkaruntime.SchemaFor(codec, r.project, message.TypeRef); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", contract.Name, method.Name, err)
+			}
+			definitions[jen.Lit(model.MethodKafkaTopic(r.project, contract, method))] = jen.Values(jen.Dict{
+				jen.Id("name"):   jen.Lit(schema.Name),
+				jen.Id("schema"): jen.Lit(schema.Schema),
+			})
+		}
+	}
+	return definitions, nil
+}
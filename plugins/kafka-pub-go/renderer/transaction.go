@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"github.com/dave/jennifer/jen"
+
+	"tgp/internal/model"
+)
+
+func (r *Renderer) addTransaction(source *GoFile) {
+
+	source.Comment("Tx выполняет fn в транзакции Kafka: записи методов tx фиксируются атомарно, ошибка fn отменяет транзакцию.")
+	source.Comment("Требует опцию Transactional; транзакции одного издателя выполняются последовательно.")
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("Tx").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("fn").Func().Params(jen.Id("tx").Op("*").Id("Client")).Params(jen.Id("err").Error())).Params(jen.Id("err").Error()).Block(
+		jen.If(jen.Id("fn").Op("==").Nil()).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka transaction func is nil")))),
+		jen.If(jen.Id("client").Dot("producer").Op("!=").Nil()).Block(jen.Return(jen.Id("client").Dot("producerTx").Call(jen.Id("ctx"), jen.Id("fn")))),
+		jen.Var().Id("kafkaClient").Op("*").Qual(kgoPath, "Client"),
+		jen.If(jen.List(jen.Id("kafkaClient"), jen.Id("err")).Op("=").Id("client").Dot("transactionClient").Call(), jen.Id("err").Op("!=").Nil()).Block(jen.Return()),
+		jen.Id("client").Dot("txMu").Dot("Lock").Call(),
+		jen.Defer().Id("client").Dot("txMu").Dot("Unlock").Call(),
+		jen.If(jen.Id("err").Op("=").Id("kafkaClient").Dot("BeginTransaction").Call(), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("kafka transaction begin: %w"), jen.Id("err"))),
+		),
+		jen.Defer().Func().Params().Block(
+			jen.If(jen.Id("recovered").Op(":=").Recover(), jen.Id("recovered").Op("!=").Nil()).Block(
+				jen.Id("_").Op("=").Id("endTransaction").Call(jen.Id("ctx"), jen.Id("kafkaClient"), jen.False()),
+				jen.Panic(jen.Id("recovered")),
+			),
+		).Call(),
+		jen.Id("tx").Op(":=").Id("client").Dot("view").Call(),
+		jen.Id("tx").Dot("txClient").Op("=").Id("kafkaClient"),
+		jen.If(jen.Id("err").Op("=").Id("fn").Call(jen.Id("tx")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("errors", "Join").Call(jen.Id("err"), jen.Id("endTransaction").Call(jen.Id("ctx"), jen.Id("kafkaClient"), jen.False()))),
+		),
+		jen.Return(jen.Id("endTransaction").Call(jen.Id("ctx"), jen.Id("kafkaClient"), jen.True())),
+	)
+	source.Line()
+	source.Comment("producerTx накапливает записи методов tx и передаёт их Producer одним вызовом после успешного fn.")
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("producerTx").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("fn").Func().Params(jen.Id("tx").Op("*").Id("Client")).Params(jen.Id("err").Error())).Params(jen.Id("err").Error()).Block(
+		jen.Id("client").Dot("txMu").Dot("Lock").Call(),
+		jen.Defer().Id("client").Dot("txMu").Dot("Unlock").Call(),
+		jen.Var().Id("buffered").Index().Op("*").Qual(kgoPath, "Record"),
+		jen.Id("tx").Op(":=").Id("client").Dot("view").Call(),
+		jen.Id("tx").Dot("producer").Op("=").Func().Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("records").Index().Op("*").Qual(kgoPath, "Record")).Params(jen.Id("err").Error()).Block(
+			jen.Id("buffered").Op("=").Append(jen.Id("buffered"), jen.Id("records").Op("...")),
+			jen.Return(jen.Nil()),
+		),
+		jen.If(jen.Id("err").Op("=").Id("fn").Call(jen.Id("tx")), jen.Id("err").Op("!=").Nil()).Block(jen.Return()),
+		jen.Return(jen.Id("client").Dot("producer").Call(jen.Id("ctx"), jen.Id("buffered"))),
+	)
+	source.Line()
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("transactionClient").Params().Params(jen.Id("result").Op("*").Qual(kgoPath, "Client"), jen.Id("err").Error()).Block(
+		jen.Id("client").Dot("mu").Dot("RLock").Call(),
+		jen.Defer().Id("client").Dot("mu").Dot("RUnlock").Call(),
+		jen.If(jen.Id("client").Dot("closed")).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka publisher is closed")))),
+		jen.If(jen.Id("client").Dot("transactional").Op("==").Nil()).Block(jen.Return(jen.Nil(), jen.Qual("errors", "New").Call(jen.Lit("kafka publisher is not transactional")))),
+		jen.Return(jen.Id("client").Dot("transactional"), jen.Nil()),
+	)
+	source.Line()
+	source.Comment("view создаёт издателя без собственных клиентов Kafka с кодеками и наблюдаемостью client.")
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("view").Params().Params(jen.Id("view").Op("*").Id("Client")).Block(
+		jen.Return(jen.Op("&").Id("Client").Values(jen.DictFunc(func(dict jen.Dict) {
+			dict[jen.Id("log")] = jen.Id("client").Dot("log")
+			dict[jen.Id("codecs")] = jen.Id("client").Dot("codecs")
+			dict[jen.Id("outbox")] = jen.Id("client").Dot("outbox")
+			if r.hasAnnotation(model.TagMetrics) {
+				dict[jen.Id("metrics")] = jen.Id("client").Dot("metrics")
+			}
+			if r.hasAnnotation(model.TagTrace) {
+				dict[jen.Id("tracer")] = jen.Id("client").Dot("tracer")
+			}
+		}))),
+	)
+	source.Line()
+}
+
+func (r *Renderer) addOutbox(source *GoFile) {
+
+	source.Comment("Outbox возвращает издателя, который сохраняет сообщения в таблицу outbox внутри tx вместо отправки в Kafka.")
+	source.Comment("Записи отправляет RunOutbox после фиксации tx.")
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("Outbox").Params(jen.Id("tx").Op("*").Qual("database/sql", "Tx")).Params(jen.Id("outbox").Op("*").Id("Client")).Block(
+		jen.Id("outbox").Op("=").Id("client").Dot("view").Call(),
+		jen.Id("outbox").Dot("outboxTx").Op("=").Id("tx"),
+		jen.Return(jen.Id("outbox")),
+	)
+	source.Line()
+	source.Comment("RunOutbox переносит записи outbox из db в Kafka до отмены ctx; ошибки логируются, батч повторяется.")
+	source.Comment("Доставка at-least-once: строка удаляется только после подтверждения брокером.")
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("RunOutbox").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("db").Op("*").Qual("database/sql", "DB")).Params(jen.Id("err").Error()).Block(
+		jen.If(jen.Id("db").Op("==").Nil()).Block(jen.Return(jen.Qual("errors", "New").Call(jen.Lit("kafka outbox database is nil")))),
+		jen.Id("runOutboxRelay").Call(jen.Id("ctx"), jen.Id("db"), jen.Id("client").Dot("outbox"), jen.Id("client").Dot("log"), jen.Id("client").Dot("produceOutbox")),
+		jen.Return(jen.Nil()),
+	)
+	source.Line()
+	hooks := jen.Nil()
+	if r.hasAnnotation(model.TagMetrics) {
+		hooks = jen.Id("client").Dot("produceHooks").Call()
+	}
+	source.Func().Params(jen.Id("client").Op("*").Id("Client")).Id("produceOutbox").Params(jen.Id("ctx").Qual("context", "Context"), jen.Id("records").Index().Op("*").Qual(kgoPath, "Record")).Params(jen.Id("err").Error()).Block(
+		jen.If(jen.Id("client").Dot("producer").Op("!=").Nil()).Block(jen.Return(jen.Id("client").Dot("producer").Call(jen.Id("ctx"), jen.Id("records")))),
+		jen.Id("acks").Op(":=").Id("topicAcks").Call(),
+		jen.Id("groups").Op(":=").Make(jen.Map(jen.String()).Index().Op("*").Qual(kgoPath, "Record")),
+		jen.For(jen.List(jen.Id("_"), jen.Id("record")).Op(":=").Range().Id("records")).Block(
+			jen.List(jen.Id("ack"), jen.Id("found")).Op(":=").Id("acks").Index(jen.Id("record").Dot("Topic")),
+			jen.If(jen.Op("!").Id("found")).Block(jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("kafka outbox topic %q is not published by this client"), jen.Id("record").Dot("Topic")))),
+			jen.Id("groups").Index(jen.Id("ack")).Op("=").Append(jen.Id("groups").Index(jen.Id("ack")), jen.Id("record")),
+		),
+		jen.For(jen.List(jen.Id("ack"), jen.Id("group")).Op(":=").Range().Id("groups")).Block(
+			jen.Var().Id("kafkaClient").Op("*").Qual(kgoPath, "Client"),
+			jen.If(jen.List(jen.Id("kafkaClient"), jen.Id("err")).Op("=").Id("client").Dot("kafkaClient").Call(jen.Id("ack")), jen.Id("err").Op("!=").Nil()).Block(jen.Return()),
+			jen.If(jen.List(jen.Id("_"), jen.Id("err")).Op("=").Id("produceAllAndWait").Call(jen.Id("ctx"), jen.Id("kafkaClient"), jen.Id("group"), hooks), jen.Id("err").Op("!=").Nil()).Block(jen.Return()),
+		),
+		jen.Return(jen.Nil()),
+	)
+	source.Line()
+	topics := make(jen.Dict)
+	seen := make(map[string]struct{})
+	for _, contract := range r.contracts {
+		for _, method := range contract.Methods {
+			topic := model.MethodKafkaTopic(r.project, contract, method)
+			if _, ok := seen[topic]; ok {
+				continue
+			}
+			seen[topic] = struct{}{}
+			topics[jen.Lit(topic)] = jen.Lit(model.MethodKafkaAcks(r.project, contract, method))
+		}
+	}
+	source.Comment("topicAcks возвращает acks топиков издателя для отправки записей outbox.")
+	source.Func().Id("topicAcks").Params().Params(jen.Id("acks").Map(jen.String()).String()).Block(
+		jen.Return(jen.Map(jen.String()).String().Values(topics)),
+	)
+	source.Line()
+}
@@ -11,7 +11,7 @@ import (
 )
 
 // Generate валидирует модель и генерирует Kafka-подписчик.
-func Generate(project *model.Project, outDir string, targetModulePath string, outputRelPath string) (err error) {
+func Generate(project *model.Project, outDir string, targetModulePath string, outputRelPath string, kafkaTest bool) (err error) {
 
 	if err = validate.Project(project); err != nil {
 		return fmt.Errorf("invalid project: %w", err)
@@ -31,5 +31,10 @@ func Generate(project *model.Project, outDir string, targetModulePath string, ou
 	if err = render.RenderAll(); err != nil {
 		return fmt.Errorf("render kafka subscriber: %w", err)
 	}
+	if kafkaTest {
+		if err = render.RenderKafkaTest(); err != nil {
+			return fmt.Errorf("render kafkatest: %w", err)
+		}
+	}
 	return nil
 }
@@ -63,12 +63,13 @@ func (p *KafkaSubGoPlugin) Execute(request data.Storage) (response data.Storage,
 	if contracts, err = helper.ParseStringList(request, "contracts"); err != nil {
 		return nil, fmt.Errorf("%s: %w", i18n.Msg("failed to parse contracts"), err)
 	}
+	kafkaTest, _ := data.Get[bool](request, "kafkatest")
 	filtered := *project
 	filtered.Contracts = helper.FilterContracts(project, contracts)
 	if err = cleanup.GeneratedFiles(output); err != nil {
 		return nil, fmt.Errorf("cleanup generated files: %w", err)
 	}
-	if err = generator.Generate(&filtered, output, targetModulePath, outputRelPath); err != nil {
+	if err = generator.Generate(&filtered, output, targetModulePath, outputRelPath, kafkaTest); err != nil {
 		return nil, fmt.Errorf("generate kafka-sub-go: %w", err)
 	}
 	return response, nil
@@ -92,8 +93,12 @@ func (p *KafkaSubGoPlugin) Info() (info plugin.Info, err error) {
 			Description: i18n.Msg("Generate Kafka Go subscriber"),
 			Options: []plugin.Option{
 				{Name: "contracts-dir", Type: "string", Description: i18n.Msg("Path to contracts folder (relative to rootDir)"), Default: "contracts"},
+				{Name: "contracts-recursive", Type: "bool", Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"), Default: false},
+				{Name: "contracts-include", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)")},
+				{Name: "contracts-exclude", Type: "string", Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)")},
 				{Name: "out", Type: "string", Description: i18n.Msg("Path to output directory (package name = basename)"), Required: true},
 				{Name: "contracts", Type: "string", Description: i18n.Msg("Comma-separated list of contracts for filtering")},
+				{Name: "kafkatest", Type: "bool", Description: i18n.Msg("Generate kafkatest package with in-memory broker for tests"), Default: false},
 			},
 		}},
 		AllowedEnvVars: []string{"GOPATH", "GOROOT", "GOMODCACHE"},
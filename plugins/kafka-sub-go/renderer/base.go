@@ -19,12 +19,13 @@ type Renderer struct {
 	project *model.Project
 	outDir  string
 	pkgName string
+	pkgPath string
 }
 
 // NewRenderer создаёт генератор исходных файлов.
 func NewRenderer(project *model.Project, outDir string, targetModulePath string, outputRelPath string) (renderer *Renderer) {
 
-	return &Renderer{project: project, outDir: outDir, pkgName: filepath.Base(outDir)}
+	return &Renderer{project: project, outDir: outDir, pkgName: filepath.Base(outDir), pkgPath: targetModulePath + "/" + filepath.ToSlash(outputRelPath)}
 }
 
 // HasKafka сообщает, есть ли в запуске Kafka-контракты.
@@ -105,6 +106,14 @@ func (r *Renderer) renderRuntime() (err error) {
 	if err = kafka.WriteSecurity(r.outDir, r.pkgName); err != nil {
 		return
 	}
+	if r.hasDeadLetter() {
+		if err = kafka.WriteDeadLetter(r.outDir, r.pkgName); err != nil {
+			return
+		}
+	}
+	if err = r.renderSchemas(); err != nil {
+		return
+	}
 	return kafka.WritePoll(r.outDir, r.pkgName)
 }
 
@@ -119,6 +128,18 @@ func (r *Renderer) topics() (topics []string) {
 	return topics
 }
 
+func (r *Renderer) hasDeadLetter() (ok bool) {
+
+	for _, contract := range r.contracts() {
+		for _, method := range contract.Methods {
+			if model.MethodKafkaHasDeadLetter(r.project, contract, method) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *Renderer) hasMetrics() (ok bool) {
 
 	for _, contract := range r.contracts() {
@@ -4,6 +4,7 @@ package renderer_test
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -43,6 +44,9 @@ func TestRenderAllSubscriber(t *testing.T) {
 	mustContain(t, filepath.Join(outDir, "handlers.go"), "type OrderEventsMetaHandler interface")
 	mustContain(t, filepath.Join(outDir, "options.go"), "func Codec(name string, c codec) Option")
 	mustContain(t, filepath.Join(outDir, "options.go"), "func CommitAfterBatch() Option")
+	mustContain(t, filepath.Join(outDir, "options.go"), "func Concurrency(n int) Option")
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), "dispatchTopicsConcurrent(runContext, byTopic, client.handlers, client.concurrency)")
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), "client.client.CommitRecords(runContext, committable...)")
 	mustContain(t, filepath.Join(outDir, "options.go"), "func TLS(config *tls.Config) Option")
 	mustContain(t, filepath.Join(outDir, "security.go"), "func saslMechanism")
 	mustContain(t, filepath.Join(outDir, "version.go"), `VersionASTg = "`+internal.Version+`"`)
@@ -137,6 +141,240 @@ func TestRenderSubscriberDedupsCodecChecks(t *testing.T) {
 	}
 }
 
+func TestRenderAllSubscriberWithDeadLetter(t *testing.T) {
+
+	outDir := filepath.Join(t.TempDir(), "kafka")
+	project := &model.Project{
+		Types: map[string]*model.Type{
+			"example.com/contracts:Order": {TypeName: "Order", ImportPkgPath: "example.com/contracts"},
+		},
+		Contracts: []*model.Contract{{
+			Name:        "OrderEvents",
+			PkgPath:     "example.com/contracts",
+			Annotations: tags.DocTags{"kafka": "", "metrics": "", "trace": ""},
+			Methods: []*model.Method{{
+				Name:        "OrderCreated",
+				Annotations: tags.DocTags{"kafka-topic": "orders", "kafka-retry": "2", "kafka-dlq": "orders.dlq"},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "event", TypeRef: model.TypeRef{TypeID: "example.com/contracts:Order"}},
+				},
+				Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			}},
+		}},
+	}
+	render := renderer.NewRenderer(project, outDir, "example.com/app", "kafka")
+	if err := render.RenderAll(); err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	mustContain(t, filepath.Join(outDir, "deadletter.go"), "func parkedRecord")
+	mustContain(t, filepath.Join(outDir, "options.go"), "func DeadLetter(topic string, retries int, dlq string) Option")
+	mustContain(t, filepath.Join(outDir, "options.go"), "func RetryDelay(duration time.Duration) Option")
+	mustContain(t, filepath.Join(outDir, "options.go"), `dlq:     "orders.dlq"`)
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), "retryTopics(setup.policies)")
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), "client.hold.holdNotDue(client.client, byTopic, time.Now())")
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), `client.park(ctx, "OrderEvents", "OrderCreated", "orders", record,`)
+	mustContain(t, filepath.Join(outDir, "metrics.go"), "consume_parked_total")
+	mustContain(t, filepath.Join(outDir, "tracing.go"), `"kafka.park"`)
+}
+
+func TestRenderAllSubscriberWithoutDeadLetter(t *testing.T) {
+
+	outDir := filepath.Join(t.TempDir(), "kafka")
+	project := &model.Project{
+		Contracts: []*model.Contract{{
+			Name:        "OrderEvents",
+			Annotations: tags.DocTags{"kafka": ""},
+			Methods: []*model.Method{{
+				Name:        "OrderCreated",
+				Annotations: tags.DocTags{"kafka-topic": "orders"},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "event", TypeRef: model.TypeRef{TypeID: "[]byte"}},
+				},
+				Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			}},
+		}},
+	}
+	render := renderer.NewRenderer(project, outDir, "example.com/app", "kafka")
+	if err := render.RenderAll(); err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "deadletter.go")); !os.IsNotExist(err) {
+		t.Fatalf("deadletter.go must not be generated without kafka-retry/kafka-dlq: %v", err)
+	}
+	body, err := os.ReadFile(filepath.Join(outDir, "subscriber.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "client.park") {
+		t.Fatal("subscriber.go must not park records without a policy")
+	}
+}
+
+func TestRenderAllSubscriberWithSchemaRegistry(t *testing.T) {
+
+	outDir := filepath.Join(t.TempDir(), "kafka")
+	project := &model.Project{
+		Types: map[string]*model.Type{
+			"example.com/contracts:Order": {Kind: model.TypeKindStruct, TypeName: "Order", ImportPkgPath: "example.com/contracts", StructFields: []*model.StructField{
+				{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}}},
+			}},
+		},
+		Contracts: []*model.Contract{{
+			Name:        "OrderEvents",
+			PkgPath:     "example.com/contracts",
+			Annotations: tags.DocTags{"kafka": ""},
+			Methods: []*model.Method{{
+				Name:        "OrderCreated",
+				Annotations: tags.DocTags{"kafka-topic": "orders", "kafka-codec": "avro"},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "event", TypeRef: model.TypeRef{TypeID: "example.com/contracts:Order"}},
+				},
+				Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			}},
+		}},
+	}
+	render := renderer.NewRenderer(project, outDir, "example.com/app", "kafka")
+	if err := render.RenderAll(); err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	mustContain(t, filepath.Join(outDir, "registry.go"), "func NewSchemaRegistry")
+	mustContain(t, filepath.Join(outDir, "avro.go"), "func newAvroCodec")
+	mustContain(t, filepath.Join(outDir, "schemas.go"), `name:   "example.com.contracts.Order"`)
+	mustContain(t, filepath.Join(outDir, "options.go"), "func Registry(registry *SchemaRegistry) Option")
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), `setup.codecs["avro"] = newAvroCodec(setup.registry, avroSchemas())`)
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), `unmarshalTopic(ctx, codec, "orders", record.Value, &event)`)
+	if _, err := os.Stat(filepath.Join(outDir, "protobuf.go")); !os.IsNotExist(err) {
+		t.Fatalf("protobuf.go must not be generated without kafka-codec=protobuf: %v", err)
+	}
+}
+
+func TestRenderSubscriberKafkaTest(t *testing.T) {
+
+	root := t.TempDir()
+	outDir := filepath.Join(root, "kafka")
+	contractsDir := filepath.Join(root, "contracts")
+	if err := os.MkdirAll(contractsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	goMod := "module example.com/app\n\ngo 1.26\n\nrequire github.com/twmb/franz-go v1.21.5\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	contractSource := `package contracts
+import "context"
+type Order struct { ID string }
+type OrderEvents interface {
+	OrderCreated(ctx context.Context, event Order) (err error)
+}
+`
+	if err := os.WriteFile(filepath.Join(contractsDir, "contracts.go"), []byte(contractSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &model.Project{
+		Types: map[string]*model.Type{
+			"example.com/app/contracts:Order": {TypeName: "Order", ImportPkgPath: "example.com/app/contracts"},
+		},
+		Contracts: []*model.Contract{{
+			Name:        "OrderEvents",
+			PkgPath:     "example.com/app/contracts",
+			Annotations: tags.DocTags{"kafka": ""},
+			Methods: []*model.Method{{
+				Name:        "OrderCreated",
+				Annotations: tags.DocTags{"kafka-topic": "orders", "kafka-retry": "1", "kafka-dlq": "orders.dlq"},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "event", TypeRef: model.TypeRef{TypeID: "example.com/app/contracts:Order"}},
+				},
+				Results: []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+			}},
+		}},
+	}
+	render := renderer.NewRenderer(project, outDir, "example.com/app", "kafka")
+	if err := render.RenderAll(); err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	if err := render.RenderKafkaTest(); err != nil {
+		t.Fatalf("RenderKafkaTest: %v", err)
+	}
+	mustContain(t, filepath.Join(outDir, "options.go"), "func Producer(produce func(ctx context.Context, records []*kgo.Record) (err error)) Option")
+	mustContain(t, filepath.Join(outDir, "subscriber.go"), "func (client *Client) Deliver(ctx context.Context, records ...*kgo.Record) (err error)")
+	mustContain(t, filepath.Join(outDir, "kafkatest", "broker.go"), "kafka.Producer(broker.park)")
+	brokerTest := `package app_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"example.com/app/contracts"
+	"example.com/app/kafka"
+	"example.com/app/kafka/kafkatest"
+)
+
+type orders struct {
+	failures int
+	handled  []string
+}
+
+func (handler *orders) OrderCreated(_ context.Context, event contracts.Order) (err error) {
+
+	if handler.failures > 0 {
+		handler.failures--
+		return errors.New("handler failed")
+	}
+	handler.handled = append(handler.handled, event.ID)
+	return nil
+}
+
+func TestBroker(t *testing.T) {
+
+	ctx := context.Background()
+	handler := &orders{failures: 1}
+	broker, err := kafkatest.New(slog.New(slog.DiscardHandler), kafka.OrderEvents(handler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer broker.Close()
+	if err = broker.Deliver(ctx, &kgo.Record{Topic: "orders", Value: []byte(` + "`" + `{"ID":"1"}` + "`" + `)}); err != nil {
+		t.Fatal(err)
+	}
+	if parked := broker.Parked("orders.retry.1"); len(parked) != 1 || len(handler.handled) != 0 {
+		t.Fatalf("failed record must be parked to retry topic: %+v", parked)
+	}
+	if err = broker.Redeliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(handler.handled) != 1 || handler.handled[0] != "1" || len(broker.Parked()) != 0 {
+		t.Fatalf("retry must be handled: %v %+v", handler.handled, broker.Parked())
+	}
+	handler.failures = 2
+	if err = broker.Deliver(ctx, &kgo.Record{Topic: "orders", Value: []byte(` + "`" + `{"ID":"2"}` + "`" + `)}); err != nil {
+		t.Fatal(err)
+	}
+	if err = broker.Redeliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if parked := broker.Parked(); len(parked) != 1 || parked[0].Topic != "orders.dlq" {
+		t.Fatalf("exhausted record must stay in DLQ: %+v", parked)
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(root, "broker_test.go"), []byte(brokerTest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	command := exec.Command("go", "test", "-mod=mod", ".")
+	command.Dir = root
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("kafkatest broker test failed: %v\n%s", err, output)
+	}
+}
+
 func mustContain(t *testing.T, filePath string, expected string) {
 
 	t.Helper()
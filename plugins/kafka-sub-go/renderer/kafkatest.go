@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/generated"
+)
+
+// RenderKafkaTest записывает пакет kafkatest с in-memory брокером для тестов подписчика.
+func (r *Renderer) RenderKafkaTest() (err error) {
+
+	hasDeadLetter := r.hasDeadLetter()
+	record := Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record")
+
+	file := NewSrcFile("kafkatest")
+	file.PackageComment(generated.ByToolGateway)
+	file.Comment("Broker — подписчик без Kafka: Deliver передаёт записи зарегистрированным обработчикам.")
+	if hasDeadLetter {
+		file.Comment("Перекладка в retry-топики и DLQ сохраняется в памяти (Parked, Redeliver).")
+	}
+	file.Type().Id("Broker").StructFunc(func(group *Group) {
+		group.Op("*").Qual(r.pkgPath, "Client")
+		group.Line()
+		group.Id("mu").Qual("sync", "Mutex")
+		group.Id("offsets").Map(String()).Int64()
+		if hasDeadLetter {
+			group.Id("parked").Index().Add(record)
+		}
+	})
+	file.Line()
+	file.Comment("New создаёт подписчик с обработчиками из options поверх in-memory брокера; Brokers и Group не требуются.")
+	file.Func().Id("New").Params(Id("log").Op("*").Qual("log/slog", "Logger"), Id("options").Op("...").Qual(r.pkgPath, "Option")).Params(Id("broker").Op("*").Id("Broker"), Err().Error()).BlockFunc(func(group *Group) {
+		group.Id("broker").Op("=").Op("&").Id("Broker").Values(Dict{Id("offsets"): Make(Map(String()).Int64())})
+		group.Id("options").Op("=").Append(Qual("slices", "Clip").Call(Id("options")), Qual(r.pkgPath, "Producer").Call(Id("broker").Dot("park")))
+		group.If(List(Id("broker").Dot("Client"), Err()).Op("=").Qual(r.pkgPath, "New").Call(Id("log"), Id("options").Op("...")), Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
+		group.Return(Id("broker"), Nil())
+	})
+	file.Line()
+	file.Comment("Deliver передаёт копии records обработчикам: partition 0, последовательный offset топика, время записи.")
+	file.Comment("Записи kafkatest издателя передаются напрямую: Deliver(ctx, publisher.Records()...).")
+	file.Func().Params(Id("broker").Op("*").Id("Broker")).Id("Deliver").Params(Id("ctx").Qual("context", "Context"), Id("records").Op("...").Add(record)).Params(Err().Error()).Block(
+		Id("delivered").Op(":=").Make(Index().Add(record), Lit(0), Len(Id("records"))),
+		Id("broker").Dot("mu").Dot("Lock").Call(),
+		For(List(Id("_"), Id("source")).Op(":=").Range().Id("records")).Block(
+			Id("copied").Op(":=").Op("*").Id("source"),
+			Id("copied").Dot("Partition").Op("=").Lit(0),
+			Id("copied").Dot("Offset").Op("=").Id("broker").Dot("offsets").Index(Id("copied").Dot("Topic")),
+			Id("broker").Dot("offsets").Index(Id("copied").Dot("Topic")).Op("++"),
+			If(Id("copied").Dot("Timestamp").Dot("IsZero").Call()).Block(
+				Id("copied").Dot("Timestamp").Op("=").Qual("time", "Now").Call(),
+			),
+			Id("delivered").Op("=").Append(Id("delivered"), Op("&").Id("copied")),
+		),
+		Id("broker").Dot("mu").Dot("Unlock").Call(),
+		Return(Id("broker").Dot("Client").Dot("Deliver").Call(Id("ctx"), Id("delivered").Op("..."))),
+	)
+	if !hasDeadLetter {
+		file.Line()
+		file.Func().Params(Id("broker").Op("*").Id("Broker")).Id("park").Params(Id("_").Qual("context", "Context"), Id("_").Index().Add(record)).Params(Err().Error()).Block(
+			Return(Nil()),
+		)
+		return file.Save(filepath.Join(r.outDir, "kafkatest", "broker.go"))
+	}
+	file.Line()
+	file.Comment("Parked возвращает записи, переложенные в retry-топики и DLQ и ещё не доставленные повторно; topics ограничивает выборку.")
+	file.Func().Params(Id("broker").Op("*").Id("Broker")).Id("Parked").Params(Id("topics").Op("...").String()).Params(Id("records").Index().Add(record)).Block(
+		Id("broker").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("broker").Dot("mu").Dot("Unlock").Call(),
+		For(List(Id("_"), Id("parked")).Op(":=").Range().Id("broker").Dot("parked")).Block(
+			If(Len(Id("topics")).Op("==").Lit(0).Op("||").Qual("slices", "Contains").Call(Id("topics"), Id("parked").Dot("Topic"))).Block(
+				Id("records").Op("=").Append(Id("records"), Id("parked")),
+			),
+		),
+		Return(Id("records")),
+	)
+	file.Line()
+	file.Comment("Redeliver доставляет записи retry-топиков, пока они не закончатся; записи DLQ остаются в Parked.")
+	file.Func().Params(Id("broker").Op("*").Id("Broker")).Id("Redeliver").Params(Id("ctx").Qual("context", "Context")).Params(Err().Error()).Block(
+		For().Block(
+			Var().Id("retries").Index().Add(record),
+			Id("broker").Dot("mu").Dot("Lock").Call(),
+			Id("kept").Op(":=").Id("broker").Dot("parked").Index(Empty(), Lit(0)),
+			For(List(Id("_"), Id("parked")).Op(":=").Range().Id("broker").Dot("parked")).Block(
+				If(Id("isRetry").Call(Id("parked"))).Block(
+					Id("retries").Op("=").Append(Id("retries"), Id("parked")),
+					Continue(),
+				),
+				Id("kept").Op("=").Append(Id("kept"), Id("parked")),
+			),
+			Id("broker").Dot("parked").Op("=").Id("kept"),
+			Id("broker").Dot("mu").Dot("Unlock").Call(),
+			If(Len(Id("retries")).Op("==").Lit(0)).Block(Return(Nil())),
+			If(Err().Op("=").Id("broker").Dot("Deliver").Call(Id("ctx"), Id("retries").Op("...")), Err().Op("!=").Nil()).Block(Return(Err())),
+		),
+	)
+	file.Line()
+	file.Func().Params(Id("broker").Op("*").Id("Broker")).Id("park").Params(Id("_").Qual("context", "Context"), Id("records").Index().Add(record)).Params(Err().Error()).Block(
+		Id("broker").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("broker").Dot("mu").Dot("Unlock").Call(),
+		Id("broker").Dot("parked").Op("=").Append(Id("broker").Dot("parked"), Id("records").Op("...")),
+		Return(Nil()),
+	)
+	file.Line()
+	file.Comment("isRetry отличает запись retry-топика от DLQ по заголовку времени повтора.")
+	file.Func().Id("isRetry").Params(Id("record").Add(record)).Params(Id("ok").Bool()).Block(
+		For(List(Id("_"), Id("header")).Op(":=").Range().Id("record").Dot("Headers")).Block(
+			If(Id("header").Dot("Key").Op("==").Qual(r.pkgPath, "HeaderRetryNotBefore")).Block(Return(True())),
+		),
+		Return(False()),
+	)
+	return file.Save(filepath.Join(r.outDir, "kafkatest", "broker.go"))
+}
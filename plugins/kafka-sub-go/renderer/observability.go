@@ -6,6 +6,21 @@ import "path/filepath"
 
 func (r *Renderer) metricsSource() (source string) {
 
+	parkedField, parkedInit, parkedCollector, parkedObserver := "", "", "", ""
+	if r.hasDeadLetter() {
+		parkedField = "\tparked          *prometheus.CounterVec\n"
+		parkedInit = "\t\tparked: prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: \"tgp_kafka\", Name: \"consume_parked_total\", Help: \"Число записей, переложенных в retry-топики и DLQ.\"}, []string{\"contract\", \"method\", \"topic\", \"kind\"}),\n"
+		parkedCollector = ", result.parked"
+		parkedObserver = `
+func (client *Client) observeParked(contract string, method string, topic string, kind string) {
+
+	if client.metrics == nil {
+		return
+	}
+	client.metrics.parked.WithLabelValues(contract, method, topic, kind).Inc()
+}
+`
+	}
 	return `package ` + filepath.Base(r.outDir) + `
 
 import (
@@ -24,7 +39,7 @@ type metrics struct {
 	handlerDuration *prometheus.HistogramVec
 	lag             *prometheus.GaugeVec
 	lagScrapeErrors *prometheus.CounterVec
-}
+` + parkedField + `}
 
 func newMetrics(registerer prometheus.Registerer) (result *metrics, err error) {
 
@@ -38,8 +53,8 @@ func newMetrics(registerer prometheus.Registerer) (result *metrics, err error) {
 		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{Namespace: "tgp_kafka", Name: "consume_handler_duration_seconds", Help: "Длительность обработчиков."}, []string{"contract", "method", "topic", "result"}),
 		lag: prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "tgp_kafka", Name: "consume_lag_records", Help: "Лаг чтения по partition."}, []string{"topic", "partition"}),
 		lagScrapeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: "tgp_kafka", Name: "consume_lag_scrape_errors_total", Help: "Ошибки обновления лага."}, []string{"cause"}),
-	}
-	for _, collector := range []prometheus.Collector{result.polls, result.pollDuration, result.pollActive, result.records, result.bytes, result.decodeDuration, result.handlerDuration, result.lag, result.lagScrapeErrors} {
+` + parkedInit + `	}
+	for _, collector := range []prometheus.Collector{result.polls, result.pollDuration, result.pollActive, result.records, result.bytes, result.decodeDuration, result.handlerDuration, result.lag, result.lagScrapeErrors` + parkedCollector + `} {
 		if err = registerer.Register(collector); err != nil {
 			return nil, err
 		}
@@ -76,18 +91,33 @@ func (client *Client) observeHandler(contract string, method string, topic strin
 	client.metrics.records.WithLabelValues(contract, method, topic, result, cause).Add(float64(records))
 	client.metrics.bytes.WithLabelValues(contract, method, topic, result).Add(float64(bytes))
 }
-`
+` + parkedObserver
 }
 
 func (r *Renderer) tracingSource() (source string) {
 
+	attributeImport, traceImport, parkedEvent := "", "", ""
+	if r.hasDeadLetter() {
+		attributeImport = "\t\"go.opentelemetry.io/otel/attribute\"\n"
+		traceImport = "\t\"go.opentelemetry.io/otel/trace\"\n"
+		parkedEvent = `
+func traceParked(ctx context.Context, target string, kind string, attempt int) {
+
+	trace.SpanFromContext(ctx).AddEvent("kafka.park", trace.WithAttributes(
+		attribute.String("tgp.park", kind),
+		attribute.String("tgp.park.topic", target),
+		attribute.Int("tgp.retry.attempt", attempt),
+	))
+}
+`
+	}
 	return `package ` + filepath.Base(r.outDir) + `
 
 import (
 	"context"
 
-	"go.opentelemetry.io/otel/codes"
-)
+` + attributeImport + `	"go.opentelemetry.io/otel/codes"
+` + traceImport + `)
 
 func (client *Client) startConsumeSpan(ctx context.Context, contract string, method string, topic string, records int) (spanContext context.Context, finish func(err error)) {
 
@@ -103,5 +133,5 @@ func (client *Client) startConsumeSpan(ctx context.Context, contract string, met
 		span.End()
 	}
 }
-`
+` + parkedEvent
 }
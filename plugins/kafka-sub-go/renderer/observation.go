@@ -8,7 +8,7 @@ import (
 	"tgp/internal/model"
 )
 
-func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, method *model.Method, metrics bool, log bool, trace bool) {
+func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, method *model.Method, metrics bool, log bool, trace bool, deadLetter bool) {
 
 	topic := model.MethodKafkaTopic(r.project, contract, method)
 	codec := model.MethodKafkaCodec(r.project, contract, method)
@@ -27,17 +27,17 @@ func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, met
 				switchGroup.Case(Lit("")).BlockFunc(func(caseGroup *Group) {
 					caseGroup.Id("handler").Op(":=").Id("registered").Dot("handler").Assert(Id(contract.Name + "Handler"))
 					caseGroup.For(List(Id("_"), Id("record")).Op(":=").Range().Id("records")).BlockFunc(func(loop *Group) {
-						r.writeDecodeRecord(loop, metrics, log, contract, method, topic)
+						r.writeDecodeRecord(loop, metrics, log, contract, method, topic, deadLetter)
 						r.writeHandlerPlain(loop, metrics, log, contract, method, topic)
-						loop.If(Err().Op("!=").Nil()).Block(Return(Err()))
+						loop.If(Err().Op("!=").Nil()).Block(r.recordFailed(deadLetter, contract, method, topic, Err())...)
 					})
 				})
 				switchGroup.Case(Lit("Meta")).BlockFunc(func(caseGroup *Group) {
 					caseGroup.Id("handler").Op(":=").Id("registered").Dot("handler").Assert(Id(contract.Name + "MetaHandler"))
 					caseGroup.For(List(Id("_"), Id("record")).Op(":=").Range().Id("records")).BlockFunc(func(loop *Group) {
-						r.writeDecodeRecord(loop, metrics, log, contract, method, topic)
+						r.writeDecodeRecord(loop, metrics, log, contract, method, topic, deadLetter)
 						r.writeHandlerMeta(loop, metrics, log, contract, method, topic)
-						loop.If(Err().Op("!=").Nil()).Block(Return(Err()))
+						loop.If(Err().Op("!=").Nil()).Block(r.recordFailed(deadLetter, contract, method, topic, Err())...)
 					})
 				})
 				switchGroup.Case(Lit("Slice")).BlockFunc(func(caseGroup *Group) {
@@ -45,14 +45,16 @@ func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, met
 					caseGroup.Id("handler").Op(":=").Id("registered").Dot("handler").Assert(Id(contract.Name + "SliceHandler"))
 					caseGroup.Id("events").Op(":=").Make(Index().Add(eventType), Lit(0), Len(Id("records")))
 					caseGroup.Var().Id("totalBytes").Int()
+					r.writeDecodedRecords(caseGroup, deadLetter)
 					caseGroup.For(List(Id("_"), Id("record")).Op(":=").Range().Id("records")).BlockFunc(func(loop *Group) {
-						r.writeDecodeRecord(loop, metrics, log, contract, method, topic)
+						r.writeDecodeRecord(loop, metrics, log, contract, method, topic, deadLetter)
+						r.writeDecodedRecord(loop, deadLetter)
 						loop.Id("events").Op("=").Append(Id("events"), Id("event"))
 						loop.Id("totalBytes").Op("+=").Len(Id("record").Dot("Value"))
 					})
 					caseGroup.If(Len(Id("events")).Op("!=").Lit(0)).BlockFunc(func(ifGroup *Group) {
 						r.writeHandlerSlice(ifGroup, metrics, log, contract, method, topic)
-						ifGroup.If(Err().Op("!=").Nil()).Block(Return(Err()))
+						ifGroup.If(Err().Op("!=").Nil()).Block(r.batchFailed(deadLetter, contract, method, topic)...)
 					})
 				})
 				switchGroup.Case(Lit("Batch")).BlockFunc(func(caseGroup *Group) {
@@ -62,8 +64,10 @@ func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, met
 						Id("Records"): Make(Index().Id("Record").Types(eventType), Lit(0), Len(Id("records"))),
 					})
 					caseGroup.Var().Id("totalBytes").Int()
+					r.writeDecodedRecords(caseGroup, deadLetter)
 					caseGroup.For(List(Id("_"), Id("record")).Op(":=").Range().Id("records")).BlockFunc(func(loop *Group) {
-						r.writeDecodeRecord(loop, metrics, log, contract, method, topic)
+						r.writeDecodeRecord(loop, metrics, log, contract, method, topic, deadLetter)
+						r.writeDecodedRecord(loop, deadLetter)
 						loop.Id("batch").Dot("Records").Op("=").Append(Id("batch").Dot("Records"), Id("Record").Types(eventType).Values(Dict{
 							Id("Value"): Id("event"),
 							Id("Meta"):  Id("metaFromRecord").Call(Id("record")),
@@ -72,7 +76,7 @@ func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, met
 					})
 					caseGroup.If(Len(Id("batch").Dot("Records")).Op("!=").Lit(0)).BlockFunc(func(ifGroup *Group) {
 						r.writeHandlerBatch(ifGroup, metrics, log, contract, method, topic)
-						ifGroup.If(Err().Op("!=").Nil()).Block(Return(Err()))
+						ifGroup.If(Err().Op("!=").Nil()).Block(r.batchFailed(deadLetter, contract, method, topic)...)
 					})
 				})
 				switchGroup.Default().Block(Return(Qual("fmt", "Errorf").Call(Lit("kafka subscriber: unsupported handler form"))))
@@ -82,21 +86,65 @@ func (r *Renderer) writeTopicHandler(group *Group, contract *model.Contract, met
 	})
 }
 
-func (r *Renderer) writeDecodeRecord(group *Group, metrics bool, log bool, contract *model.Contract, method *model.Method, topic string) {
+func (r *Renderer) writeDecodeRecord(group *Group, metrics bool, log bool, contract *model.Contract, method *model.Method, topic string, deadLetter bool) {
 
 	group.Var().Id("event").Add(r.eventType(contract, method))
 	r.writeDecode(group, metrics, log, contract, method, topic)
-	group.If(Err().Op("!=").Nil()).Block(Return(Qual("fmt", "Errorf").Call(Lit("decode "+contract.Name+"."+method.Name+": %w"), Err())))
+	group.If(Err().Op("!=").Nil()).Block(r.recordFailed(deadLetter, contract, method, topic, Qual("fmt", "Errorf").Call(Lit("decode "+contract.Name+"."+method.Name+": %w"), Err()))...)
+}
+
+// recordFailed возвращает ошибку записи или перекладывает запись по политике и переходит к следующей.
+func (r *Renderer) recordFailed(deadLetter bool, contract *model.Contract, method *model.Method, topic string, cause Code) (body []Code) {
+
+	if !deadLetter {
+		return []Code{Return(cause)}
+	}
+	return []Code{
+		If(Err().Op("=").Id("client").Dot("park").Call(Id("ctx"), Lit(contract.Name), Lit(method.Name), Lit(topic), Id("record"), cause), Err().Op("!=").Nil()).Block(Return(Err())),
+		Continue(),
+	}
+}
+
+func (r *Renderer) writeDecodedRecords(group *Group, deadLetter bool) {
+
+	if deadLetter {
+		group.Id("decoded").Op(":=").Make(Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record"), Lit(0), Len(Id("records")))
+	}
+}
+
+func (r *Renderer) writeDecodedRecord(group *Group, deadLetter bool) {
+
+	if deadLetter {
+		group.Id("decoded").Op("=").Append(Id("decoded"), Id("record"))
+	}
+}
+
+// batchFailed возвращает ошибку пакетного обработчика или перекладывает по политике все декодированные записи пакета.
+func (r *Renderer) batchFailed(deadLetter bool, contract *model.Contract, method *model.Method, topic string) (body []Code) {
+
+	if !deadLetter {
+		return []Code{Return(Err())}
+	}
+	return []Code{
+		Id("cause").Op(":=").Err(),
+		For(List(Id("_"), Id("record")).Op(":=").Range().Id("decoded")).Block(
+			If(Err().Op("=").Id("client").Dot("park").Call(Id("ctx"), Lit(contract.Name), Lit(method.Name), Lit(topic), Id("record"), Id("cause")), Err().Op("!=").Nil()).Block(Return(Err())),
+		),
+	}
 }
 
 func (r *Renderer) writeDecode(group *Group, metrics bool, log bool, contract *model.Contract, method *model.Method, topic string) {
 
+	decode := Id("codec").Dot("Unmarshal").Call(Id("record").Dot("Value"), Op("&").Id("event"))
+	if codec := model.MethodKafkaCodec(r.project, contract, method); codec == model.KafkaCodecAvro || codec == model.KafkaCodecProto {
+		decode = Id("unmarshalTopic").Call(Id("ctx"), Id("codec"), Lit(topic), Id("record").Dot("Value"), Op("&").Id("event"))
+	}
 	if !metrics && !log {
-		group.Err().Op("=").Id("codec").Dot("Unmarshal").Call(Id("record").Dot("Value"), Op("&").Id("event"))
+		group.Err().Op("=").Add(decode)
 		return
 	}
 	group.Id("started").Op(":=").Qual("time", "Now").Call()
-	group.Err().Op("=").Id("codec").Dot("Unmarshal").Call(Id("record").Dot("Value"), Op("&").Id("event"))
+	group.Err().Op("=").Add(decode)
 	if metrics {
 		group.Id("client").Dot("observeDecode").Call(Lit(contract.Name), Lit(method.Name), Lit(topic), Len(Id("record").Dot("Value")), Qual("time", "Since").Call(Id("started")), Err())
 	}
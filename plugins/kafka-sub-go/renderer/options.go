@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/model"
 )
 
 func (r *Renderer) renderOptions() (err error) {
@@ -13,6 +15,7 @@ func (r *Renderer) renderOptions() (err error) {
 	file := NewSrcFile(r.pkgName)
 	hasMetrics := r.hasMetrics()
 	hasTrace := r.hasTrace()
+	hasDeadLetter := r.hasDeadLetter()
 
 	file.Type().Id("registeredHandler").Struct(
 		Id("kind").String(),
@@ -22,6 +25,7 @@ func (r *Renderer) renderOptions() (err error) {
 		group.Id("brokers").Index().String()
 		group.Id("group").String()
 		group.Id("maxPollRecords").Int()
+		group.Id("concurrency").Int()
 		group.Id("fetchMinBytes").Int32()
 		group.Id("fetchMaxWait").Qual("time", "Duration")
 		group.Id("resetPosition").Id("ResetPosition")
@@ -37,7 +41,15 @@ func (r *Renderer) renderOptions() (err error) {
 		group.Id("saslName").String()
 		group.Id("clientOptions").Index().Qual("github.com/twmb/franz-go/pkg/kgo", "Opt")
 		group.Id("handlers").Map(String()).Id("registeredHandler")
+		group.Id("producer").Func().Params(Id("ctx").Qual("context", "Context"), Id("records").Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record")).Params(Err().Error())
 		group.Id("err").Error()
+		if r.hasSchemaCodec() {
+			group.Id("registry").Op("*").Id("SchemaRegistry")
+		}
+		if hasDeadLetter {
+			group.Id("policies").Map(String()).Id("deadLetterPolicy")
+			group.Id("retryDelay").Qual("time", "Duration")
+		}
 		if hasMetrics {
 			group.Id("metrics").Qual("github.com/prometheus/client_golang/prometheus", "Registerer")
 			group.Id("lagInterval").Qual("time", "Duration")
@@ -49,11 +61,11 @@ func (r *Renderer) renderOptions() (err error) {
 	file.Line().Comment("Option настраивает подписчик Kafka.")
 	file.Type().Id("Option").Func().Params(Id("setup").Op("*").Id("setup"))
 
-	r.writeOptions(file, hasMetrics, hasTrace)
+	r.writeOptions(file, hasMetrics, hasTrace, hasDeadLetter)
 	return file.Save(filepath.Join(r.outDir, "options.go"))
 }
 
-func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
+func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool, hasDeadLetter bool) {
 
 	file.Line().Comment("Brokers задаёт адреса брокеров Kafka (host:port). Хотя бы один обязателен.")
 	r.writeOption(file, "Brokers", Id("brokers").Op("...").String(), Id("setup").Dot("brokers").Op("=").Append(Id("setup").Dot("brokers"), Id("brokers").Op("...")))
@@ -61,6 +73,14 @@ func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
 	r.writeOption(file, "Group", Id("id").String(), Id("setup").Dot("group").Op("=").Id("id"))
 	file.Line().Comment("MaxPollRecords — максимум записей за один PollRecords.")
 	r.writeOption(file, "MaxPollRecords", Id("n").Int(), Id("setup").Dot("maxPollRecords").Op("=").Id("n"))
+	file.Line().Comment("Concurrency обрабатывает записи опроса в n воркерах: порядок сохраняется в пределах ключа записи.")
+	r.writeOption(file, "Concurrency", Id("n").Int(),
+		If(Id("n").Op("<").Lit(1)).Block(
+			Id("setup").Dot("err").Op("=").Qual("fmt", "Errorf").Call(Lit("kafka concurrency must be positive, got %d"), Id("n")),
+			Return(),
+		),
+		Id("setup").Dot("concurrency").Op("=").Id("n"),
+	)
 	file.Line().Comment("FetchMinBytes — минимальный объём данных перед fetch.")
 	r.writeOption(file, "FetchMinBytes", Id("n").Int32(), Id("setup").Dot("fetchMinBytes").Op("=").Id("n"))
 	file.Line().Comment("FetchMaxWait — максимум ожидания брокера при fetch.")
@@ -85,6 +105,16 @@ func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
 			Id("setup").Dot("codecs").Index(Id("name")).Op("=").Id("c"),
 		)),
 	)
+	if r.hasSchemaCodec() {
+		file.Line().Comment("Registry задаёт клиент schema registry кодеков avro и protobuf.")
+		r.writeOption(file, "Registry", Id("registry").Op("*").Id("SchemaRegistry"),
+			If(Id("registry").Op("==").Nil()).Block(
+				Id("setup").Dot("err").Op("=").Qual("fmt", "Errorf").Call(Lit("kafka schema registry is nil")),
+				Return(),
+			),
+			Id("setup").Dot("registry").Op("=").Id("registry"),
+		)
+	}
 	file.Line().Comment("TLS включает TLS к брокерам Kafka.")
 	file.Func().Id("TLS").Params(Id("config").Op("*").Qual("crypto/tls", "Config")).Id("Option").Block(
 		Return(Func().Params(Id("setup").Op("*").Id("setup")).Block(
@@ -126,6 +156,47 @@ func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
 	)
 	file.Line().Comment("ClientOpt расширяет настройку franz-go. Не для TLS/SASL/Auth.")
 	r.writeOption(file, "ClientOpt", Id("option").Qual("github.com/twmb/franz-go/pkg/kgo", "Opt"), Id("setup").Dot("clientOptions").Op("=").Append(Id("setup").Dot("clientOptions"), Id("option")))
+	file.Line().Comment("Producer отключает подключение к Kafka: записи передаются через Deliver, перекладка в retry/DLQ — функции produce.")
+	file.Comment("Brokers и Group не требуются (in-memory тесты, kafkatest).")
+	r.writeOption(file, "Producer", Id("produce").Func().Params(Id("ctx").Qual("context", "Context"), Id("records").Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record")).Params(Err().Error()),
+		If(Id("produce").Op("==").Nil()).Block(
+			Id("setup").Dot("err").Op("=").Qual("fmt", "Errorf").Call(Lit("kafka producer is nil")),
+			Return(),
+		),
+		Id("setup").Dot("producer").Op("=").Id("produce"),
+	)
+	if hasDeadLetter {
+		file.Line().Comment("RetryDelay задаёт задержку первой ступени retry-топика; каждая следующая ступень удваивает её.")
+		r.writeOption(file, "RetryDelay", Id("duration").Qual("time", "Duration"),
+			If(Id("duration").Op("<").Lit(0)).Block(
+				Id("setup").Dot("err").Op("=").Qual("fmt", "Errorf").Call(Lit("kafka retry delay must not be negative")),
+				Return(),
+			),
+			Id("setup").Dot("retryDelay").Op("=").Id("duration"),
+		)
+		file.Line().Comment("DeadLetter переопределяет политику kafka-retry/kafka-dlq топика; retries=0 и пустой dlq отключают её.")
+		file.Func().Id("DeadLetter").Params(Id("topic").String(), Id("retries").Int(), Id("dlq").String()).Id("Option").Block(
+			Return(Func().Params(Id("setup").Op("*").Id("setup")).Block(
+				If(Id("retries").Op("<").Lit(0)).Block(
+					Id("setup").Dot("err").Op("=").Qual("fmt", "Errorf").Call(Lit("kafka dead letter %q: retries must not be negative"), Id("topic")),
+					Return(),
+				),
+				If(Id("dlq").Op("!=").Lit("").Op("&&").Id("dlq").Op("==").Id("topic")).Block(
+					Id("setup").Dot("err").Op("=").Qual("fmt", "Errorf").Call(Lit("kafka dead letter %q: dlq must differ from topic"), Id("topic")),
+					Return(),
+				),
+				If(Id("retries").Op("==").Lit(0).Op("&&").Id("dlq").Op("==").Lit("")).Block(
+					Delete(Id("setup").Dot("policies"), Id("topic")),
+					Return(),
+				),
+				Id("setup").Dot("policies").Index(Id("topic")).Op("=").Id("deadLetterPolicy").Values(Dict{
+					Id("topic"):   Id("topic"),
+					Id("retries"): Id("retries"),
+					Id("dlq"):     Id("dlq"),
+				}),
+			)),
+		)
+	}
 	if hasMetrics {
 		file.Line().Comment("Metrics включает Prometheus-метрики.")
 		r.writeOption(file, "Metrics", Id("registerer").Qual("github.com/prometheus/client_golang/prometheus", "Registerer"),
@@ -152,6 +223,7 @@ func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
 	file.Line().Func().Id("defaultSetup").Params().Params(Id("result").Op("*").Id("setup")).BlockFunc(func(group *Group) {
 		values := Dict{
 			Id("maxPollRecords"): Lit(500),
+			Id("concurrency"):    Lit(1),
 			Id("fetchMinBytes"):  Lit(1),
 			Id("fetchMaxWait"):   Lit(5).Op("*").Qual("time", "Second"),
 			Id("resetPosition"):  Id("AtStart"),
@@ -159,6 +231,10 @@ func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
 			Id("codecs"):         Id("defaultCodecs").Call(),
 			Id("handlers"):       Make(Map(String()).Id("registeredHandler")),
 		}
+		if hasDeadLetter {
+			values[Id("policies")] = Map(String()).Id("deadLetterPolicy").Values(r.deadLetterPolicies())
+			values[Id("retryDelay")] = Qual("time", "Second")
+		}
 		if hasMetrics {
 			values[Id("lagInterval")] = Lit(15).Op("*").Qual("time", "Second")
 		}
@@ -190,6 +266,25 @@ func (r *Renderer) writeOptions(file GoFile, hasMetrics bool, hasTrace bool) {
 	)
 }
 
+func (r *Renderer) deadLetterPolicies() (policies Dict) {
+
+	policies = Dict{}
+	for _, contract := range r.contracts() {
+		for _, method := range contract.Methods {
+			if !model.MethodKafkaHasDeadLetter(r.project, contract, method) {
+				continue
+			}
+			topic := model.MethodKafkaTopic(r.project, contract, method)
+			policies[Lit(topic)] = Values(Dict{
+				Id("topic"):   Lit(topic),
+				Id("retries"): Lit(model.MethodKafkaRetry(r.project, contract, method)),
+				Id("dlq"):     Lit(model.MethodKafkaDLQ(r.project, contract, method)),
+			})
+		}
+	}
+	return policies
+}
+
 func (r *Renderer) writeOption(file GoFile, name string, parameter Code, body ...Code) {
 
 	file.Func().Id(name).Params(parameter).Id("Option").Block(
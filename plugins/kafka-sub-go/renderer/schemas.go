@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/kafka"
+	"tgp/internal/model"
+)
+
+func (r *Renderer) hasCodec(codecs ...string) (ok bool) {
+
+	for _, contract := range r.contracts() {
+		for _, method := range contract.Methods {
+			if slices.Contains(codecs, model.MethodKafkaCodec(r.project, contract, method)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Renderer) hasSchemaCodec() (ok bool) {
+
+	return r.hasCodec(model.KafkaCodecAvro, model.KafkaCodecProto)
+}
+
+func (r *Renderer) renderSchemas() (err error) {
+
+	if !r.hasSchemaCodec() {
+		return nil
+	}
+	if err = kafka.WriteSchemaRegistry(r.outDir, r.pkgName); err != nil {
+		return
+	}
+	file := NewSrcFile(r.pkgName)
+	for _, codec := range []string{model.KafkaCodecAvro, model.KafkaCodecProto} {
+		if !r.hasCodec(codec) {
+			continue
+		}
+		var definitions Dict
+		if definitions, err = r.schemaDefinitions(codec); err != nil {
+			return
+		}
+		if codec == model.KafkaCodecAvro {
+			err = kafka.WriteAvro(r.outDir, r.pkgName)
+		} else {
+			err = kafka.WriteProtobuf(r.outDir, r.pkgName)
+		}
+		if err != nil {
+			return
+		}
+		name := codec + "Schemas"
+		file.Line().Comment(name + " возвращает схемы тел сообщений кодека " + codec + " по топикам.")
+		file.Func().Id(name).Params().Params(Id("schemas").Map(String()).Id("schemaDefinition")).Block(
+			Return(Map(String()).Id("schemaDefinition").Values(definitions)),
+		)
+	}
+	return file.Save(filepath.Join(r.outDir, "schemas.go"))
+}
+
+func (r *Renderer) schemaDefinitions(codec string) (definitions Dict, err error) {
+
+	definitions = make(Dict)
+	for _, contract := range r.contracts() {
+		for _, method := range contract.Methods {
+			if model.MethodKafkaCodec(r.project, contract, method) != codec {
+				continue
+			}
+			message, _ := model.MethodKafkaMessageArg(r.project, contract, method)
+			var schema kafka.MessageSchema
+			if schema, err = kafka.SchemaFor(codec, r.project, message.TypeRef); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", contract.Name, method.Name, err)
+			}
+			definitions[Lit(model.MethodKafkaTopic(r.project, contract, method))] = Values(Dict{
+				Id("name"):   Lit(schema.Name),
+				Id("schema"): Lit(schema.Schema),
+			})
+		}
+	}
+	return definitions, nil
+}
+
+// schemaCodecs регистрирует кодеки schema registry, если задан Registry и кодек не переопределён через Codec.
+func (r *Renderer) schemaCodecs(group *Group) {
+
+	if !r.hasSchemaCodec() {
+		return
+	}
+	group.If(Id("setup").Dot("registry").Op("!=").Nil()).BlockFunc(func(block *Group) {
+		if r.hasCodec(model.KafkaCodecAvro) {
+			block.If(Id("setup").Dot("codecs").Index(Lit(model.KafkaCodecAvro)).Op("==").Nil()).Block(
+				Id("setup").Dot("codecs").Index(Lit(model.KafkaCodecAvro)).Op("=").Id("newAvroCodec").Call(Id("setup").Dot("registry"), Id("avroSchemas").Call()),
+			)
+		}
+		if r.hasCodec(model.KafkaCodecProto) {
+			block.If(Id("setup").Dot("codecs").Index(Lit(model.KafkaCodecProto)).Op("==").Nil()).Block(
+				Id("setup").Dot("codecs").Index(Lit(model.KafkaCodecProto)).Op("=").Id("newProtobufCodec").Call(Id("setup").Dot("registry"), Id("protobufSchemas").Call()),
+			)
+		}
+	})
+}
@@ -17,6 +17,7 @@ func (r *Renderer) renderSubscriber() (err error) {
 	hasMetrics := r.hasMetrics()
 	hasTrace := r.hasTrace()
 	hasLog := r.hasLog()
+	hasDeadLetter := r.hasDeadLetter()
 
 	file.Comment("Client управляет единой consumer group Kafka.")
 	file.Type().Id("Client").StructFunc(func(group *Group) {
@@ -24,11 +25,18 @@ func (r *Renderer) renderSubscriber() (err error) {
 		group.Id("client").Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Client")
 		group.Id("handlers").Map(String()).Id("TopicHandler")
 		group.Id("maxPoll").Int()
+		group.Id("concurrency").Int()
 		group.Id("commitAfter").Bool()
 		group.Id("stop").Qual("context", "CancelFunc")
 		group.Id("mu").Qual("sync", "Mutex")
 		group.Id("running").Bool()
 		group.Id("closed").Bool()
+		group.Id("producer").Func().Params(Id("ctx").Qual("context", "Context"), Id("records").Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record")).Params(Err().Error())
+		if hasDeadLetter {
+			group.Id("policies").Map(String()).Id("deadLetterPolicy")
+			group.Id("retryDelay").Qual("time", "Duration")
+			group.Id("hold").Id("retryHold")
+		}
 		if hasMetrics {
 			group.Id("metrics").Op("*").Id("metrics")
 			group.Id("lagStop").Qual("context", "CancelFunc")
@@ -38,16 +46,20 @@ func (r *Renderer) renderSubscriber() (err error) {
 			group.Id("tracer").Qual("go.opentelemetry.io/otel/trace", "Tracer")
 		}
 	})
-	r.writeNew(file, hasMetrics, hasTrace, hasLog)
+	r.writeNew(file, hasMetrics, hasTrace, hasLog, hasDeadLetter)
 	r.writeClose(file, hasMetrics)
-	r.writeRun(file, hasMetrics)
+	r.writeRun(file, hasMetrics, hasDeadLetter)
+	r.writeDeliver(file)
+	if hasDeadLetter {
+		r.writePark(file, hasMetrics, hasTrace)
+	}
 	if hasMetrics {
 		r.writeLag(file)
 	}
 	return file.Save(filepath.Join(r.outDir, "subscriber.go"))
 }
 
-func (r *Renderer) writeNew(file GoFile, hasMetrics bool, hasTrace bool, hasLog bool) {
+func (r *Renderer) writeNew(file GoFile, hasMetrics bool, hasTrace bool, hasLog bool, hasDeadLetter bool) {
 
 	file.Line().Comment("New создаёт подписчик Kafka.")
 	file.Func().Id("New").Params(Id("log").Op("*").Qual("log/slog", "Logger"), Id("options").Op("...").Id("Option")).Params(Id("client").Op("*").Id("Client"), Id("err").Error()).BlockFunc(func(group *Group) {
@@ -57,8 +69,11 @@ func (r *Renderer) writeNew(file GoFile, hasMetrics bool, hasTrace bool, hasLog
 			If(Id("option").Op("!=").Nil()).Block(Id("option").Call(Id("setup"))),
 		)
 		group.If(Err().Op("=").Id("validateSetup").Call(Id("setup")), Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
-		group.If(Len(Id("setup").Dot("brokers")).Op("==").Lit(0)).Block(Return(Nil(), Qual("fmt", "Errorf").Call(Lit("kafka subscriber: brokers are required"))))
-		group.If(Id("setup").Dot("group").Op("==").Lit("")).Block(Return(Nil(), Qual("fmt", "Errorf").Call(Lit("kafka subscriber: group is required"))))
+		group.If(Id("setup").Dot("producer").Op("==").Nil()).Block(
+			If(Len(Id("setup").Dot("brokers")).Op("==").Lit(0)).Block(Return(Nil(), Qual("fmt", "Errorf").Call(Lit("kafka subscriber: brokers are required")))),
+			If(Id("setup").Dot("group").Op("==").Lit("")).Block(Return(Nil(), Qual("fmt", "Errorf").Call(Lit("kafka subscriber: group is required")))),
+		)
+		r.schemaCodecs(group)
 		requiredCodecs := make([]string, 0)
 		seenCodec := make(map[string]struct{})
 		for _, contract := range r.contracts() {
@@ -78,49 +93,72 @@ func (r *Renderer) writeNew(file GoFile, hasMetrics bool, hasTrace bool, hasLog
 		for _, codec := range requiredCodecs {
 			group.If(Id("setup").Dot("codecs").Index(Lit(codec)).Op("==").Nil()).Block(Return(Nil(), Qual("fmt", "Errorf").Call(Lit("kafka subscriber: codec "+codec+" is required"))))
 		}
+		consumeTopics := r.topicLiterals()
+		if hasDeadLetter {
+			group.Id("consumeTopics").Op(":=").Index().String().Values(r.topicLiterals()...)
+			group.For(Id("topic").Op(":=").Range().Id("setup").Dot("policies")).Block(
+				If(Op("!").Qual("slices", "Contains").Call(Id("consumeTopics"), Id("topic"))).Block(
+					Return(Nil(), Qual("fmt", "Errorf").Call(Lit("kafka subscriber: dead letter topic %q is not consumed"), Id("topic"))),
+				),
+			)
+			group.Id("consumeTopics").Op("=").Append(Id("consumeTopics"), Id("retryTopics").Call(Id("setup").Dot("policies")).Op("..."))
+			consumeTopics = []Code{Id("consumeTopics").Op("...")}
+		}
 		options := []Code{
 			Qual("github.com/twmb/franz-go/pkg/kgo", "SeedBrokers").Call(Id("setup").Dot("brokers").Op("...")),
 			Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumerGroup").Call(Id("setup").Dot("group")),
-			Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumeTopics").Call(r.topicLiterals()...),
+			Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumeTopics").Call(consumeTopics...),
 			Qual("github.com/twmb/franz-go/pkg/kgo", "BlockRebalanceOnPoll").Call(),
 			Qual("github.com/twmb/franz-go/pkg/kgo", "FetchMinBytes").Call(Id("setup").Dot("fetchMinBytes")),
 			Qual("github.com/twmb/franz-go/pkg/kgo", "FetchMaxWait").Call(Id("setup").Dot("fetchMaxWait")),
 		}
-		group.Id("clientOptions").Op(":=").Index().Qual("github.com/twmb/franz-go/pkg/kgo", "Opt").Values(options...)
-		group.If(Id("setup").Dot("resetPosition").Op("==").Id("AtEnd")).Block(
-			Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumeResetOffset").Call(Qual("github.com/twmb/franz-go/pkg/kgo", "NewOffset").Call().Dot("AtEnd").Call())),
-		).Else().Block(
-			Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumeResetOffset").Call(Qual("github.com/twmb/franz-go/pkg/kgo", "NewOffset").Call().Dot("AtStart").Call())),
-		)
-		group.If(Id("setup").Dot("commitAfter")).Block(Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "DisableAutoCommit").Call()))
-		group.If(Id("setup").Dot("tlsConfig").Op("!=").Nil()).Block(
-			Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "DialTLSConfig").Call(Id("setup").Dot("tlsConfig"))),
-		)
-		group.If(Id("setup").Dot("saslName").Op("!=").Lit("")).Block(
-			Var().Id("mechanism").Qual("github.com/twmb/franz-go/pkg/sasl", "Mechanism"),
-			If(List(Id("mechanism"), Err()).Op("=").Id("saslMechanism").Call(Id("setup").Dot("saslName"), Id("setup").Dot("authUser"), Id("setup").Dot("authPassword")), Err().Op("!=").Nil()).Block(
-				Return(Nil(), Err()),
-			),
-			Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "SASL").Call(Id("mechanism"))),
-		)
-		group.Id("clientOptions").Op("=").Append(Id("clientOptions"), Id("setup").Dot("clientOptions").Op("..."))
 		group.Var().Id("kafkaClient").Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Client")
-		group.If(List(Id("kafkaClient"), Err()).Op("=").Qual("github.com/twmb/franz-go/pkg/kgo", "NewClient").Call(Id("clientOptions").Op("...")), Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
+		group.If(Id("setup").Dot("producer").Op("==").Nil()).BlockFunc(func(build *Group) {
+			build.Id("clientOptions").Op(":=").Index().Qual("github.com/twmb/franz-go/pkg/kgo", "Opt").Values(options...)
+			build.If(Id("setup").Dot("resetPosition").Op("==").Id("AtEnd")).Block(
+				Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumeResetOffset").Call(Qual("github.com/twmb/franz-go/pkg/kgo", "NewOffset").Call().Dot("AtEnd").Call())),
+			).Else().Block(
+				Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "ConsumeResetOffset").Call(Qual("github.com/twmb/franz-go/pkg/kgo", "NewOffset").Call().Dot("AtStart").Call())),
+			)
+			build.If(Id("setup").Dot("commitAfter")).Block(Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "DisableAutoCommit").Call()))
+			build.If(Id("setup").Dot("tlsConfig").Op("!=").Nil()).Block(
+				Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "DialTLSConfig").Call(Id("setup").Dot("tlsConfig"))),
+			)
+			build.If(Id("setup").Dot("saslName").Op("!=").Lit("")).Block(
+				Var().Id("mechanism").Qual("github.com/twmb/franz-go/pkg/sasl", "Mechanism"),
+				If(List(Id("mechanism"), Err()).Op("=").Id("saslMechanism").Call(Id("setup").Dot("saslName"), Id("setup").Dot("authUser"), Id("setup").Dot("authPassword")), Err().Op("!=").Nil()).Block(
+					Return(Nil(), Err()),
+				),
+				Id("clientOptions").Op("=").Append(Id("clientOptions"), Qual("github.com/twmb/franz-go/pkg/kgo", "SASL").Call(Id("mechanism"))),
+			)
+			build.Id("clientOptions").Op("=").Append(Id("clientOptions"), Id("setup").Dot("clientOptions").Op("..."))
+			build.If(List(Id("kafkaClient"), Err()).Op("=").Qual("github.com/twmb/franz-go/pkg/kgo", "NewClient").Call(Id("clientOptions").Op("...")), Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
+		})
 		values := Dict{
 			Id("log"):         Id("log"),
 			Id("client"):      Id("kafkaClient"),
 			Id("commitAfter"): Id("setup").Dot("commitAfter"),
+			Id("producer"):    Id("setup").Dot("producer"),
 			Id("maxPoll"):     Id("setup").Dot("maxPollRecords"),
+			Id("concurrency"): Id("setup").Dot("concurrency"),
 			Id("handlers"):    Make(Map(String()).Id("TopicHandler")),
 		}
+		if hasDeadLetter {
+			values[Id("policies")] = Id("setup").Dot("policies")
+			values[Id("retryDelay")] = Id("setup").Dot("retryDelay")
+		}
 		if hasMetrics {
-			values[Id("topics")] = Index().String().Values(r.topicLiterals()...)
+			if hasDeadLetter {
+				values[Id("topics")] = Id("consumeTopics")
+			} else {
+				values[Id("topics")] = Index().String().Values(r.topicLiterals()...)
+			}
 		}
 		group.Id("client").Op("=").Op("&").Id("Client").Values(values)
 		if hasMetrics {
 			group.If(Id("setup").Dot("metrics").Op("!=").Nil()).Block(
 				If(List(Id("client").Dot("metrics"), Err()).Op("=").Id("newMetrics").Call(Id("setup").Dot("metrics")), Err().Op("!=").Nil()).Block(
-					Id("kafkaClient").Dot("Close").Call(),
+					Id("client").Dot("Close").Call(),
 					Return(Nil(), Err()),
 				),
 				If(Id("setup").Dot("lagInterval").Op(">").Lit(0)).Block(Id("client").Dot("startLagLoop").Call(Id("setup").Dot("lagInterval"))),
@@ -131,9 +169,16 @@ func (r *Renderer) writeNew(file GoFile, hasMetrics bool, hasTrace bool, hasLog
 		}
 		for _, contract := range r.contracts() {
 			for _, method := range contract.Methods {
-				r.writeTopicHandler(group, contract, method, hasMetrics, hasLog, hasTrace)
+				r.writeTopicHandler(group, contract, method, hasMetrics, hasLog, hasTrace, hasDeadLetter)
 			}
 		}
+		if hasDeadLetter {
+			group.For(List(Id("_"), Id("policy")).Op(":=").Range().Id("client").Dot("policies")).Block(
+				For(Id("attempt").Op(":=").Lit(1), Id("attempt").Op("<=").Id("policy").Dot("retries"), Id("attempt").Op("++")).Block(
+					Id("client").Dot("handlers").Index(Id("retryTopic").Call(Id("policy").Dot("topic"), Id("attempt"))).Op("=").Id("client").Dot("handlers").Index(Id("policy").Dot("topic")),
+				),
+			)
+		}
 		group.Return(Id("client"), Nil())
 	})
 }
@@ -156,7 +201,7 @@ func (r *Renderer) writeClose(file GoFile, hasMetrics bool) {
 	})
 }
 
-func (r *Renderer) writeRun(file GoFile, hasMetrics bool) {
+func (r *Renderer) writeRun(file GoFile, hasMetrics bool, hasDeadLetter bool) {
 
 	file.Line().Comment("Run запускает цикл чтения до отмены контекста или ошибки.")
 	file.Func().Params(Id("client").Op("*").Id("Client")).Id("Run").Params(Id("ctx").Qual("context", "Context")).Params(Id("err").Error()).BlockFunc(func(group *Group) {
@@ -183,7 +228,13 @@ func (r *Renderer) writeRun(file GoFile, hasMetrics bool) {
 				loop.Id("pollStarted").Op(":=").Qual("time", "Now").Call()
 				loop.If(Id("client").Dot("metrics").Op("!=").Nil()).Block(Id("client").Dot("metrics").Dot("pollActive").Dot("Set").Call(Lit(1)))
 			}
-			loop.Id("fetches").Op(":=").Id("client").Dot("client").Dot("PollRecords").Call(Id("runContext"), Id("maxPoll"))
+			if hasDeadLetter {
+				loop.List(Id("pollContext"), Id("cancelPoll")).Op(":=").Id("client").Dot("hold").Dot("pollContext").Call(Id("runContext"), Id("client").Dot("client"), Qual("time", "Now").Call())
+				loop.Id("fetches").Op(":=").Id("client").Dot("client").Dot("PollRecords").Call(Id("pollContext"), Id("maxPoll"))
+				loop.Id("cancelPoll").Call()
+			} else {
+				loop.Id("fetches").Op(":=").Id("client").Dot("client").Dot("PollRecords").Call(Id("runContext"), Id("maxPoll"))
+			}
 			loop.If(Id("fetches").Dot("IsClientClosed").Call()).Block(
 				r.pollFinished(hasMetrics, "ok"),
 				Return(Nil()),
@@ -191,11 +242,27 @@ func (r *Renderer) writeRun(file GoFile, hasMetrics bool) {
 			loop.If(List(Id("fetchErrs")).Op(":=").Id("fetches").Dot("Errors").Call(), Len(Id("fetchErrs")).Op(">").Lit(0)).BlockFunc(func(errors *Group) {
 				errors.For(List(Id("_"), Id("fetchErr")).Op(":=").Range().Id("fetchErrs")).Block(
 					If(Id("fetchErr").Dot("Err").Op("==").Id("runContext").Dot("Err").Call()).Block(Id("client").Dot("client").Dot("AllowRebalance").Call(), Return(Id("runContext").Dot("Err").Call())),
+					r.pollDeadlineSkip(hasDeadLetter),
 					Id("client").Dot("log").Dot("Error").Call(Lit("kafka fetch error"), Qual("log/slog", "String").Call(Lit("topic"), Id("fetchErr").Dot("Topic")), Qual("log/slog", "Int").Call(Lit("partition"), Int().Call(Id("fetchErr").Dot("Partition"))), Qual("log/slog", "Any").Call(Lit("error"), Id("fetchErr").Dot("Err"))),
 				)
 			})
 			loop.Id("byTopic").Op(":=").Id("groupRecordsByTopic").Call(Id("fetches"))
-			loop.If(Err().Op("=").Id("dispatchTopics").Call(Id("runContext"), Id("byTopic"), Id("client").Dot("handlers")), Err().Op("!=").Nil()).Block(
+			if hasDeadLetter {
+				loop.Id("client").Dot("hold").Dot("holdNotDue").Call(Id("client").Dot("client"), Id("byTopic"), Qual("time", "Now").Call())
+			}
+			loop.If(Id("client").Dot("concurrency").Op(">").Lit(1)).Block(
+				Var().Id("committable").Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record"),
+				If(List(Id("committable"), Err()).Op("=").Id("dispatchTopicsConcurrent").Call(Id("runContext"), Id("byTopic"), Id("client").Dot("handlers"), Id("client").Dot("concurrency")), Err().Op("!=").Nil()).Block(
+					If(Id("client").Dot("commitAfter").Op("&&").Len(Id("committable")).Op(">").Lit(0)).Block(
+						If(Id("commitErr").Op(":=").Id("client").Dot("client").Dot("CommitRecords").Call(Id("runContext"), Id("committable").Op("...")), Id("commitErr").Op("!=").Nil()).Block(
+							Err().Op("=").Qual("errors", "Join").Call(Err(), Id("commitErr")),
+						),
+					),
+					r.pollFinished(hasMetrics, "error"),
+					Id("client").Dot("client").Dot("AllowRebalance").Call(),
+					Return(Err()),
+				),
+			).Else().If(Err().Op("=").Id("dispatchTopics").Call(Id("runContext"), Id("byTopic"), Id("client").Dot("handlers")), Err().Op("!=").Nil()).Block(
 				r.pollFinished(hasMetrics, "error"),
 				Id("client").Dot("client").Dot("AllowRebalance").Call(),
 				Return(Err()),
@@ -213,6 +280,69 @@ func (r *Renderer) writeRun(file GoFile, hasMetrics bool) {
 	})
 }
 
+func (r *Renderer) writeDeliver(file GoFile) {
+
+	file.Line().Comment("Deliver передаёт records обработчикам подписчика так же, как записи опроса, без commit offset и задержки retry-топиков.")
+	file.Comment("Предназначен для тестов с опцией Producer; возвращает первую ошибку обработки.")
+	file.Func().Params(Id("client").Op("*").Id("Client")).Id("Deliver").Params(Id("ctx").Qual("context", "Context"), Id("records").Op("...").Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record")).Params(Err().Error()).Block(
+		If(Id("client").Op("==").Nil()).Block(Return(Qual("fmt", "Errorf").Call(Lit("kafka subscriber: client is nil")))),
+		Id("byTopic").Op(":=").Make(Map(String()).Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record")),
+		For(List(Id("_"), Id("record")).Op(":=").Range().Id("records")).Block(
+			Id("byTopic").Index(Id("record").Dot("Topic")).Op("=").Append(Id("byTopic").Index(Id("record").Dot("Topic")), Id("record")),
+		),
+		If(Id("client").Dot("concurrency").Op(">").Lit(1)).Block(
+			List(Id("_"), Err()).Op("=").Id("dispatchTopicsConcurrent").Call(Id("ctx"), Id("byTopic"), Id("client").Dot("handlers"), Id("client").Dot("concurrency")),
+			Return(Err()),
+		),
+		Return(Id("dispatchTopics").Call(Id("ctx"), Id("byTopic"), Id("client").Dot("handlers"))),
+	)
+}
+
+func (r *Renderer) writePark(file GoFile, hasMetrics bool, hasTrace bool) {
+
+	file.Line().Comment("park перекладывает упавшую запись в retry-топик или DLQ; без политики возвращает cause.")
+	file.Func().Params(Id("client").Op("*").Id("Client")).Id("park").Params(
+		Id("ctx").Qual("context", "Context"),
+		Id("contract").String(),
+		Id("method").String(),
+		Id("topic").String(),
+		Id("record").Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record"),
+		Id("cause").Error(),
+	).Params(Err().Error()).BlockFunc(func(group *Group) {
+		group.List(Id("policy"), Id("ok")).Op(":=").Id("client").Dot("policies").Index(Id("topic"))
+		group.If(Op("!").Id("ok")).Block(Return(Id("cause")))
+		group.List(Id("target"), Id("kind"), Id("attempt"), Id("ok")).Op(":=").Id("parkDestination").Call(Id("policy"), Id("record"))
+		group.If(Op("!").Id("ok")).Block(Return(Id("cause")))
+		group.Id("notBefore").Op(":=").Qual("time", "Now").Call().Dot("Add").Call(Id("retryDelay").Call(Id("client").Dot("retryDelay"), Id("attempt")))
+		group.Id("parked").Op(":=").Id("parkedRecord").Call(Id("record"), Id("target"), Id("kind"), Id("attempt"), Id("notBefore"), Id("cause"))
+		group.If(Id("client").Dot("producer").Op("!=").Nil()).Block(
+			Err().Op("=").Id("client").Dot("producer").Call(Id("ctx"), Index().Op("*").Qual("github.com/twmb/franz-go/pkg/kgo", "Record").Values(Id("parked"))),
+		).Else().Block(
+			Err().Op("=").Id("client").Dot("client").Dot("ProduceSync").Call(Id("ctx"), Id("parked")).Dot("FirstErr").Call(),
+		)
+		group.If(Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("kafka subscriber: park %s.%s to %s: %w (cause: %w)"), Id("contract"), Id("method"), Id("target"), Err(), Id("cause"))),
+		)
+		group.Id("client").Dot("log").Dot("Warn").Call(Lit("kafka record parked"), Lit("tgp.contract"), Id("contract"), Lit("tgp.method"), Id("method"), Lit("messaging.destination"), Id("topic"), Lit("tgp.park"), String().Call(Id("kind")), Lit("tgp.park.topic"), Id("target"), Lit("tgp.retry.attempt"), Id("attempt"), Lit("messaging.kafka.partition"), Id("record").Dot("Partition"), Lit("messaging.kafka.offset"), Id("record").Dot("Offset"), Lit("error"), Id("cause"))
+		if hasMetrics {
+			group.Id("client").Dot("observeParked").Call(Id("contract"), Id("method"), Id("topic"), String().Call(Id("kind")))
+		}
+		if hasTrace {
+			group.Id("traceParked").Call(Id("ctx"), Id("target"), String().Call(Id("kind")), Id("attempt"))
+		}
+		group.Return(Nil())
+	})
+}
+
+// pollDeadlineSkip пропускает истечение опроса, ограниченного временем возобновления retry-partition.
+func (r *Renderer) pollDeadlineSkip(hasDeadLetter bool) Code {
+
+	if !hasDeadLetter {
+		return Null()
+	}
+	return If(Id("fetchErr").Dot("Err").Op("==").Qual("context", "DeadlineExceeded")).Block(Continue())
+}
+
 func (r *Renderer) pollFinished(hasMetrics bool, result string) Code {
 
 	if !hasMetrics {
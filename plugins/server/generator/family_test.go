@@ -9,6 +9,7 @@ import (
 
 	"tgp/internal/model"
 	"tgp/internal/tags"
+	"tgp/plugins/server/renderer"
 )
 
 func TestGenerateServerSkipsNonHTTPFamily(t *testing.T) {
@@ -35,7 +36,7 @@ func TestGenerateServerSkipsNonHTTPFamily(t *testing.T) {
 			}},
 		}},
 	}
-	if err := GenerateServer(project, "OrderEvents", outDir); err != nil {
+	if err := GenerateServer(project, "OrderEvents", outDir, renderer.TargetFiber); err != nil {
 		t.Fatalf("GenerateServer: %v", err)
 	}
 	entries, err := os.ReadDir(outDir)
@@ -68,7 +69,7 @@ func TestGenerateServerAcceptsHTTPFamily(t *testing.T) {
 			}},
 		}},
 	}
-	if err := GenerateServer(project, "Http", outDir); err != nil {
+	if err := GenerateServer(project, "Http", outDir, renderer.TargetFiber); err != nil {
 		t.Fatalf("GenerateServer: %v", err)
 	}
 	if _, err := os.Stat(filepath.Join(outDir, "http-http.go")); err != nil {
@@ -14,7 +14,7 @@ import (
 	"tgp/plugins/server/renderer"
 )
 
-func GenerateServer(project *model.Project, contractID string, outDir string) (err error) {
+func GenerateServer(project *model.Project, contractID string, outDir string, target string) (err error) {
 
 	if err = validate.Project(project); err != nil {
 		return fmt.Errorf("invalid project: %w", err)
@@ -50,7 +50,7 @@ func GenerateServer(project *model.Project, contractID string, outDir string) (e
 		project:          project,
 		contract:         contract,
 		outDir:           outDir,
-		contractRenderer: renderer.NewContractRenderer(project, contract, outDir),
+		contractRenderer: renderer.NewContractRenderer(project, contract, outDir, target),
 	}
 
 	if err = gen.generate(); err != nil {
@@ -88,7 +88,7 @@ func getServerType(project *model.Project, contract *model.Contract) (serverType
 	return
 }
 
-func GenerateTransportFiles(project *model.Project, outDir string, contracts ...string) (err error) {
+func GenerateTransportFiles(project *model.Project, outDir string, target string, contracts ...string) (err error) {
 
 	if err = validate.Project(project); err != nil {
 		return fmt.Errorf("invalid project: %w", err)
@@ -104,7 +104,7 @@ func GenerateTransportFiles(project *model.Project, outDir string, contracts ...
 	gen := &generator{
 		project:           project,
 		outDir:            outDir,
-		transportRenderer: renderer.NewTransportRenderer(project, outDir),
+		transportRenderer: renderer.NewTransportRenderer(project, outDir, target),
 	}
 
 	if len(contracts) > 0 {
@@ -113,7 +113,7 @@ func GenerateTransportFiles(project *model.Project, outDir string, contracts ...
 			return fmt.Errorf("filter contracts: %w", err)
 		}
 		gen.project = filteredProject
-		gen.transportRenderer = renderer.NewTransportRenderer(filteredProject, outDir)
+		gen.transportRenderer = renderer.NewTransportRenderer(filteredProject, outDir, target)
 	}
 
 	for _, contract := range gen.project.Contracts {
@@ -237,6 +237,22 @@ func (g *generator) generateTransport() (err error) {
 		}
 	}
 
+	if err = g.transportRenderer.RenderTransportValidation(); err != nil {
+		return fmt.Errorf("render transport validation: %w", err)
+	}
+
+	if err = g.transportRenderer.RenderTransportLimits(); err != nil {
+		return fmt.Errorf("render transport limits: %w", err)
+	}
+
+	if err = g.transportRenderer.RenderTransportSecurity(); err != nil {
+		return fmt.Errorf("render transport security: %w", err)
+	}
+
+	if err = g.transportRenderer.RenderTransportIdempotency(); err != nil {
+		return fmt.Errorf("render transport idempotency: %w", err)
+	}
+
 	return
 }
 
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+	"tgp/plugins/server/renderer"
+)
+
+func TestGenerateServerNetHTTPBuilds(t *testing.T) {
+
+	root := t.TempDir()
+	t.Chdir(root)
+	goMod := "module example.com/app\n\ngo 1.26\n\nrequire (\n\tgithub.com/google/uuid v1.6.0\n\tgithub.com/prometheus/client_golang v1.23.2\n)\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	contractSource := `package contracts
+
+import "context"
+
+type Orders interface {
+	Get(ctx context.Context, id string) (name string, err error)
+	Create(ctx context.Context, name string) (id string, err error)
+	Health(ctx context.Context) (status string, err error)
+}
+
+type Feeds interface {
+	Watch(ctx context.Context) (ticks <-chan string, err error)
+}
+`
+	if err := os.MkdirAll(filepath.Join(root, "contracts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "contracts", "orders.go"), []byte(contractSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &model.Project{
+		ModulePath:  "example.com/app",
+		Annotations: tags.DocTags{model.TagSecurity: "http:bearer"},
+		Contracts: []*model.Contract{{
+			ID:          "Orders",
+			Name:        "Orders",
+			PkgPath:     "example.com/app/contracts",
+			Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagServerJsonRPC: "", model.TagMetrics: ""},
+			Methods: []*model.Method{{
+				Name:        "Get",
+				Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/orders/:id", model.TagScopes: "orders.read"},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}},
+				},
+				Results: []*model.Variable{
+					{Name: "name", TypeRef: model.TypeRef{TypeID: "string"}},
+					{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+				},
+			}, {
+				Name:        "Create",
+				Annotations: tags.DocTags{model.TagIdempotent: ""},
+				Args: []*model.Variable{
+					{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+					{Name: "name", TypeRef: model.TypeRef{TypeID: "string"}},
+				},
+				Results: []*model.Variable{
+					{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}},
+					{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+				},
+			}, {
+				Name:        "Health",
+				Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/health", model.TagSecurity: model.SecurityNone},
+				Args:        []*model.Variable{{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}},
+				Results: []*model.Variable{
+					{Name: "status", TypeRef: model.TypeRef{TypeID: "string"}},
+					{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+				},
+			}},
+		}, {
+			ID:          "Feeds",
+			Name:        "Feeds",
+			PkgPath:     "example.com/app/contracts",
+			Annotations: tags.DocTags{model.TagServerSSE: "", model.TagMetrics: ""},
+			Methods: []*model.Method{{
+				Name:        "Watch",
+				Annotations: tags.DocTags{model.TagStream: model.StreamModeServer, model.TagSSEPath: "/sse/orders/watch"},
+				Args:        []*model.Variable{{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}},
+				Results: []*model.Variable{
+					{Name: "ticks", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "string"}, ChanDirection: 2}},
+					{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+				},
+			}},
+		}},
+	}
+	for _, contract := range []string{"Orders", "Feeds"} {
+		if err := GenerateServer(project, contract, "transport", renderer.TargetNetHTTP); err != nil {
+			t.Fatalf("GenerateServer %s: %v", contract, err)
+		}
+	}
+	if err := GenerateTransportFiles(project, "transport", renderer.TargetNetHTTP); err != nil {
+		t.Fatalf("GenerateTransportFiles: %v", err)
+	}
+	serverTest := `package transport_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/app/transport"
+	"example.com/app/transport/srvctx"
+)
+
+type orders struct {
+	calls int
+}
+
+func (svc *orders) Get(_ context.Context, id string) (name string, err error) {
+
+	svc.calls++
+	return "order " + id, nil
+}
+
+func (svc *orders) Create(_ context.Context, name string) (id string, err error) {
+
+	svc.calls++
+	return name, nil
+}
+
+func (svc *orders) Health(_ context.Context) (status string, err error) {
+
+	return "ok", nil
+}
+
+func (svc *orders) Watch(_ context.Context) (ticks <-chan string, err error) {
+
+	svc.calls++
+	out := make(chan string)
+	close(out)
+	return out, nil
+}
+
+type tokens struct{}
+
+func (tokens) AuthenticateBearer(_ context.Context, token string) (principal *srvctx.Principal, err error) {
+
+	return &srvctx.Principal{Subject: token, Scopes: []string{"orders.read"}}, nil
+}
+
+func serve(srv http.Handler, method string, target string, body string) (rec *httptest.ResponseRecorder) {
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer alice")
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return
+}
+
+func TestServer(t *testing.T) {
+
+	log := slog.New(slog.DiscardHandler)
+	svc := &orders{}
+	closed := transport.New(log, transport.Orders(svc), transport.Feeds(svc))
+	if rec := serve(closed, http.MethodGet, "/orders/1", ""); rec.Code != http.StatusUnauthorized || svc.calls != 0 {
+		t.Fatalf("without authenticator: %d %s, calls %d", rec.Code, rec.Body.String(), svc.calls)
+	}
+	if rec := serve(closed, http.MethodPost, "/sse/orders/watch", "{}"); rec.Code != http.StatusUnauthorized || svc.calls != 0 {
+		t.Fatalf("stream without authenticator: %d %s, calls %d", rec.Code, rec.Body.String(), svc.calls)
+	}
+	if rec := serve(closed, http.MethodGet, "/health", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "ok") {
+		t.Fatalf("public method: %d %s", rec.Code, rec.Body.String())
+	}
+	srv := transport.New(log, transport.Orders(svc), transport.Feeds(svc), transport.WithBearerAuth(tokens{}))
+	anonymous := httptest.NewRequest(http.MethodPost, "/sse/orders/watch", strings.NewReader("{}"))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, anonymous)
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") != "Bearer" || svc.calls != 0 {
+		t.Fatalf("stream without credentials: %d %s, calls %d", rec.Code, rec.Body.String(), svc.calls)
+	}
+	if err := srv.App().Err(); err != nil {
+		t.Fatalf("route registration: %v", err)
+	}
+	if rec := serve(srv, http.MethodGet, "/orders/1", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "order 1") {
+		t.Fatalf("GET /orders/1: %d %s", rec.Code, rec.Body.String())
+	}
+	body := ` + "`" + `{"jsonrpc":"2.0","id":1,"method":"create","params":{"name":"` + "`" + ` + strings.Repeat("x", 9<<20) + ` + "`" + `"}}` + "`" + `
+	if rec := serve(srv, http.MethodPost, "/sse/orders/watch", "{}"); rec.Code != http.StatusOK || svc.calls != 2 {
+		t.Fatalf("stream with credentials: %d %s, calls %d", rec.Code, rec.Body.String(), svc.calls)
+	}
+	if rec := serve(srv, http.MethodPost, "/orders/create", body); rec.Code != http.StatusRequestEntityTooLarge || svc.calls != 2 {
+		t.Fatalf("body over the default 8 MB limit: status %d, calls %d", rec.Code, svc.calls)
+	}
+	batch := ` + "`" + `[{"jsonrpc":"2.0","id":1,"method":"create","params":{"name":"a"}},{"jsonrpc":"2.0","id":2,"method":"create","params":{"name":"b"}}]` + "`" + `
+	for attempt := 1; attempt <= 2; attempt++ {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(batch))
+		req.Header.Set("Authorization", "Bearer alice")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "batch-key")
+		req.Header.Set("X-Sync-On", "true")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "error") || svc.calls != 4 {
+			t.Fatalf("idempotent batch, attempt %d: %d %s, calls %d", attempt, rec.Code, rec.Body.String(), svc.calls)
+		}
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(root, "transport", "server_test.go"), []byte(serverTest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Логгер (zerolog) генерируется отдельным пакетом и серверу без @tg log не нужен.
+	packages := []string{"./transport", "./transport/nethttp", "./transport/srvctx"}
+	for _, args := range [][]string{{"vet", "-mod=mod"}, {"test", "-mod=mod"}} {
+		command := exec.Command("go", append(args, packages...)...)
+		command.Dir = root
+		if output, err := command.CombinedOutput(); err != nil {
+			t.Fatalf("go %s failed: %v\n%s", args[0], err, output)
+		}
+	}
+}
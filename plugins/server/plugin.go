@@ -6,6 +6,7 @@ package main
 import (
 	_ "embed"
 	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	"tgp/internal/helper"
 	"tgp/internal/model"
 	"tgp/plugins/server/generator"
+	"tgp/plugins/server/renderer"
 )
 
 //go:embed plugin.md
@@ -36,6 +38,15 @@ func (p *ServerPlugin) Execute(request data.Storage) (response data.Storage, err
 		return nil, errors.New(i18n.Msg("out option is required and must be a string"))
 	}
 
+	target, _ := data.Get[string](request, "target")
+	switch target {
+	case "":
+		target = renderer.TargetFiber
+	case renderer.TargetFiber, renderer.TargetNetHTTP:
+	default:
+		return nil, fmt.Errorf("%s: %q", i18n.Msg("unknown server target, expected fiber or nethttp"), target)
+	}
+
 	// project уже отфильтрован по contracts в плагине astg (зависимость)
 
 	// Очищаем старые сгенерированные файлы перед новой генерацией
@@ -51,8 +62,9 @@ func (p *ServerPlugin) Execute(request data.Storage) (response data.Storage, err
 	slog.Info(i18n.Msg("generating transport files"),
 		slog.String("output", output),
 		slog.String("contracts", strings.Join(contractNames, ", ")),
+		slog.String("target", target),
 	)
-	if err = generator.GenerateTransportFiles(project, output); err != nil {
+	if err = generator.GenerateTransportFiles(project, output, target); err != nil {
 		slog.Error(i18n.Msg("failed to generate transport files"),
 			slog.String("output", output),
 			slog.String("error", err.Error()),
@@ -64,7 +76,7 @@ func (p *ServerPlugin) Execute(request data.Storage) (response data.Storage, err
 		if !model.ContractIsHTTPFamily(project, contract) {
 			continue
 		}
-		if err = generator.GenerateServer(project, contract.ID, output); err != nil {
+		if err = generator.GenerateServer(project, contract.ID, output, target); err != nil {
 			slog.Error(i18n.Msg("failed to generate server"),
 				slog.String("contract", contract.ID),
 				slog.String("error", err.Error()),
@@ -85,7 +97,7 @@ func (p *ServerPlugin) Info() (info plugin.Info, err error) {
 	info = plugin.Info{
 		Name:         "server",
 		Doc:          pluginDoc,
-		Description:  i18n.Msg("HTTP/JSON-RPC server code generator based on Fiber"),
+		Description:  i18n.Msg("HTTP/JSON-RPC server code generator based on Fiber or net/http"),
 		Author:       "AlexK (seniorGolang@gmail.com)",
 		License:      "MIT",
 		Category:     "server",
@@ -102,6 +114,25 @@ func (p *ServerPlugin) Info() (info plugin.Info, err error) {
 						Required:    false,
 						Default:     "contracts",
 					},
+					{
+						Name:        "contracts-recursive",
+						Type:        "bool",
+						Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"),
+						Required:    false,
+						Default:     false,
+					},
+					{
+						Name:        "contracts-include",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)"),
+						Required:    false,
+					},
+					{
+						Name:        "contracts-exclude",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)"),
+						Required:    false,
+					},
 					{
 						Name:        "out",
 						Short:       "o",
@@ -109,6 +140,13 @@ func (p *ServerPlugin) Info() (info plugin.Info, err error) {
 						Description: i18n.Msg("Path to output directory"),
 						Required:    true,
 					},
+					{
+						Name:        "target",
+						Type:        "string",
+						Description: i18n.Msg("Server target: fiber or nethttp"),
+						Required:    false,
+						Default:     renderer.TargetFiber,
+					},
 					{
 						Name:        "contracts",
 						Type:        "string",
@@ -5,6 +5,7 @@ package renderer
 import (
 	"bytes"
 	"embed"
+	"fmt"
 	"io/fs"
 	"os"
 	"path"
@@ -12,6 +13,8 @@ import (
 	"strings"
 	"text/template"
 
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
 	"tgp/internal/common"
 	"tgp/internal/generated"
 	"tgp/internal/model"
@@ -32,20 +35,93 @@ var streamSSEGo string
 //go:embed stream/context.go
 var streamContextGo string
 
+//go:embed nethttp/*.go
+var netHTTPFS embed.FS
+
 type baseRenderer struct {
 	outDir   string
+	target   string
 	project  *model.Project
 	contract *model.Contract
+
+	validation *validationIndex
 }
 
-func newBaseRenderer(project *model.Project, contract *model.Contract, outDir string) (r *baseRenderer) {
+func newBaseRenderer(project *model.Project, contract *model.Contract, outDir string, target string) (r *baseRenderer) {
+	if target == "" {
+		target = TargetFiber
+	}
 	return &baseRenderer{
 		outDir:   outDir,
+		target:   target,
 		project:  project,
 		contract: contract,
 	}
 }
 
+func (r *baseRenderer) isNetHTTP() (ok bool) {
+
+	return r.target == TargetNetHTTP
+}
+
+// httpPkg — пакет с App/Ctx/Handler для выбранной цели: Fiber или встроенный runtime поверх net/http.
+func (r *baseRenderer) httpPkg() (pkg string) {
+
+	if r.isNetHTTP() {
+		return fmt.Sprintf("%s/nethttp", r.pkgPath(r.outDir))
+	}
+	return PackageFiber
+}
+
+func (r *baseRenderer) httpPkgName() (name string) {
+
+	if r.isNetHTTP() {
+		return "nethttp"
+	}
+	return "fiber"
+}
+
+// appAccessor — имя метода Server, возвращающего App.
+func (r *baseRenderer) appAccessor() (name string) {
+
+	if r.isNetHTTP() {
+		return "App"
+	}
+	return "Fiber"
+}
+
+func (r *baseRenderer) httpHandlerAdaptor() (c *Statement) {
+
+	if r.isNetHTTP() {
+		return Qual(r.httpPkg(), "HTTPHandler")
+	}
+	return Qual(PackageFiberAdaptor, "HTTPHandler")
+}
+
+func (r *baseRenderer) wsConn() (c *Statement) {
+
+	if r.isNetHTTP() {
+		return Qual(r.httpPkg(), "WSConn")
+	}
+	return Qual(PackageFiberWebsocket, "Conn")
+}
+
+func (r *baseRenderer) wsIsUpgrade() (c *Statement) {
+
+	if r.isNetHTTP() {
+		return Qual(r.httpPkg(), "IsWebSocketUpgrade")
+	}
+	return Qual(PackageFiberWebsocket, "IsWebSocketUpgrade")
+}
+
+func (r *baseRenderer) wsNew() (c *Statement) {
+
+	if r.isNetHTTP() {
+		return Qual(r.httpPkg(), "WebSocket")
+	}
+	return Qual(PackageFiberWebsocket, "New")
+}
+
 func (r *baseRenderer) pkgPath(dir string) (s string) {
 
 	pkgDir := filepath.ToSlash(dir)
@@ -141,12 +217,50 @@ func (r *baseRenderer) renderStreamPackage() (err error) {
 	return
 }
 
+// renderNetHTTPPackage копирует встроенный runtime net/http (без тестов) в <outDir>/nethttp.
+func (r *baseRenderer) renderNetHTTPPackage() (err error) {
+
+	dir := path.Join(r.outDir, "nethttp")
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+	var names []string
+	if names, err = fs.Glob(netHTTPFS, "nethttp/*.go"); err != nil {
+		return
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		var body []byte
+		if body, err = netHTTPFS.ReadFile(name); err != nil {
+			return
+		}
+		src := string(body)
+		if idx := strings.Index(src, "\npackage "); idx >= 0 {
+			src = src[idx+1:]
+		}
+		content := generated.ByToolGatewayComment + "\n\n" + src
+		if err = os.WriteFile(path.Join(dir, filepath.Base(name)), []byte(content), 0600); err != nil {
+			return
+		}
+	}
+	return
+}
+
 type pkgTemplateData struct {
 	DoNotEditComment string
+	// HTTPPkg и HTTPName — импорт и имя пакета с Ctx/Handler для шаблонов tracer.
+	HTTPPkg  string
+	HTTPName string
 }
 
-func newPkgTemplateData() (data *pkgTemplateData) {
-	return &pkgTemplateData{DoNotEditComment: generated.ByToolGatewayComment}
+func (r *baseRenderer) newPkgTemplateData() (data *pkgTemplateData) {
+	return &pkgTemplateData{
+		DoNotEditComment: generated.ByToolGatewayComment,
+		HTTPPkg:          r.httpPkg(),
+		HTTPName:         r.httpPkgName(),
+	}
 }
 
 func (r *baseRenderer) hasJsonRPC() (ok bool) {
@@ -179,6 +293,16 @@ func (r *baseRenderer) hasHTTPServerContracts() (ok bool) {
 	return false
 }
 
+func (r *baseRenderer) hasProblemErrors() (ok bool) {
+
+	for _, contract := range r.contractsSorted() {
+		if model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) && model.ContractHasProblemErrors(r.project, contract) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *baseRenderer) needsCookieType() (ok bool) {
 
 	for _, contract := range r.contractsSorted() {
@@ -233,9 +357,9 @@ type contractRenderer struct {
 	*baseRenderer
 }
 
-func NewContractRenderer(project *model.Project, contract *model.Contract, outDir string) (r ContractRenderer) {
+func NewContractRenderer(project *model.Project, contract *model.Contract, outDir string, target string) (r ContractRenderer) {
 	return &contractRenderer{
-		baseRenderer: newBaseRenderer(project, contract, outDir),
+		baseRenderer: newBaseRenderer(project, contract, outDir, target),
 	}
 }
 
@@ -243,8 +367,8 @@ type transportRenderer struct {
 	*baseRenderer
 }
 
-func NewTransportRenderer(project *model.Project, outDir string) (r TransportRenderer) {
+func NewTransportRenderer(project *model.Project, outDir string, target string) (r TransportRenderer) {
 	return &transportRenderer{
-		baseRenderer: newBaseRenderer(project, nil, outDir),
+		baseRenderer: newBaseRenderer(project, nil, outDir, target),
 	}
 }
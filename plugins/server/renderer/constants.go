@@ -48,12 +48,22 @@ const (
 	PackageMimeMultipart        = "mime/multipart"
 	PackageNetTextproto         = "net/textproto"
 	PackageURL                  = "net/url"
+	PackageNetMail              = "net/mail"
+	PackageNetIP                = "net/netip"
+	PackageSlices               = "slices"
+	PackageHTTP                 = "net/http"
 	PackageXML                  = "encoding/xml"
 	PackageMsgpack              = "github.com/vmihailenco/msgpack/v5"
 	PackageCBOR                 = "github.com/fxamacker/cbor/v2"
 	PackageYAML                 = "gopkg.in/yaml.v3"
 )
 
+// Цели генерации сервера.
+const (
+	TargetFiber   = "fiber"
+	TargetNetHTTP = "nethttp"
+)
+
 const (
 	VarNameCtx  = "ctx"
 	VarNameNext = "next"
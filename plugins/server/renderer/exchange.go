@@ -39,6 +39,9 @@ func (r *contractRenderer) RenderExchange() (err error) {
 		respName := responseStructName(r.contract.Name, method.Name)
 		srcFile.Line().Add(r.exchangeStruct(typeGen, reqName, requestFields, reqXML))
 		r.addExchangeLogValue(&srcFile, reqName, requestFields)
+		if r.methodHasValidation(r.contract, method) {
+			srcFile.Line().Add(r.exchangeValidateMethod(reqName, method))
+		}
 		if len(responseFields) > 0 || !model.MethodIsStream(r.project, r.contract, method) {
 			srcFile.Line().Add(r.exchangeStruct(typeGen, respName, responseFields, respXML))
 			r.addExchangeLogValue(&srcFile, respName, responseFields)
@@ -112,6 +115,32 @@ func (r *contractRenderer) exchangeLogValueMethod(typeName string) (c Code) {
 		)
 }
 
+// exchangeValidateMethod генерирует validate() запроса: проверки аргументов по аннотациям и типам с правилами.
+func (r *contractRenderer) exchangeValidateMethod(typeName string, method *model.Method) (c Code) {
+
+	var code []Code
+	for _, arg := range argsWithoutContext(method) {
+		effective := model.EffectiveVariable(method, arg)
+		jsonTag := tags.ParseMethodVarTags(method.Annotations, arg.Name)["json"]
+		fieldCode := Lit(validationFieldName(jsonTag, arg.Name))
+		if jsonTag == ",inline" {
+			fieldCode = Lit("")
+		}
+		value := Id("r").Dot(r.requestStructFieldName(method, arg))
+		r.validateValueCode(&code, value, fieldCode, arg.TypeRef, annotationRules(effective.Annotations), 0)
+	}
+	return Func().Params(Id("r").Op("*").Id(typeName)).Id("validate").Params().
+		Params(Id("violations").Index().Id("validationViolation")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("v").Op(":=").Op("&").Id("requestValidator").Values()
+			for _, line := range code {
+				bg.Add(line)
+			}
+			bg.Return(Id("v").Dot("violations"))
+		})
+}
+
 type exchangeField struct {
 	name             string
 	typeID           string
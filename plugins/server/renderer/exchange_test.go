@@ -64,7 +64,7 @@ func TestRenderExchange_SkipsEmptyStreamResponse(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -133,7 +133,7 @@ func TestRenderExchange_LogValueOnlyForStreamBody(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -207,7 +207,7 @@ func TestRenderExchange_XMLTagsOnlyForXMLContentType(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -269,7 +269,7 @@ func TestRenderTransportHeader_CookieTypeOnlyForResponseCookies(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	tr := NewTransportRenderer(project, dir)
+	tr := NewTransportRenderer(project, dir, TargetFiber)
 	if err := tr.RenderTransportHeader(); err != nil {
 		t.Fatalf("RenderTransportHeader: %v", err)
 	}
@@ -315,7 +315,7 @@ func TestRenderREST_DownloadClosesWithBlankAssign(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -19,7 +19,7 @@ func (r *contractRenderer) RenderHTTP() (err error) {
 	srcFile.PackageComment(generated.ByToolGateway)
 
 	srcFile.ImportName(PackageCors, "cors")
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
 
 	r.renderHTTPTypes(&srcFile)
@@ -91,7 +91,7 @@ func (r *contractRenderer) renderHTTPSetRoutes(srcFile *GoFile) {
 
 	srcFile.Line().Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).
 		Id("SetRoutes").
-		Params(Id("route").Op("*").Qual(PackageFiber, "App")).
+		Params(Id("route").Op("*").Qual(r.httpPkg(), "App")).
 		BlockFunc(func(bg *Group) {
 			if model.IsAnnotationSet(r.project, r.contract, nil, nil, model.TagServerJsonRPC) {
 				bg.Id("route").Dot("Post").Call(Lit(r.batchPath()), Id("http").Dot("serveBatch"))
@@ -110,7 +110,8 @@ func (r *contractRenderer) renderHTTPSetRoutes(srcFile *GoFile) {
 					if handlerValue := model.GetAnnotationValue(r.project, r.contract, method, nil, TagHandler, ""); handlerValue != "" && strings.Contains(handlerValue, ":") {
 						handlerQual := r.methodHandlerQual(srcFile, method)
 						bg.Id("route").Dot(toCamel(r.methodHTTPMethod(method))).
-							Call(Lit(r.methodHTTPPath(method)), Func().Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).Params(Err().Error()).Block(
+							Call(Lit(r.methodHTTPPath(method)), Func().Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).Params(Err().Error()).Block(
+								r.handlerAuthCheck(method),
 								Return(handlerQual.Call(Id(VarNameFtx), Id("http").Dot("base"))),
 							))
 						continue
@@ -121,13 +122,14 @@ func (r *contractRenderer) renderHTTPSetRoutes(srcFile *GoFile) {
 			}
 			if model.ContractHasWS(r.project, r.contract) {
 				wsPath := model.ContractWSPath(r.project, r.contract)
-				bg.Id("route").Dot("Use").Call(Lit(wsPath), Func().Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).Params(Err().Error()).Block(
-					If(Qual(PackageFiberWebsocket, "IsWebSocketUpgrade").Call(Id(VarNameFtx))).Block(
+				bg.Id("route").Dot("Use").Call(Lit(wsPath), Func().Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).Params(Err().Error()).Block(
+					If(r.wsIsUpgrade().Call(Id(VarNameFtx))).Block(
+						r.wsUpgradeAuth(),
 						Return(Id(VarNameFtx).Dot("Next").Call()),
 					),
-					Return(Qual(PackageFiber, "ErrUpgradeRequired")),
+					Return(Qual(r.httpPkg(), "ErrUpgradeRequired")),
 				))
-				bg.Id("route").Dot("Get").Call(Lit(wsPath), Qual(PackageFiberWebsocket, "New").Call(Id("http").Dot("serveWS")))
+				bg.Id("route").Dot("Get").Call(Lit(wsPath), r.wsNew().Call(Id("http").Dot("serveWS")))
 			}
 			if model.ContractHasSSE(r.project, r.contract) {
 				for _, method := range r.contract.Methods {
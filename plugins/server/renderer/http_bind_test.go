@@ -47,7 +47,7 @@ func TestRenderREST_FormUsesBodyParserOnly(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -103,7 +103,7 @@ func TestRenderREST_HeaderBindingUsesPeek(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -160,11 +160,11 @@ func TestRenderREST_MultiMultipartUsesOrderedStream(t *testing.T) {
 		},
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
-	tr := NewTransportRenderer(project, dir)
+	tr := NewTransportRenderer(project, dir, TargetFiber)
 	if err := tr.RenderTransportContext(); err != nil {
 		t.Fatalf("RenderTransportContext: %v", err)
 	}
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -191,7 +191,7 @@ func TestRenderREST_HandlerSkipsServeMethod(t *testing.T) {
 
 	project, contract := overridesTestContract()
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, contract, dir)
+	renderer := NewContractRenderer(project, contract, dir, TargetFiber)
 	if err := renderer.RenderExchange(); err != nil {
 		t.Fatalf("RenderExchange: %v", err)
 	}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func idempotencyTestProject() (project *model.Project) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errVar := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Contracts: []*model.Contract{
+			{
+				Name:        "Orders",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagServerJsonRPC: ""},
+				Methods: []*model.Method{
+					{
+						Name:        "Create",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "POST", model.TagHttpPath: "/orders", model.TagIdempotent: ""},
+						Args:        []*model.Variable{ctx, {Name: "sku", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results:     []*model.Variable{errVar},
+					},
+					{
+						Name:        "Pay",
+						Annotations: tags.DocTags{model.TagIdempotent: "", model.TagIdempotencyHeader: "X-Request-Key"},
+						Args:        []*model.Variable{ctx},
+						Results:     []*model.Variable{errVar},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderTransportIdempotency(t *testing.T) {
+
+	project := idempotencyTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetNetHTTP).RenderTransportIdempotency(); err != nil {
+		t.Fatalf("RenderTransportIdempotency: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "idempotency.go"))
+
+	for _, want := range []string{
+		"idempotencyConflictError = -32009",
+		"func NewMemoryIdempotencyStore(capacity int, ttl time.Duration) IdempotencyStore",
+		"Begin(ctx context.Context, key string, fingerprint string) (record *IdempotencyRecord, err error)",
+		"func (srv *Server) beginIdempotentHTTP(ftx *nethttp.Ctx, method string, header string) (finish func(err error), replayed bool, idemErr *errIdempotency)",
+		"func (srv *Server) beginIdempotentJsonRPC(ctx context.Context, method string, header string, id idJsonRPC, params json.RawMessage)",
+		`if batch, _ := ctx.Value(keyBatchCall).(bool); batch {`,
+		`key += "\x00" + string(id)`,
+		"if oldest := older.Value.(*idempotencyEntry); oldest.record != nil || !now.Before(oldest.expiresAt) {",
+		`ftx.Set("Idempotent-Replayed", "true")`,
+		"if ftx.BodyErr() != nil {",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("idempotency.go must contain %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestRenderTransportIdempotency_FiberRestoresBodyStream(t *testing.T) {
+
+	project := idempotencyTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportIdempotency(); err != nil {
+		t.Fatalf("RenderTransportIdempotency: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "idempotency.go"))
+	if !strings.Contains(source, "ftx.Request().SetBodyStream(bytes.NewReader(body), len(body))") {
+		t.Fatalf("fiber target must hand the read body back to RequestBodyStream:\n%s", source)
+	}
+}
+
+func TestRenderTransportIdempotency_NoIdempotentMethods(t *testing.T) {
+
+	project := problemTestProject("")
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportIdempotency(); err != nil {
+		t.Fatalf("RenderTransportIdempotency: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "idempotency.go")); err == nil {
+		t.Fatalf("idempotency.go must not be generated without @tg idempotent")
+	}
+}
+
+func TestRenderIdempotency_Checks(t *testing.T) {
+
+	project := idempotencyTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	contract := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
+	if err := contract.RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	if err := contract.RenderJsonRPC(); err != nil {
+		t.Fatalf("RenderJsonRPC: %v", err)
+	}
+
+	rest := readGenerated(t, filepath.Join(dir, "orders-rest.go"))
+	for _, want := range []string{
+		`server.beginIdempotentHTTP(ftx, "orders.create", "Idempotency-Key")`,
+		"ftx.Status(idemErr.Code())",
+		"finishIdempotent(err)",
+	} {
+		if !strings.Contains(rest, want) {
+			t.Fatalf("orders-rest.go must contain %q:\n%s", want, rest)
+		}
+	}
+	jsonRPC := readGenerated(t, filepath.Join(dir, "orders-jsonrpc.go"))
+	if !strings.Contains(jsonRPC, `http.srv.beginIdempotentJsonRPC(ctx, "orders.pay", "X-Request-Key", requestBase.ID, requestBase.Params)`) ||
+		!strings.Contains(jsonRPC, "finishIdempotent(responseBase)") {
+		t.Fatalf("JSON-RPC method must check idempotency key per call:\n%s", jsonRPC)
+	}
+	if strings.Contains(jsonRPC, `"orders.create"`) {
+		t.Fatalf("REST method must not be checked in JSON-RPC:\n%s", jsonRPC)
+	}
+}
@@ -26,4 +26,8 @@ type TransportRenderer interface {
 	RenderTransportMetrics() (err error)
 	RenderTransportVersion() (err error)
 	RenderTransportJsonRPC() (err error)
+	RenderTransportValidation() (err error)
+	RenderTransportLimits() (err error)
+	RenderTransportSecurity() (err error)
+	RenderTransportIdempotency() (err error)
 }
@@ -17,7 +17,7 @@ import (
 
 func (r *contractRenderer) RenderJsonRPC() (err error) {
 
-	if err = r.pkgRenderTo("srvctx", r.outDir, newPkgTemplateData()); err != nil {
+	if err = r.pkgRenderTo("srvctx", r.outDir, r.newPkgTemplateData()); err != nil {
 		return fmt.Errorf("render srvctx package: %w", err)
 	}
 
@@ -25,7 +25,7 @@ func (r *contractRenderer) RenderJsonRPC() (err error) {
 	srcFile.PackageComment(generated.ByToolGateway)
 
 	jsonPkg := r.getPackageJSON()
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(PackageErrors, "errors")
 	srcFile.ImportName("io", "io")
 	srcFile.ImportName(PackageSlog, "slog")
@@ -47,7 +47,7 @@ func (r *contractRenderer) RenderJsonRPC() (err error) {
 		methodName := strings.ToLower(method.Name)
 		srcFile.Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).
 			Id("serve" + method.Name).
-			Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+			Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 			Params(Err().Error()).
 			Block(
 				Return().Id("http").Dot("_serveMethod").Call(Id(VarNameFtx), Lit(methodName), Id("http").Dot(toLowerCamel(method.Name))),
@@ -66,7 +66,7 @@ func (r *contractRenderer) rpcMethodFuncWithFiberCtx(srcFile *GoFile, typeGen *t
 
 	return Func().Params(Id("http").Op("*").Id("http"+r.contract.Name)).
 		Id(toLowerCamel(method.Name)).
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("requestBase").Id("baseJsonRPC")).
 		Params(Id("responseBase").Op("*").Id("baseJsonRPC")).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
@@ -83,6 +83,9 @@ func (r *contractRenderer) rpcMethodFuncWithContext(srcFile *GoFile, typeGen *ty
 		BlockFunc(func(bg *Group) {
 			bg.Line()
 			bg.Id(VarNameCtx).Op("=").Id("withMethodLogger").Call(Id(VarNameCtx), Lit(toLowerCamel(r.contract.Name)), Lit(toLowerCamel(method.Name)))
+			bg.Add(r.jsonRPCLimitsCheck(method))
+			bg.Add(r.jsonRPCAuthCheck(method))
+			bg.Add(r.jsonRPCIdempotencyCheck(method))
 			bg.Line()
 			bg.Var().Err().Error()
 			bg.Var().Id("request").Id(requestStructName(r.contract.Name, method.Name))
@@ -94,6 +97,11 @@ func (r *contractRenderer) rpcMethodFuncWithContext(srcFile *GoFile, typeGen *ty
 				})
 			})
 			bg.Add(r.applyOverlayFromContext(srcFile, typeGen, method, r.jsonRPCArgErrReturn(), true))
+			if r.methodHasValidation(r.contract, method) {
+				bg.If(Id("violations").Op(":=").Id("request").Dot("validate").Call().Op(";").Len(Id("violations")).Op(">").Lit(0)).Block(
+					Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidParamsError"), Id("validationSummary").Call(Id("violations")), Id("violations"))),
+				)
+			}
 			bg.Line()
 			bg.ListFunc(func(lg *Group) {
 				for _, target := range r.responseAssignmentTargets(method, "response") {
@@ -144,7 +152,7 @@ func (r *contractRenderer) serveMethodFunc(jsonPkg string) (c Code) {
 	return Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).
 		Id("_serveMethod").
 		ParamsFunc(func(pg *Group) {
-			pg.Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")
+			pg.Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")
 			pg.Id("methodName").String()
 			pg.Id("methodHandler").Id("methodJsonRPCWithFiber")
 		}).
@@ -152,20 +160,21 @@ func (r *contractRenderer) serveMethodFunc(jsonPkg string) (c Code) {
 		BlockFunc(func(bg *Group) {
 			bg.Line()
 			bg.Id("methodHTTP").Op(":=").Id(VarNameFtx).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(PackageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusMethodNotAllowed"))
+			bg.If(Id("methodHTTP").Op("!=").Qual(r.httpPkg(), "MethodPost")).BlockFunc(func(ig *Group) {
+				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(r.httpPkg(), "StatusMethodNotAllowed"))
 				ig.If(List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
 			})
+			bg.Add(r.contractAuthenticateRequest())
 			bg.Var().Id("request").Id("baseJsonRPC")
 			bg.Var().Id("response").Op("*").Id("baseJsonRPC")
 			bg.Id("bodyStream").Op(":=").Id("ensureBodyReader").Call(Id(VarNameFtx).Dot("Context").Call().Dot("RequestBodyStream").Call())
 			bg.If(Err().Op("=").Qual(jsonPkg, "NewDecoder").Call(Id("bodyStream")).Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Return().Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())
+				ig.Return().Id("sendHTTPError").Call(Id(VarNameFtx), Qual(r.httpPkg(), "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())
 			})
 			bg.If(Err().Op("=").Id("validateJsonRPCRequest").Call(Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Return().Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call())
+				ig.Return().Id("sendHTTPError").Call(Id(VarNameFtx), Qual(r.httpPkg(), "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call())
 			})
 			bg.If(Id(VarNameFtx).Dot("UserContext").Call().Dot("Err").Call().Op("!=").Nil()).Block(
 				Return().Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("invalidRequestError"), Lit("request context cancelled"), Nil())),
@@ -190,7 +199,7 @@ func (r *contractRenderer) serviceServeBatchFunc(jsonPkg string) (c Code) {
 	servicePath := r.batchPath()
 	return Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).
 		Id("serveBatch").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Id("err").Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
@@ -199,8 +208,8 @@ func (r *contractRenderer) serviceServeBatchFunc(jsonPkg string) (c Code) {
 			bg.Id("clientID").Op(":=").Qual(srvctxPkgPath, "GetClientID").Call(Id(VarNameFtx).Dot("UserContext").Call())
 			bg.Id("methods").Op(":=").Id("http").Dot("srv").Dot("jsonRPCMethodMaps").Index(Lit(servicePath))
 			bg.Id("methodHTTP").Op(":=").Id(VarNameFtx).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(PackageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusMethodNotAllowed"))
+			bg.If(Id("methodHTTP").Op("!=").Qual(r.httpPkg(), "MethodPost")).BlockFunc(func(ig *Group) {
+				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(r.httpPkg(), "StatusMethodNotAllowed"))
 				ig.If(List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
@@ -209,6 +218,7 @@ func (r *contractRenderer) serviceServeBatchFunc(jsonPkg string) (c Code) {
 				)
 				ig.Return()
 			})
+			bg.Add(r.contractAuthenticateRequest())
 			bg.Id("bodyStream").Op(":=").Id("ensureBodyReader").Call(Id(VarNameFtx).Dot("Context").Call().Dot("RequestBodyStream").Call())
 			bg.List(Id("firstByte"), Err()).Op(":=").Id("readUntilFirstNonWhitespace").Call(Id("bodyStream"))
 			bg.If(Err().Op("!=").Nil().Op("&&").Op("!").Qual("errors", "Is").Call(Err(), Qual("io", "EOF"))).BlockFunc(func(ig *Group) {
@@ -261,7 +271,7 @@ func (r *contractRenderer) serviceServeBatchFunc(jsonPkg string) (c Code) {
 				ig.If(Id("http").Dot("srv").Op("!=").Nil().Op("&&").Id("http").Dot("srv").Dot("metrics").Op("!=").Nil()).Block(
 					Id("http").Dot("srv").Dot("metrics").Dot("EntryRequestsTotal").Dot("WithLabelValues").Call(Lit("json-rpc"), Lit("batch_size_exceeded"), Id("clientID")).Dot("Inc").Call(),
 				)
-				ig.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("batch size exceeded")))
+				ig.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(r.httpPkg(), "StatusBadRequest"), Lit("batch size exceeded")))
 			})
 			bg.If(Id("http").Dot("srv").Op("!=").Nil().Op("&&").Id("http").Dot("srv").Dot("metrics").Op("!=").Nil()).Block(
 				Id("http").Dot("srv").Dot("metrics").Dot("BatchSize").Dot("WithLabelValues").Call(Lit("json-rpc"), Lit(servicePath), Id("clientID")).Dot("Observe").Call(Id("float64").Call(Len(Id("requests")))),
@@ -271,7 +281,7 @@ func (r *contractRenderer) serviceServeBatchFunc(jsonPkg string) (c Code) {
 					vg.If(Id("http").Dot("srv").Op("!=").Nil().Op("&&").Id("http").Dot("srv").Dot("metrics").Op("!=").Nil()).Block(
 						Id("http").Dot("srv").Dot("metrics").Dot("EntryRequestsTotal").Dot("WithLabelValues").Call(Lit("json-rpc"), Lit("invalid_request"), Id("clientID")).Dot("Inc").Call(),
 					)
-					vg.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call()))
+					vg.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(r.httpPkg(), "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call()))
 				})
 				ig.Defer().Func().Params().Block(
 					If(Id("http").Dot("srv").Op("!=").Nil().Op("&&").Id("http").Dot("srv").Dot("metrics").Op("!=").Nil()).Block(
@@ -44,7 +44,7 @@ func TestRenderJsonRPC_ServiceBatchUsesScopedMethodMap(t *testing.T) {
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderJsonRPC(); err != nil {
 		t.Fatalf("RenderJsonRPC: %v", err)
 	}
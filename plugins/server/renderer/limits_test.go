@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func limitsTestProject() (project *model.Project) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errVar := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Contracts: []*model.Contract{
+			{
+				Name:        "Orders",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagServerJsonRPC: "", model.TagMaxInFlight: "50"},
+				Methods: []*model.Method{
+					{
+						Name:        "Get",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/orders/:id", model.TagRateLimit: "100/s,burst=200,key=header:X-Api-Key"},
+						Args:        []*model.Variable{ctx, {Name: "id", TypeRef: model.TypeRef{TypeID: "int"}}},
+						Results:     []*model.Variable{errVar},
+					},
+					{
+						Name:        "Count",
+						Annotations: tags.DocTags{model.TagRateLimit: "5/10s,key=client-id"},
+						Args:        []*model.Variable{ctx},
+						Results:     []*model.Variable{errVar},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderTransportLimits(t *testing.T) {
+
+	project := limitsTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportLimits(); err != nil {
+		t.Fatalf("RenderTransportLimits: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "limits.go"))
+
+	for _, want := range []string{
+		"const tooManyRequestsError = -32029",
+		"func NewTokenBucketLimiter() Limiter",
+		"Per:   10 * time.Second",
+		`rateScope: "Orders.Get"`,
+		`inFlightScope: "Orders"`,
+		`"Orders": make(chan struct{}, 50)`,
+		"func (srv *Server) acquireLimits(ctx context.Context, limits *methodLimits, key string) (release func(), err *errTooManyRequests)",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("limits.go must contain %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestRenderTransportLimits_NoLimits(t *testing.T) {
+
+	project := problemTestProject("")
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportLimits(); err != nil {
+		t.Fatalf("RenderTransportLimits: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "limits.go")); err == nil {
+		t.Fatalf("limits.go must not be generated without @tg rate-limit or max-inflight")
+	}
+}
+
+func TestRenderLimits_Checks(t *testing.T) {
+
+	project := limitsTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	contract := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
+	if err := contract.RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	if err := contract.RenderJsonRPC(); err != nil {
+		t.Fatalf("RenderJsonRPC: %v", err)
+	}
+
+	rest := readGenerated(t, filepath.Join(dir, "orders-rest.go"))
+	for _, want := range []string{
+		`server.acquireLimits(ftx.UserContext(), &limitsOrdersGet, limitKeyOr(ftx.Get("X-Api-Key"), ftx.IP()))`,
+		`ftx.Set("Retry-After", strconv.Itoa(limitErr.RetryAfterSeconds))`,
+		"defer release()",
+	} {
+		if !strings.Contains(rest, want) {
+			t.Fatalf("orders-rest.go must contain %q:\n%s", want, rest)
+		}
+	}
+	jsonRPC := readGenerated(t, filepath.Join(dir, "orders-jsonrpc.go"))
+	if !strings.Contains(jsonRPC, "http.srv.acquireLimits(ctx, &limitsOrdersCount, srvctx.GetClientID(ctx))") ||
+		!strings.Contains(jsonRPC, "makeErrorResponseJsonRPC(requestBase.ID, tooManyRequestsError, limitErr.Error(), limitErr)") {
+		t.Fatalf("JSON-RPC method must check limits per call:\n%s", jsonRPC)
+	}
+}
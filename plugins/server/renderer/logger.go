@@ -17,10 +17,10 @@ import (
 
 func (r *contractRenderer) RenderLogger() (err error) {
 
-	if err = r.pkgRenderTo("srvctx", r.outDir, newPkgTemplateData()); err != nil {
+	if err = r.pkgRenderTo("srvctx", r.outDir, r.newPkgTemplateData()); err != nil {
 		return fmt.Errorf("render srvctx package: %w", err)
 	}
-	if err = r.pkgRenderTo("viewer", r.outDir, newPkgTemplateData()); err != nil {
+	if err = r.pkgRenderTo("viewer", r.outDir, r.newPkgTemplateData()); err != nil {
 		return fmt.Errorf("render viewer package: %w", err)
 	}
 
@@ -24,7 +24,7 @@ func (r *contractRenderer) RenderMetrics() (err error) {
 	srcFile.ImportName(PackageStrconv, "strconv")
 	srcFile.ImportName(PackageTime, "time")
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(PackagePrometheus, "metrics")
 	srcFile.ImportName("fmt", "fmt")
 	srcFile.ImportName(fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir)), "srvctx")
@@ -78,7 +78,7 @@ func (r *contractRenderer) metricFuncBody(method *model.Method) func(bg *Group)
 		errCodeAssignment := Id("errCode").Op("=")
 
 		if r.methodIsHTTP(method) {
-			errCodeAssignment.Qual(PackageFiber, "StatusInternalServerError")
+			errCodeAssignment.Qual(r.httpPkg(), "StatusInternalServerError")
 		} else {
 			errCodeAssignment.Id("internalError")
 		}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderMiddlewareOptions(t *testing.T) {
+
+	project := limitsTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	transport := NewTransportRenderer(project, dir, TargetFiber)
+	if err := transport.RenderTransportOptions(); err != nil {
+		t.Fatalf("RenderTransportOptions: %v", err)
+	}
+	if err := transport.RenderTransportServer(); err != nil {
+		t.Fatalf("RenderTransportServer: %v", err)
+	}
+
+	options := readGenerated(t, filepath.Join(dir, "options.go"))
+	for _, want := range []string{
+		"func WithMiddlewareOrders(mw ...MiddlewareOrders) Option {",
+		"func WithMiddlewareOrdersGet(mw ...MiddlewareOrdersGet) Option {",
+		"srv.middlewareOrders = append(srv.middlewareOrders, func(svc *serverOrders) {",
+		"svc.WrapCount(mw[i])",
+	} {
+		if !strings.Contains(options, want) {
+			t.Fatalf("options.go must contain %q:\n%s", want, options)
+		}
+	}
+
+	server := readGenerated(t, filepath.Join(dir, "server.go"))
+	apply := "srv.middlewareOrders[i](srv.httpOrders.svc)"
+	index := strings.Index(server, apply)
+	if index < 0 {
+		t.Fatalf("New must apply queued middleware:\n%s", server)
+	}
+	if strings.LastIndex(server[:index], "option(srv)") < 0 {
+		t.Fatalf("middleware must be applied after service options register contracts:\n%s", server)
+	}
+}
+
+func TestRenderServer_WrapKeepsMethodMiddleware(t *testing.T) {
+
+	project := limitsTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber).RenderServer(); err != nil {
+		t.Fatalf("RenderServer: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "orders-server.go"))
+	for _, want := range []string{
+		"current := *srv",
+		"srv.svc = m(&current)",
+		"srv.get = srv.svc.Get",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("orders-server.go must contain %q:\n%s", want, source)
+		}
+	}
+}
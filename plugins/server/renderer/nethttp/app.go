@@ -0,0 +1,384 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBodyLimit  = 4 * 1024 * 1024
+	wildcardParamName = "wildcard"
+)
+
+// Handler — обработчик запроса; совпадает по форме с fiber.Handler.
+type Handler = func(*Ctx) error
+
+// ErrorHandler — обработчик ошибки, которую вернула цепочка обработчиков.
+type ErrorHandler = func(*Ctx, error) error
+
+// Config — настройки App. Набор полей повторяет используемую генератором часть fiber.Config,
+// чтобы опции сервера (SetHTTPCfg, MaxBodySize, ReadTimeout и др.) работали одинаково для обеих целей.
+type Config struct {
+	// BodyLimit — максимальный размер тела запроса в байтах (по умолчанию 4 МБ).
+	BodyLimit int
+	// ReadTimeout, WriteTimeout, IdleTimeout передаются в http.Server.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ReadBufferSize ограничивает размер заголовков запроса (http.Server.MaxHeaderBytes).
+	ReadBufferSize int
+	// WriteBufferSize, Concurrency, StreamRequestBody, DisableStartupMessage и DisablePreParseMultipartForm
+	// принимаются для совместимости с fiber.Config: net/http всегда читает тело потоком и не печатает баннер.
+	WriteBufferSize              int
+	Concurrency                  int
+	StreamRequestBody            bool
+	DisableStartupMessage        bool
+	DisablePreParseMultipartForm bool
+	// ErrorHandler вызывается, если обработчик вернул ошибку (по умолчанию DefaultErrorHandler).
+	ErrorHandler ErrorHandler
+}
+
+// Route — зарегистрированный маршрут: метод, исходный путь в синтаксисе Fiber и имена параметров.
+type Route struct {
+	Method string
+	Path   string
+	Params []string
+
+	seq      int
+	handlers []Handler
+}
+
+type layer struct {
+	seq      int
+	prefix   string
+	handlers []Handler
+}
+
+type routeSet struct {
+	pattern string
+	routes  []*Route
+}
+
+// App — маршрутизатор поверх http.ServeMux с цепочкой middleware в стиле Fiber.
+// Реализует http.Handler; маршруты регистрируются шаблонами Go 1.22+ (METHOD /path/{param}).
+type App struct {
+	config Config
+
+	mu     sync.Mutex
+	seq    int
+	mux    *http.ServeMux
+	layers []layer
+	routes map[string]*routeSet
+	server *http.Server
+	errs   []error
+}
+
+// New создаёт App; незаданные поля конфигурации получают значения по умолчанию.
+func New(config ...Config) (app *App) {
+
+	app = &App{
+		mux:    http.NewServeMux(),
+		routes: make(map[string]*routeSet),
+	}
+	if len(config) > 0 {
+		app.config = config[0]
+	}
+	if app.config.BodyLimit <= 0 {
+		app.config.BodyLimit = defaultBodyLimit
+	}
+	if app.config.ErrorHandler == nil {
+		app.config.ErrorHandler = DefaultErrorHandler
+	}
+	app.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		app.serve(w, r, nil)
+	})
+	return
+}
+
+// Config возвращает действующую конфигурацию.
+func (app *App) Config() (config Config) {
+
+	return app.config
+}
+
+// Use добавляет middleware. Первым аргументом можно передать префикс пути, далее — обработчики.
+func (app *App) Use(args ...any) (router *App) {
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	prefix := "/"
+	var handlers []Handler
+	for _, arg := range args {
+		switch value := arg.(type) {
+		case string:
+			prefix = value
+		case Handler:
+			handlers = append(handlers, value)
+		case []Handler:
+			handlers = append(handlers, value...)
+		default:
+			app.errs = append(app.errs, fmt.Errorf("nethttp: Use: unsupported argument %T (path prefix or handler expected)", arg))
+		}
+	}
+	app.seq++
+	app.layers = append(app.layers, layer{seq: app.seq, prefix: strings.TrimSuffix(prefix, "/"), handlers: handlers})
+	return app
+}
+
+func (app *App) Get(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodGet, path, handlers...)
+}
+
+func (app *App) Head(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodHead, path, handlers...)
+}
+
+func (app *App) Post(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodPost, path, handlers...)
+}
+
+func (app *App) Put(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodPut, path, handlers...)
+}
+
+func (app *App) Patch(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodPatch, path, handlers...)
+}
+
+func (app *App) Delete(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodDelete, path, handlers...)
+}
+
+func (app *App) Options(path string, handlers ...Handler) (router *App) {
+
+	return app.Add(http.MethodOptions, path, handlers...)
+}
+
+// All регистрирует маршрут для любого метода.
+func (app *App) All(path string, handlers ...Handler) (router *App) {
+
+	return app.Add("", path, handlers...)
+}
+
+// Add регистрирует маршрут. Путь задаётся в синтаксисе Fiber (:param, :param?, завершающий *)
+// и переводится в шаблоны http.ServeMux; необязательный параметр даёт шаблоны с сегментом и без него.
+// Шаблон, конфликтующий с уже зарегистрированным, не добавляется: ошибку возвращают Err и Listen.
+func (app *App) Add(method string, path string, handlers ...Handler) (router *App) {
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	patterns, params := muxPatterns(path)
+	app.seq++
+	route := &Route{Method: method, Path: path, Params: params, seq: app.seq, handlers: handlers}
+	for _, pattern := range patterns {
+		if method != "" {
+			pattern = method + " " + pattern
+		}
+		set, found := app.routes[pattern]
+		if !found {
+			set = &routeSet{pattern: pattern}
+			if err := app.handle(set); err != nil {
+				app.errs = append(app.errs, fmt.Errorf("nethttp: route %s %s: %w", method, path, err))
+				continue
+			}
+			app.routes[pattern] = set
+		}
+		set.routes = append(set.routes, route)
+	}
+	return app
+}
+
+// handle регистрирует шаблон в http.ServeMux, превращая панику ServeMux (конфликт шаблонов) в ошибку.
+func (app *App) handle(set *routeSet) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	app.mux.HandleFunc(set.pattern, func(w http.ResponseWriter, r *http.Request) {
+		app.serve(w, r, set)
+	})
+	return
+}
+
+// Err возвращает ошибки регистрации маршрутов и middleware (конфликт шаблонов, неверные аргументы Use).
+func (app *App) Err() (err error) {
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	return errors.Join(app.errs...)
+}
+
+// ServeHTTP выполняет middleware и обработчик маршрута, выбранного http.ServeMux.
+// При ошибках регистрации (см. Err) каждый запрос получает 500: маршрутизация неполна.
+func (app *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if err := app.Err(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	app.mux.ServeHTTP(w, r)
+}
+
+func (app *App) serve(w http.ResponseWriter, r *http.Request, set *routeSet) {
+
+	ctx := newCtx(app, w, r)
+	ctx.handlers, ctx.route = app.chain(r.URL.Path, set)
+	ctx.index = -1
+	err := ctx.Next()
+	if ctx.bodyErr != nil && !ctx.direct {
+		// Обработчик видел неполное тело: его ответ отбрасывается, клиент получает ошибку чтения (413 при BodyLimit).
+		ctx.reset()
+		err = ctx.bodyErr
+	}
+	if err != nil {
+		if handlerErr := app.config.ErrorHandler(ctx, err); handlerErr != nil {
+			_ = DefaultErrorHandler(ctx, handlerErr)
+		}
+	}
+	ctx.send()
+}
+
+// chain собирает обработчики в порядке регистрации, как стек Fiber: подходящие по префиксу middleware и маршрут.
+func (app *App) chain(path string, set *routeSet) (handlers []Handler, route *Route) {
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	type entry struct {
+		seq      int
+		handlers []Handler
+	}
+	entries := make([]entry, 0, len(app.layers)+1)
+	for _, l := range app.layers {
+		if l.prefix == "" || path == l.prefix || strings.HasPrefix(path, l.prefix+"/") {
+			entries = append(entries, entry{seq: l.seq, handlers: l.handlers})
+		}
+	}
+	if set != nil {
+		route = set.routes[0]
+		for _, r := range set.routes {
+			entries = append(entries, entry{seq: r.seq, handlers: r.handlers})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int { return a.seq - b.seq })
+	for _, e := range entries {
+		handlers = append(handlers, e.handlers...)
+	}
+	if route == nil {
+		route = &Route{Path: path}
+	}
+	return
+}
+
+// Listen запускает http.Server на адресе; после Shutdown возвращает nil.
+// При ошибках регистрации маршрутов (см. Err) сервер не запускается и Listen возвращает их.
+func (app *App) Listen(addr string) (err error) {
+
+	if err = app.Err(); err != nil {
+		return
+	}
+	app.mu.Lock()
+	app.server = &http.Server{
+		Addr:           addr,
+		Handler:        app,
+		ReadTimeout:    app.config.ReadTimeout,
+		WriteTimeout:   app.config.WriteTimeout,
+		IdleTimeout:    app.config.IdleTimeout,
+		MaxHeaderBytes: app.config.ReadBufferSize,
+	}
+	server := app.server
+	app.mu.Unlock()
+
+	if err = server.ListenAndServe(); errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return
+}
+
+// Shutdown плавно останавливает сервер, дожидаясь завершения активных запросов.
+func (app *App) Shutdown() (err error) {
+
+	return app.ShutdownWithContext(context.Background())
+}
+
+func (app *App) ShutdownWithTimeout(timeout time.Duration) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return app.ShutdownWithContext(ctx)
+}
+
+func (app *App) ShutdownWithContext(ctx context.Context) (err error) {
+
+	app.mu.Lock()
+	server := app.server
+	app.mu.Unlock()
+
+	if server == nil {
+		return
+	}
+	return server.Shutdown(ctx)
+}
+
+// muxPatterns переводит путь Fiber в шаблоны http.ServeMux и возвращает имена параметров.
+// Каждый необязательный параметр (:name?) удваивает набор шаблонов: с сегментом и без него.
+func muxPatterns(path string) (patterns []string, params []string) {
+
+	if path == "" || path == "/" {
+		return []string{"/{$}"}, nil
+	}
+	segments := strings.Split(path, "/")
+	variants := [][]string{nil}
+	for i, segment := range segments {
+		optional := false
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			name := strings.TrimPrefix(segment, ":")
+			name, optional = strings.CutSuffix(name, "?")
+			segment = "{" + name + "}"
+			params = append(params, name)
+		case segment == "*" && i == len(segments)-1:
+			segment = "{" + wildcardParamName + "...}"
+			params = append(params, "*")
+		}
+		count := len(variants)
+		for j := 0; j < count; j++ {
+			if optional {
+				variants = append(variants, slices.Clone(variants[j]))
+			}
+			variants[j] = append(variants[j], segment)
+		}
+	}
+	for _, variant := range variants {
+		pattern := strings.Join(variant, "/")
+		switch {
+		case pattern == "":
+			pattern = "/{$}"
+		case strings.HasSuffix(pattern, "/"):
+			pattern += "{$}"
+		}
+		if !slices.Contains(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return
+}
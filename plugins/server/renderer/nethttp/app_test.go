@@ -0,0 +1,342 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type ctxKey struct{}
+
+func serve(app *App, method string, target string, body string, headers map[string]string) (rec *httptest.ResponseRecorder) {
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	return
+}
+
+func TestApp_MiddlewareOrderAndParams(t *testing.T) {
+
+	app := New()
+	var trace []string
+	app.Use(func(c *Ctx) error {
+		trace = append(trace, "mw")
+		c.SetUserContext(context.WithValue(c.UserContext(), ctxKey{}, "value"))
+		c.Locals("server", "srv")
+		return c.Next()
+	})
+	app.Get("/users/:id", func(c *Ctx) error {
+		trace = append(trace, "route")
+		if c.UserContext().Value(ctxKey{}) != "value" || c.Locals("server") != "srv" {
+			t.Fatal("middleware state is not visible in route handler")
+		}
+		if c.Route().Path != "/users/:id" {
+			t.Fatalf("Route().Path = %q", c.Route().Path)
+		}
+		_, err := c.WriteString("user " + c.Params("id") + " " + string(c.Request().URI().QueryArgs().Peek("expand")))
+		return err
+	})
+	app.Use(func(c *Ctx) error {
+		trace = append(trace, "late")
+		return c.Next()
+	})
+
+	rec := serve(app, http.MethodGet, "/users/42?expand=true", "", nil)
+	if rec.Code != http.StatusOK || rec.Body.String() != "user 42 true" {
+		t.Fatalf("response = %d %q", rec.Code, rec.Body.String())
+	}
+	if strings.Join(trace, ",") != "mw,route" {
+		t.Fatalf("trace = %v, want mw,route", trace)
+	}
+}
+
+func TestApp_NotFoundRunsMiddleware(t *testing.T) {
+
+	app := New()
+	called := false
+	app.Use(func(c *Ctx) error {
+		called = true
+		return c.Next()
+	})
+	app.Get("/", func(c *Ctx) error { return nil })
+
+	rec := serve(app, http.MethodGet, "/missing", "", nil)
+	if rec.Code != http.StatusNotFound || !called {
+		t.Fatalf("status = %d, middleware called = %v", rec.Code, called)
+	}
+	if rec = serve(app, http.MethodGet, "/", "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("root status = %d", rec.Code)
+	}
+}
+
+func TestApp_PrefixMiddleware(t *testing.T) {
+
+	app := New()
+	app.Use("/ws", func(c *Ctx) error { return ErrUpgradeRequired })
+	app.Get("/ws", func(c *Ctx) error { return nil })
+	app.Get("/wsx", func(c *Ctx) error { return nil })
+
+	if rec := serve(app, http.MethodGet, "/ws", "", nil); rec.Code != http.StatusUpgradeRequired {
+		t.Fatalf("/ws status = %d", rec.Code)
+	}
+	if rec := serve(app, http.MethodGet, "/wsx", "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("/wsx status = %d", rec.Code)
+	}
+}
+
+func TestApp_BufferedResponse(t *testing.T) {
+
+	app := New()
+	app.Post("/echo", func(c *Ctx) error {
+		body, err := io.ReadAll(c.Context().RequestBodyStream())
+		if err != nil {
+			return err
+		}
+		c.Response().Header.SetContentType("text/plain")
+		_, _ = c.Response().BodyWriter().Write(body)
+		c.Status(StatusCreated)
+		c.Set("X-Trace", "1")
+		return nil
+	})
+
+	rec := serve(app, http.MethodPost, "/echo", "payload", nil)
+	if rec.Code != http.StatusCreated || rec.Body.String() != "payload" {
+		t.Fatalf("response = %d %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("X-Trace") != "1" || rec.Header().Get("Content-Length") != "7" {
+		t.Fatalf("headers = %v", rec.Header())
+	}
+}
+
+func TestApp_ErrorHandler(t *testing.T) {
+
+	app := New(Config{ErrorHandler: func(c *Ctx, err error) error {
+		c.Status(http.StatusTeapot)
+		return c.SendString("handled: " + err.Error())
+	}})
+	app.Get("/fail", func(c *Ctx) error { return errors.New("boom") })
+
+	rec := serve(app, http.MethodGet, "/fail", "", nil)
+	if rec.Code != http.StatusTeapot || rec.Body.String() != "handled: boom" {
+		t.Fatalf("response = %d %q", rec.Code, rec.Body.String())
+	}
+
+	app = New()
+	app.Get("/fail", func(c *Ctx) error { return NewError(StatusBadRequest, "bad input") })
+	if rec = serve(app, http.MethodGet, "/fail", "", nil); rec.Code != http.StatusBadRequest || rec.Body.String() != "bad input" {
+		t.Fatalf("default handler response = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestApp_BodyLimit(t *testing.T) {
+
+	app := New(Config{BodyLimit: 4})
+	app.Post("/", func(c *Ctx) error {
+		_, err := io.ReadAll(c.Context().RequestBodyStream())
+		return err
+	})
+
+	if rec := serve(app, http.MethodPost, "/", "too large", nil); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestApp_BodyLimitBody(t *testing.T) {
+
+	app := New(Config{BodyLimit: 4})
+	app.Post("/", func(c *Ctx) error {
+		c.Set("X-Handled", "true")
+		return c.Status(http.StatusOK).SendString(strconv.Itoa(len(c.Body())))
+	})
+	app.Post("/parse", func(c *Ctx) error {
+		var payload map[string]any
+		if err := c.BodyParser(&payload); err != nil {
+			return err
+		}
+		return c.SendString("parsed")
+	})
+
+	rec := serve(app, http.MethodPost, "/", "too large", nil)
+	if rec.Code != http.StatusRequestEntityTooLarge || rec.Header().Get("X-Handled") != "" {
+		t.Fatalf("response = %d %q, headers %v; want 413 without handler response", rec.Code, rec.Body.String(), rec.Header())
+	}
+	rec = serve(app, http.MethodPost, "/parse", `{"key":"value"}`, map[string]string{"Content-Type": "application/json"})
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("BodyParser status = %d %q, want 413", rec.Code, rec.Body.String())
+	}
+	if rec = serve(app, http.MethodPost, "/", "ok", nil); rec.Code != http.StatusOK || rec.Body.String() != "2" {
+		t.Fatalf("small body response = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestApp_StreamWriter(t *testing.T) {
+
+	app := New()
+	app.Post("/events", func(c *Ctx) error {
+		c.Set("Content-Type", "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString("data: 1\n\n")
+			_ = w.Flush()
+			_, _ = w.WriteString("data: 2\n\n")
+		})
+		return nil
+	})
+
+	rec := serve(app, http.MethodPost, "/events", "", nil)
+	if rec.Body.String() != "data: 1\n\ndata: 2\n\n" || !rec.Flushed {
+		t.Fatalf("body = %q flushed = %v", rec.Body.String(), rec.Flushed)
+	}
+}
+
+func TestCtx_BodyParserForm(t *testing.T) {
+
+	type request struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Tags  []string `json:"tags" form:"tag"`
+		Admin *bool    `json:"admin"`
+	}
+	app := New()
+	var got request
+	app.Post("/form", func(c *Ctx) error { return c.BodyParser(&got) })
+
+	rec := serve(app, http.MethodPost, "/form", "name=Ann&AGE=30&tag=a&tag=b&admin=true&unknown=1",
+		map[string]string{"Content-Type": "application/x-www-form-urlencoded"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d body = %q", rec.Code, rec.Body.String())
+	}
+	if got.Name != "Ann" || got.Age != 30 || strings.Join(got.Tags, ",") != "a,b" || got.Admin == nil || !*got.Admin {
+		t.Fatalf("decoded = %+v", got)
+	}
+
+	if rec = serve(app, http.MethodPost, "/form", "age=x", map[string]string{"Content-Type": "application/x-www-form-urlencoded"}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid form status = %d", rec.Code)
+	}
+}
+
+func TestCtx_BodyRereadAndVisitAll(t *testing.T) {
+
+	type request struct {
+		Name string `json:"name"`
+	}
+	app := New()
+	var got request
+	var raw string
+	headers := make(map[string]string)
+	app.Post("/form", func(c *Ctx) error {
+		raw = string(c.Body())
+		c.Set("X-Trace", "1")
+		c.Response().Header.VisitAll(func(key []byte, value []byte) {
+			headers[string(key)] = string(value)
+		})
+		return c.BodyParser(&got)
+	})
+
+	rec := serve(app, http.MethodPost, "/form", "name=Ann", map[string]string{"Content-Type": "application/x-www-form-urlencoded"})
+	if rec.Code != http.StatusOK || raw != "name=Ann" || got.Name != "Ann" {
+		t.Fatalf("status = %d raw = %q decoded = %+v", rec.Code, raw, got)
+	}
+	if headers["X-Trace"] != "1" {
+		t.Fatalf("visited headers = %v", headers)
+	}
+}
+
+func TestCtx_CookiesAndRedirect(t *testing.T) {
+
+	app := New()
+	app.Get("/login", func(c *Ctx) error {
+		c.Cookie(&Cookie{Name: "session", Value: c.Cookies("token") + string(c.Request().Header.Cookie("token"))})
+		return c.Redirect("/home")
+	})
+
+	rec := serve(app, http.MethodGet, "/login", "", map[string]string{"Cookie": "token=t"})
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/home" {
+		t.Fatalf("response = %d %v", rec.Code, rec.Header())
+	}
+	if cookie := rec.Header().Get("Set-Cookie"); !strings.HasPrefix(cookie, "session=tt") {
+		t.Fatalf("Set-Cookie = %q", cookie)
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+
+	app := New()
+	app.All("/metrics", HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, "metrics")
+	})))
+
+	rec := serve(app, http.MethodGet, "/metrics", "", nil)
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "metrics" {
+		t.Fatalf("response = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMuxPatterns(t *testing.T) {
+
+	cases := map[string][]string{
+		"/":                 {"/{$}"},
+		"/users/:id":        {"/users/{id}"},
+		"/users/:id/posts/": {"/users/{id}/posts/{$}"},
+		"/files/*":          {"/files/{wildcard...}"},
+		"/api/v1/rpc":       {"/api/v1/rpc"},
+		"/orders/:id?":      {"/orders/{id}", "/orders"},
+		"/:id?":             {"/{id}", "/{$}"},
+		"/a/:b?/c/:d?":      {"/a/{b}/c/{d}", "/a/c/{d}", "/a/{b}/c", "/a/c"},
+	}
+	for path, want := range cases {
+		if got, _ := muxPatterns(path); !slices.Equal(got, want) {
+			t.Fatalf("muxPatterns(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestApp_OptionalParam(t *testing.T) {
+
+	app := New()
+	app.Get("/orders/:id?", func(c *Ctx) error {
+		return c.SendString("id=" + c.Params("id"))
+	})
+
+	if rec := serve(app, http.MethodGet, "/orders/7", "", nil); rec.Body.String() != "id=7" {
+		t.Fatalf("/orders/7 = %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := serve(app, http.MethodGet, "/orders", "", nil); rec.Code != http.StatusOK || rec.Body.String() != "id=" {
+		t.Fatalf("/orders = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestApp_RegistrationErrors(t *testing.T) {
+
+	app := New()
+	app.Use(42)
+	app.Get("/users/:id", func(c *Ctx) error { return nil })
+	app.Get("/users/:name", func(c *Ctx) error { return nil })
+
+	err := app.Err()
+	if err == nil || !strings.Contains(err.Error(), "unsupported argument int") || !strings.Contains(err.Error(), "/users/:name") {
+		t.Fatalf("Err() = %v", err)
+	}
+	if listenErr := app.Listen("127.0.0.1:0"); listenErr == nil {
+		t.Fatal("Listen must fail on registration errors")
+	}
+	if rec := serve(app, http.MethodGet, "/users/1", "", nil); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if New().Get("/ok", func(c *Ctx) error { return nil }).Err() != nil {
+		t.Fatal("valid routes must not report errors")
+	}
+}
@@ -0,0 +1,600 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ctx — контекст запроса с API, совместимым с используемой генератором частью fiber.Ctx.
+// Ответ буферизуется и отправляется после цепочки обработчиков, поэтому статус и заголовки
+// можно менять после записи тела — так же, как в Fiber.
+type Ctx struct {
+	app      *App
+	writer   http.ResponseWriter
+	request  *http.Request
+	route    *Route
+	handlers []Handler
+	index    int
+	locals   map[any]any
+	userCtx  context.Context
+
+	req     Request
+	resp    Response
+	body    []byte
+	bodyErr error
+
+	bodyRead     bool
+	direct       bool
+	streamWriter func(*bufio.Writer)
+}
+
+func newCtx(app *App, w http.ResponseWriter, r *http.Request) (c *Ctx) {
+
+	c = &Ctx{app: app, writer: w, request: r}
+	if r.Body != nil && app.config.BodyLimit > 0 {
+		r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, int64(app.config.BodyLimit)), c: c}
+	}
+	c.req = Request{Header: RequestHeader{request: r}}
+	c.resp = Response{Header: ResponseHeader{header: w.Header()}, statusCode: http.StatusOK}
+	return
+}
+
+// HTTPRequest возвращает исходный *http.Request.
+func (c *Ctx) HTTPRequest() (r *http.Request) {
+
+	return c.request
+}
+
+// ResponseWriter возвращает исходный http.ResponseWriter; прямая запись в него минует буфер ответа.
+func (c *Ctx) ResponseWriter() (w http.ResponseWriter) {
+
+	return c.writer
+}
+
+func (c *Ctx) App() (app *App) {
+
+	return c.app
+}
+
+func (c *Ctx) Route() (route *Route) {
+
+	return c.route
+}
+
+// Next передаёт управление следующему обработчику цепочки.
+func (c *Ctx) Next() (err error) {
+
+	c.index++
+	if c.index < len(c.handlers) {
+		return c.handlers[c.index](c)
+	}
+	return NewError(StatusNotFound, "Cannot "+c.Method()+" "+c.Path())
+}
+
+func (c *Ctx) UserContext() (ctx context.Context) {
+
+	if c.userCtx != nil {
+		return c.userCtx
+	}
+	return c.request.Context()
+}
+
+func (c *Ctx) SetUserContext(ctx context.Context) {
+
+	c.userCtx = ctx
+}
+
+// Locals читает значение по ключу или, если передано значение, сохраняет его.
+func (c *Ctx) Locals(key any, value ...any) (v any) {
+
+	if len(value) == 0 {
+		return c.locals[key]
+	}
+	if c.locals == nil {
+		c.locals = make(map[any]any)
+	}
+	c.locals[key] = value[0]
+	return value[0]
+}
+
+// Context возвращает низкоуровневый контекст запроса (аналог *fasthttp.RequestCtx).
+func (c *Ctx) Context() (ctx *RequestCtx) {
+
+	return &RequestCtx{c: c}
+}
+
+func (c *Ctx) Request() (req *Request) {
+
+	return &c.req
+}
+
+func (c *Ctx) Response() (resp *Response) {
+
+	return &c.resp
+}
+
+func (c *Ctx) Method() (method string) {
+
+	return c.request.Method
+}
+
+func (c *Ctx) Path() (path string) {
+
+	return c.request.URL.Path
+}
+
+func (c *Ctx) OriginalURL() (url string) {
+
+	return c.request.RequestURI
+}
+
+func (c *Ctx) Protocol() (protocol string) {
+
+	if c.request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func (c *Ctx) Hostname() (host string) {
+
+	return c.request.Host
+}
+
+func (c *Ctx) IP() (ip string) {
+
+	host, _, err := net.SplitHostPort(c.request.RemoteAddr)
+	if err != nil {
+		return c.request.RemoteAddr
+	}
+	return host
+}
+
+// Params возвращает параметр пути; "*" — хвост пути для завершающего wildcard.
+func (c *Ctx) Params(key string, defaultValue ...string) (value string) {
+
+	if key == "*" {
+		key = wildcardParamName
+	}
+	if value = c.request.PathValue(key); value == "" && len(defaultValue) > 0 {
+		value = defaultValue[0]
+	}
+	return
+}
+
+// Get возвращает заголовок запроса.
+func (c *Ctx) Get(key string, defaultValue ...string) (value string) {
+
+	if value = c.request.Header.Get(key); value == "" && len(defaultValue) > 0 {
+		value = defaultValue[0]
+	}
+	return
+}
+
+// GetRespHeader возвращает заголовок ответа.
+func (c *Ctx) GetRespHeader(key string, defaultValue ...string) (value string) {
+
+	if value = c.writer.Header().Get(key); value == "" && len(defaultValue) > 0 {
+		value = defaultValue[0]
+	}
+	return
+}
+
+// Set устанавливает заголовок ответа.
+func (c *Ctx) Set(key string, value string) {
+
+	c.writer.Header().Set(key, value)
+}
+
+func (c *Ctx) Cookies(key string, defaultValue ...string) (value string) {
+
+	if cookie, err := c.request.Cookie(key); err == nil {
+		value = cookie.Value
+	}
+	if value == "" && len(defaultValue) > 0 {
+		value = defaultValue[0]
+	}
+	return
+}
+
+// Cookie добавляет Set-Cookie в ответ.
+func (c *Ctx) Cookie(cookie *Cookie) {
+
+	http.SetCookie(c.writer, cookie.httpCookie())
+}
+
+func (c *Ctx) Status(status int) (ctx *Ctx) {
+
+	c.resp.statusCode = status
+	return c
+}
+
+func (c *Ctx) Write(p []byte) (n int, err error) {
+
+	return c.resp.body.Write(p)
+}
+
+func (c *Ctx) WriteString(s string) (n int, err error) {
+
+	return c.resp.body.WriteString(s)
+}
+
+// SendString заменяет тело ответа строкой.
+func (c *Ctx) SendString(body string) (err error) {
+
+	c.resp.body.Reset()
+	_, err = c.resp.body.WriteString(body)
+	return
+}
+
+// JSON кодирует значение в тело ответа и выставляет Content-Type: application/json.
+func (c *Ctx) JSON(data any) (err error) {
+
+	var payload []byte
+	if payload, err = json.Marshal(data); err != nil {
+		return
+	}
+	c.resp.Header.SetContentType("application/json")
+	c.resp.body.Reset()
+	_, err = c.resp.body.Write(payload)
+	return
+}
+
+// Redirect отвечает перенаправлением (по умолчанию 302 Found).
+func (c *Ctx) Redirect(location string, status ...int) (err error) {
+
+	c.Set("Location", location)
+	c.resp.statusCode = StatusFound
+	if len(status) > 0 {
+		c.resp.statusCode = status[0]
+	}
+	return
+}
+
+// Body читает и кэширует всё тело запроса; тело запроса подменяется прочитанной копией для ParseForm.
+// Если тело не прочитано целиком (например, превышен BodyLimit), Body возвращает nil, ошибка доступна
+// через BodyErr, а App заменяет ответ обработчика ответом ErrorHandler (413 для *http.MaxBytesError).
+// Превышение BodyLimit при потоковом чтении (RequestBodyStream) обрабатывается так же.
+func (c *Ctx) Body() (body []byte) {
+
+	if !c.bodyRead {
+		c.bodyRead = true
+		if c.request.Body != nil {
+			if c.body, c.bodyErr = io.ReadAll(c.request.Body); c.bodyErr != nil {
+				c.body = nil
+				c.request.Body = io.NopCloser(errReader{err: c.bodyErr})
+				return
+			}
+			c.request.Body = io.NopCloser(bytes.NewReader(c.body))
+		}
+	}
+	return c.body
+}
+
+// BodyErr возвращает ошибку чтения тела в Body: *http.MaxBytesError при превышении BodyLimit.
+func (c *Ctx) BodyErr() (err error) {
+
+	c.Body()
+	return c.bodyErr
+}
+
+// limitedBody запоминает превышение BodyLimit при любом чтении тела (Body, BodyParser, RequestBodyStream).
+type limitedBody struct {
+	io.ReadCloser
+	c *Ctx
+}
+
+func (b *limitedBody) Read(p []byte) (n int, err error) {
+
+	n, err = b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) && b.c.bodyErr == nil {
+		b.c.bodyErr = err
+	}
+	return
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (n int, err error) {
+
+	return 0, r.err
+}
+
+// BodyParser разбирает тело по Content-Type: JSON, XML, urlencoded или multipart-форма.
+// Поля формы сопоставляются по тегу form, затем json, затем по имени поля без учёта регистра.
+func (c *Ctx) BodyParser(out any) (err error) {
+
+	mediaType, _, _ := mime.ParseMediaType(c.request.Header.Get("Content-Type"))
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		if err = c.BodyErr(); err != nil {
+			return
+		}
+		return json.Unmarshal(c.Body(), out)
+	case strings.HasSuffix(mediaType, "xml"):
+		if err = c.BodyErr(); err != nil {
+			return
+		}
+		return xml.Unmarshal(c.Body(), out)
+	case mediaType == "application/x-www-form-urlencoded":
+		if err = c.request.ParseForm(); err != nil {
+			return NewError(StatusBadRequest, err.Error())
+		}
+		return decodeForm(c.request.PostForm, out)
+	case mediaType == "multipart/form-data":
+		if err = c.request.ParseMultipartForm(int64(c.app.config.BodyLimit)); err != nil {
+			return NewError(StatusBadRequest, err.Error())
+		}
+		return decodeForm(c.request.MultipartForm.Value, out)
+	}
+	return ErrUnprocessableEntity
+}
+
+func (c *Ctx) send() {
+
+	if c.direct {
+		return
+	}
+	if c.streamWriter != nil {
+		c.writer.Header().Del("Content-Length")
+		c.writer.WriteHeader(c.resp.statusCode)
+		writer := bufio.NewWriter(&flushWriter{writer: c.writer, controller: http.NewResponseController(c.writer)})
+		c.streamWriter(writer)
+		_ = writer.Flush()
+		return
+	}
+	if bodyAllowed(c.resp.statusCode) {
+		c.writer.Header().Set("Content-Length", strconv.Itoa(c.resp.body.Len()))
+	}
+	c.writer.WriteHeader(c.resp.statusCode)
+	_, _ = c.writer.Write(c.resp.body.Bytes())
+}
+
+// reset отбрасывает буферизованный ответ: статус, заголовки, тело и потоковую запись.
+func (c *Ctx) reset() {
+
+	for name := range c.writer.Header() {
+		delete(c.writer.Header(), name)
+	}
+	c.resp.statusCode = http.StatusOK
+	c.resp.body.Reset()
+	c.streamWriter = nil
+}
+
+func bodyAllowed(status int) (ok bool) {
+
+	return status >= 200 && status != StatusNoContent && status != StatusNotModified
+}
+
+// Hijack передаёт соединение вызывающему коду: буферизованный ответ после этого не отправляется.
+func (c *Ctx) Hijack() (conn net.Conn, rw *bufio.ReadWriter, err error) {
+
+	if conn, rw, err = http.NewResponseController(c.writer).Hijack(); err == nil {
+		c.direct = true
+	}
+	return
+}
+
+// Request — доступ к запросу в стиле fasthttp.Request.
+type Request struct {
+	Header RequestHeader
+}
+
+func (r *Request) URI() (uri *URI) {
+
+	return &URI{request: r.Header.request}
+}
+
+type RequestHeader struct {
+	request *http.Request
+}
+
+func (h *RequestHeader) Peek(key string) (value []byte) {
+
+	if v := h.request.Header.Get(key); v != "" {
+		return []byte(v)
+	}
+	if strings.EqualFold(key, "Host") {
+		return []byte(h.request.Host)
+	}
+	return
+}
+
+func (h *RequestHeader) Set(key string, value string) {
+
+	h.request.Header.Set(key, value)
+}
+
+func (h *RequestHeader) Cookie(key string) (value []byte) {
+
+	if cookie, err := h.request.Cookie(key); err == nil {
+		return []byte(cookie.Value)
+	}
+	return
+}
+
+func (h *RequestHeader) UserAgent() (value []byte) {
+
+	return []byte(h.request.UserAgent())
+}
+
+func (h *RequestHeader) IsHTTP11() (ok bool) {
+
+	return h.request.ProtoAtLeast(1, 1)
+}
+
+// VisitAll вызывает f для каждого значения каждого заголовка.
+func (h *RequestHeader) VisitAll(f func(key []byte, value []byte)) {
+
+	for key, values := range h.request.Header {
+		for _, value := range values {
+			f([]byte(key), []byte(value))
+		}
+	}
+}
+
+type URI struct {
+	request *http.Request
+}
+
+func (u *URI) QueryArgs() (args *Args) {
+
+	return &Args{values: u.request.URL.Query()}
+}
+
+type Args struct {
+	values map[string][]string
+}
+
+func (a *Args) Peek(key string) (value []byte) {
+
+	if values := a.values[key]; len(values) > 0 {
+		return []byte(values[0])
+	}
+	return
+}
+
+// Response — буферизованный ответ в стиле fasthttp.Response.
+type Response struct {
+	Header ResponseHeader
+
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *Response) SetStatusCode(status int) {
+
+	r.statusCode = status
+}
+
+func (r *Response) StatusCode() (status int) {
+
+	return r.statusCode
+}
+
+func (r *Response) BodyWriter() (w io.Writer) {
+
+	return &r.body
+}
+
+func (r *Response) Body() (body []byte) {
+
+	return r.body.Bytes()
+}
+
+type ResponseHeader struct {
+	header http.Header
+}
+
+func (h *ResponseHeader) Set(key string, value string) {
+
+	h.header.Set(key, value)
+}
+
+func (h *ResponseHeader) Add(key string, value string) {
+
+	h.header.Add(key, value)
+}
+
+func (h *ResponseHeader) Peek(key string) (value []byte) {
+
+	if v := h.header.Get(key); v != "" {
+		return []byte(v)
+	}
+	return
+}
+
+// VisitAll обходит все значения заголовков ответа, как fasthttp.ResponseHeader.VisitAll.
+func (h *ResponseHeader) VisitAll(f func(key []byte, value []byte)) {
+
+	for key, values := range h.header {
+		for _, value := range values {
+			f([]byte(key), []byte(value))
+		}
+	}
+}
+
+func (h *ResponseHeader) SetContentType(contentType string) {
+
+	h.header.Set("Content-Type", contentType)
+}
+
+// SetContentLength принимается для совместимости: длина буферизованного тела выставляется при отправке.
+func (h *ResponseHeader) SetContentLength(int) {}
+
+// RequestCtx — низкоуровневый доступ к телу запроса, потоковой записи ответа и соединению.
+type RequestCtx struct {
+	c *Ctx
+}
+
+// RequestBodyStream возвращает тело запроса как поток с учётом BodyLimit.
+func (rc *RequestCtx) RequestBodyStream() (body io.Reader) {
+
+	if rc.c.bodyErr != nil {
+		return errReader{err: rc.c.bodyErr}
+	}
+	if rc.c.bodyRead {
+		return bytes.NewReader(rc.c.body)
+	}
+	if rc.c.request.Body == nil {
+		return nil
+	}
+	return rc.c.request.Body
+}
+
+// SetBodyStreamWriter задаёт потоковую запись тела: writer вызывается после цепочки обработчиков,
+// каждый Flush отправляет накопленные данные клиенту.
+func (rc *RequestCtx) SetBodyStreamWriter(writer func(w *bufio.Writer)) {
+
+	rc.c.streamWriter = writer
+}
+
+func (rc *RequestCtx) Conn() (conn *Conn) {
+
+	return &Conn{controller: http.NewResponseController(rc.c.writer)}
+}
+
+// Conn — управление дедлайнами соединения через http.ResponseController.
+type Conn struct {
+	controller *http.ResponseController
+}
+
+func (c *Conn) SetWriteDeadline(deadline time.Time) (err error) {
+
+	return c.controller.SetWriteDeadline(deadline)
+}
+
+func (c *Conn) SetReadDeadline(deadline time.Time) (err error) {
+
+	return c.controller.SetReadDeadline(deadline)
+}
+
+type flushWriter struct {
+	writer     http.ResponseWriter
+	controller *http.ResponseController
+}
+
+func (w *flushWriter) Write(p []byte) (n int, err error) {
+
+	if n, err = w.writer.Write(p); err != nil {
+		return
+	}
+	err = w.controller.Flush()
+	return
+}
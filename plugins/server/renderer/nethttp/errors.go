@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	MethodGet     = http.MethodGet
+	MethodHead    = http.MethodHead
+	MethodPost    = http.MethodPost
+	MethodPut     = http.MethodPut
+	MethodPatch   = http.MethodPatch
+	MethodDelete  = http.MethodDelete
+	MethodOptions = http.MethodOptions
+)
+
+const (
+	StatusOK                    = http.StatusOK
+	StatusCreated               = http.StatusCreated
+	StatusAccepted              = http.StatusAccepted
+	StatusNoContent             = http.StatusNoContent
+	StatusMovedPermanently      = http.StatusMovedPermanently
+	StatusFound                 = http.StatusFound
+	StatusNotModified           = http.StatusNotModified
+	StatusTemporaryRedirect     = http.StatusTemporaryRedirect
+	StatusPermanentRedirect     = http.StatusPermanentRedirect
+	StatusBadRequest            = http.StatusBadRequest
+	StatusUnauthorized          = http.StatusUnauthorized
+	StatusForbidden             = http.StatusForbidden
+	StatusNotFound              = http.StatusNotFound
+	StatusMethodNotAllowed      = http.StatusMethodNotAllowed
+	StatusRequestTimeout        = http.StatusRequestTimeout
+	StatusConflict              = http.StatusConflict
+	StatusRequestEntityTooLarge = http.StatusRequestEntityTooLarge
+	StatusUnsupportedMediaType  = http.StatusUnsupportedMediaType
+	StatusUnprocessableEntity   = http.StatusUnprocessableEntity
+	StatusUpgradeRequired       = http.StatusUpgradeRequired
+	StatusTooManyRequests       = http.StatusTooManyRequests
+	StatusInternalServerError   = http.StatusInternalServerError
+	StatusNotImplemented        = http.StatusNotImplemented
+	StatusBadGateway            = http.StatusBadGateway
+	StatusServiceUnavailable    = http.StatusServiceUnavailable
+	StatusGatewayTimeout        = http.StatusGatewayTimeout
+)
+
+// Error — ошибка с HTTP-статусом; DefaultErrorHandler отвечает её кодом и текстом.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() (s string) {
+
+	return e.Message
+}
+
+// NewError создаёт Error; без сообщения используется стандартный текст статуса.
+func NewError(code int, message ...string) (err *Error) {
+
+	err = &Error{Code: code, Message: http.StatusText(code)}
+	if len(message) > 0 {
+		err.Message = message[0]
+	}
+	return
+}
+
+var (
+	ErrUpgradeRequired     = NewError(StatusUpgradeRequired)
+	ErrUnprocessableEntity = NewError(StatusUnprocessableEntity)
+)
+
+// DefaultErrorHandler отвечает кодом *Error (или 500) и текстом ошибки в text/plain.
+func DefaultErrorHandler(c *Ctx, err error) (handlerErr error) {
+
+	code := StatusInternalServerError
+	var httpErr *Error
+	if errors.As(err, &httpErr) {
+		code = httpErr.Code
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		code = StatusRequestEntityTooLarge
+	}
+	c.Response().Header.SetContentType("text/plain; charset=utf-8")
+	c.Status(code)
+	return c.SendString(err.Error())
+}
+
+// Cookie — параметры Set-Cookie; совпадает по полям с fiber.Cookie.
+type Cookie struct {
+	Name        string
+	Value       string
+	Path        string
+	Domain      string
+	MaxAge      int
+	Expires     time.Time
+	Secure      bool
+	HTTPOnly    bool
+	SameSite    string
+	SessionOnly bool
+}
+
+func (c *Cookie) httpCookie() (cookie *http.Cookie) {
+
+	cookie = &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	if !c.SessionOnly {
+		cookie.MaxAge = c.MaxAge
+		cookie.Expires = c.Expires
+	}
+	switch c.SameSite {
+	case "Strict", "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "None", "none":
+		cookie.SameSite = http.SameSiteNoneMode
+	case "Lax", "lax":
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	return
+}
+
+// HTTPHandler оборачивает http.Handler в Handler (аналог adaptor.HTTPHandler).
+func HTTPHandler(handler http.Handler) (h Handler) {
+
+	return func(c *Ctx) (err error) {
+		c.direct = true
+		handler.ServeHTTP(c.writer, c.request)
+		return
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// decodeForm заполняет поля структуры значениями формы; неизвестные ключи игнорируются.
+func decodeForm(values map[string][]string, out any) (err error) {
+
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form: expected pointer to struct, got %T", out)
+	}
+	target = target.Elem()
+	for key, raw := range values {
+		field, found := formField(target, key)
+		if !found || len(raw) == 0 {
+			continue
+		}
+		if err = setFormValue(field, raw); err != nil {
+			return NewError(StatusBadRequest, fmt.Sprintf("form field %q: %v", key, err))
+		}
+	}
+	return
+}
+
+func formField(target reflect.Value, key string) (field reflect.Value, found bool) {
+
+	targetType := target.Type()
+	for i := range targetType.NumField() {
+		fieldType := targetType.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		if strings.EqualFold(formName(fieldType), key) {
+			return target.Field(i), true
+		}
+	}
+	return
+}
+
+func formName(field reflect.StructField) (name string) {
+
+	for _, tag := range []string{"form", "json"} {
+		if value, ok := field.Tag.Lookup(tag); ok {
+			if name, _, _ = strings.Cut(value, ","); name != "" && name != "-" {
+				return
+			}
+		}
+	}
+	return field.Name
+}
+
+func setFormValue(field reflect.Value, raw []string) (err error) {
+
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setFormValue(field.Elem(), raw)
+	}
+	if field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw[0]))
+	}
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(field.Type(), len(raw), len(raw))
+		for i, value := range raw {
+			if err = setFormValue(slice.Index(i), []string{value}); err != nil {
+				return
+			}
+		}
+		field.Set(slice)
+		return
+	}
+	return setFormScalar(field, raw[0])
+}
+
+func setFormScalar(field reflect.Value, value string) (err error) {
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Slice:
+		field.SetBytes([]byte(value))
+	case reflect.Bool:
+		var v bool
+		if v, err = strconv.ParseBool(value); err == nil {
+			field.SetBool(v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		if v, err = strconv.ParseInt(value, 10, field.Type().Bits()); err == nil {
+			field.SetInt(v)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		if v, err = strconv.ParseUint(value, 10, field.Type().Bits()); err == nil {
+			field.SetUint(v)
+		}
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		if v, err = strconv.ParseFloat(value, field.Type().Bits()); err == nil {
+			field.SetFloat(v)
+		}
+	default:
+		err = fmt.Errorf("unsupported kind %s", field.Kind())
+	}
+	return
+}
@@ -0,0 +1,394 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"bufio"
+	"crypto/sha1" // nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Типы сообщений RFC 6455.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+const (
+	opContinuation    = 0
+	maxControlPayload = 125
+	acceptGUID        = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	// DefaultWSReadLimit — максимальный размер входящего сообщения.
+	DefaultWSReadLimit = 16 * 1024 * 1024
+)
+
+var (
+	ErrBadHandshake    = errors.New("websocket: bad handshake")
+	ErrReadLimit       = errors.New("websocket: read limit exceeded")
+	ErrUnmaskedFrame   = errors.New("websocket: client frame is not masked")
+	ErrProtocolFailure = errors.New("websocket: protocol error")
+)
+
+// CloseError — получен кадр закрытия от клиента.
+type CloseError struct {
+	Code int
+	Text string
+}
+
+func (e *CloseError) Error() (s string) {
+
+	return fmt.Sprintf("websocket: close %d %s", e.Code, e.Text)
+}
+
+// WSConn — серверное WebSocket-соединение поверх перехваченного net/http соединения.
+// Значения заголовков, query, cookie и параметров пути сохраняются на момент upgrade.
+type WSConn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	readLimit int64
+
+	writeMu sync.Mutex
+	closed  bool
+
+	headers http.Header
+	query   url.Values
+	cookies []*http.Cookie
+	params  map[string]string
+	locals  map[any]any
+}
+
+// IsWebSocketUpgrade сообщает, является ли запрос запросом на WebSocket upgrade.
+func IsWebSocketUpgrade(c *Ctx) (ok bool) {
+
+	return isUpgradeRequest(c.request)
+}
+
+// WebSocket возвращает обработчик, который выполняет upgrade и передаёт соединение handler
+// (аналог websocket.New из gofiber/contrib). Соединение закрывается после возврата из handler.
+func WebSocket(handler func(*WSConn)) (h Handler) {
+
+	return func(c *Ctx) (err error) {
+		params := make(map[string]string, len(c.route.Params))
+		for _, name := range c.route.Params {
+			params[name] = c.Params(name)
+		}
+		var conn *WSConn
+		if conn, err = upgradeWebSocket(c.writer, c.request, params); err != nil {
+			if errors.Is(err, ErrBadHandshake) {
+				return ErrUpgradeRequired
+			}
+			return
+		}
+		conn.locals = c.locals
+		c.direct = true
+		defer conn.Close()
+		handler(conn)
+		return nil
+	}
+}
+
+func isUpgradeRequest(r *http.Request) (ok bool) {
+
+	return headerContainsToken(r.Header, "Connection", "upgrade") &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// upgradeWebSocket выполняет рукопожатие RFC 6455 и перехватывает соединение.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request, params map[string]string) (conn *WSConn, err error) {
+
+	if r.Method != http.MethodGet || !isUpgradeRequest(r) {
+		return nil, ErrBadHandshake
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, ErrBadHandshake
+	}
+
+	var netConn net.Conn
+	var rw *bufio.ReadWriter
+	if netConn, rw, err = http.NewResponseController(w).Hijack(); err != nil {
+		return nil, fmt.Errorf("websocket: hijack: %w", err)
+	}
+	_ = netConn.SetDeadline(time.Time{})
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err = rw.WriteString(response); err == nil {
+		err = rw.Flush()
+	}
+	if err != nil {
+		_ = netConn.Close()
+		return nil, fmt.Errorf("websocket: handshake: %w", err)
+	}
+	conn = &WSConn{
+		conn:      netConn,
+		reader:    rw.Reader,
+		readLimit: DefaultWSReadLimit,
+		headers:   r.Header.Clone(),
+		query:     r.URL.Query(),
+		cookies:   r.Cookies(),
+		params:    params,
+	}
+	return
+}
+
+func acceptKey(key string) (accept string) {
+
+	hash := sha1.Sum([]byte(key + acceptGUID)) // nolint:gosec
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func headerContainsToken(header http.Header, name string, token string) (ok bool) {
+
+	for _, value := range header.Values(name) {
+		for part := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func firstOr(value string, defaultValue []string) (s string) {
+
+	if value == "" && len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return value
+}
+
+func (c *WSConn) Headers(key string, defaultValue ...string) (value string) {
+
+	return firstOr(c.headers.Get(key), defaultValue)
+}
+
+func (c *WSConn) Query(key string, defaultValue ...string) (value string) {
+
+	return firstOr(c.query.Get(key), defaultValue)
+}
+
+func (c *WSConn) Cookies(key string, defaultValue ...string) (value string) {
+
+	for _, cookie := range c.cookies {
+		if cookie.Name == key {
+			return firstOr(cookie.Value, defaultValue)
+		}
+	}
+	return firstOr("", defaultValue)
+}
+
+func (c *WSConn) Params(key string, defaultValue ...string) (value string) {
+
+	return firstOr(c.params[key], defaultValue)
+}
+
+// Locals читает значение, сохранённое в Ctx.Locals до upgrade, или, если передано значение, сохраняет его.
+func (c *WSConn) Locals(key any, value ...any) (v any) {
+
+	if len(value) == 0 {
+		return c.locals[key]
+	}
+	if c.locals == nil {
+		c.locals = make(map[any]any)
+	}
+	c.locals[key] = value[0]
+	return value[0]
+}
+
+// SetReadLimit ограничивает размер собранного входящего сообщения.
+func (c *WSConn) SetReadLimit(limit int64) {
+
+	c.readLimit = limit
+}
+
+func (c *WSConn) SetReadDeadline(deadline time.Time) (err error) {
+
+	return c.conn.SetReadDeadline(deadline)
+}
+
+func (c *WSConn) SetWriteDeadline(deadline time.Time) (err error) {
+
+	return c.conn.SetWriteDeadline(deadline)
+}
+
+// ReadMessage возвращает следующее сообщение данных, собирая фрагменты и отвечая на ping.
+func (c *WSConn) ReadMessage() (messageType int, payload []byte, err error) {
+
+	for {
+		var fin bool
+		var opcode int
+		var frame []byte
+		if fin, opcode, frame, err = c.readFrame(); err != nil {
+			return
+		}
+		switch opcode {
+		case PingMessage:
+			if err = c.writeFrame(PongMessage, frame); err != nil {
+				return
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			closeErr := &CloseError{Code: 1005}
+			if len(frame) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(frame))
+				closeErr.Text = string(frame[2:])
+			}
+			_ = c.writeFrame(CloseMessage, frame[:min(len(frame), 2)])
+			return 0, nil, closeErr
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, ErrProtocolFailure
+			}
+			messageType = opcode
+		case opContinuation:
+			if messageType == 0 {
+				return 0, nil, ErrProtocolFailure
+			}
+		default:
+			return 0, nil, ErrProtocolFailure
+		}
+		if int64(len(payload)+len(frame)) > c.readLimit {
+			return 0, nil, ErrReadLimit
+		}
+		payload = append(payload, frame...)
+		if fin {
+			return
+		}
+	}
+}
+
+func (c *WSConn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+
+	var header [2]byte
+	if _, err = io.ReadFull(c.reader, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	opcode = int(header[0] & 0x0f)
+	// Расширения не согласуются при upgrade, поэтому биты RSV1-3 должны быть нулевыми.
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, ErrProtocolFailure
+	}
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, ErrUnmaskedFrame
+	}
+	length := int64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]) & (1<<63 - 1))
+	}
+	// Управляющие кадры (close, ping, pong) не фрагментируются и несут не больше 125 байт.
+	if opcode >= CloseMessage && (!fin || length > maxControlPayload) {
+		return false, 0, nil, ErrProtocolFailure
+	}
+	if length > c.readLimit {
+		return false, 0, nil, ErrReadLimit
+	}
+	var mask [4]byte
+	if _, err = io.ReadFull(c.reader, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return
+}
+
+// WriteMessage отправляет сообщение одним кадром; безопасен для конкурентного вызова.
+func (c *WSConn) WriteMessage(messageType int, data []byte) (err error) {
+
+	return c.writeFrame(messageType, data)
+}
+
+func (c *WSConn) writeFrame(opcode int, payload []byte) (err error) {
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|byte(opcode))
+	switch length := len(payload); {
+	case length < 126:
+		header = append(header, byte(length))
+	case length <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+	if _, err = c.conn.Write(header); err != nil {
+		return
+	}
+	_, err = c.conn.Write(payload)
+	return
+}
+
+func (c *WSConn) ReadJSON(v any) (err error) {
+
+	var payload []byte
+	if _, payload, err = c.ReadMessage(); err != nil {
+		return
+	}
+	return json.Unmarshal(payload, v)
+}
+
+func (c *WSConn) WriteJSON(v any) (err error) {
+
+	var payload []byte
+	if payload, err = json.Marshal(v); err != nil {
+		return
+	}
+	return c.WriteMessage(TextMessage, payload)
+}
+
+// Close отправляет кадр закрытия (1000) и закрывает соединение.
+func (c *WSConn) Close() (err error) {
+
+	_ = c.writeFrame(CloseMessage, binary.BigEndian.AppendUint16(nil, 1000))
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	return c.conn.Close()
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package nethttp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func writeClientFrame(t *testing.T, conn net.Conn, opcode byte, payload []byte) {
+
+	t.Helper()
+
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, 0x80|byte(len(payload)))
+	default:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatalf("write frame: %v", err)
+	}
+}
+
+func readServerFrame(t *testing.T, reader *bufio.Reader) (opcode byte, payload []byte) {
+
+	t.Helper()
+
+	var header [2]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		t.Fatalf("read frame header: %v", err)
+	}
+	opcode = header[0] & 0x0f
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(reader, ext[:]); err != nil {
+			t.Fatalf("read frame length: %v", err)
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		t.Fatalf("read frame payload: %v", err)
+	}
+	return
+}
+
+func TestWebSocket_EchoJSON(t *testing.T) {
+
+	app := New()
+	app.Use("/ws", func(c *Ctx) error {
+		if IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+		return ErrUpgradeRequired
+	})
+	app.Get("/ws/:room", WebSocket(func(conn *WSConn) {
+		var msg map[string]string
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		msg["room"] = conn.Params("room")
+		msg["token"] = conn.Query("token")
+		msg["client"] = conn.Headers("X-Client-Id")
+		_ = conn.WriteJSON(msg)
+	}))
+	server := httptest.NewServer(app)
+	defer server.Close()
+
+	if resp, err := http.Get(server.URL + "/ws/lobby"); err != nil || resp.StatusCode != http.StatusUpgradeRequired {
+		t.Fatalf("plain GET: %v %v", resp, err)
+	}
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	request := "GET /ws/lobby?token=abc HTTP/1.1\r\n" +
+		"Host: example\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: keep-alive, Upgrade\r\n" +
+		"X-Client-Id: cli\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	if _, err = conn.Write([]byte(request)); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("handshake response: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	writeClientFrame(t, conn, PingMessage, []byte("p"))
+	writeClientFrame(t, conn, TextMessage, []byte(`{"text":"hi"}`))
+	if opcode, payload := readServerFrame(t, reader); opcode != PongMessage || string(payload) != "p" {
+		t.Fatalf("pong frame: %d %q", opcode, payload)
+	}
+	opcode, payload := readServerFrame(t, reader)
+	want := `{"client":"cli","room":"lobby","text":"hi","token":"abc"}`
+	if opcode != TextMessage || string(payload) != want {
+		t.Fatalf("echo frame: %d %q, want %q", opcode, payload, want)
+	}
+	if opcode, _ = readServerFrame(t, reader); opcode != CloseMessage {
+		t.Fatalf("expected close frame after handler return, got %d", opcode)
+	}
+}
+
+func TestWebSocket_RejectsUnmaskedFrame(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer client.Close()
+	ws := &WSConn{conn: server, reader: bufio.NewReader(server), readLimit: DefaultWSReadLimit}
+	go func() {
+		_, _ = client.Write([]byte{0x81, 0x01, 'x'})
+	}()
+	if _, _, err := ws.ReadMessage(); err != ErrUnmaskedFrame {
+		t.Fatalf("ReadMessage error = %v, want ErrUnmaskedFrame", err)
+	}
+}
+
+func TestWebSocket_RejectsInvalidFrames(t *testing.T) {
+
+	maskedFrame := func(first byte, payload []byte) (frame []byte) {
+		frame = []byte{first}
+		if len(payload) < 126 {
+			frame = append(frame, 0x80|byte(len(payload)))
+		} else {
+			frame = append(frame, 0x80|126)
+			frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+		}
+		frame = append(frame, 0, 0, 0, 0)
+		return append(frame, payload...)
+	}
+	for name, frames := range map[string][]byte{
+		"rsv1":             maskedFrame(0x80|0x40|TextMessage, []byte("x")),
+		"rsv3":             maskedFrame(0x80|0x10|BinaryMessage, []byte("x")),
+		"fragmented ping":  maskedFrame(PingMessage, []byte("p")),
+		"fragmented close": maskedFrame(CloseMessage, nil),
+		"long ping":        maskedFrame(0x80|PingMessage, make([]byte, 126)),
+		"long pong":        maskedFrame(0x80|PongMessage, make([]byte, 200)),
+		"unfinished ping inside a fragmented message": append(
+			maskedFrame(TextMessage, []byte("a")),
+			maskedFrame(PingMessage, []byte("p"))...,
+		),
+	} {
+		t.Run(name, func(t *testing.T) {
+			server, client := net.Pipe()
+			defer client.Close()
+			ws := &WSConn{conn: server, reader: bufio.NewReader(server), readLimit: DefaultWSReadLimit}
+			go func() {
+				_, _ = client.Write(frames)
+			}()
+			if _, _, err := ws.ReadMessage(); err != ErrProtocolFailure {
+				t.Fatalf("ReadMessage error = %v, want ErrProtocolFailure", err)
+			}
+		})
+	}
+}
+
+func TestWebSocket_AcceptsMaxControlFrame(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer client.Close()
+	ws := &WSConn{conn: server, reader: bufio.NewReader(server), readLimit: DefaultWSReadLimit}
+	ping := make([]byte, maxControlPayload)
+	go func() {
+		writeClientFrame(t, client, PingMessage, ping)
+		reader := bufio.NewReader(client)
+		if opcode, payload := readServerFrame(t, reader); opcode != PongMessage || len(payload) != maxControlPayload {
+			t.Errorf("pong frame: %d, %d bytes", opcode, len(payload))
+		}
+		writeClientFrame(t, client, TextMessage, []byte("ok"))
+	}()
+	if messageType, payload, err := ws.ReadMessage(); err != nil || messageType != TextMessage || string(payload) != "ok" {
+		t.Fatalf("ReadMessage = %d, %q, %v", messageType, payload, err)
+	}
+}
@@ -60,7 +60,7 @@ func TestRenderTransportServer_IgnoresKafkaTraceAndMetrics(t *testing.T) {
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
@@ -121,7 +121,7 @@ func TestRenderTransportServer_HTTPTraceAndMetricsStillWork(t *testing.T) {
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
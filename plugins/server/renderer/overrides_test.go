@@ -17,7 +17,7 @@ func TestRenderHTTP_HandlerAnnotationCallsFunction(t *testing.T) {
 	project, contract := overridesTestContract()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewContractRenderer(project, contract, dir)
+	renderer := NewContractRenderer(project, contract, dir, TargetFiber)
 	if err := renderer.RenderHTTP(); err != nil {
 		t.Fatalf("RenderHTTP: %v", err)
 	}
@@ -41,7 +41,7 @@ func TestRenderREST_HttpResponseAnnotationCallsFunction(t *testing.T) {
 	project, contract := overridesTestContract()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewContractRenderer(project, contract, dir)
+	renderer := NewContractRenderer(project, contract, dir, TargetFiber)
 	if err := renderer.RenderREST(); err != nil {
 		t.Fatalf("RenderREST: %v", err)
 	}
@@ -71,7 +71,7 @@ func TestRenderHTTP_HandlerAnnotationRejectsMalformedReturn(t *testing.T) {
 	project, contract := overridesTestContract()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewContractRenderer(project, contract, dir)
+	renderer := NewContractRenderer(project, contract, dir, TargetFiber)
 	if err := renderer.RenderHTTP(); err != nil {
 		t.Fatalf("RenderHTTP: %v", err)
 	}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func problemTestProject(mode string) (project *model.Project) {
+
+	annotations := tags.DocTags{model.TagServerHTTP: ""}
+	if mode != "" {
+		annotations[model.TagHttpErrors] = mode
+	}
+	return &model.Project{
+		ModulePath:  "example",
+		Annotations: tags.DocTags{model.TagHttpProblemBase: "https://api.example.com/problems"},
+		Contracts: []*model.Contract{
+			{
+				Name:        "Orders",
+				PkgPath:     "example/contracts",
+				Annotations: annotations,
+				Methods: []*model.Method{
+					{
+						Name:        "Get",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/orders/:id"},
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+							{Name: "id", TypeRef: model.TypeRef{TypeID: "int"}},
+						},
+						Results: []*model.Variable{
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+						Errors: []*model.ErrorInfo{
+							{PkgPath: "example/errs", TypeName: "ErrNotFound", TypeID: "example/errs:ErrNotFound", HTTPCode: 404, HTTPCodeText: "Not Found"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderTransportErrors_ProblemTypes(t *testing.T) {
+
+	project := problemTestProject(model.HTTPErrorsProblem)
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportErrors(); err != nil {
+		t.Fatalf("RenderTransportErrors: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "errors.go"))
+
+	for _, want := range []string{
+		`"example/errs.ErrNotFound": {`,
+		`uri:   "https://api.example.com/problems/not-found"`,
+		"func sendProblem(ftx *fiber.Ctx, err error) error",
+		`ftx.Response().Header.SetContentType("application/problem+json")`,
+		`members["instance"] = ftx.Path()`,
+		"func problemTypeOf(err error) (problem problemType)",
+		`return problemType{uri: "about:blank"}`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("errors.go must contain %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestRenderREST_ProblemErrors(t *testing.T) {
+
+	project := problemTestProject(model.HTTPErrorsProblem)
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber).RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "orders-rest.go"))
+	if !strings.Contains(source, "return sendProblem(ftx, err)") ||
+		!strings.Contains(source, `return sendProblem(ftx, errBadRequestData("path arguments could not be decoded: "+err.Error()))`) {
+		t.Fatalf("problem mode must answer with sendProblem:\n%s", source)
+	}
+}
+
+func TestRenderREST_DefaultErrorsUnchanged(t *testing.T) {
+
+	project := problemTestProject("")
+	dir := filepath.Join(t.TempDir(), "transport")
+	transport := NewTransportRenderer(project, dir, TargetFiber)
+	if err := transport.RenderTransportErrors(); err != nil {
+		t.Fatalf("RenderTransportErrors: %v", err)
+	}
+	if err := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber).RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	if strings.Contains(readGenerated(t, filepath.Join(dir, "errors.go")), "sendProblem") {
+		t.Fatalf("sendProblem must be opt-in")
+	}
+	if source := readGenerated(t, filepath.Join(dir, "orders-rest.go")); !strings.Contains(source, "return sendResponse(ftx, err)") {
+		t.Fatalf("default mode must keep sendResponse:\n%s", source)
+	}
+}
@@ -18,7 +18,11 @@ func (r *contractRenderer) httpServeRequestBodyDecode(bg *Group, jsonPkg string,
 		ig.If(List(Id("server"), Id("ok")).Op(":=").Id(VarNameFtx).Dot("Locals").Call(Lit("server")).Assert(Op("*").Id("Server")).Op(";").Id("ok").Op("&&").Id("server").Dot("metrics").Op("!=").Nil()).Block(
 			Id("server").Dot("metrics").Dot("ErrorResponsesTotal").Dot("WithLabelValues").Call(Lit("rest"), Lit("400"), Id("clientID")).Dot("Inc").Call(),
 		)
-		ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusBadRequest"))
+		ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(r.httpPkg(), "StatusBadRequest"))
+		if model.HTTPErrorsAsProblem(r.project, r.contract, method) {
+			ig.Return(Id("sendProblem").Call(Id(VarNameFtx), Id("errBadRequestData").Call(Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())))
+			return
+		}
 		ig.List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())
 		ig.Return()
 	}
@@ -56,7 +60,7 @@ func (r *contractRenderer) httpServeResponseEncode(method *model.Method, resKind
 			ig.If(Id("logger").Op(":=").Qual(srvctxPkgPath, "FromCtx").Types(Op("*").Qual(PackageSlog, "Logger")).Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("logger").Op("!=").Nil()).Block(
 				Id("logger").Dot("Error").Call(Lit("response encode error"), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
 			)
-			ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError"))
+			ig.Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusInternalServerError"))
 			ig.Return(Err())
 		})
 	}
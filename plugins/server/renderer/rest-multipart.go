@@ -23,13 +23,13 @@ func (r *contractRenderer) httpServeMultipartRequest(method *model.Method) (c Co
 	st := Line()
 	st.Var().Id("params").Map(String()).String()
 	st.Line().If(List(Id("_"), Id("params"), Err()).Op("=").Qual(PackageMime, "ParseMediaType").Call(Id(VarNameFtx).Dot("Get").Call(Lit("Content-Type"))).Op(";").Err().Op("!=").Nil()).Block(
-		Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-		Return().Id("sendResponse").Call(Id(VarNameFtx), Id("errBadRequestData").Call(Lit("invalid or missing Content-Type"))),
+		Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+		r.httpSendError(method, Id("errBadRequestData").Call(Lit("invalid or missing Content-Type"))),
 	)
 	st.Line().Id("boundary").Op(",").Id("ok").Op(":=").Id("params").Index(Lit("boundary"))
 	st.Line().If(Op("!").Id("ok").Op("||").Id("boundary").Op("==").Lit("")).Block(
-		Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-		Return().Id("sendResponse").Call(Id(VarNameFtx), Id("errBadRequestData").Call(Lit("missing boundary"))),
+		Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+		r.httpSendError(method, Id("errBadRequestData").Call(Lit("missing boundary"))),
 	)
 	st.Line().Id("bodyStream").Op(":=").Id("ensureBodyReader").Call(Id(VarNameFtx).Dot("Context").Call().Dot("RequestBodyStream").Call())
 	st.Line().Id("mr").Op(":=").Qual(PackageMimeMultipart, "NewReader").Call(Id("bodyStream"), Id("boundary"))
@@ -42,8 +42,8 @@ func (r *contractRenderer) httpServeMultipartRequest(method *model.Method) (c Co
 			fg.List(Id("p"), Err()).Op("=").Id("mr").Dot("NextPart").Call()
 			fg.If(Qual("errors", "Is").Call(Err(), Qual("io", "EOF"))).Block(Break())
 			fg.If(Err().Op("!=").Nil()).Block(
-				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-				Return().Id("sendResponse").Call(Id(VarNameFtx), Id("errBadRequestData").Call(Lit("multipart read error"))),
+				Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+				r.httpSendError(method, Id("errBadRequestData").Call(Lit("multipart read error"))),
 			)
 			fg.Id("partName").Op(":=").Id("p").Dot("FormName").Call()
 			fg.Id("partContentType").Op(":=").Id("p").Dot("Header").Dot("Get").Call(Lit("Content-Type"))
@@ -56,8 +56,8 @@ func (r *contractRenderer) httpServeMultipartRequest(method *model.Method) (c Co
 					if expectedContent != "" {
 						cg.If(Id("partContentType").Op("!=").Lit(expectedContent)).Block(
 							Id("request").Dot(fieldName).Op("=").Qual(PackageBytes, "NewReader").Call(Nil()),
-							Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-							Return().Id("sendResponse").Call(Id(VarNameFtx), Id("errBadRequestData").Call(Lit("part ").Op("+").Lit(partName).Op("+").Lit(": invalid content-type"))),
+							Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+							r.httpSendError(method, Id("errBadRequestData").Call(Lit("part ").Op("+").Lit(partName).Op("+").Lit(": invalid content-type"))),
 						)
 					}
 					cg.Id("request").Dot(fieldName).Op("=").Id("p")
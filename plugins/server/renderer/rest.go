@@ -23,7 +23,7 @@ func (r *contractRenderer) RenderREST() (err error) {
 	srcFile.PackageComment(generated.ByToolGateway)
 
 	jsonPkg := r.getPackageJSON()
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
 	srcFile.ImportName(jsonPkg, "json")
 	srcFile.ImportName(PackageReflect, "reflect")
@@ -141,7 +141,7 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).
 		Id("serve" + method.Name).
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Err().Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
@@ -153,6 +153,9 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 					),
 				).Call()
 			})
+			bg.Add(r.restLimitsCheck(method))
+			bg.Add(r.restAuthCheck(method))
+			bg.Add(r.restIdempotencyCheck(method))
 			bg.Var().Id("request").Id(requestStructName(r.contract.Name, method.Name))
 			if successCodeStr := model.GetAnnotationValue(r.project, r.contract, method, nil, model.TagHttpSuccess, ""); successCodeStr != "" {
 				if successCode, err := strconv.Atoi(successCodeStr); err == nil && successCode != 0 {
@@ -168,14 +171,14 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 				r.httpServeRequestBodyDecode(bg, jsonPkg, method, reqKind)
 				bg.Add(r.httpArgHeadersBodyMode(srcFile, typeGen, method, func(arg, header string) []Code {
 					return []Code{
-						Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-						Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+						Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+						r.httpSendDecodeError(method, Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
 					}
 				}))
 				bg.Add(r.httpCookiesBodyMode(srcFile, typeGen, method, func(arg, header string) []Code {
 					return []Code{
-						Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-						Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+						Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+						r.httpSendDecodeError(method, Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
 					}
 				}))
 			}
@@ -185,28 +188,34 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 			}
 			bg.Add(r.urlArgs(srcFile, typeGen, method, func(arg, header string) []Code {
 				return []Code{
-					Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-					Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("path arguments could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+					Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+					r.httpSendDecodeError(method, Lit("path arguments could not be decoded: ").Op("+").Err().Dot("Error").Call()),
 				}
 			}))
 			bg.Add(r.urlParams(srcFile, typeGen, method, func(arg, header string) []Code {
 				return []Code{
-					Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-					Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("url arguments could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+					Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+					r.httpSendDecodeError(method, Lit("url arguments could not be decoded: ").Op("+").Err().Dot("Error").Call()),
 				}
 			}))
 			bg.Add(r.httpArgHeaders(srcFile, typeGen, method, func(arg, header string) []Code {
 				return []Code{
-					Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-					Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+					Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+					r.httpSendDecodeError(method, Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
 				}
 			}))
 			bg.Add(r.httpCookies(srcFile, typeGen, method, func(arg, header string) []Code {
 				return []Code{
-					Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest")),
-					Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+					Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+					r.httpSendDecodeError(method, Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()),
 				}
 			}))
+			if r.methodHasValidation(r.contract, method) {
+				bg.If(Id("violations").Op(":=").Id("request").Dot("validate").Call().Op(";").Len(Id("violations")).Op(">").Lit(0)).Block(
+					Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusBadRequest")),
+					r.httpSendError(method, Id("newRequestValidationError").Call(Id("violations"))),
+				)
+			}
 			if responseMethod := model.GetAnnotationValue(r.project, r.contract, method, nil, TagHttpResponse, ""); responseMethod != "" {
 				args := argsWithoutContext(method)
 				callArgs := make([]Code, 0, len(args)+2)
@@ -268,7 +277,7 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 					Id("statusCode").Op("=").Id("errCoder").Dot("Code").Call(),
 					Id(VarNameFtx).Dot("Status").Call(Id("statusCode")),
 				).Else().Block(
-					Id("statusCode").Op("=").Qual(PackageFiber, "StatusInternalServerError"),
+					Id("statusCode").Op("=").Qual(r.httpPkg(), "StatusInternalServerError"),
 					Id(VarNameFtx).Dot("Status").Call(Id("statusCode")),
 				)
 				bg.If(List(Id("server"), Id("ok")).Op(":=").Id(VarNameFtx).Dot("Locals").Call(Lit("server")).Assert(Op("*").Id("Server")).Op(";").Id("ok").Op("&&").Id("server").Dot("metrics").Op("!=").Nil()).Block(
@@ -278,7 +287,7 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 						Id("clientID"),
 					).Dot("Inc").Call(),
 				)
-				bg.Return().Id("sendResponse").Call(Id(VarNameFtx), Err())
+				bg.Add(r.httpSendError(method, Err()))
 			}
 		})
 }
@@ -297,7 +306,25 @@ func (r *contractRenderer) httpServePipeRequestContentTypeCheck(method *model.Me
 			Op("||").
 			Id("gotMT").Op("!=").Lit(expectedMT),
 	).Block(
-		Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusUnsupportedMediaType")),
-		Return().Id("sendResponse").Call(Id(VarNameFtx), Id("errBadRequestData").Call(Lit("unsupported request Content-Type"))),
+		Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusUnsupportedMediaType")),
+		r.httpSendError(method, Id("errBadRequestData").Call(Lit("unsupported request Content-Type"))),
 	)
 }
+
+// httpSendError — ответ с ошибкой: сама ошибка в JSON или problem+json по RFC 9457 (@tg http-errors=problem).
+func (r *contractRenderer) httpSendError(method *model.Method, errCode Code) (c Code) {
+
+	if model.HTTPErrorsAsProblem(r.project, r.contract, method) {
+		return Return().Id("sendProblem").Call(Id(VarNameFtx), errCode)
+	}
+	return Return().Id("sendResponse").Call(Id(VarNameFtx), errCode)
+}
+
+// httpSendDecodeError — ответ на ошибку разбора запроса: строка сообщения или problem+json с ним в detail.
+func (r *contractRenderer) httpSendDecodeError(method *model.Method, message Code) (c Code) {
+
+	if model.HTTPErrorsAsProblem(r.project, r.contract, method) {
+		return Return().Id("sendProblem").Call(Id(VarNameFtx), Id("errBadRequestData").Call(message))
+	}
+	return Return().Id("sendResponse").Call(Id(VarNameFtx), message)
+}
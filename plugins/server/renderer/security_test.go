@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func securityTestProject() (project *model.Project) {
+
+	project = limitsTestProject()
+	project.Annotations = tags.DocTags{model.TagSecurity: "http:bearer,apiKey:query:api_key"}
+	project.Contracts[0].Annotations = tags.DocTags{model.TagServerHTTP: "", model.TagServerJsonRPC: ""}
+	project.Contracts[0].Methods[0].Annotations = tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/orders/:id", model.TagScopes: "orders.read,orders.write"}
+	project.Contracts[0].Methods[1].Annotations = tags.DocTags{}
+	return
+}
+
+func TestRenderTransportSecurity(t *testing.T) {
+
+	project := securityTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportSecurity(); err != nil {
+		t.Fatalf("RenderTransportSecurity: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "security.go"))
+
+	for _, want := range []string{
+		"AuthenticateBearer(ctx context.Context, token string) (principal *srvctx.Principal, err error)",
+		"AuthenticateAPIKey(ctx context.Context, key string) (principal *srvctx.Principal, err error)",
+		`authorizationCredentials(ftx.Get("Authorization"), "Bearer")`,
+		`string(ftx.Request().URI().QueryArgs().Peek("api_key"))`,
+		"if srv.bearerAuth == nil {",
+		`results["apiKey:query:api_key"] = newAuthResult("apiKey", principal, authErr)`,
+		`newErrUnauthorized("authenticator not configured")`,
+		`err = newErrUnauthorized("missing credentials")`,
+		"func sendAuthError(ftx *fiber.Ctx, authErr *errAuth) (err error) {",
+		`const authChallenge = "Bearer"`,
+		"unauthorizedError = -32001",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("security.go must contain %q:\n%s", want, source)
+		}
+	}
+	if strings.Contains(source, "BasicAuthenticator") {
+		t.Fatalf("security.go must not declare authenticators for schemes outside @tg security:\n%s", source)
+	}
+}
+
+func TestRenderTransportSecurity_NoSecurity(t *testing.T) {
+
+	project := limitsTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportSecurity(); err != nil {
+		t.Fatalf("RenderTransportSecurity: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "security.go")); err == nil {
+		t.Fatalf("security.go must not be generated without @tg security")
+	}
+}
+
+func TestRenderSecurity_Checks(t *testing.T) {
+
+	project := securityTestProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	contract := NewContractRenderer(project, project.Contracts[0], dir, TargetNetHTTP)
+	if err := contract.RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	if err := contract.RenderJsonRPC(); err != nil {
+		t.Fatalf("RenderJsonRPC: %v", err)
+	}
+
+	rest := readGenerated(t, filepath.Join(dir, "orders-rest.go"))
+	for _, want := range []string{
+		"ftx.SetUserContext(server.authenticate(ftx))",
+		`authCtx, authErr := authorize(ftx.UserContext(), []string{"bearer", "apiKey:query:api_key"}, "orders.read", "orders.write")`,
+		"ftx.SetUserContext(authCtx)",
+		`ftx.Set("WWW-Authenticate", authErr.challenge)`,
+		"ftx.Status(authErr.Code())",
+	} {
+		if !strings.Contains(rest, want) {
+			t.Fatalf("orders-rest.go must contain %q:\n%s", want, rest)
+		}
+	}
+	jsonRPC := readGenerated(t, filepath.Join(dir, "orders-jsonrpc.go"))
+	for _, want := range []string{
+		"ftx.SetUserContext(http.srv.authenticate(ftx))",
+		`authCtx, authErr := authorize(ctx, []string{"bearer", "apiKey:query:api_key"})`,
+		"ctx = authCtx",
+		"makeErrorResponseJsonRPC(requestBase.ID, authErr.jsonRPCCode(), authErr.Error(), authErr)",
+	} {
+		if !strings.Contains(jsonRPC, want) {
+			t.Fatalf("orders-jsonrpc.go must contain %q:\n%s", want, jsonRPC)
+		}
+	}
+}
+
+func TestRenderSecurity_PublicAndPerMethodSchemes(t *testing.T) {
+
+	project := securityTestProject()
+	project.Contracts[0].Methods[0].Annotations[model.TagSecurity] = "none"
+	project.Contracts[0].Methods[1].Annotations[model.TagSecurity] = "apiKey:header:X-Import-Key"
+	dir := filepath.Join(t.TempDir(), "transport")
+	contract := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
+	if err := contract.RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	if err := contract.RenderJsonRPC(); err != nil {
+		t.Fatalf("RenderJsonRPC: %v", err)
+	}
+	if rest := readGenerated(t, filepath.Join(dir, "orders-rest.go")); strings.Contains(rest, "authorize(") {
+		t.Fatalf("method with security=none must not be authorized:\n%s", rest)
+	}
+	jsonRPC := readGenerated(t, filepath.Join(dir, "orders-jsonrpc.go"))
+	if want := `authorize(ctx, []string{"apiKey:header:X-Import-Key"})`; !strings.Contains(jsonRPC, want) {
+		t.Fatalf("orders-jsonrpc.go must contain %q:\n%s", want, jsonRPC)
+	}
+
+	transportDir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, transportDir, TargetFiber).RenderTransportSecurity(); err != nil {
+		t.Fatalf("RenderTransportSecurity: %v", err)
+	}
+	if source := readGenerated(t, filepath.Join(transportDir, "security.go")); !strings.Contains(source, `ftx.Get("X-Import-Key")`) {
+		t.Fatalf("security.go must authenticate the method-level scheme:\n%s", source)
+	}
+}
+
+func TestRenderSecurity_StreamsAndHandlerOverrides(t *testing.T) {
+
+	project := securityTestProject()
+	contract := project.Contracts[0]
+	contract.Annotations[model.TagServerSSE] = ""
+	contract.Annotations[model.TagServerWS] = ""
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	ticks := &model.Variable{Name: "ticks", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "string"}, ChanDirection: 2}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	contract.Methods = append(contract.Methods,
+		&model.Method{Name: "Watch", Annotations: tags.DocTags{model.TagStream: model.StreamModeServer, model.TagScopes: "orders.watch"}, Args: []*model.Variable{ctx}, Results: []*model.Variable{ticks, errResult}},
+		&model.Method{Name: "Feed", Annotations: tags.DocTags{model.TagStream: model.StreamModeServer, model.TagSSEPath: "/sse/orders/feed"}, Args: []*model.Variable{ctx}, Results: []*model.Variable{ticks, errResult}},
+		&model.Method{Name: "Ping", Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/ping", TagHandler: "example/hooks:Ping"}, Args: []*model.Variable{ctx}, Results: []*model.Variable{errResult}},
+	)
+	dir := filepath.Join(t.TempDir(), "transport")
+	renderer := NewContractRenderer(project, contract, dir, TargetFiber)
+	if err := renderer.RenderHTTP(); err != nil {
+		t.Fatalf("RenderHTTP: %v", err)
+	}
+	if err := renderer.RenderWebSocket(); err != nil {
+		t.Fatalf("RenderWebSocket: %v", err)
+	}
+	if err := renderer.RenderSSE(); err != nil {
+		t.Fatalf("RenderSSE: %v", err)
+	}
+
+	http := readGenerated(t, filepath.Join(dir, "orders-http.go"))
+	for _, want := range []string{
+		`if _, authErr := authorize(ftx.UserContext(), []string{"bearer", "apiKey:query:api_key"}); authErr != nil {`,
+		`ftx.Locals("authContext", ftx.UserContext())`,
+		"return sendAuthError(ftx, authErr)\n\t\t}\n\t\tftx.SetUserContext(authCtx)\n\t\treturn hooks.Ping(ftx, http.base)",
+	} {
+		if !strings.Contains(http, want) {
+			t.Fatalf("orders-http.go must contain %q:\n%s", want, http)
+		}
+	}
+	ws := readGenerated(t, filepath.Join(dir, "orders-websocket.go"))
+	for _, want := range []string{
+		`authContext, ok := conn.Locals("authContext").(context.Context)`,
+		"ctx := context.WithValue(authContext, stream.KeyOverlay, overlay)",
+		`authCtx, authErr := authorize(ctx, []string{"bearer", "apiKey:query:api_key"}, "orders.watch")`,
+	} {
+		if !strings.Contains(ws, want) {
+			t.Fatalf("orders-websocket.go must contain %q:\n%s", want, ws)
+		}
+	}
+	if sse := readGenerated(t, filepath.Join(dir, "orders-sse.go")); !strings.Contains(sse, "return sendAuthError(ftx, authErr)") {
+		t.Fatalf("orders-sse.go must reject unauthenticated streams:\n%s", sse)
+	}
+}
@@ -129,11 +129,14 @@ func (r *contractRenderer) newServerFunc(typeGen *types.Generator) (c Code) {
 
 func (r *contractRenderer) wrapFunc(typeGen *types.Generator) (c Code) {
 
-	return Func().Params(Id("srv").Op("*").Id("server" + r.contract.Name)).
+	return Comment("Wrap оборачивает контракт целиком; внутри остаются уже установленные middleware методов.").
+		Line().
+		Func().Params(Id("srv").Op("*").Id("server" + r.contract.Name)).
 		Id("Wrap").
 		Params(Id("m").Id("Middleware" + r.contract.Name)).
 		BlockFunc(func(bg *Group) {
-			bg.Id("srv").Dot("svc").Op("=").Id("m").Call(Id("srv").Dot("svc"))
+			bg.Id("current").Op(":=").Op("*").Id("srv")
+			bg.Id("srv").Dot("svc").Op("=").Id("m").Call(Op("&").Id("current"))
 			for _, method := range r.contract.Methods {
 				bg.Id("srv").Dot(toLowerCamel(method.Name)).Op("=").Id("srv").Dot("svc").Dot(method.Name)
 			}
@@ -22,7 +22,7 @@ func (r *contractRenderer) RenderSSE() (err error) {
 	srcFile.ImportName("bufio", "bufio")
 	srcFile.ImportName(PackageStdJSON, "json")
 	srcFile.ImportName(PackageTime, "time")
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(fmt.Sprintf("%s/stream", r.pkgPath(r.outDir)), "stream")
 
 	typeGen := types.NewGenerator(r.project, &srcFile)
@@ -42,22 +42,23 @@ func (r *contractRenderer) sseHandler(srcFile *GoFile, typeGen *types.Generator,
 		model.ContractHasSSE(r.project, r.contract)
 	fiberErr := func(arg, header string) []Code {
 		return []Code{
-			Return(Qual(PackageFiber, "NewError").Call(Qual(PackageFiber, "StatusBadRequest"), Lit("http value could not be decoded: ").Op("+").Err().Dot("Error").Call())),
+			Return(Qual(r.httpPkg(), "NewError").Call(Qual(r.httpPkg(), "StatusBadRequest"), Lit("http value could not be decoded: ").Op("+").Err().Dot("Error").Call())),
 		}
 	}
 	return Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).Id("serveSSE" + method.Name).
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).Params(Id("err").Error()).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).Params(Id("err").Error()).
 		BlockFunc(func(bg *Group) {
+			bg.Add(r.handlerAuthCheck(method))
 			bg.Var().Id("requestBase").Qual(streamPath, "Message")
 			bg.If(Len(Id(VarNameFtx).Dot("Body").Call()).Op(">").Lit(0)).Block(
 				If(Err().Op("=").Qual(PackageStdJSON, "Unmarshal").Call(Id(VarNameFtx).Dot("Body").Call(), Op("&").Id("requestBase")).Op(";").Err().Op("!=").Nil()).Block(
-					Return(Qual(PackageFiber, "NewError").Call(Qual(PackageFiber, "StatusBadRequest"), Lit("invalid stream request: ").Op("+").Err().Dot("Error").Call())),
+					Return(Qual(r.httpPkg(), "NewError").Call(Qual(r.httpPkg(), "StatusBadRequest"), Lit("invalid stream request: ").Op("+").Err().Dot("Error").Call())),
 				),
 			)
 			bg.Var().Id("request").Id(requestStructName(r.contract.Name, method.Name))
 			bg.If(Len(Id("requestBase").Dot("Params")).Op(">").Lit(0)).Block(
 				If(Err().Op("=").Qual(PackageStdJSON, "Unmarshal").Call(Id("requestBase").Dot("Params"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
-					Return(Qual(PackageFiber, "NewError").Call(Qual(PackageFiber, "StatusBadRequest"), Lit("invalid stream parameters: ").Op("+").Err().Dot("Error").Call())),
+					Return(Qual(r.httpPkg(), "NewError").Call(Qual(r.httpPkg(), "StatusBadRequest"), Lit("invalid stream parameters: ").Op("+").Err().Dot("Error").Call())),
 				),
 			)
 			bg.Add(r.httpArgHeadersBodyMode(srcFile, typeGen, method, fiberErr))
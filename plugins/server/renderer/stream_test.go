@@ -16,7 +16,7 @@ func TestRenderWebSocket_UniqueConnAndMixedResults(t *testing.T) {
 
 	project := streamTestProject("Live", model.TagServerWS)
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderWebSocket(); err != nil {
 		t.Fatalf("RenderWebSocket: %v", err)
 	}
@@ -43,7 +43,7 @@ func TestRenderSSE_MixedResultsFinalMarshal(t *testing.T) {
 
 	project := streamTestProject("Live", model.TagServerSSE)
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderSSE(); err != nil {
 		t.Fatalf("RenderSSE: %v", err)
 	}
@@ -84,7 +84,7 @@ func TestRenderSSE_HTTPContractWiresServerRef(t *testing.T) {
 
 	project := streamTestProject("Live", model.TagServerSSE)
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderHTTP(); err != nil {
 		t.Fatalf("RenderHTTP: %v", err)
 	}
@@ -101,7 +101,7 @@ func TestRenderTransportOptions_SSEHeartbeat(t *testing.T) {
 
 	project := streamTestProject("Live", model.TagServerSSE)
 	dir := filepath.Join(t.TempDir(), "transport")
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportOptions(); err != nil {
 		t.Fatalf("RenderTransportOptions: %v", err)
 	}
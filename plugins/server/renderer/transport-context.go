@@ -15,7 +15,7 @@ import (
 
 func (r *transportRenderer) RenderTransportContext() (err error) {
 
-	if err = r.pkgRenderTo("srvctx", r.outDir, newPkgTemplateData()); err != nil {
+	if err = r.pkgRenderTo("srvctx", r.outDir, r.newPkgTemplateData()); err != nil {
 		return
 	}
 	if r.hasHTTPServerContracts() || r.hasStreamContracts() {
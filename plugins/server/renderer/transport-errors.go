@@ -3,12 +3,15 @@
 package renderer
 
 import (
+	"fmt"
 	"path"
 	"path/filepath"
+	"sort"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
 	"tgp/internal/generated"
+	"tgp/internal/model"
 )
 
 func (r *transportRenderer) RenderTransportErrors() (err error) {
@@ -26,6 +29,13 @@ func (r *transportRenderer) RenderTransportErrors() (err error) {
 		srcFile.Line().Add(r.errValidationType())
 		srcFile.Line().Add(r.errBadRequestDataFunc())
 	}
+	if r.hasProblemErrors() {
+		srcFile.ImportName(r.httpPkg(), r.httpPkgName())
+		srcFile.ImportName(r.getPackageJSON(), "json")
+		srcFile.Line().Add(r.problemTypesVar())
+		srcFile.Line().Add(r.sendProblemFunc())
+		srcFile.Line().Add(r.problemTypeOfFunc())
+	}
 	srcFile.Line().Add(r.exitOnErrorFunc())
 
 	return srcFile.Save(errorsPath)
@@ -84,3 +94,99 @@ func (r *transportRenderer) exitOnErrorFunc() (c Code) {
 			),
 		)
 }
+
+// problemTypesVar — таблица типов проблем RFC 9457: ошибки методов с @tg http-errors=problem по пакету и имени типа.
+func (r *transportRenderer) problemTypesVar() (c Code) {
+
+	types := make(map[string][2]string)
+	for _, contract := range r.contractsSorted() {
+		if !model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if !model.HTTPErrorsAsProblem(r.project, contract, method) {
+				continue
+			}
+			for _, errInfo := range method.Errors {
+				if errInfo.PkgPath == "" || errInfo.TypeName == "" {
+					continue
+				}
+				types[model.ProblemErrorKey(errInfo)] = [2]string{model.ProblemType(r.project, contract, errInfo), errInfo.HTTPCodeText}
+			}
+		}
+	}
+	keys := make([]string, 0, len(types))
+	for key := range types {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return Type().Id("problemType").Struct(
+		Id("uri").String(),
+		Id("title").String(),
+	).
+		Line().Line().
+		Var().Id("problemTypes").Op("=").Map(String()).Id("problemType").Values(DictFunc(func(d Dict) {
+		for _, key := range keys {
+			values := Dict{Id("uri"): Lit(types[key][0])}
+			if title := types[key][1]; title != "" {
+				values[Id("title")] = Lit(title)
+			}
+			d[Lit(key)] = Values(values)
+		}
+	}))
+}
+
+// sendProblemFunc генерирует ответ application/problem+json: поля JSON ошибки становятся членами-расширениями,
+// статус берётся из уже выставленного кода ответа.
+func (r *transportRenderer) sendProblemFunc() (c Code) {
+
+	jsonPkg := r.getPackageJSON()
+	return Func().Id("sendProblem").
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Err().Error()).
+		Params(Error()).
+		BlockFunc(func(bg *Group) {
+			bg.Id("status").Op(":=").Id(VarNameFtx).Dot("Response").Call().Dot("StatusCode").Call()
+			bg.Var().Id("members").Map(String()).Any()
+			bg.If(List(Id("data"), Id("marshalErr")).Op(":=").Qual(jsonPkg, "Marshal").Call(Err()).Op(";").Id("marshalErr").Op("==").Nil()).Block(
+				Id("_").Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("data"), Op("&").Id("members")),
+			)
+			bg.If(Id("members").Op("==").Nil()).Block(
+				Id("members").Op("=").Make(Map(String()).Any()),
+			)
+			bg.Id("problem").Op(":=").Id("problemTypeOf").Call(Err())
+			bg.If(Id("problem").Dot("title").Op("==").Lit("")).Block(
+				Id("problem").Dot("title").Op("=").Qual(PackageHTTP, "StatusText").Call(Id("status")),
+			)
+			bg.Id("members").Index(Lit("type")).Op("=").Id("problem").Dot("uri")
+			bg.Id("members").Index(Lit("title")).Op("=").Id("problem").Dot("title")
+			bg.Id("members").Index(Lit("status")).Op("=").Id("status")
+			bg.Id("members").Index(Lit("detail")).Op("=").Err().Dot("Error").Call()
+			bg.Id("members").Index(Lit("instance")).Op("=").Id(VarNameFtx).Dot("Path").Call()
+			bg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Lit(model.ProblemContentType))
+			bg.If(Id("encodeErr").Op(":=").Qual(jsonPkg, "NewEncoder").Call(Id(VarNameFtx).Dot("Response").Call().Dot("BodyWriter").Call()).Dot("Encode").Call(Id("members")).Op(";").Id("encodeErr").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				ig.If(Id("logger").Op(":=").Qual(fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir)), "FromCtx").Types(Op("*").Qual(PackageSlog, "Logger")).Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("logger").Op("!=").Nil()).Block(
+					Id("logger").Dot("Error").Call(Lit("problem marshal error"), Qual(PackageSlog, "Any").Call(Lit("error"), Id("encodeErr"))),
+				)
+				ig.Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusInternalServerError"))
+				ig.Return(Id("encodeErr"))
+			})
+			bg.Return(Nil())
+		})
+}
+
+// problemTypeOfFunc генерирует поиск типа проблемы по цепочке обёрнутых ошибок; неизвестные ошибки — about:blank.
+func (r *transportRenderer) problemTypeOfFunc() (c Code) {
+
+	return Func().Id("problemTypeOf").Params(Err().Error()).Params(Id("problem").Id("problemType")).Block(
+		For(Op(";").Err().Op("!=").Nil().Op(";").Err().Op("=").Qual(PackageErrors, "Unwrap").Call(Err())).Block(
+			Id("errType").Op(":=").Qual(PackageReflect, "TypeOf").Call(Err()),
+			For(Id("errType").Dot("Kind").Call().Op("==").Qual(PackageReflect, "Pointer")).Block(
+				Id("errType").Op("=").Id("errType").Dot("Elem").Call(),
+			),
+			If(List(Id("found"), Id("ok")).Op(":=").Id("problemTypes").Index(Id("errType").Dot("PkgPath").Call().Op("+").Lit(".").Op("+").Id("errType").Dot("Name").Call()).Op(";").Id("ok")).Block(
+				Return(Id("found")),
+			),
+		),
+		Return(Id("problemType").Values(Dict{Id("uri"): Lit(model.ProblemTypeBlank)})),
+	)
+}
@@ -20,7 +20,7 @@ func (r *transportRenderer) RenderTransportFiber() (err error) {
 	srcFile := NewSrcFile(filepath.Base(r.outDir))
 	srcFile.PackageComment(generated.ByToolGateway)
 
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(PackageSlog, "slog")
 	srcFile.ImportName(PackageContext, "context")
 	srcFile.ImportName(PackageStrings, "strings")
@@ -124,7 +124,7 @@ func (r *transportRenderer) renderFiberLogger(srcFile *GoFile) {
 
 	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("setLogger").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Error().
 		BlockFunc(func(bg *Group) {
 			bg.Id("ctx").Op(":=").Id(VarNameFtx).Dot("UserContext").Call()
@@ -163,7 +163,7 @@ func (r *transportRenderer) renderFiberLogger(srcFile *GoFile) {
 func (r *transportRenderer) renderFiberRecover(srcFile *GoFile) {
 
 	srcFile.Line().Func().Id("recoverHandler").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Error().
 		BlockFunc(func(bg *Group) {
 			bg.Id("path").Op(":=").Qual(PackageStrings, "Clone").Call(Id(VarNameFtx).Dot("Path").Call())
@@ -187,7 +187,7 @@ func (r *transportRenderer) renderFiberRecover(srcFile *GoFile) {
 							Qual(PackageSlog, "String").Call(Lit("stack"), Id("string").Call(Qual("runtime/debug", "Stack").Call())),
 						),
 					)
-					ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError"))
+					ig.Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusInternalServerError"))
 					ig.Id("_").Op("=").Id(VarNameFtx).Dot("JSON").Call(Map(String()).String().Values(Dict{Lit("message"): Lit("internal server error")}))
 				})
 			}).Call()
@@ -17,7 +17,7 @@ func TestRenderTransportFiber_RequestLoggerAlwaysIncludesClientID(t *testing.T)
 	project := transportFiberTestProject()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportFiber(); err != nil {
 		t.Fatalf("RenderTransportFiber: %v", err)
 	}
@@ -47,7 +47,7 @@ func TestRenderTransportFiber_RequestLoggerPreservesClientIDWithLogLevel(t *test
 	project := transportFiberTestProject()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportFiber(); err != nil {
 		t.Fatalf("RenderTransportFiber: %v", err)
 	}
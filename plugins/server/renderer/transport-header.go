@@ -20,7 +20,7 @@ func (r *transportRenderer) RenderTransportHeader() (err error) {
 	srcFile := NewSrcFile(filepath.Base(r.outDir))
 	srcFile.PackageComment(generated.ByToolGateway)
 
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(PackageErrors, "errors")
 	srcFile.ImportName(PackageSlog, "slog")
 	srcFile.ImportName(srvctxPkgPath, "srvctx")
@@ -56,7 +56,7 @@ func (r *transportRenderer) renderHeaderHandler(srcFile *GoFile) {
 
 	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("headersHandler").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
@@ -135,7 +135,7 @@ func (r *transportRenderer) renderHeaderValueInterface(srcFile *GoFile) {
 	)
 	if r.needsCookieType() {
 		srcFile.Line().Line().Type().Id("cookieType").Interface(
-			Id("Cookie").Params().Params(Qual(PackageFiber, "Cookie")),
+			Id("Cookie").Params().Params(Qual(r.httpPkg(), "Cookie")),
 		)
 	}
 }
@@ -0,0 +1,531 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/generated"
+	"tgp/internal/model"
+)
+
+const (
+	packageContainerList = "container/list"
+	packageSHA256        = "crypto/sha256"
+	packageHex           = "encoding/hex"
+)
+
+func (r *baseRenderer) hasIdempotency() (ok bool) {
+
+	for _, contract := range r.contractsSorted() {
+		for _, method := range contract.Methods {
+			if model.MethodIsIdempotent(r.project, contract, method) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonRPCIdempotencyHeaders — заголовки ключа идемпотентности JSON-RPC методов: их читает requestOverlay.
+func (r *baseRenderer) jsonRPCIdempotencyHeaders() (headerNames []string) {
+
+	for _, contract := range r.contractsSorted() {
+		for _, method := range methodsSorted(contract.Methods) {
+			if model.MethodIsJSONRPC(r.project, contract, method) && model.MethodIsIdempotent(r.project, contract, method) {
+				headerNames = append(headerNames, model.MethodIdempotencyHeader(r.project, contract, method))
+			}
+		}
+	}
+	return
+}
+
+// idempotencyScopeName — имя метода в ключе хранилища идемпотентности.
+func idempotencyScopeName(contract *model.Contract, method *model.Method) (name string) {
+
+	return toLowerCamel(contract.Name) + "." + toLowerCamel(method.Name)
+}
+
+func (r *transportRenderer) RenderTransportIdempotency() (err error) {
+
+	if !r.hasIdempotency() {
+		return
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(generated.ByToolGateway)
+
+	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
+	srcFile.ImportName(PackageBytes, "bytes")
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageErrors, "errors")
+	srcFile.ImportName(PackageHTTP, "http")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(packageContainerList, "list")
+	srcFile.ImportName(packageSHA256, "sha256")
+	srcFile.ImportName(packageHex, "hex")
+	srcFile.ImportName(srvctxPkgPath, "srvctx")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
+
+	if r.hasJsonRPC() {
+		srcFile.Line().Const().Op("(").
+			Line().Id("idempotencyConflictError").Op("=").Lit(-32009).
+			Line().Id("idempotencyMismatchError").Op("=").Lit(-32022).
+			Line().Op(")")
+	}
+	srcFile.Line().Const().Op("(").
+		Line().Id("defaultIdempotencyCapacity").Op("=").Lit(10000).
+		Line().Id("defaultIdempotencyTTL").Op("=").Lit(24).Op("*").Qual(PackageTime, "Hour").
+		Line().Op(")")
+	srcFile.Line().Add(r.idempotencyStoreTypes())
+	srcFile.Line().Add(r.memoryIdempotencyStore())
+	srcFile.Line().Add(r.errIdempotencyType())
+	srcFile.Line().Add(r.idempotencyHelpers(srvctxPkgPath))
+	if r.hasIdempotentHTTP() {
+		srcFile.Line().Add(r.beginIdempotentHTTPFunc())
+	}
+	if len(r.jsonRPCIdempotencyHeaders()) > 0 {
+		srcFile.Line().Type().Id("batchCallKey").Struct().
+			Line().Line().
+			Comment("keyBatchCall — отметка контекста вызова из JSON-RPC batch.").
+			Line().Var().Id("keyBatchCall").Op("=").Id("batchCallKey").Values()
+		srcFile.Line().Add(r.beginIdempotentJsonRPCFunc())
+	}
+
+	return srcFile.Save(path.Join(r.outDir, "idempotency.go"))
+}
+
+func (r *transportRenderer) hasIdempotentHTTP() (ok bool) {
+
+	for _, contract := range r.contractsSorted() {
+		for _, method := range contract.Methods {
+			if model.MethodIsHTTP(r.project, contract, method) && model.MethodIsIdempotent(r.project, contract, method) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *transportRenderer) idempotencyStoreTypes() (c Code) {
+
+	ctx := Id(VarNameCtx).Qual(PackageContext, "Context")
+	return Comment("IdempotencyRecord — сохранённый ответ на запрос с ключом идемпотентности и отпечаток этого запроса.").
+		Line().Type().Id("IdempotencyRecord").Struct(
+		Id("Fingerprint").String(),
+		Id("Status").Int(),
+		Id("Header").Qual(PackageHTTP, "Header"),
+		Id("Body").Index().Byte(),
+	).
+		Line().Line().
+		Var().Op("(").
+		Line().Comment("ErrIdempotencyInProgress — запрос с тем же ключом ещё выполняется (HTTP 409).").
+		Line().Id("ErrIdempotencyInProgress").Op("=").Qual(PackageErrors, "New").Call(Lit("request with this idempotency key is in progress")).
+		Line().Comment("ErrIdempotencyMismatch — ключ уже использован запросом с другим содержимым (HTTP 422).").
+		Line().Id("ErrIdempotencyMismatch").Op("=").Qual(PackageErrors, "New").Call(Lit("idempotency key was used for a different request")).
+		Line().Op(")").
+		Line().Line().
+		Comment("IdempotencyStore хранит ответы методов @tg idempotent. Begin резервирует key за запросом с отпечатком").
+		Line().Comment("fingerprint или возвращает сохранённый ответ; занятый ключ — ErrIdempotencyInProgress, ключ с другим").
+		Line().Comment("отпечатком — ErrIdempotencyMismatch. Complete сохраняет ответ, Release снимает резерв, чтобы запрос можно было повторить.").
+		Line().Type().Id("IdempotencyStore").Interface(
+		Id("Begin").Params(ctx.Clone(), Id("key").String(), Id("fingerprint").String()).Params(Id("record").Op("*").Id("IdempotencyRecord"), Err().Error()),
+		Id("Complete").Params(ctx.Clone(), Id("key").String(), Id("record").Id("IdempotencyRecord")).Params(Err().Error()),
+		Id("Release").Params(ctx.Clone(), Id("key").String()).Params(Err().Error()),
+	)
+}
+
+func (r *transportRenderer) memoryIdempotencyStore() (c Code) {
+
+	entry := func(element Code) *Statement {
+		return Add(element).Dot("Value").Assert(Op("*").Id("idempotencyEntry"))
+	}
+	return Type().Id("idempotencyEntry").Struct(
+		Id("key").String(),
+		Id("fingerprint").String(),
+		Id("record").Op("*").Id("IdempotencyRecord"),
+		Id("expiresAt").Qual(PackageTime, "Time"),
+	).
+		Line().Line().
+		Type().Id("memoryIdempotencyStore").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("capacity").Int(),
+		Id("ttl").Qual(PackageTime, "Duration"),
+		Id("order").Op("*").Qual(packageContainerList, "List"),
+		Id("entries").Map(String()).Op("*").Qual(packageContainerList, "Element"),
+	).
+		Line().Line().
+		Comment("NewMemoryIdempotencyStore — хранилище по умолчанию: LRU на capacity ключей в памяти процесса, ответ хранится ttl.").
+		Line().Func().Id("NewMemoryIdempotencyStore").Params(Id("capacity").Int(), Id("ttl").Qual(PackageTime, "Duration")).Params(Id("IdempotencyStore")).Block(
+		Return(Op("&").Id("memoryIdempotencyStore").Values(Dict{
+			Id("capacity"): Id("capacity"),
+			Id("ttl"):      Id("ttl"),
+			Id("order"):    Qual(packageContainerList, "New").Call(),
+			Id("entries"):  Make(Map(String()).Op("*").Qual(packageContainerList, "Element")),
+		})),
+	).
+		Line().Line().
+		Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).Id("Begin").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String(), Id("fingerprint").String()).
+		Params(Id("record").Op("*").Id("IdempotencyRecord"), Err().Error()).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("s").Dot("mu").Dot("Lock").Call()
+			bg.Defer().Id("s").Dot("mu").Dot("Unlock").Call()
+			bg.Line()
+			bg.If(Id("stored").Op(":=").Id("s").Dot("lookup").Call(Id("key")).Op(";").Id("stored").Op("!=").Nil()).Block(
+				Switch().Block(
+					Case(Id("stored").Dot("fingerprint").Op("!=").Id("fingerprint")).Block(
+						Return(Nil(), Id("ErrIdempotencyMismatch")),
+					),
+					Case(Id("stored").Dot("record").Op("==").Nil()).Block(
+						Return(Nil(), Id("ErrIdempotencyInProgress")),
+					),
+				),
+				Return(Id("stored").Dot("record"), Nil()),
+			)
+			bg.Id("s").Dot("put").Call(Op("&").Id("idempotencyEntry").Values(Dict{
+				Id("key"):         Id("key"),
+				Id("fingerprint"): Id("fingerprint"),
+				Id("expiresAt"):   Qual(PackageTime, "Now").Call().Dot("Add").Call(Id("s").Dot("ttl")),
+			}))
+			bg.Return(Nil(), Nil())
+		}).
+		Line().Line().
+		Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).Id("Complete").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String(), Id("record").Id("IdempotencyRecord")).
+		Params(Err().Error()).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("s").Dot("mu").Dot("Lock").Call()
+			bg.Defer().Id("s").Dot("mu").Dot("Unlock").Call()
+			bg.Line()
+			bg.Id("s").Dot("put").Call(Op("&").Id("idempotencyEntry").Values(Dict{
+				Id("key"):         Id("key"),
+				Id("fingerprint"): Id("record").Dot("Fingerprint"),
+				Id("record"):      Op("&").Id("record"),
+				Id("expiresAt"):   Qual(PackageTime, "Now").Call().Dot("Add").Call(Id("s").Dot("ttl")),
+			}))
+			bg.Return()
+		}).
+		Line().Line().
+		Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).Id("Release").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String()).
+		Params(Err().Error()).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("s").Dot("mu").Dot("Lock").Call()
+			bg.Defer().Id("s").Dot("mu").Dot("Unlock").Call()
+			bg.Line()
+			bg.If(List(Id("element"), Id("found")).Op(":=").Id("s").Dot("entries").Index(Id("key")).Op(";").Id("found").Op("&&").Add(entry(Id("element"))).Dot("record").Op("==").Nil()).Block(
+				Id("s").Dot("order").Dot("Remove").Call(Id("element")),
+				Delete(Id("s").Dot("entries"), Id("key")),
+			)
+			bg.Return()
+		}).
+		Line().Line().
+		Comment("lookup — действующая запись по ключу; просроченная запись удаляется.").
+		Line().Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).Id("lookup").
+		Params(Id("key").String()).
+		Params(Op("*").Id("idempotencyEntry")).
+		BlockFunc(func(bg *Group) {
+			bg.List(Id("element"), Id("found")).Op(":=").Id("s").Dot("entries").Index(Id("key"))
+			bg.If(Op("!").Id("found")).Block(Return(Nil()))
+			bg.Id("stored").Op(":=").Add(entry(Id("element")))
+			bg.If(Op("!").Qual(PackageTime, "Now").Call().Dot("Before").Call(Id("stored").Dot("expiresAt"))).Block(
+				Id("s").Dot("order").Dot("Remove").Call(Id("element")),
+				Delete(Id("s").Dot("entries"), Id("key")),
+				Return(Nil()),
+			)
+			bg.Id("s").Dot("order").Dot("MoveToFront").Call(Id("element"))
+			bg.Return(Id("stored"))
+		}).
+		Line().Line().
+		Comment("put — записывает entry в начало LRU и вытесняет самые старые ключи сверх capacity. Резерв выполняющегося").
+		Line().Comment("запроса не вытесняется, пока не истёк: иначе его повтор выполнил бы метод второй раз; такие резервы").
+		Line().Comment("могут временно превысить capacity.").
+		Line().Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).Id("put").
+		Params(Id("stored").Op("*").Id("idempotencyEntry")).
+		BlockFunc(func(bg *Group) {
+			bg.If(List(Id("element"), Id("found")).Op(":=").Id("s").Dot("entries").Index(Id("stored").Dot("key")).Op(";").Id("found")).Block(
+				Id("s").Dot("order").Dot("Remove").Call(Id("element")),
+			)
+			bg.Id("s").Dot("entries").Index(Id("stored").Dot("key")).Op("=").Id("s").Dot("order").Dot("PushFront").Call(Id("stored"))
+			bg.If(Id("s").Dot("capacity").Op("<=").Lit(0)).Block(Return())
+			bg.Id("now").Op(":=").Qual(PackageTime, "Now").Call()
+			bg.For(Id("element").Op(":=").Id("s").Dot("order").Dot("Back").Call().Op(";").Id("element").Op("!=").Nil().Op("&&").Id("s").Dot("order").Dot("Len").Call().Op(">").Id("s").Dot("capacity").Op(";")).Block(
+				Id("older").Op(":=").Id("element"),
+				Id("element").Op("=").Id("element").Dot("Prev").Call(),
+				If(Id("oldest").Op(":=").Add(entry(Id("older"))).Op(";").Id("oldest").Dot("record").Op("!=").Nil().Op("||").Op("!").Id("now").Dot("Before").Call(Id("oldest").Dot("expiresAt"))).Block(
+					Id("s").Dot("order").Dot("Remove").Call(Id("older")),
+					Delete(Id("s").Dot("entries"), Id("oldest").Dot("key")),
+				),
+			)
+		})
+}
+
+func (r *transportRenderer) errIdempotencyType() (c Code) {
+
+	return Comment("errIdempotency — отказ по ключу идемпотентности: запрос ещё выполняется (409), ключ использован").
+		Line().Comment("с другим запросом (422) или хранилище недоступно (503).").
+		Line().Type().Id("errIdempotency").Struct(
+		Id("TrKey").String().Tag(map[string]string{"json": "trKey"}),
+		Id("Data").String().Tag(map[string]string{"json": "data,omitempty"}),
+		Id("code").Int(),
+	).
+		Line().Line().
+		Func().Params(Id("e").Op("*").Id("errIdempotency")).Id("Error").Params().String().
+		Block(Return(Id("e").Dot("Data"))).
+		Line().Line().
+		Func().Params(Id("e").Op("*").Id("errIdempotency")).Id("Code").Params().Int().
+		Block(Return(Id("e").Dot("code"))).
+		Do(func(s *Statement) {
+			if !r.hasJsonRPC() {
+				return
+			}
+			s.Line().Line().
+				Func().Params(Id("e").Op("*").Id("errIdempotency")).Id("jsonRPCCode").Params().Int().Block(
+				Switch(Id("e").Dot("code")).Block(
+					Case(Qual(r.httpPkg(), "StatusConflict")).Block(Return(Id("idempotencyConflictError"))),
+					Case(Qual(r.httpPkg(), "StatusUnprocessableEntity")).Block(Return(Id("idempotencyMismatchError"))),
+				),
+				Return(Id("internalError")),
+			)
+		}).
+		Line().Line().
+		Func().Id("newErrIdempotency").Params(Err().Error()).Params(Op("*").Id("errIdempotency")).Block(
+		Switch().Block(
+			Case(Qual(PackageErrors, "Is").Call(Err(), Id("ErrIdempotencyInProgress"))).Block(
+				Return(Op("&").Id("errIdempotency").Values(Dict{Id("TrKey"): Lit("conflict"), Id("Data"): Err().Dot("Error").Call(), Id("code"): Qual(r.httpPkg(), "StatusConflict")})),
+			),
+			Case(Qual(PackageErrors, "Is").Call(Err(), Id("ErrIdempotencyMismatch"))).Block(
+				Return(Op("&").Id("errIdempotency").Values(Dict{Id("TrKey"): Lit("unprocessableEntity"), Id("Data"): Err().Dot("Error").Call(), Id("code"): Qual(r.httpPkg(), "StatusUnprocessableEntity")})),
+			),
+		),
+		Return(Op("&").Id("errIdempotency").Values(Dict{Id("TrKey"): Lit("serviceUnavailable"), Id("Data"): Lit("idempotency store: ").Op("+").Err().Dot("Error").Call(), Id("code"): Qual(r.httpPkg(), "StatusServiceUnavailable")})),
+	)
+}
+
+func (r *transportRenderer) idempotencyHelpers(srvctxPkgPath string) (c Code) {
+
+	return Comment("idempotencyStoreKey — ключ хранилища: метод, аутентифицированный субъект и значение заголовка, поэтому").
+		Line().Comment("одинаковые ключи разных методов и разных пользователей не пересекаются.").
+		Line().Func().Id("idempotencyStoreKey").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), Id("key").String()).
+		Params(String()).
+		BlockFunc(func(bg *Group) {
+			bg.Var().Id("subject").String()
+			bg.If(Id("principal").Op(":=").Qual(srvctxPkgPath, "GetPrincipal").Call(Id(VarNameCtx)).Op(";").Id("principal").Op("!=").Nil()).Block(
+				Id("subject").Op("=").Id("principal").Dot("Subject"),
+			)
+			bg.Return(Id("method").Op("+").Lit("\x00").Op("+").Id("subject").Op("+").Lit("\x00").Op("+").Id("key"))
+		}).
+		Line().Line().
+		Comment("idempotencyFingerprint — отпечаток запроса: SHA-256 частей, разделённых нулевым байтом.").
+		Line().Func().Id("idempotencyFingerprint").
+		Params(Id("parts").Op("...").Index().Byte()).
+		Params(String()).
+		BlockFunc(func(bg *Group) {
+			bg.Id("hash").Op(":=").Qual(packageSHA256, "New").Call()
+			bg.For(List(Id("_"), Id("part")).Op(":=").Range().Id("parts")).Block(
+				Id("hash").Dot("Write").Call(Id("part")),
+				Id("hash").Dot("Write").Call(Index().Byte().Values(Lit(0))),
+			)
+			bg.Return(Qual(packageHex, "EncodeToString").Call(Id("hash").Dot("Sum").Call(Nil())))
+		}).
+		Line().Line().
+		Comment("idempotencyStorable — ответ с таким статусом сохраняется; 5xx, 408 и 429 снимают резерв ключа для повтора.").
+		Line().Func().Id("idempotencyStorable").
+		Params(Id("status").Int()).
+		Params(Bool()).
+		Block(
+			Return(Id("status").Op("<").Lit(500).Op("&&").Id("status").Op("!=").Qual(r.httpPkg(), "StatusRequestTimeout").Op("&&").Id("status").Op("!=").Qual(r.httpPkg(), "StatusTooManyRequests")),
+		)
+}
+
+func (r *transportRenderer) beginIdempotentHTTPFunc() (c Code) {
+
+	return Comment("beginIdempotentHTTP проверяет ключ идемпотентности REST-запроса из заголовка header. Без ключа или хранилища").
+		Line().Comment("finish ничего не делает; replayed — сохранённый ответ уже записан в ftx; idemErr — отказ 409, 422 или 503.").
+		Line().Comment("finish сохраняет ответ обработчика или снимает резерв ключа, если ответ нельзя переиспользовать.").
+		Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("beginIdempotentHTTP").
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("method").String(), Id("header").String()).
+		Params(Id("finish").Func().Params(Err().Error()), Id("replayed").Bool(), Id("idemErr").Op("*").Id("errIdempotency")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("finish").Op("=").Func().Params(Error()).Block()
+			bg.Id("key").Op(":=").Id(VarNameFtx).Dot("Get").Call(Id("header"))
+			bg.If(Id("key").Op("==").Lit("").Op("||").Id("srv").Dot("idempotency").Op("==").Nil()).Block(Return())
+			bg.Id(VarNameCtx).Op(":=").Id(VarNameFtx).Dot("UserContext").Call()
+			bg.Id("key").Op("=").Id("idempotencyStoreKey").Call(Id(VarNameCtx), Id("method"), Id("key"))
+			if r.isNetHTTP() {
+				bg.Id("body").Op(":=").Id(VarNameFtx).Dot("Body").Call()
+				// Тело не прочитано целиком (BodyLimit): ключ не резервируется, App ответит ошибкой чтения (413).
+				bg.If(Id(VarNameFtx).Dot("BodyErr").Call().Op("!=").Nil()).Block(Return())
+			} else {
+				// Body вычитывает потоковое тело fasthttp целиком; обработчик метода читает его заново через RequestBodyStream.
+				bg.Id("body").Op(":=").Qual(PackageBytes, "Clone").Call(Id(VarNameFtx).Dot("Body").Call())
+				bg.Id(VarNameFtx).Dot("Request").Call().Dot("SetBodyStream").Call(Qual(PackageBytes, "NewReader").Call(Id("body")), Len(Id("body")))
+			}
+			bg.Id("fingerprint").Op(":=").Id("idempotencyFingerprint").Call(
+				Index().Byte().Call(Id(VarNameFtx).Dot("Method").Call()),
+				Index().Byte().Call(Id(VarNameFtx).Dot("OriginalURL").Call()),
+				Id("body"),
+			)
+			bg.List(Id("record"), Err()).Op(":=").Id("srv").Dot("idempotency").Dot("Begin").Call(Id(VarNameCtx), Id("key"), Id("fingerprint"))
+			bg.If(Err().Op("!=").Nil()).Block(
+				Return(Id("finish"), False(), Id("newErrIdempotency").Call(Err())),
+			)
+			bg.If(Id("record").Op("!=").Nil()).Block(
+				Id(VarNameFtx).Dot("Status").Call(Id("record").Dot("Status")),
+				For(List(Id("name"), Id("values")).Op(":=").Range().Id("record").Dot("Header")).Block(
+					For(List(Id("i"), Id("value")).Op(":=").Range().Id("values")).Block(
+						If(Id("i").Op("==").Lit(0)).Block(
+							Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("Set").Call(Id("name"), Id("value")),
+							Continue(),
+						),
+						Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("Add").Call(Id("name"), Id("value")),
+					),
+				),
+				Id(VarNameFtx).Dot("Set").Call(Lit("Idempotent-Replayed"), Lit("true")),
+				List(Id("_"), Id("_")).Op("=").Id(VarNameFtx).Dot("Response").Call().Dot("BodyWriter").Call().Dot("Write").Call(Id("record").Dot("Body")),
+				Return(Id("finish"), True(), Nil()),
+			)
+			bg.Id("finish").Op("=").Func().Params(Id("handlerErr").Error()).BlockFunc(func(fg *Group) {
+				fg.Id("status").Op(":=").Id(VarNameFtx).Dot("Response").Call().Dot("StatusCode").Call()
+				fg.If(Id("handlerErr").Op("!=").Nil().Op("||").Op("!").Id("idempotencyStorable").Call(Id("status"))).Block(
+					If(Err().Op(":=").Id("srv").Dot("idempotency").Dot("Release").Call(Id(VarNameCtx), Id("key")).Op(";").Err().Op("!=").Nil()).Block(
+						Id("srv").Dot("log").Dot("Warn").Call(Lit("idempotency key release failed"), Lit("method"), Id("method"), Lit("error"), Err()),
+					),
+					Return(),
+				)
+				fg.Id("stored").Op(":=").Id("IdempotencyRecord").Values(Dict{
+					Id("Fingerprint"): Id("fingerprint"),
+					Id("Status"):      Id("status"),
+					Id("Header"):      Make(Qual(PackageHTTP, "Header")),
+					Id("Body"):        Qual(PackageBytes, "Clone").Call(Id(VarNameFtx).Dot("Response").Call().Dot("Body").Call()),
+				})
+				fg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("VisitAll").Call(Func().Params(Id("name"), Id("value").Index().Byte()).Block(
+					Switch(String().Call(Id("name"))).Block(
+						Case(Lit("Content-Length"), Lit("Date"), Lit("Server"), Lit("Connection"), Lit("Transfer-Encoding")).Block(
+							Return(),
+						),
+					),
+					Id("stored").Dot("Header").Dot("Add").Call(String().Call(Id("name")), String().Call(Id("value"))),
+				))
+				fg.If(Err().Op(":=").Id("srv").Dot("idempotency").Dot("Complete").Call(Id(VarNameCtx), Id("key"), Id("stored")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("srv").Dot("log").Dot("Warn").Call(Lit("idempotency response store failed"), Lit("method"), Id("method"), Lit("error"), Err()),
+				)
+			})
+			bg.Return(Id("finish"), False(), Nil())
+		})
+}
+
+func (r *transportRenderer) beginIdempotentJsonRPCFunc() (c Code) {
+
+	jsonPkg := r.getPackageJSON()
+	return Comment("beginIdempotentJsonRPC проверяет ключ идемпотентности JSON-RPC вызова из заголовка header HTTP-запроса.").
+		Line().Comment("В batch ключ дополняется id вызова, уведомления batch без id не дедуплицируются.").
+		Line().Comment("replay — сохранённый ответ для повтора; finish сохраняет ответ вызова или снимает резерв при внутренней ошибке.").
+		Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("beginIdempotentJsonRPC").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), Id("header").String(), Id("id").Id("idJsonRPC"), Id("params").Qual(jsonPkg, "RawMessage")).
+		Params(Id("finish").Func().Params(Id("response").Op("*").Id("baseJsonRPC")), Id("replay").Op("*").Id("baseJsonRPC"), Id("idemErr").Op("*").Id("errIdempotency")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("finish").Op("=").Func().Params(Op("*").Id("baseJsonRPC")).Block()
+			bg.List(Id("getter"), Id("ok")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("keyRequestOverlay")).Assert(Id("requestOverlayGetter"))
+			bg.If(Op("!").Id("ok").Op("||").Id("srv").Dot("idempotency").Op("==").Nil()).Block(Return())
+			bg.Id("key").Op(":=").Id("getter").Call().Dot("Get").Call(Id("header"))
+			bg.If(Id("key").Op("==").Lit("")).Block(Return())
+			bg.If(List(Id("batch"), Id("_")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("keyBatchCall")).Assert(Bool()).Op(";").Id("batch")).Block(
+				If(Id("id").Op("==").Nil()).Block(Return()),
+				Id("key").Op("+=").Lit("\x00").Op("+").String().Call(Id("id")),
+			)
+			bg.Id("key").Op("=").Id("idempotencyStoreKey").Call(Id(VarNameCtx), Id("method"), Id("key"))
+			bg.Id("fingerprint").Op(":=").Id("idempotencyFingerprint").Call(Index().Byte().Call(Id("method")), Id("params"))
+			bg.List(Id("record"), Err()).Op(":=").Id("srv").Dot("idempotency").Dot("Begin").Call(Id(VarNameCtx), Id("key"), Id("fingerprint"))
+			bg.If(Err().Op("!=").Nil()).Block(
+				Return(Id("finish"), Nil(), Id("newErrIdempotency").Call(Err())),
+			)
+			bg.If(Id("record").Op("!=").Nil()).Block(
+				Id("replay").Op("=").Op("&").Id("baseJsonRPC").Values(),
+				If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("record").Dot("Body"), Id("replay")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(Id("finish"), Nil(), Id("newErrIdempotency").Call(Err())),
+				),
+				Return(Id("finish"), Id("replay"), Nil()),
+			)
+			bg.Id("finish").Op("=").Func().Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(func(fg *Group) {
+				fg.Var().Id("body").Index().Byte()
+				fg.If(Id("response").Op("!=").Nil().Op("&&").Parens(Id("response").Dot("Error").Op("==").Nil().Op("||").Id("response").Dot("Error").Dot("Code").Op("!=").Id("internalError"))).Block(
+					List(Id("body"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(Id("response")),
+				)
+				fg.If(Id("body").Op("==").Nil()).Block(
+					If(Err().Op(":=").Id("srv").Dot("idempotency").Dot("Release").Call(Id(VarNameCtx), Id("key")).Op(";").Err().Op("!=").Nil()).Block(
+						Id("srv").Dot("log").Dot("Warn").Call(Lit("idempotency key release failed"), Lit("method"), Id("method"), Lit("error"), Err()),
+					),
+					Return(),
+				)
+				fg.Id("stored").Op(":=").Id("IdempotencyRecord").Values(Dict{
+					Id("Fingerprint"): Id("fingerprint"),
+					Id("Status"):      Qual(r.httpPkg(), "StatusOK"),
+					Id("Body"):        Id("body"),
+				})
+				fg.If(Err().Op(":=").Id("srv").Dot("idempotency").Dot("Complete").Call(Id(VarNameCtx), Id("key"), Id("stored")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("srv").Dot("log").Dot("Warn").Call(Lit("idempotency response store failed"), Lit("method"), Id("method"), Lit("error"), Err()),
+				)
+			})
+			bg.Return(Id("finish"), Nil(), Nil())
+		})
+}
+
+// restIdempotencyCheck — проверка ключа идемпотентности после лимитов и авторизации: повтор сохранённого ответа,
+// отказ 409/422 или сохранение ответа обработчика по завершении.
+func (r *contractRenderer) restIdempotencyCheck(method *model.Method) (c Code) {
+
+	if !model.MethodIsIdempotent(r.project, r.contract, method) {
+		return Null()
+	}
+	return If(List(Id("server"), Id("ok")).Op(":=").Id(VarNameFtx).Dot("Locals").Call(Lit("server")).Assert(Op("*").Id("Server")).Op(";").Id("ok")).BlockFunc(func(ig *Group) {
+		ig.List(Id("finishIdempotent"), Id("replayed"), Id("idemErr")).Op(":=").Id("server").Dot("beginIdempotentHTTP").Call(
+			Id(VarNameFtx),
+			Lit(idempotencyScopeName(r.contract, method)),
+			Lit(model.MethodIdempotencyHeader(r.project, r.contract, method)),
+		)
+		ig.If(Id("idemErr").Op("!=").Nil()).Block(
+			Id(VarNameFtx).Dot("Status").Call(Id("idemErr").Dot("Code").Call()),
+			r.httpSendError(method, Id("idemErr")),
+		)
+		ig.If(Id("replayed")).Block(Return())
+		ig.Defer().Func().Params().Block(Id("finishIdempotent").Call(Err())).Call()
+	})
+}
+
+// jsonRPCIdempotencyCheck — проверка ключа идемпотентности JSON-RPC вызова (и каждого элемента batch).
+func (r *contractRenderer) jsonRPCIdempotencyCheck(method *model.Method) (c Code) {
+
+	if !model.MethodIsIdempotent(r.project, r.contract, method) {
+		return Null()
+	}
+	return If(Id("http").Dot("srv").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+		ig.List(Id("finishIdempotent"), Id("replay"), Id("idemErr")).Op(":=").Id("http").Dot("srv").Dot("beginIdempotentJsonRPC").Call(
+			Id(VarNameCtx),
+			Lit(idempotencyScopeName(r.contract, method)),
+			Lit(model.MethodIdempotencyHeader(r.project, r.contract, method)),
+			Id("requestBase").Dot("ID"),
+			Id("requestBase").Dot("Params"),
+		)
+		ig.If(Id("idemErr").Op("!=").Nil()).Block(
+			Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("idemErr").Dot("jsonRPCCode").Call(), Id("idemErr").Dot("Error").Call(), Nil())),
+		)
+		ig.If(Id("replay").Op("!=").Nil()).Block(
+			Id("replay").Dot("ID").Op("=").Id("requestBase").Dot("ID"),
+			Return(Id("replay")),
+		)
+		ig.Defer().Func().Params().Block(Id("finishIdempotent").Call(Id("responseBase"))).Call()
+	})
+}
@@ -17,11 +17,11 @@ func (r *transportRenderer) sendResponseJsonRPCFunc() (c Code) {
 	jsonPkg := r.getPackageJSON()
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Id("sendResponse").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("resp").Any()).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("resp").Any()).
 		Params(Err().Error()).
 		BlockFunc(func(bg *Group) {
 			bg.If(List(Id("responses"), Id("ok")).Op(":=").Id("resp").Op(".").Call(Index().Op("*").Id("baseJsonRPC")).Op(";").Id("ok").Op("&&").Len(Id("responses")).Op("==").Lit(0)).Block(
-				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusNoContent")),
+				Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusNoContent")),
 				Return(Nil()),
 			)
 			bg.Id("clientID").Op(":=").Qual(srvctxPkgPath, "GetClientID").Call(Id(VarNameFtx).Dot("UserContext").Call())
@@ -54,7 +54,7 @@ func (r *transportRenderer) sendResponseJsonRPCFunc() (c Code) {
 				ig.If(Id("logger").Op(":=").Qual(srvctxPkgPath, "FromCtx").Types(Op("*").Qual(PackageSlog, "Logger")).Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("logger").Op("!=").Nil()).Block(
 					Id("logger").Dot("Error").Call(Lit("response marshal error"), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
 				)
-				ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError"))
+				ig.Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusInternalServerError"))
 				ig.Return(Err())
 			})
 			bg.Return(Err())
@@ -122,10 +122,10 @@ func (r *transportRenderer) batchHandlerFunc() (c Code) {
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("batchHandler").
 		Params(Id("batchPath").String()).
-		Params(Qual(PackageFiber, "Handler")).
+		Params(Qual(r.httpPkg(), "Handler")).
 		Block(
 			Return(Func().
-				Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+				Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 				Params(Id("err").Error()).
 				Block(
 					Return(Id("srv").Dot("serveBatch").Call(Id(VarNameFtx), Id("batchPath"))),
@@ -164,7 +164,7 @@ func (r *transportRenderer) batchFunc() (c Code) {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("doBatch").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("requests").Op("[]").Id("baseJsonRPC"), Id("methods").Map(String()).Id("methodJsonRPC")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("requests").Op("[]").Id("baseJsonRPC"), Id("methods").Map(String()).Id("methodJsonRPC")).
 		Params(Id("responses").Op("[]").Op("*").Id("baseJsonRPC")).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
@@ -178,6 +178,10 @@ func (r *transportRenderer) batchFunc() (c Code) {
 			).Else().Block(
 				Id("batchCtx").Op("=").Id("userCtx"),
 			)
+			if len(r.jsonRPCIdempotencyHeaders()) > 0 {
+				bg.Comment("Вызовы batch делят заголовок ключа идемпотентности: beginIdempotentJsonRPC дополняет ключ id вызова.")
+				bg.Id("batchCtx").Op("=").Qual(PackageContext, "WithValue").Call(Id("batchCtx"), Id("keyBatchCall"), True())
+			}
 			bg.If(Qual(PackageStrings, "EqualFold").Call(Id(VarNameFtx).Dot("Get").Call(Id("syncHeader")), Lit("true"))).Block(
 				Id("syncResponses").Op(":=").Make(Index().Op("*").Id("baseJsonRPC"), Lit(0), Len(Id("requests"))),
 				For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
@@ -243,7 +247,7 @@ func (r *transportRenderer) serveBatchFunc() (c Code) {
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("serveBatch").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("batchPath").String()).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("batchPath").String()).
 		Params(Id("err").Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
@@ -252,8 +256,8 @@ func (r *transportRenderer) serveBatchFunc() (c Code) {
 			bg.Id("methods").Op(":=").Id("srv").Dot("jsonRPCMethodMaps").Index(Id("batchPath"))
 			bg.Id("clientID").Op(":=").Qual(srvctxPkgPath, "GetClientID").Call(Id(VarNameFtx).Dot("UserContext").Call())
 			bg.Id("methodHTTP").Op(":=").Id(VarNameFtx).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(PackageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusMethodNotAllowed"))
+			bg.If(Id("methodHTTP").Op("!=").Qual(r.httpPkg(), "MethodPost")).BlockFunc(func(ig *Group) {
+				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(r.httpPkg(), "StatusMethodNotAllowed"))
 				ig.If(List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
@@ -262,6 +266,7 @@ func (r *transportRenderer) serveBatchFunc() (c Code) {
 				)
 				ig.Return()
 			})
+			bg.Add(r.authenticateRequest(Id("srv")))
 			bg.Id("bodyStream").Op(":=").Id("ensureBodyReader").Call(Id(VarNameFtx).Dot("Context").Call().Dot("RequestBodyStream").Call())
 			bg.List(Id("firstByte"), Err()).Op(":=").Id("readUntilFirstNonWhitespace").Call(Id("bodyStream"))
 			bg.If(Err().Op("!=").Nil().Op("&&").Op("!").Qual("errors", "Is").Call(Err(), Qual("io", "EOF"))).BlockFunc(func(ig *Group) {
@@ -314,7 +319,7 @@ func (r *transportRenderer) serveBatchFunc() (c Code) {
 				ig.If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
 					Id("srv").Dot("metrics").Dot("EntryRequestsTotal").Dot("WithLabelValues").Call(Lit("json-rpc"), Lit("batch_size_exceeded"), Id("clientID")).Dot("Inc").Call(),
 				)
-				ig.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("batch size exceeded")))
+				ig.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(r.httpPkg(), "StatusBadRequest"), Lit("batch size exceeded")))
 			})
 			bg.If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
 				Id("srv").Dot("metrics").Dot("BatchSize").Dot("WithLabelValues").Call(Lit("json-rpc"), Id("batchPath"), Id("clientID")).Dot("Observe").Call(Id("float64").Call(Len(Id("requests")))),
@@ -324,7 +329,7 @@ func (r *transportRenderer) serveBatchFunc() (c Code) {
 					vg.If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
 						Id("srv").Dot("metrics").Dot("EntryRequestsTotal").Dot("WithLabelValues").Call(Lit("json-rpc"), Lit("invalid_request"), Id("clientID")).Dot("Inc").Call(),
 					)
-					vg.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call()))
+					vg.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(r.httpPkg(), "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call()))
 				})
 				ig.Defer().Func().Params().Block(
 					If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
@@ -416,6 +421,13 @@ func (r *transportRenderer) jsonRPCUsedOverlayKeys() (headerNames []string, cook
 			}
 		}
 	}
+	_, limitHeaders := r.jsonRPCLimitKeys()
+	for _, h := range limitHeaders {
+		headers[h] = struct{}{}
+	}
+	for _, h := range r.jsonRPCIdempotencyHeaders() {
+		headers[h] = struct{}{}
+	}
 	return common.SortedKeys(headers), common.SortedKeys(cookies)
 }
 
@@ -29,7 +29,7 @@ func (r *transportRenderer) RenderTransportJsonRPC() (err error) {
 
 func (r *transportRenderer) renderJsonRPCImports(srcFile *GoFile) {
 
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName("io", "io")
 	srcFile.ImportName(PackageSync, "sync")
 	srcFile.ImportName(PackageBytes, "bytes")
@@ -74,7 +74,7 @@ func (r *transportRenderer) renderJsonRPCFunctions(srcFile *GoFile) {
 	srcFile.Line()
 	srcFile.Line().Type().Id("methodJsonRPCWithFiber").
 		Func().
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("requestBase").Id("baseJsonRPC")).
 		Params(Id("responseBase").Op("*").Id("baseJsonRPC"))
 	srcFile.Line()
 	srcFile.Line().Add(r.initJsonRPCMethodMaps())
@@ -152,6 +152,7 @@ func (r *transportRenderer) requestOverlayKeyType() (c Code) {
 func (r *transportRenderer) requestOverlayStructType() (c Code) {
 
 	headerNames, cookieNames := r.jsonRPCUsedOverlayKeys()
+	needIP, _ := r.jsonRPCLimitKeys()
 	return Type().Id("requestOverlay").StructFunc(func(tg *Group) {
 		for _, name := range headerNames {
 			tg.Id(overlayKeyToFieldName(name)).String()
@@ -159,6 +160,9 @@ func (r *transportRenderer) requestOverlayStructType() (c Code) {
 		for _, name := range cookieNames {
 			tg.Id(overlayKeyToFieldName(name)).String()
 		}
+		if needIP {
+			tg.Id("remoteIP").String()
+		}
 	})
 }
 
@@ -187,7 +191,7 @@ func (r *transportRenderer) requestOverlayFromFiberFunc() (c Code) {
 
 	headerNames, cookieNames := r.jsonRPCUsedOverlayKeys()
 	return Line().Func().Id("requestOverlayFromFiber").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Id("requestOverlay")).
 		BlockFunc(func(block *Group) {
 			block.Id("o").Op(":=").Id("requestOverlay").Values()
@@ -197,6 +201,9 @@ func (r *transportRenderer) requestOverlayFromFiberFunc() (c Code) {
 			for _, name := range cookieNames {
 				block.Id("o").Dot(overlayKeyToFieldName(name)).Op("=").Qual(PackageStrings, "Clone").Call(Id(VarNameFtx).Dot("Cookies").Call(Lit(name)))
 			}
+			if needIP, _ := r.jsonRPCLimitKeys(); needIP {
+				block.Id("o").Dot("remoteIP").Op("=").Qual(PackageStrings, "Clone").Call(Id(VarNameFtx).Dot("IP").Call())
+			}
 			block.Return(Id("o"))
 		})
 }
@@ -204,7 +211,7 @@ func (r *transportRenderer) requestOverlayFromFiberFunc() (c Code) {
 func (r *transportRenderer) requestOverlayMiddlewareFunc() (c Code) {
 
 	return Line().Func().Id("requestOverlayMiddleware").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Id(VarNameFtx).Dot("SetUserContext").Call(
@@ -0,0 +1,424 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/common"
+	"tgp/internal/generated"
+	"tgp/internal/model"
+)
+
+// limitedMethod — метод с лимитами @tg rate-limit / max-inflight, обслуживаемый REST или JSON-RPC.
+type limitedMethod struct {
+	contract *model.Contract
+	method   *model.Method
+}
+
+func (r *baseRenderer) limitedMethods() (out []limitedMethod) {
+
+	for _, contract := range r.contractsSorted() {
+		for _, method := range methodsSorted(contract.Methods) {
+			if !model.MethodIsHTTP(r.project, contract, method) && !model.MethodIsJSONRPC(r.project, contract, method) {
+				continue
+			}
+			if model.MethodHasLimits(r.project, contract, method) {
+				out = append(out, limitedMethod{contract: contract, method: method})
+			}
+		}
+	}
+	return
+}
+
+func (r *baseRenderer) hasLimits() (ok bool) {
+
+	return len(r.limitedMethods()) > 0
+}
+
+// jsonRPCLimitKeys — что нужно JSON-RPC лимитам из HTTP-запроса: адрес клиента и имена заголовков-ключей.
+func (r *baseRenderer) jsonRPCLimitKeys() (needIP bool, headerNames []string) {
+
+	headers := make(map[string]struct{})
+	for _, it := range r.limitedMethods() {
+		if !model.MethodIsJSONRPC(r.project, it.contract, it.method) {
+			continue
+		}
+		limit, ok := model.MethodRateLimit(r.project, it.contract, it.method)
+		if !ok {
+			continue
+		}
+		switch {
+		case limit.Key == model.LimitKeyIP:
+			needIP = true
+		case strings.HasPrefix(limit.Key, model.LimitKeyHeaderPrefix):
+			needIP = true
+			headers[strings.TrimPrefix(limit.Key, model.LimitKeyHeaderPrefix)] = struct{}{}
+		}
+	}
+	return needIP, common.SortedKeys(headers)
+}
+
+func methodLimitsVarName(contract *model.Contract, method *model.Method) (name string) {
+
+	return "limits" + contract.Name + method.Name
+}
+
+func (r *transportRenderer) RenderTransportLimits() (err error) {
+
+	if !r.hasLimits() {
+		return
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(generated.ByToolGateway)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName("math", "math")
+
+	if r.hasJsonRPC() {
+		srcFile.Line().Const().Id("tooManyRequestsError").Op("=").Lit(-32029)
+	}
+	srcFile.Line().Const().Id("limiterPruneThreshold").Op("=").Lit(10000)
+	srcFile.Line().Add(r.limitTypes())
+	srcFile.Line().Add(r.tokenBucketLimiter())
+	srcFile.Line().Add(r.errTooManyRequestsType())
+	srcFile.Line().Add(r.methodLimitsType())
+	srcFile.Line().Add(r.methodLimitsVars())
+	srcFile.Line().Add(r.newInFlightLimitsFunc())
+	srcFile.Line().Add(r.acquireLimitsFunc())
+	srcFile.Line().Add(r.limitKeyOrFunc())
+	if r.hasJsonRPC() {
+		srcFile.Line().Add(r.limitKeyFromContextFunc())
+	}
+
+	return srcFile.Save(path.Join(r.outDir, "limits.go"))
+}
+
+func (r *transportRenderer) limitTypes() (c Code) {
+
+	return Comment("RateLimit — правило ограничения частоты: Rate запросов за Per, запас Burst.").
+		Line().Type().Id("RateLimit").Struct(
+		Id("Rate").Int(),
+		Id("Per").Qual(PackageTime, "Duration"),
+		Id("Burst").Int(),
+	).
+		Line().Line().
+		Comment("Limiter — ограничитель частоты запросов: scope — контракт или метод, key — ключ клиента (IP, client-id, заголовок).").
+		Line().Comment("При отказе возвращает, через сколько стоит повторить запрос.").
+		Line().Type().Id("Limiter").Interface(
+		Id("Allow").Params(Id("scope").String(), Id("key").String(), Id("limit").Id("RateLimit")).Params(Id("ok").Bool(), Id("retryAfter").Qual(PackageTime, "Duration")),
+	)
+}
+
+func (r *transportRenderer) tokenBucketLimiter() (c Code) {
+
+	return Type().Id("tokenBucket").Struct(
+		Id("tokens").Float64(),
+		Id("last").Qual(PackageTime, "Time"),
+		Id("full").Qual(PackageTime, "Time"),
+	).
+		Line().Line().
+		Type().Id("tokenBucketLimiter").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("buckets").Map(String()).Op("*").Id("tokenBucket"),
+	).
+		Line().Line().
+		Comment("NewTokenBucketLimiter — ограничитель по умолчанию: token bucket в памяти процесса на каждую пару scope/key.").
+		Line().Func().Id("NewTokenBucketLimiter").Params().Params(Id("Limiter")).Block(
+		Return(Op("&").Id("tokenBucketLimiter").Values(Dict{
+			Id("buckets"): Make(Map(String()).Op("*").Id("tokenBucket")),
+		})),
+	).
+		Line().Line().
+		Func().Params(Id("l").Op("*").Id("tokenBucketLimiter")).Id("Allow").
+		Params(Id("scope").String(), Id("key").String(), Id("limit").Id("RateLimit")).
+		Params(Id("ok").Bool(), Id("retryAfter").Qual(PackageTime, "Duration")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("now").Op(":=").Qual(PackageTime, "Now").Call()
+			bg.Id("perSecond").Op(":=").Float64().Call(Id("limit").Dot("Rate")).Op("/").Id("limit").Dot("Per").Dot("Seconds").Call()
+			bg.Line()
+			bg.Id("l").Dot("mu").Dot("Lock").Call()
+			bg.Defer().Id("l").Dot("mu").Dot("Unlock").Call()
+			bg.Line()
+			bg.If(Len(Id("l").Dot("buckets")).Op(">=").Id("limiterPruneThreshold")).Block(
+				For(List(Id("bucketKey"), Id("bucket")).Op(":=").Range().Id("l").Dot("buckets")).Block(
+					If(Op("!").Id("bucket").Dot("full").Dot("After").Call(Id("now"))).Block(
+						Delete(Id("l").Dot("buckets"), Id("bucketKey")),
+					),
+				),
+			)
+			bg.Id("bucketKey").Op(":=").Id("scope").Op("+").Lit("\x00").Op("+").Id("key")
+			bg.List(Id("bucket"), Id("found")).Op(":=").Id("l").Dot("buckets").Index(Id("bucketKey"))
+			bg.If(Op("!").Id("found")).Block(
+				Id("bucket").Op("=").Op("&").Id("tokenBucket").Values(Dict{
+					Id("tokens"): Float64().Call(Id("limit").Dot("Burst")),
+					Id("last"):   Id("now"),
+				}),
+				Id("l").Dot("buckets").Index(Id("bucketKey")).Op("=").Id("bucket"),
+			)
+			bg.Id("bucket").Dot("tokens").Op("=").Min(
+				Float64().Call(Id("limit").Dot("Burst")),
+				Id("bucket").Dot("tokens").Op("+").Id("now").Dot("Sub").Call(Id("bucket").Dot("last")).Dot("Seconds").Call().Op("*").Id("perSecond"),
+			)
+			bg.Id("bucket").Dot("last").Op("=").Id("now")
+			bg.If(Id("bucket").Dot("tokens").Op("<").Lit(1)).Block(
+				Return(False(), Qual(PackageTime, "Duration").Call(Parens(Lit(1).Op("-").Id("bucket").Dot("tokens")).Op("/").Id("perSecond").Op("*").Float64().Call(Qual(PackageTime, "Second")))),
+			)
+			bg.Id("bucket").Dot("tokens").Op("--")
+			bg.Id("bucket").Dot("full").Op("=").Id("now").Dot("Add").Call(
+				Qual(PackageTime, "Duration").Call(Parens(Float64().Call(Id("limit").Dot("Burst")).Op("-").Id("bucket").Dot("tokens")).Op("/").Id("perSecond").Op("*").Float64().Call(Qual(PackageTime, "Second"))),
+			)
+			bg.Return(True(), Lit(0))
+		})
+}
+
+func (r *transportRenderer) errTooManyRequestsType() (c Code) {
+
+	return Comment("errTooManyRequests — отказ по лимиту частоты или одновременных вызовов (HTTP 429).").
+		Line().Type().Id("errTooManyRequests").Struct(
+		Id("TrKey").String().Tag(map[string]string{"json": "trKey"}),
+		Id("Data").String().Tag(map[string]string{"json": "data,omitempty"}),
+		Id("RetryAfterSeconds").Int().Tag(map[string]string{"json": "retryAfter"}),
+	).
+		Line().Line().
+		Func().Params(Id("e").Op("*").Id("errTooManyRequests")).Id("Error").Params().String().
+		Block(Return(Id("e").Dot("Data"))).
+		Line().Line().
+		Func().Params(Id("e").Op("*").Id("errTooManyRequests")).Id("Code").Params().Int().
+		Block(Return(Lit(429))).
+		Line().Line().
+		Func().Id("newErrTooManyRequests").Params(Id("message").String(), Id("retryAfter").Qual(PackageTime, "Duration")).Params(Op("*").Id("errTooManyRequests")).
+		BlockFunc(func(bg *Group) {
+			bg.Id("seconds").Op(":=").Int().Call(Qual("math", "Ceil").Call(Id("retryAfter").Dot("Seconds").Call()))
+			bg.If(Id("seconds").Op("<").Lit(1)).Block(
+				Id("seconds").Op("=").Lit(1),
+			)
+			bg.Return(Op("&").Id("errTooManyRequests").Values(Dict{
+				Id("TrKey"):             Lit("tooManyRequests"),
+				Id("Data"):              Id("message"),
+				Id("RetryAfterSeconds"): Id("seconds"),
+			}))
+		})
+}
+
+func (r *transportRenderer) methodLimitsType() (c Code) {
+
+	return Comment("methodLimits — лимиты метода: область и правило частоты, ключ клиента, область одновременных вызовов.").
+		Line().Type().Id("methodLimits").Struct(
+		Id("service").String(),
+		Id("method").String(),
+		Id("rateScope").String(),
+		Id("rate").Id("RateLimit"),
+		Id("inFlightScope").String(),
+	)
+}
+
+func (r *transportRenderer) methodLimitsVars() (c Code) {
+
+	return Var().DefsFunc(func(dg *Group) {
+		for _, it := range r.limitedMethods() {
+			values := Dict{
+				Id("service"): Lit(toLowerCamel(it.contract.Name)),
+				Id("method"):  Lit(toLowerCamel(it.method.Name)),
+			}
+			if limit, ok := model.MethodRateLimit(r.project, it.contract, it.method); ok {
+				values[Id("rateScope")] = Lit(model.MethodLimitScope(it.contract, it.method, model.TagRateLimit))
+				values[Id("rate")] = Id("RateLimit").Values(Dict{
+					Id("Rate"):  Lit(limit.Rate),
+					Id("Per"):   durationCode(limit.Per),
+					Id("Burst"): Lit(limit.Burst),
+				})
+			}
+			if model.MethodMaxInFlight(r.project, it.contract, it.method) > 0 {
+				values[Id("inFlightScope")] = Lit(model.MethodLimitScope(it.contract, it.method, model.TagMaxInFlight))
+			}
+			dg.Id(methodLimitsVarName(it.contract, it.method)).Op("=").Id("methodLimits").Values(values)
+		}
+	})
+}
+
+// durationCode — длительность в виде выражения time: time.Second, time.Minute или N * time.Second.
+func durationCode(d time.Duration) (c *Statement) {
+
+	switch {
+	case d == time.Hour:
+		return Qual(PackageTime, "Hour")
+	case d == time.Minute:
+		return Qual(PackageTime, "Minute")
+	case d == time.Second:
+		return Qual(PackageTime, "Second")
+	case d%time.Second == 0:
+		return Lit(int(d/time.Second)).Op("*").Qual(PackageTime, "Second")
+	}
+	return Lit(int(d/time.Millisecond)).Op("*").Qual(PackageTime, "Millisecond")
+}
+
+func (r *transportRenderer) newInFlightLimitsFunc() (c Code) {
+
+	scopes := make(map[string]int)
+	for _, it := range r.limitedMethods() {
+		if limit := model.MethodMaxInFlight(r.project, it.contract, it.method); limit > 0 {
+			scopes[model.MethodLimitScope(it.contract, it.method, model.TagMaxInFlight)] = limit
+		}
+	}
+	return Comment("newInFlightLimits — семафоры @tg max-inflight по областям (контракт или метод).").
+		Line().Func().Id("newInFlightLimits").Params().Params(Map(String()).Chan().Struct()).
+		Block(
+			Return(Map(String()).Chan().Struct().Values(DictFunc(func(d Dict) {
+				for scope, limit := range scopes {
+					d[Lit(scope)] = Make(Chan().Struct(), Lit(limit))
+				}
+			}))),
+		)
+}
+
+func (r *transportRenderer) acquireLimitsFunc() (c Code) {
+
+	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
+	countRejection := func(kind string) Code {
+		if !r.hasMetrics() {
+			return Null()
+		}
+		return If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
+			Id("srv").Dot("metrics").Dot("LimitRejectionsTotal").Dot("WithLabelValues").Call(
+				Id("limits").Dot("service"),
+				Id("limits").Dot("method"),
+				Lit(kind),
+				Qual(srvctxPkgPath, "GetClientID").Call(Id(VarNameCtx)),
+			).Dot("Inc").Call(),
+		)
+	}
+	return Comment("acquireLimits проверяет лимиты вызова: сначала частоту по ключу клиента, затем число одновременных вызовов.").
+		Line().Comment("При успехе release освобождает слот одновременного вызова и должен быть вызван по завершении метода.").
+		Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("acquireLimits").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("limits").Op("*").Id("methodLimits"), Id("key").String()).
+		Params(Id("release").Func().Params(), Err().Op("*").Id("errTooManyRequests")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("release").Op("=").Func().Params().Block()
+			bg.If(Id("limits").Dot("rateScope").Op("!=").Lit("").Op("&&").Id("srv").Dot("limiter").Op("!=").Nil()).Block(
+				If(List(Id("ok"), Id("retryAfter")).Op(":=").Id("srv").Dot("limiter").Dot("Allow").Call(Id("limits").Dot("rateScope"), Id("key"), Id("limits").Dot("rate")).Op(";").Op("!").Id("ok")).Block(
+					countRejection("rate"),
+					Return(Id("release"), Id("newErrTooManyRequests").Call(Lit("rate limit exceeded"), Id("retryAfter"))),
+				),
+			)
+			bg.If(List(Id("slots"), Id("found")).Op(":=").Id("srv").Dot("inFlight").Index(Id("limits").Dot("inFlightScope")).Op(";").Id("found")).Block(
+				Select().Block(
+					Case(Id("slots").Op("<-").Struct().Values()).Block(
+						Return(Func().Params().Block(Op("<-").Id("slots")), Nil()),
+					),
+					Default().Block(
+						countRejection("inflight"),
+						Return(Id("release"), Id("newErrTooManyRequests").Call(Lit("too many concurrent requests"), Qual(PackageTime, "Second"))),
+					),
+				),
+			)
+			bg.Return(Id("release"), Nil())
+		})
+}
+
+func (r *transportRenderer) limitKeyOrFunc() (c Code) {
+
+	return Func().Id("limitKeyOr").Params(Id("key").String(), Id("fallback").String()).Params(String()).Block(
+		If(Id("key").Op("==").Lit("")).Block(Return(Id("fallback"))),
+		Return(Id("key")),
+	)
+}
+
+func (r *transportRenderer) limitKeyFromContextFunc() (c Code) {
+
+	needIP, _ := r.jsonRPCLimitKeys()
+	return Comment("limitKeyFromContext — ключ лимита JSON-RPC вызова: значение заголовка header, иначе адрес клиента.").
+		Line().Func().Id("limitKeyFromContext").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("header").String()).
+		Params(Id("key").String()).
+		BlockFunc(func(bg *Group) {
+			bg.List(Id("getter"), Id("ok")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("keyRequestOverlay")).Assert(Id("requestOverlayGetter"))
+			bg.If(Op("!").Id("ok")).Block(Return())
+			bg.Id("overlay").Op(":=").Id("getter").Call()
+			bg.If(Id("header").Op("!=").Lit("")).Block(
+				If(Id("key").Op("=").Id("overlay").Dot("Get").Call(Id("header")).Op(";").Id("key").Op("!=").Lit("")).Block(Return()),
+			)
+			if needIP {
+				bg.Return(Id("overlay").Dot("remoteIP"))
+			} else {
+				bg.Return()
+			}
+		})
+}
+
+// restLimitsCheck — проверка лимитов в начале REST-обработчика: 429 с Retry-After при отказе.
+func (r *contractRenderer) restLimitsCheck(method *model.Method) (c Code) {
+
+	if !model.MethodHasLimits(r.project, r.contract, method) {
+		return Null()
+	}
+	return If(List(Id("server"), Id("ok")).Op(":=").Id(VarNameFtx).Dot("Locals").Call(Lit("server")).Assert(Op("*").Id("Server")).Op(";").Id("ok")).BlockFunc(func(ig *Group) {
+		ig.List(Id("release"), Id("limitErr")).Op(":=").Id("server").Dot("acquireLimits").Call(
+			Id(VarNameFtx).Dot("UserContext").Call(),
+			Op("&").Id(methodLimitsVarName(r.contract, method)),
+			r.restLimitKey(method),
+		)
+		ig.If(Id("limitErr").Op("!=").Nil()).Block(
+			Id(VarNameFtx).Dot("Set").Call(Lit("Retry-After"), Qual(PackageStrconv, "Itoa").Call(Id("limitErr").Dot("RetryAfterSeconds"))),
+			Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusTooManyRequests")),
+			r.httpSendError(method, Id("limitErr")),
+		)
+		ig.Defer().Id("release").Call()
+	})
+}
+
+func (r *contractRenderer) restLimitKey(method *model.Method) (c Code) {
+
+	limit, ok := model.MethodRateLimit(r.project, r.contract, method)
+	switch {
+	case !ok:
+		return Lit("")
+	case limit.Key == model.LimitKeyClientID:
+		return Id("clientID")
+	case strings.HasPrefix(limit.Key, model.LimitKeyHeaderPrefix):
+		return Id("limitKeyOr").Call(Id(VarNameFtx).Dot("Get").Call(Lit(strings.TrimPrefix(limit.Key, model.LimitKeyHeaderPrefix))), Id(VarNameFtx).Dot("IP").Call())
+	}
+	return Id(VarNameFtx).Dot("IP").Call()
+}
+
+// jsonRPCLimitsCheck — проверка лимитов JSON-RPC вызова (и каждого элемента batch): ошибка tooManyRequestsError с retryAfter в data.
+func (r *contractRenderer) jsonRPCLimitsCheck(method *model.Method) (c Code) {
+
+	if !model.MethodHasLimits(r.project, r.contract, method) {
+		return Null()
+	}
+	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
+	var key Code = Lit("")
+	if limit, ok := model.MethodRateLimit(r.project, r.contract, method); ok {
+		switch {
+		case limit.Key == model.LimitKeyClientID:
+			key = Qual(srvctxPkgPath, "GetClientID").Call(Id(VarNameCtx))
+		case strings.HasPrefix(limit.Key, model.LimitKeyHeaderPrefix):
+			key = Id("limitKeyFromContext").Call(Id(VarNameCtx), Lit(strings.TrimPrefix(limit.Key, model.LimitKeyHeaderPrefix)))
+		default:
+			key = Id("limitKeyFromContext").Call(Id(VarNameCtx), Lit(""))
+		}
+	}
+	return If(Id("http").Dot("srv").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+		ig.List(Id("release"), Id("limitErr")).Op(":=").Id("http").Dot("srv").Dot("acquireLimits").Call(
+			Id(VarNameCtx),
+			Op("&").Id(methodLimitsVarName(r.contract, method)),
+			key,
+		)
+		ig.If(Id("limitErr").Op("!=").Nil()).Block(
+			Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("tooManyRequestsError"), Id("limitErr").Dot("Error").Call(), Id("limitErr"))),
+		)
+		ig.Defer().Id("release").Call()
+	})
+}
@@ -28,8 +28,10 @@ func (r *transportRenderer) RenderTransportMetrics() (err error) {
 	srcFile.ImportName(PackagePrometheusCollectors, "collectors")
 	srcFile.ImportName("github.com/prometheus/client_golang/prometheus/promauto", "promauto")
 	srcFile.ImportName(PackageSlog, "slog")
-	srcFile.ImportName(PackageFiber, "fiber")
-	srcFile.ImportName(PackageFiberAdaptor, "adaptor")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
+	if !r.isNetHTTP() {
+		srcFile.ImportName(PackageFiberAdaptor, "adaptor")
+	}
 	srcFile.ImportName(PackagePrometheusHttp, "promhttp")
 
 	srcFile.Line().Const().Defs(
@@ -48,6 +50,7 @@ func (r *transportRenderer) RenderTransportMetrics() (err error) {
 		Id("BatchSize").Op("*").Qual(PackagePrometheus, "HistogramVec"),
 		Id("RequestCount").Op("*").Qual(PackagePrometheus, "CounterVec"),
 		Id("RequestLatency").Op("*").Qual(PackagePrometheus, "HistogramVec"),
+		Id("LimitRejectionsTotal").Op("*").Qual(PackagePrometheus, "CounterVec"),
 	)
 
 	srcFile.Line().Var().Id("registerGoCollectorOnce").Qual(PackageSync, "Once")
@@ -144,6 +147,14 @@ func (r *transportRenderer) newMetricsFunc() (c Code) {
 					}),
 					Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode"), Lit("client_id")),
 				),
+				Id("LimitRejectionsTotal"): Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewCounterVec").Call(
+					Qual(PackagePrometheus, "CounterOpts").Values(Dict{
+						Id("Help"):      Lit("Calls rejected by rate-limit or max-inflight"),
+						Id("Name"):      Lit("limit_rejections_total"),
+						Id("Namespace"): Lit("service"),
+					}),
+					Index().String().Values(Lit("service"), Lit("method"), Lit("limit"), Lit("client_id")),
+				),
 				Id("VersionGauge"): Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewGaugeVec").Call(
 					Qual(PackagePrometheus, "GaugeOpts").Values(Dict{
 						Id("Help"):      Lit("Versions of service parts"),
@@ -170,7 +181,7 @@ func (r *transportRenderer) serveMetricsFunc() (c Code) {
 			Id("regs").Op("...").Op("*").Qual(PackagePrometheus, "Registry"),
 		).
 		BlockFunc(func(g *Group) {
-			g.Id("srv").Dot("srvMetrics").Op("=").Qual(PackageFiber, "New").Call(Qual(PackageFiber, "Config").Values(Dict{Id("DisableStartupMessage"): True()}))
+			g.Id("srv").Dot("srvMetrics").Op("=").Qual(r.httpPkg(), "New").Call(Qual(r.httpPkg(), "Config").Values(Dict{Id("DisableStartupMessage"): True()}))
 			g.Id("gatherers").Op(":=").Index().Qual(PackagePrometheus, "Gatherer").Values(Qual(PackagePrometheus, "DefaultGatherer"))
 			g.For(List(Id("_"), Id("r")).Op(":=").Range().Id("regs")).Block(
 				If(Id("r").Op("!=").Nil()).Block(
@@ -181,7 +192,7 @@ func (r *transportRenderer) serveMetricsFunc() (c Code) {
 				Qual(PackagePrometheus, "Gatherers").Call(Id("gatherers")),
 				Qual(PackagePrometheusHttp, "HandlerOpts").Values(Dict{}),
 			)
-			g.Id("srv").Dot("srvMetrics").Dot("All").Call(Id("path"), Qual(PackageFiberAdaptor, "HTTPHandler").Call(Id("handler")))
+			g.Id("srv").Dot("srvMetrics").Dot("All").Call(Id("path"), r.httpHandlerAdaptor().Call(Id("handler")))
 			g.Go().Func().Params().Block(
 				Err().Op(":=").Id("srv").Dot("srvMetrics").Dot("Listen").Call(Id("address")),
 				Id("ExitOnError").Call(Id("log"), Err(), Lit("serve metrics on ").Op("+").Id("address")),
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/model"
+)
+
+// contractsWithMiddleware — контракты, у которых Server хранит обработчик и принимает WithMiddleware-опции.
+func (r *transportRenderer) contractsWithMiddleware() (contracts []*model.Contract) {
+
+	for _, contract := range r.contractsSorted() {
+		if model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) ||
+			model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerJsonRPC) ||
+			model.ContractHasWS(r.project, contract) ||
+			model.ContractHasSSE(r.project, contract) {
+			contracts = append(contracts, contract)
+		}
+	}
+	return
+}
+
+func middlewareField(contract *model.Contract) (name string) {
+
+	return "middleware" + contract.Name
+}
+
+// renderOptionsMiddleware генерирует WithMiddleware{Contract} и WithMiddleware{Contract}{Method}.
+// Опции только копят обёртки: New применяет их после регистрации контрактов, поэтому порядок
+// относительно опции контракта не важен, а первая зарегистрированная middleware оказывается внешней.
+func (r *transportRenderer) renderOptionsMiddleware(srcFile *GoFile) {
+
+	for _, contract := range r.contractsWithMiddleware() {
+		r.middlewareOption(srcFile, contract, "WithMiddleware"+contract.Name, "Middleware"+contract.Name, "Wrap",
+			"оборачивает реализацию "+contract.Name+" типизированными middleware для REST, JSON-RPC, batch и stream вызовов; первая в списке — внешняя.")
+		for _, method := range contract.Methods {
+			r.middlewareOption(srcFile, contract, "WithMiddleware"+contract.Name+method.Name, "Middleware"+contract.Name+method.Name, "Wrap"+method.Name,
+				"оборачивает только метод "+contract.Name+"."+method.Name+".")
+		}
+	}
+}
+
+func (r *transportRenderer) middlewareOption(srcFile *GoFile, contract *model.Contract, optionName, middlewareType, wrapMethod, doc string) {
+
+	srcFile.Line().Comment(optionName + " " + doc)
+	srcFile.Func().Id(optionName).
+		Params(Id("mw").Op("...").Id(middlewareType)).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot(middlewareField(contract)).Op("=").Append(Id("srv").Dot(middlewareField(contract)), Func().Params(Id("svc").Op("*").Id("server"+contract.Name)).Block(
+					For(Id("i").Op(":=").Len(Id("mw")).Op("-").Lit(1), Id("i").Op(">=").Lit(0), Id("i").Op("--")).Block(
+						Id("svc").Dot(wrapMethod).Call(Id("mw").Index(Id("i"))),
+					),
+				)),
+			)),
+		)
+}
@@ -19,13 +19,14 @@ func (r *transportRenderer) RenderTransportOptions() (err error) {
 	srcFile := NewSrcFile(filepath.Base(r.outDir))
 	srcFile.PackageComment(generated.ByToolGateway)
 
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(PackageUUID, "uuid")
 	srcFile.ImportName(PackageTime, "time")
 
 	r.renderOptionsTypes(&srcFile)
 	r.renderOptionsService(&srcFile)
 	r.renderOptionsForContracts(&srcFile)
+	r.renderOptionsMiddleware(&srcFile)
 	r.renderOptionsConfig(&srcFile)
 	r.renderOptionsTimeouts(&srcFile)
 	r.renderOptionsHeaders(&srcFile)
@@ -37,11 +38,11 @@ func (r *transportRenderer) RenderTransportOptions() (err error) {
 func (r *transportRenderer) renderOptionsTypes(srcFile *GoFile) {
 
 	srcFile.Line().Type().Id("ServiceRoute").Interface(
-		Id("SetRoutes").Params(Id("route").Op("*").Qual(PackageFiber, "App")),
+		Id("SetRoutes").Params(Id("route").Op("*").Qual(r.httpPkg(), "App")),
 	)
 
 	srcFile.Line().Type().Id("Option").Func().Params(Id("srv").Op("*").Id("Server"))
-	srcFile.Type().Id("Handler").Op("=").Qual(PackageFiber, "Handler")
+	srcFile.Type().Id("Handler").Op("=").Qual(r.httpPkg(), "Handler")
 	srcFile.Type().Id("ErrorHandler").Func().Params(Err().Error()).Params(Error())
 }
 
@@ -53,7 +54,7 @@ func (r *transportRenderer) renderOptionsService(srcFile *GoFile) {
 		Block(
 			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
 				If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
-					Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call()),
+					Id("svc").Dot("SetRoutes").Call(Id("srv").Dot(r.appAccessor()).Call()),
 				),
 			)),
 		)
@@ -81,7 +82,7 @@ func (r *transportRenderer) renderOptionsForContracts(srcFile *GoFile) {
 							if model.ContractHasSSE(r.project, contract) {
 								gr.Id("httpSvc").Dot("srv").Op("=").Id("srv")
 							}
-							gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
+							gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot(r.appAccessor()).Call())
 						}),
 					)),
 				)
@@ -98,7 +99,7 @@ func (r *transportRenderer) renderOptionsForContracts(srcFile *GoFile) {
 							gr.Id("httpSvc").Op(":=").Id("new" + contract.Name).Call(Id("svc"))
 							gr.Id("srv").Dot("http" + contract.Name).Op("=").Id("httpSvc")
 							gr.Id("httpSvc").Dot("srv").Op("=").Id("srv")
-							gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
+							gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot(r.appAccessor()).Call())
 						}),
 					)),
 				)
@@ -116,7 +117,7 @@ func (r *transportRenderer) renderOptionsForContracts(srcFile *GoFile) {
 						if model.ContractHasSSE(r.project, contract) {
 							gr.Id("httpSvc").Dot("srv").Op("=").Id("srv")
 						}
-						gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
+						gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot(r.appAccessor()).Call())
 					}),
 				)),
 			)
@@ -125,8 +126,12 @@ func (r *transportRenderer) renderOptionsForContracts(srcFile *GoFile) {
 
 func (r *transportRenderer) renderOptionsConfig(srcFile *GoFile) {
 
-	srcFile.Line().Func().Id("SetFiberCfg").
-		Params(Id("cfg").Qual(PackageFiber, "Config")).
+	cfgOption := "SetFiberCfg"
+	if r.isNetHTTP() {
+		cfgOption = "SetHTTPCfg"
+	}
+	srcFile.Line().Func().Id(cfgOption).
+		Params(Id("cfg").Qual(r.httpPkg(), "Config")).
 		Id("Option").
 		Block(
 			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
@@ -198,6 +203,42 @@ func (r *transportRenderer) renderOptionsTimeouts(srcFile *GoFile) {
 				Id("srv").Dot("config").Dot("WriteTimeout").Op("=").Id("timeout"),
 			)),
 		)
+	if r.hasLimits() {
+		srcFile.Line().Comment("WithLimiter подменяет ограничитель частоты @tg rate-limit (по умолчанию — token bucket в памяти процесса); nil отключает проверку частоты.")
+		srcFile.Func().Id("WithLimiter").
+			Params(Id("limiter").Id("Limiter")).
+			Id("Option").
+			Block(
+				Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+					Id("srv").Dot("limiter").Op("=").Id("limiter"),
+				)),
+			)
+	}
+	if r.hasIdempotency() {
+		srcFile.Line().Comment("WithIdempotencyStore подменяет хранилище ответов @tg idempotent (по умолчанию — LRU в памяти процесса); nil отключает проверку ключей.")
+		srcFile.Func().Id("WithIdempotencyStore").
+			Params(Id("store").Id("IdempotencyStore")).
+			Id("Option").
+			Block(
+				Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+					Id("srv").Dot("idempotency").Op("=").Id("store"),
+				)),
+			)
+	}
+	for _, kind := range []string{model.SecurityBearer, model.SecurityBasic, model.SecurityAPIKey} {
+		if !model.HasSecurityKind(r.securitySchemes(), kind) {
+			continue
+		}
+		srcFile.Line().Comment(authenticatorOption(kind) + " включает проверку @tg security аутентификатором " + authenticatorType(kind) + ".")
+		srcFile.Func().Id(authenticatorOption(kind)).
+			Params(Id("authenticator").Id(authenticatorType(kind))).
+			Id("Option").
+			Block(
+				Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+					Id("srv").Dot(authenticatorField(kind)).Op("=").Id("authenticator"),
+				)),
+			)
+	}
 	if r.hasSSE() {
 		srcFile.Line().Func().Id("SetSSEHeartbeat").
 			Params(Id("interval").Qual(PackageTime, "Duration")).
@@ -0,0 +1,460 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/generated"
+	"tgp/internal/model"
+)
+
+const packageBase64 = "encoding/base64"
+
+func (r *baseRenderer) securitySchemes() (schemes []model.SecurityScheme) {
+
+	return model.ProjectSecuritySchemes(r.project)
+}
+
+func (r *baseRenderer) hasSecurity() (ok bool) {
+
+	return len(r.securitySchemes()) > 0
+}
+
+// authenticateRequest — аутентификация HTTP-запроса на входе JSON-RPC: результат один на все элементы batch.
+func (r *baseRenderer) authenticateRequest(srv *Statement) (c Code) {
+
+	if !r.hasSecurity() {
+		return Null()
+	}
+	return Id(VarNameFtx).Dot("SetUserContext").Call(srv.Dot("authenticate").Call(Id(VarNameFtx)))
+}
+
+func (r *contractRenderer) contractAuthenticateRequest() (c Code) {
+
+	if !r.hasSecurity() {
+		return Null()
+	}
+	return If(Id("http").Dot("srv").Op("!=").Nil()).Block(r.authenticateRequest(Id("http").Dot("srv")))
+}
+
+func (r *transportRenderer) RenderTransportSecurity() (err error) {
+
+	if !r.hasSecurity() {
+		return
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(generated.ByToolGateway)
+
+	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(packageBase64, "base64")
+	srcFile.ImportName(srvctxPkgPath, "srvctx")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
+
+	if r.hasJsonRPC() {
+		srcFile.Line().Const().Op("(").
+			Line().Id("unauthorizedError").Op("=").Lit(-32001).
+			Line().Id("forbiddenError").Op("=").Lit(-32003).
+			Line().Op(")")
+	}
+	srcFile.Line().Const().Id("authChallenge").Op("=").Lit(r.authChallenge())
+	srcFile.Line().Add(r.authenticatorTypes(srvctxPkgPath))
+	srcFile.Line().Add(r.errAuthType())
+	srcFile.Line().Add(r.authResultType(srvctxPkgPath))
+	srcFile.Line().Add(r.authenticateFunc(srvctxPkgPath))
+	srcFile.Line().Add(r.authorizationCredentialsFunc())
+	srcFile.Line().Add(r.authorizeFunc(srvctxPkgPath))
+	srcFile.Line().Add(r.sendAuthErrorFunc())
+
+	return srcFile.Save(path.Join(r.outDir, "security.go"))
+}
+
+// authChallenge — значение WWW-Authenticate ответа 401 по первой схеме с HTTP-аутентификацией.
+func (r *transportRenderer) authChallenge() (challenge string) {
+
+	for _, scheme := range r.securitySchemes() {
+		switch scheme.Kind {
+		case model.SecurityBearer:
+			return "Bearer"
+		case model.SecurityBasic:
+			return `Basic realm="api", charset="UTF-8"`
+		}
+	}
+	return ""
+}
+
+func (r *transportRenderer) authenticatorTypes(srvctxPkgPath string) (c Code) {
+
+	schemes := r.securitySchemes()
+	principal := Id("principal").Op("*").Qual(srvctxPkgPath, "Principal")
+	return Null().Do(func(s *Statement) {
+		if model.HasSecurityKind(schemes, model.SecurityBearer) {
+			s.Comment("BearerAuthenticator проверяет токен из заголовка Authorization: Bearer (@tg security http:bearer, oauth2, openId).").
+				Line().Type().Id("BearerAuthenticator").Interface(
+				Id("AuthenticateBearer").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("token").String()).Params(principal.Clone(), Err().Error()),
+			).Line().Line()
+		}
+		if model.HasSecurityKind(schemes, model.SecurityBasic) {
+			s.Comment("BasicAuthenticator проверяет логин и пароль из заголовка Authorization: Basic (@tg security http:basic).").
+				Line().Type().Id("BasicAuthenticator").Interface(
+				Id("AuthenticateBasic").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("username").String(), Id("password").String()).Params(principal.Clone(), Err().Error()),
+			).Line().Line()
+		}
+		if model.HasSecurityKind(schemes, model.SecurityAPIKey) {
+			s.Comment("APIKeyAuthenticator проверяет ключ из заголовка, query-параметра или cookie (@tg security apiKey:<in>:<name>).").
+				Line().Type().Id("APIKeyAuthenticator").Interface(
+				Id("AuthenticateAPIKey").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("key").String()).Params(principal.Clone(), Err().Error()),
+			).Line().Line()
+		}
+	})
+}
+
+func (r *transportRenderer) errAuthType() (c Code) {
+
+	return Comment("errAuth — отказ аутентификации (401) или авторизации по scopes (403).").
+		Line().Type().Id("errAuth").Struct(
+		Id("TrKey").String().Tag(map[string]string{"json": "trKey"}),
+		Id("Data").String().Tag(map[string]string{"json": "data,omitempty"}),
+		Id("code").Int(),
+		Id("challenge").String(),
+	).
+		Line().Line().
+		Func().Params(Id("e").Op("*").Id("errAuth")).Id("Error").Params().String().
+		Block(Return(Id("e").Dot("Data"))).
+		Line().Line().
+		Func().Params(Id("e").Op("*").Id("errAuth")).Id("Code").Params().Int().
+		Block(Return(Id("e").Dot("code"))).
+		Do(func(s *Statement) {
+			if !r.hasJsonRPC() {
+				return
+			}
+			s.Line().Line().
+				Func().Params(Id("e").Op("*").Id("errAuth")).Id("jsonRPCCode").Params().Int().Block(
+				If(Id("e").Dot("code").Op("==").Qual(r.httpPkg(), "StatusForbidden")).Block(Return(Id("forbiddenError"))),
+				Return(Id("unauthorizedError")),
+			)
+		}).
+		Line().Line().
+		Func().Id("newErrUnauthorized").Params(Id("message").String()).Params(Op("*").Id("errAuth")).Block(
+		Return(Op("&").Id("errAuth").Values(Dict{
+			Id("TrKey"):     Lit("unauthorized"),
+			Id("Data"):      Id("message"),
+			Id("code"):      Qual(r.httpPkg(), "StatusUnauthorized"),
+			Id("challenge"): Id("authChallenge"),
+		})),
+	).
+		Line().Line().
+		Func().Id("newErrForbidden").Params(Id("message").String()).Params(Op("*").Id("errAuth")).Block(
+		Return(Op("&").Id("errAuth").Values(Dict{
+			Id("TrKey"): Lit("forbidden"),
+			Id("Data"):  Id("message"),
+			Id("code"):  Qual(r.httpPkg(), "StatusForbidden"),
+		})),
+	)
+}
+
+func (r *transportRenderer) authResultType(srvctxPkgPath string) (c Code) {
+
+	return Type().Id("authResultKey").Struct().
+		Line().Line().
+		Var().Id("keyAuthResult").Op("=").Id("authResultKey").Values().
+		Line().Line().
+		Comment("authResult — итог аутентификации запроса одной схемой: субъект или отказ.").
+		Line().Type().Id("authResult").Struct(
+		Id("principal").Op("*").Qual(srvctxPkgPath, "Principal"),
+		Id("err").Op("*").Id("errAuth"),
+	).
+		Line().Line().
+		Comment("authResults — итоги аутентификации запроса по идентификаторам схем @tg security.").
+		Line().Type().Id("authResults").Map(String()).Id("authResult").
+		Line().Line().
+		Comment("newAuthResult — итог проверки учётных данных аутентификатором: субъект или 401 invalid credentials.").
+		Line().Func().Id("newAuthResult").
+		Params(Id("scheme").String(), Id("principal").Op("*").Qual(srvctxPkgPath, "Principal"), Err().Error()).
+		Params(Id("result").Id("authResult")).
+		Block(
+			If(Err().Op("!=").Nil().Op("||").Id("principal").Op("==").Nil()).Block(
+				Return(Id("authResult").Values(Dict{Id("err"): Id("newErrUnauthorized").Call(Lit("invalid credentials"))})),
+			),
+			If(Id("principal").Dot("Scheme").Op("==").Lit("")).Block(
+				Id("principal").Dot("Scheme").Op("=").Id("scheme"),
+			),
+			Return(Id("authResult").Values(Dict{Id("principal"): Id("principal")})),
+		)
+}
+
+func (r *transportRenderer) authenticateFunc(srvctxPkgPath string) (c Code) {
+
+	return Comment("authenticate проверяет учётные данные запроса всеми схемами @tg security и кладёт итоги в контекст;").
+		Line().Comment("какая схема нужна методу, решает authorize. Схема без аутентификатора (WithBearerAuth, WithBasicAuth,").
+		Line().Comment("WithAPIKeyAuth) отклоняет вызовы с 401 authenticator not configured.").
+		Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("authenticate").
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id(VarNameCtx).Op("=").Id(VarNameFtx).Dot("UserContext").Call()
+			bg.Id("results").Op(":=").Make(Id("authResults"))
+			for _, scheme := range r.securitySchemes() {
+				field := Id("srv").Dot(authenticatorField(scheme.Kind))
+				notConfigured := Id("results").Index(Lit(scheme.ID())).Op("=").Id("authResult").Values(Dict{Id("err"): Id("newErrUnauthorized").Call(Lit("authenticator not configured"))})
+				store := func(call Code) Code {
+					return Block(
+						List(Id("principal"), Id("authErr")).Op(":=").Add(call),
+						Id("results").Index(Lit(scheme.ID())).Op("=").Id("newAuthResult").Call(Lit(scheme.Kind), Id("principal"), Id("authErr")),
+					)
+				}
+				switch scheme.Kind {
+				case model.SecurityBearer:
+					bg.If(field.Clone().Op("==").Nil()).Block(notConfigured).
+						Else().If(List(Id("token"), Id("found")).Op(":=").Id("authorizationCredentials").Call(Id(VarNameFtx).Dot("Get").Call(Lit("Authorization")), Lit("Bearer")).Op(";").Id("found")).
+						Add(store(field.Clone().Dot("AuthenticateBearer").Call(Id(VarNameCtx), Id("token"))))
+				case model.SecurityBasic:
+					bg.If(field.Clone().Op("==").Nil()).Block(notConfigured).
+						Else().If(List(Id("encoded"), Id("found")).Op(":=").Id("authorizationCredentials").Call(Id(VarNameFtx).Dot("Get").Call(Lit("Authorization")), Lit("Basic")).Op(";").Id("found")).Block(
+						Id("results").Index(Lit(scheme.ID())).Op("=").Id("authResult").Values(Dict{Id("err"): Id("newErrUnauthorized").Call(Lit("invalid credentials"))}),
+						If(List(Id("decoded"), Id("decodeErr")).Op(":=").Qual(packageBase64, "StdEncoding").Dot("DecodeString").Call(Id("encoded")).Op(";").Id("decodeErr").Op("==").Nil()).Block(
+							If(List(Id("username"), Id("password"), Id("ok")).Op(":=").Qual(PackageStrings, "Cut").Call(String().Call(Id("decoded")), Lit(":")).Op(";").Id("ok")).
+								Add(store(field.Clone().Dot("AuthenticateBasic").Call(Id(VarNameCtx), Id("username"), Id("password")))),
+						),
+					)
+				case model.SecurityAPIKey:
+					bg.If(field.Clone().Op("==").Nil()).Block(notConfigured).
+						Else().If(Id("key").Op(":=").Add(r.apiKeyValue(scheme)).Op(";").Id("key").Op("!=").Lit("")).
+						Add(store(field.Clone().Dot("AuthenticateAPIKey").Call(Id(VarNameCtx), Id("key"))))
+				}
+			}
+			bg.Return(Qual(PackageContext, "WithValue").Call(Id(VarNameCtx), Id("keyAuthResult"), Id("results")))
+		})
+}
+
+func authenticatorField(kind string) (name string) {
+
+	return kind + "Auth"
+}
+
+func authenticatorType(kind string) (name string) {
+
+	switch kind {
+	case model.SecurityBasic:
+		return "BasicAuthenticator"
+	case model.SecurityAPIKey:
+		return "APIKeyAuthenticator"
+	}
+	return "BearerAuthenticator"
+}
+
+func authenticatorOption(kind string) (name string) {
+
+	switch kind {
+	case model.SecurityBasic:
+		return "WithBasicAuth"
+	case model.SecurityAPIKey:
+		return "WithAPIKeyAuth"
+	}
+	return "WithBearerAuth"
+}
+
+func (r *transportRenderer) apiKeyValue(scheme model.SecurityScheme) (c Code) {
+
+	switch scheme.In {
+	case model.SecurityInQuery:
+		return String().Call(Id(VarNameFtx).Dot("Request").Call().Dot("URI").Call().Dot("QueryArgs").Call().Dot("Peek").Call(Lit(scheme.Name)))
+	case model.SecurityInCookie:
+		return Id(VarNameFtx).Dot("Cookies").Call(Lit(scheme.Name))
+	}
+	return Id(VarNameFtx).Dot("Get").Call(Lit(scheme.Name))
+}
+
+func (r *transportRenderer) authorizationCredentialsFunc() (c Code) {
+
+	return Comment("authorizationCredentials — учётные данные заголовка Authorization для схемы scheme (без учёта регистра).").
+		Line().Func().Id("authorizationCredentials").
+		Params(Id("header").String(), Id("scheme").String()).
+		Params(Id("credentials").String(), Id("found").Bool()).
+		Block(
+			If(Len(Id("header")).Op("<=").Len(Id("scheme")).Op("||").Id("header").Index(Len(Id("scheme"))).Op("!=").LitRune(' ').Op("||").Op("!").Qual(PackageStrings, "EqualFold").Call(Id("header").Index(Empty(), Len(Id("scheme"))), Id("scheme"))).Block(
+				Return(),
+			),
+			Id("credentials").Op("=").Qual(PackageStrings, "TrimSpace").Call(Id("header").Index(Len(Id("scheme")).Op("+").Lit(1), Empty())),
+			Return(Id("credentials"), Id("credentials").Op("!=").Lit("")),
+		)
+}
+
+func (r *transportRenderer) authorizeFunc(srvctxPkgPath string) (c Code) {
+
+	return Comment("authorize выбирает субъекта первой схемы метода (schemes — в порядке @tg security), которая приняла учётные данные,").
+		Line().Comment("сверяет его со scopes метода (@tg scopes) и возвращает контекст с субъектом.").
+		Line().Comment("Без результата аутентификации в контексте вызов отклоняется с 401.").
+		Line().Func().Id("authorize").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("schemes").Index().String(), Id("scopes").Op("...").String()).
+		Params(Id("authCtx").Qual(PackageContext, "Context"), Err().Op("*").Id("errAuth")).
+		Block(
+			List(Id("results"), Id("_")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("keyAuthResult")).Assert(Id("authResults")),
+			Err().Op("=").Id("newErrUnauthorized").Call(Lit("missing credentials")),
+			For(List(Id("_"), Id("scheme")).Op(":=").Range().Id("schemes")).Block(
+				List(Id("result"), Id("found")).Op(":=").Id("results").Index(Id("scheme")),
+				If(Op("!").Id("found")).Block(Continue()),
+				If(Id("result").Dot("err").Op("!=").Nil()).Block(
+					Err().Op("=").Id("result").Dot("err"),
+					Continue(),
+				),
+				For(List(Id("_"), Id("scope")).Op(":=").Range().Id("scopes")).Block(
+					If(Op("!").Id("result").Dot("principal").Dot("HasScope").Call(Id("scope"))).Block(
+						Return(Id(VarNameCtx), Id("newErrForbidden").Call(Lit("missing scope ").Op("+").Id("scope"))),
+					),
+				),
+				Return(Qual(srvctxPkgPath, "WithCtx").Call(Id(VarNameCtx), Id("result").Dot("principal")), Nil()),
+			),
+			Return(Id(VarNameCtx), Err()),
+		)
+}
+
+func (r *transportRenderer) sendAuthErrorFunc() (c Code) {
+
+	return Comment("sendAuthError отвечает на отказ до вызова stream-метода или обработчика handler=: 401 с WWW-Authenticate или 403.").
+		Line().Func().Id("sendAuthError").
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("authErr").Op("*").Id("errAuth")).
+		Params(Err().Error()).
+		Block(
+			If(Id("authErr").Dot("challenge").Op("!=").Lit("")).Block(
+				Id(VarNameFtx).Dot("Set").Call(Lit("WWW-Authenticate"), Id("authErr").Dot("challenge")),
+			),
+			Return(Id(VarNameFtx).Dot("Status").Call(Id("authErr").Dot("code")).Dot("JSON").Call(Id("authErr"))),
+		)
+}
+
+// authorizeArgs — аргументы authorize для метода: идентификаторы его схем @tg security и scopes.
+// ok=false — метод публичный (@tg security=none или без схем), проверка не нужна.
+func (r *contractRenderer) authorizeArgs(ctx Code, method *model.Method) (args []Code, ok bool) {
+
+	if !r.hasSecurity() {
+		return
+	}
+	schemes := model.MethodSecuritySchemes(r.project, r.contract, method)
+	if len(schemes) == 0 {
+		return
+	}
+	ids := make([]Code, 0, len(schemes))
+	for _, scheme := range schemes {
+		ids = append(ids, Lit(scheme.ID()))
+	}
+	args = []Code{ctx, Index().String().Values(ids...)}
+	for _, scope := range model.MethodScopes(r.project, r.contract, method) {
+		args = append(args, Lit(scope))
+	}
+	return args, true
+}
+
+// httpAuthCheck — аутентификация HTTP-запроса и проверка схем и scopes метода до обработчика; отказ отдаёт reject.
+func (r *contractRenderer) httpAuthCheck(method *model.Method, reject ...Code) (c Code) {
+
+	args, ok := r.authorizeArgs(Id(VarNameFtx).Dot("UserContext").Call(), method)
+	if !ok {
+		return Null()
+	}
+	return If(List(Id("server"), Id("ok")).Op(":=").Id(VarNameFtx).Dot("Locals").Call(Lit("server")).Assert(Op("*").Id("Server")).Op(";").Id("ok")).Block(
+		Id(VarNameFtx).Dot("SetUserContext").Call(Id("server").Dot("authenticate").Call(Id(VarNameFtx))),
+	).
+		Line().List(Id("authCtx"), Id("authErr")).Op(":=").Id("authorize").Call(args...).
+		Line().If(Id("authErr").Op("!=").Nil()).Block(reject...).
+		Line().Id(VarNameFtx).Dot("SetUserContext").Call(Id("authCtx"))
+}
+
+// restAuthCheck — аутентификация и проверка scopes в начале REST-обработчика: 401 с WWW-Authenticate или 403.
+func (r *contractRenderer) restAuthCheck(method *model.Method) (c Code) {
+
+	return r.httpAuthCheck(method,
+		If(Id("authErr").Dot("challenge").Op("!=").Lit("")).Block(
+			Id(VarNameFtx).Dot("Set").Call(Lit("WWW-Authenticate"), Id("authErr").Dot("challenge")),
+		),
+		Id(VarNameFtx).Dot("Status").Call(Id("authErr").Dot("Code").Call()),
+		r.httpSendError(method, Id("authErr")),
+	)
+}
+
+// handlerAuthCheck — та же проверка перед SSE-методом и обработчиком handler=, которые не проходят через REST-обработчик.
+func (r *contractRenderer) handlerAuthCheck(method *model.Method) (c Code) {
+
+	return r.httpAuthCheck(method, Return(Id("sendAuthError").Call(Id(VarNameFtx), Id("authErr"))))
+}
+
+// jsonRPCAuthCheck — проверка схем и scopes JSON-RPC вызова (и каждого элемента batch) по результату аутентификации HTTP-запроса.
+func (r *contractRenderer) jsonRPCAuthCheck(method *model.Method) (c Code) {
+
+	args, ok := r.authorizeArgs(Id(VarNameCtx), method)
+	if !ok {
+		return Null()
+	}
+	return List(Id("authCtx"), Id("authErr")).Op(":=").Id("authorize").Call(args...).
+		Line().If(Id("authErr").Op("!=").Nil()).Block(
+		Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("authErr").Dot("jsonRPCCode").Call(), Id("authErr").Dot("Error").Call(), Id("authErr"))),
+	).
+		Line().Id(VarNameCtx).Op("=").Id("authCtx")
+}
+
+// wsAuthCheck — проверка схем и scopes вызова WebSocket-метода по результату аутентификации upgrade-запроса.
+func (r *contractRenderer) wsAuthCheck(method *model.Method) (c Code) {
+
+	args, ok := r.authorizeArgs(Id(VarNameCtx), method)
+	if !ok {
+		return Null()
+	}
+	return List(Id("authCtx"), Id("authErr")).Op(":=").Id("authorize").Call(args...).
+		Line().If(Id("authErr").Op("!=").Nil()).Block(Return(Nil(), Id("authErr"))).
+		Line().Id(VarNameCtx).Op("=").Id("authCtx")
+}
+
+// wsUpgradeAuth — аутентификация upgrade-запроса WebSocket: итоги сохраняются для вызовов по соединению,
+// а если публичных WS-методов у контракта нет, upgrade без принятых учётных данных отклоняется с 401.
+func (r *contractRenderer) wsUpgradeAuth() (c Code) {
+
+	if !r.hasSecurity() {
+		return Null()
+	}
+	var union []Code
+	seen := make(map[string]bool)
+	for _, method := range r.contract.Methods {
+		if !model.MethodIsWS(r.project, r.contract, method) {
+			continue
+		}
+		schemes := model.MethodSecuritySchemes(r.project, r.contract, method)
+		if len(schemes) == 0 {
+			union = nil
+			break
+		}
+		for _, scheme := range schemes {
+			if !seen[scheme.ID()] {
+				seen[scheme.ID()] = true
+				union = append(union, Lit(scheme.ID()))
+			}
+		}
+	}
+	return If(List(Id("server"), Id("ok")).Op(":=").Id(VarNameFtx).Dot("Locals").Call(Lit("server")).Assert(Op("*").Id("Server")).Op(";").Id("ok")).Block(
+		Id(VarNameFtx).Dot("SetUserContext").Call(Id("server").Dot("authenticate").Call(Id(VarNameFtx))),
+	).Do(func(s *Statement) {
+		if len(union) == 0 {
+			return
+		}
+		s.Line().If(List(Id("_"), Id("authErr")).Op(":=").Id("authorize").Call(Id(VarNameFtx).Dot("UserContext").Call(), Index().String().Values(union...)).Op(";").Id("authErr").Op("!=").Nil()).Block(
+			Return(Id("sendAuthError").Call(Id(VarNameFtx), Id("authErr"))),
+		)
+	}).
+		Line().Id(VarNameFtx).Dot("Locals").Call(Lit("authContext"), Id(VarNameFtx).Dot("UserContext").Call())
+}
+
+// wsBaseContext — контекст вызовов по WebSocket-соединению: итоги аутентификации upgrade-запроса или пустой контекст.
+func (r *contractRenderer) wsBaseContext() (c Code) {
+
+	if !r.hasSecurity() {
+		return Qual(PackageContext, "Background").Call()
+	}
+	return Id("authContext")
+}
@@ -18,13 +18,24 @@ func (r *transportRenderer) contractHasHTTPTransport(contract *model.Contract) (
 
 func (r *transportRenderer) fiberFunc() (c Code) {
 
-	return Func().Params(Id("srv").Op("*").Id("Server")).
-		Id("Fiber").
+	accessor := Func().Params(Id("srv").Op("*").Id("Server")).
+		Id(r.appAccessor()).
 		Params().
-		Params(Op("*").Qual(PackageFiber, "App")).
+		Params(Op("*").Qual(r.httpPkg(), "App")).
 		Block(
 			Return(Id("srv").Dot("srvHTTP")),
 		)
+	if !r.isNetHTTP() {
+		return accessor
+	}
+	// Server реализует http.Handler: его можно передать в http.Server или смонтировать в чужой mux.
+	return accessor.Line().Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("ServeHTTP").
+		Params(Id("w").Qual(PackageHTTP, "ResponseWriter"), Id("r").Op("*").Qual(PackageHTTP, "Request")).
+		Block(
+			Id("srv").Dot("srvHTTP").Dot("ServeHTTP").Call(Id("w"), Id("r")),
+		)
 }
 
 func (r *transportRenderer) withLogFunc() (c Code) {
@@ -114,8 +125,15 @@ func (r *transportRenderer) serverNewFunc() (c Code) {
 				if r.hasSSE() {
 					dict[Id("sseHeartbeat")] = Id("defaultSSEHeartbeat")
 				}
+				if r.hasLimits() {
+					dict[Id("limiter")] = Id("NewTokenBucketLimiter").Call()
+					dict[Id("inFlight")] = Id("newInFlightLimits").Call()
+				}
+				if r.hasIdempotency() {
+					dict[Id("idempotency")] = Id("NewMemoryIdempotencyStore").Call(Id("defaultIdempotencyCapacity"), Id("defaultIdempotencyTTL"))
+				}
 				dict[Id("headerHandlers")] = Make(Map(String()).Id("HeaderHandler"))
-				dict[Id("config")] = Qual(PackageFiber, "Config").Values(Dict{
+				dict[Id("config")] = Qual(r.httpPkg(), "Config").Values(Dict{
 					Id("StreamRequestBody"):            True(),
 					Id("DisableStartupMessage"):        True(),
 					Id("DisablePreParseMultipartForm"): True(),
@@ -145,8 +163,8 @@ func (r *transportRenderer) serverNewFunc() (c Code) {
 				Id("option").Call(Id("srv")),
 			)
 			bg.Line()
-			bg.Id("srv").Dot("srvHTTP").Op("=").Qual(PackageFiber, "New").Call(Id("srv").Dot("config"))
-			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Func().Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).Params(Error()).Block(
+			bg.Id("srv").Dot("srvHTTP").Op("=").Qual(r.httpPkg(), "New").Call(Id("srv").Dot("config"))
+			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Func().Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).Params(Error()).Block(
 				Id(VarNameFtx).Dot("Locals").Call(Lit("server"), Id("srv")),
 				Return(Id("recoverHandler").Call(Id(VarNameFtx))),
 			))
@@ -166,6 +184,13 @@ func (r *transportRenderer) serverNewFunc() (c Code) {
 			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("serviceOptions")).Block(
 				Id("option").Call(Id("srv")),
 			)
+			for _, contract := range r.contractsWithMiddleware() {
+				bg.If(Id("srv").Dot("http" + contract.Name).Op("!=").Nil()).Block(
+					For(Id("i").Op(":=").Len(Id("srv").Dot(middlewareField(contract))).Op("-").Lit(1), Id("i").Op(">=").Lit(0), Id("i").Op("--")).Block(
+						Id("srv").Dot(middlewareField(contract)).Index(Id("i")).Call(Id("srv").Dot("http" + contract.Name).Dot("svc")),
+					),
+				)
+			}
 			bg.Line()
 			if r.hasJsonRPC() {
 				bg.Id("initJsonRPCMethodMaps").Call(Id("srv"))
@@ -200,7 +225,7 @@ func (r *transportRenderer) serveHealthFunc() (c Code) {
 			}).Else().Block(
 				Id("responseBody").Op("=").Op("[]").Byte().Call(Lit(`"ok"`)),
 			)
-			bg.Id("srv").Op(":=").Qual(PackageFiber, "New").Call(Qual(PackageFiber, "Config").Values(Dict{
+			bg.Id("srv").Op(":=").Qual(r.httpPkg(), "New").Call(Qual(r.httpPkg(), "Config").Values(Dict{
 				Id("DisableStartupMessage"):        True(),
 				Id("DisablePreParseMultipartForm"): True(),
 				Id("IdleTimeout"):                  Id("defaultIdleTimeout"),
@@ -208,7 +233,7 @@ func (r *transportRenderer) serveHealthFunc() (c Code) {
 				Id("WriteTimeout"):                 Id("defaultWriteTimeout"),
 			}))
 			bg.Id("srv").Dot("Get").Call(Id("path"),
-				Func().Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).Params(Id("err").Error()).BlockFunc(func(hg *Group) {
+				Func().Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).Params(Id("err").Error()).BlockFunc(func(hg *Group) {
 					hg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Id("contentTypeJson"))
 					hg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentLength").Call(Len(Id("responseBody")))
 					hg.List(Id("_"), Id("err")).Op("=").Id(VarNameFtx).Dot("Write").Call(Id("responseBody"))
@@ -252,7 +277,7 @@ func (r *transportRenderer) sendResponseFunc() (c Code) {
 
 	jsonPkg := r.getPackageJSON()
 	return Func().Id("sendResponse").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("resp").Any()).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("resp").Any()).
 		Params(Err().Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Lit("application/json"))
@@ -260,7 +285,7 @@ func (r *transportRenderer) sendResponseFunc() (c Code) {
 				ig.If(Id("logger").Op(":=").Qual(fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir)), "FromCtx").Types(Op("*").Qual(PackageSlog, "Logger")).Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("logger").Op("!=").Nil()).Block(
 					Id("logger").Dot("Error").Call(Lit("response marshal error"), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
 				)
-				ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError"))
+				ig.Id(VarNameFtx).Dot("Status").Call(Qual(r.httpPkg(), "StatusInternalServerError"))
 				ig.Return(Err())
 			})
 			bg.Return(Err())
@@ -271,7 +296,7 @@ func (r *transportRenderer) sendHTTPErrorFunc() (c Code) {
 
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Id("sendHTTPError").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("statusCode").Int(), Id("message").String()).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx"), Id("statusCode").Int(), Id("message").String()).
 		Params(Err().Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Id("clientID").Op(":=").Qual(srvctxPkgPath, "GetClientID").Call(Id(VarNameFtx).Dot("UserContext").Call())
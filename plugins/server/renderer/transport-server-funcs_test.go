@@ -17,7 +17,7 @@ func TestRenderTransportServer_WithLogSingleAssignmentPerHybridContract(t *testi
 	project := transportServerTestProject()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
@@ -39,7 +39,7 @@ func TestRenderTransportServer_ClientIDMiddlewareAlwaysRegistered(t *testing.T)
 	project := transportServerTestProject()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
@@ -63,7 +63,7 @@ func TestRenderTransportServer_ClientIDMiddlewareWithoutMetrics(t *testing.T) {
 	project := transportServerLogOnlyProject()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
@@ -87,7 +87,7 @@ func TestRenderTransportServer_WithLogKeepsHTTPOnlyAndJsonRPCOnlyContracts(t *te
 	project := transportServerTestProject()
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
@@ -106,6 +106,63 @@ func TestRenderTransportServer_WithLogKeepsHTTPOnlyAndJsonRPCOnlyContracts(t *te
 	}
 }
 
+func TestRenderTransportServer_NetHTTPTarget(t *testing.T) {
+
+	project := transportServerTestProject()
+	project.ModulePath = "example.com/app"
+	dir := filepath.Join(t.TempDir(), "transport")
+
+	renderer := NewTransportRenderer(project, dir, TargetNetHTTP)
+	if err := renderer.RenderTransportServer(); err != nil {
+		t.Fatalf("RenderTransportServer: %v", err)
+	}
+	if err := renderer.RenderTransportOptions(); err != nil {
+		t.Fatalf("RenderTransportOptions: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "server.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	source := string(content)
+
+	if strings.Contains(source, "gofiber") {
+		t.Fatalf("nethttp target must not import fiber, got:\n%s", source)
+	}
+	for _, want := range []string{
+		"func (srv *Server) App() *nethttp.App {",
+		"func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {",
+		"func (srv *Server) clientIDMiddleware(ftx *nethttp.Ctx) error",
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("expected %q in server.go, got:\n%s", want, source)
+		}
+	}
+
+	if content, err = os.ReadFile(filepath.Join(dir, "options.go")); err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	if !strings.Contains(string(content), "func SetHTTPCfg(cfg nethttp.Config) Option {") {
+		t.Fatalf("expected SetHTTPCfg option, got:\n%s", content)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "nethttp"))
+	if err != nil {
+		t.Fatalf("read nethttp package: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), "_test.go") {
+			t.Fatalf("nethttp package must be copied without tests, got %s", entry.Name())
+		}
+	}
+	if content, err = os.ReadFile(filepath.Join(dir, "nethttp", "app.go")); err != nil {
+		t.Fatalf("read nethttp package: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "// Code generated") {
+		t.Fatalf("nethttp package must carry the generated header, got:\n%s", content)
+	}
+}
+
 func TestRenderTransportServer_JSONRPCBatchMountsByPrefix(t *testing.T) {
 
 	project := &model.Project{
@@ -159,7 +216,7 @@ func TestRenderTransportServer_JSONRPCBatchMountsByPrefix(t *testing.T) {
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportServer(); err != nil {
 		t.Fatalf("RenderTransportServer: %v", err)
 	}
@@ -230,7 +287,7 @@ func TestRenderTransportJsonRPC_MethodMapsScopedByPrefix(t *testing.T) {
 	}
 	dir := filepath.Join(t.TempDir(), "transport")
 
-	renderer := NewTransportRenderer(project, dir)
+	renderer := NewTransportRenderer(project, dir, TargetFiber)
 	if err := renderer.RenderTransportJsonRPC(); err != nil {
 		t.Fatalf("RenderTransportJsonRPC: %v", err)
 	}
@@ -17,11 +17,16 @@ func (r *transportRenderer) RenderTransportServer() (err error) {
 
 	serverPath := path.Join(r.outDir, "server.go")
 
-	if err = r.pkgRenderTo("logger", r.outDir, newPkgTemplateData()); err != nil {
+	if err = r.pkgRenderTo("logger", r.outDir, r.newPkgTemplateData()); err != nil {
 		return fmt.Errorf("render logger package: %w", err)
 	}
+	if r.isNetHTTP() {
+		if err = r.renderNetHTTPPackage(); err != nil {
+			return fmt.Errorf("render nethttp package: %w", err)
+		}
+	}
 	if r.hasTrace() {
-		if err = r.pkgRenderTo("tracer", r.outDir, newPkgTemplateData()); err != nil {
+		if err = r.pkgRenderTo("tracer", r.outDir, r.newPkgTemplateData()); err != nil {
 			return fmt.Errorf("render tracer package: %w", err)
 		}
 	}
@@ -54,7 +59,7 @@ func (r *transportRenderer) renderServerImports(srcFile *GoFile) {
 	}
 
 	// Внешние пакеты
-	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(r.httpPkg(), r.httpPkgName())
 	srcFile.ImportName(PackagePrometheus, "prometheus")
 	srcFile.ImportName(PackagePrometheusAuto, "promauto")
 	srcFile.ImportName(PackagePrometheusHttp, "promhttp")
@@ -124,9 +129,9 @@ func (r *transportRenderer) transportServerType() Code {
 
 	return Type().Id("Server").StructFunc(func(bg *Group) {
 		bg.Id("log").Op("*").Qual(PackageSlog, "Logger")
-		bg.Line().Id("config").Qual(PackageFiber, "Config")
-		bg.Line().Id("srvHTTP").Op("*").Qual(PackageFiber, "App")
-		bg.Id("srvMetrics").Op("*").Qual(PackageFiber, "App")
+		bg.Line().Id("config").Qual(r.httpPkg(), "Config")
+		bg.Line().Id("srvHTTP").Op("*").Qual(r.httpPkg(), "App")
+		bg.Id("srvMetrics").Op("*").Qual(r.httpPkg(), "App")
 		if r.hasMetrics() {
 			bg.Line().Id("metrics").Op("*").Id("Metrics")
 		}
@@ -138,6 +143,21 @@ func (r *transportRenderer) transportServerType() Code {
 		if r.hasSSE() {
 			bg.Line().Id("sseHeartbeat").Qual(PackageTime, "Duration")
 		}
+		if r.hasLimits() {
+			bg.Line().Id("limiter").Id("Limiter")
+			bg.Id("inFlight").Map(String()).Chan().Struct()
+		}
+		if r.hasIdempotency() {
+			bg.Line().Id("idempotency").Id("IdempotencyStore")
+		}
+		if r.hasSecurity() {
+			bg.Line()
+			for _, kind := range []string{model.SecurityBearer, model.SecurityBasic, model.SecurityAPIKey} {
+				if model.HasSecurityKind(r.securitySchemes(), kind) {
+					bg.Id(authenticatorField(kind)).Id(authenticatorType(kind))
+				}
+			}
+		}
 		for _, contract := range r.contractsSorted() {
 			if model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerHTTP) ||
 				model.IsAnnotationSet(r.project, contract, nil, nil, model.TagServerJsonRPC) ||
@@ -145,6 +165,7 @@ func (r *transportRenderer) transportServerType() Code {
 				model.ContractHasSSE(r.project, contract) {
 				bg.Line()
 				bg.Id("http" + contract.Name).Op("*").Id("http" + contract.Name)
+				bg.Id(middlewareField(contract)).Index().Func().Params(Id("svc").Op("*").Id("server" + contract.Name))
 			}
 		}
 		bg.Line()
@@ -155,7 +176,7 @@ func (r *transportRenderer) transportServerType() Code {
 func (r *transportRenderer) healthServerType() Code {
 
 	return Type().Id("HealthServer").StructFunc(func(bg *Group) {
-		bg.Id("srv").Op("*").Qual(PackageFiber, "App")
+		bg.Id("srv").Op("*").Qual(r.httpPkg(), "App")
 		bg.Id("responseBody").Index().Byte()
 	})
 }
@@ -188,7 +209,7 @@ func (r *transportRenderer) clientIDMiddleware() Code {
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("clientIDMiddleware").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Id("clientID").Op(":=").Qual(PackageStrings, "Clone").Call(Qual(PackageStrings, "TrimSpace").Call(Id(VarNameFtx).Dot("Get").Call(Lit("X-Client-Id"))))
@@ -208,7 +229,7 @@ func (r *transportRenderer) inFlightMiddleware() Code {
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("inFlightMiddleware").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Error()).
 		BlockFunc(func(bg *Group) {
 			bg.If(Id("srv").Dot("metrics").Op("!=").Nil()).BlockFunc(func(ig *Group) {
@@ -226,7 +247,7 @@ func (r *transportRenderer) requestDurationMiddleware() Code {
 	srvctxPkgPath := fmt.Sprintf("%s/srvctx", r.pkgPath(r.outDir))
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("requestDurationMiddleware").
-		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id(VarNameFtx).Op("*").Qual(r.httpPkg(), "Ctx")).
 		Params(Error()).
 		BlockFunc(func(bg *Group) {
 			bg.If(Id("srv").Dot("metrics").Op("!=").Nil()).BlockFunc(func(ig *Group) {
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/generated"
+	"tgp/internal/model"
+	"tgp/plugins/server/renderer/types"
+)
+
+// RenderTransportValidation генерирует validation.go: нарушения, помощники проверки и функции валидации типов.
+// Файл создаётся только при наличии методов с правилами required/enums/format или перечислений в аргументах.
+func (r *transportRenderer) RenderTransportValidation() (err error) {
+
+	if !r.hasValidatedMethods() {
+		return
+	}
+
+	validationPath := path.Join(r.outDir, "validation.go")
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(generated.ByToolGateway)
+
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(PackageSlices, "slices")
+	srcFile.ImportName(PackageReflect, "reflect")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(PackageURL, "url")
+	srcFile.ImportName(PackageNetMail, "mail")
+	srcFile.ImportName(PackageNetIP, "netip")
+
+	srcFile.Line().Add(r.validationViolationType())
+	srcFile.Line().Add(r.requestValidatorType())
+	srcFile.Line().Add(r.validationHelperFuncs())
+	srcFile.Line().Add(r.validFormatFunc())
+	srcFile.Line().Add(r.errRequestValidationType())
+
+	typeGen := types.NewGenerator(r.project, &srcFile)
+	for _, typeID := range r.validationFuncTypes() {
+		srcFile.Line().Add(r.typeValidationFunc(typeGen, typeID))
+	}
+
+	return srcFile.Save(validationPath)
+}
+
+func (r *transportRenderer) validationViolationType() (c Code) {
+
+	return Comment("validationViolation — нарушение правила валидации поля запроса.").
+		Line().
+		Type().Id("validationViolation").Struct(
+		Id("Field").String().Tag(map[string]string{"json": "field"}),
+		Id("Rule").String().Tag(map[string]string{"json": "rule"}),
+		Id("Message").String().Tag(map[string]string{"json": "message"}),
+	)
+}
+
+func (r *transportRenderer) requestValidatorType() (c Code) {
+
+	return Type().Id("requestValidator").Struct(
+		Id("violations").Index().Id("validationViolation"),
+	).
+		Line().
+		Func().Params(Id("v").Op("*").Id("requestValidator")).Id("add").
+		Params(Id("field").String(), Id("rule").String(), Id("message").String()).
+		Block(
+			Id("v").Dot("violations").Op("=").Append(Id("v").Dot("violations"), Id("validationViolation").Values(Dict{
+				Id("Field"):   Id("field"),
+				Id("Rule"):    Id("rule"),
+				Id("Message"): Id("message"),
+			})),
+		).
+		Line().
+		Func().Params(Id("v").Op("*").Id("requestValidator")).Id("required").
+		Params(Id("field").String(), Id("ok").Bool()).
+		Block(
+			If(Op("!").Id("ok")).Block(
+				Id("v").Dot("add").Call(Id("field"), Lit("required"), Lit("value is required")),
+			),
+		).
+		Line().
+		Func().Params(Id("v").Op("*").Id("requestValidator")).Id("format").
+		Params(Id("field").String(), Id("format").String(), Id("value").String()).
+		Block(
+			If(Id("value").Op("!=").Lit("").Op("&&").Op("!").Id("validFormat").Call(Id("format"), Id("value"))).Block(
+				Id("v").Dot("add").Call(Id("field"), Lit("format"), Lit("value must be a valid ").Op("+").Id("format")),
+			),
+		)
+}
+
+func (r *transportRenderer) validationHelperFuncs() (c Code) {
+
+	return Comment("validateEnum проверяет принадлежность значения допустимому набору; нулевое значение считается отсутствующим.").
+		Line().
+		Func().Id("validateEnum").Types(Id("T").Comparable()).
+		Params(Id("v").Op("*").Id("requestValidator"), Id("field").String(), Id("value").Id("T"), Id("allowed").Op("...").Id("T")).
+		Block(
+			Var().Id("zero").Id("T"),
+			If(Id("value").Op("==").Id("zero").Op("||").Qual(PackageSlices, "Contains").Call(Id("allowed"), Id("value"))).Block(
+				Return(),
+			),
+			Id("v").Dot("add").Call(Id("field"), Lit("enums"), Qual(PackageFmt, "Sprintf").Call(Lit("value %v is not one of %v"), Id("value"), Id("allowed"))),
+		).
+		Line().
+		Func().Id("isSet").Types(Id("T").Any()).Params(Id("value").Id("T")).Bool().
+		Block(
+			Return(Op("!").Qual(PackageReflect, "ValueOf").Call(Op("&").Id("value")).Dot("Elem").Call().Dot("IsZero").Call()),
+		).
+		Line().
+		Func().Id("fieldPath").Params(Id("parent").String(), Id("name").String()).String().
+		Block(
+			If(Id("parent").Op("==").Lit("")).Block(
+				Return(Id("name")),
+			),
+			Return(Id("parent").Op("+").Lit(".").Op("+").Id("name")),
+		).
+		Line().
+		Func().Id("indexPath").Params(Id("field").String(), Id("index").Any()).String().
+		Block(
+			Return(Qual(PackageFmt, "Sprintf").Call(Lit("%s[%v]"), Id("field"), Id("index"))),
+		)
+}
+
+func (r *transportRenderer) validFormatFunc() (c Code) {
+
+	return Func().Id("validFormat").Params(Id("format").String(), Id("value").String()).Bool().
+		Block(
+			Switch(Id("format")).Block(
+				Case(Lit("uuid")).Block(
+					Return(Id("validUUID").Call(Id("value"))),
+				),
+				Case(Lit("email")).Block(
+					List(Id("address"), Err()).Op(":=").Qual(PackageNetMail, "ParseAddress").Call(Id("value")),
+					Return(Err().Op("==").Nil().Op("&&").Id("address").Dot("Address").Op("==").Id("value")),
+				),
+				Case(Lit("date-time")).Block(
+					List(Id("_"), Err()).Op(":=").Qual(PackageTime, "Parse").Call(Qual(PackageTime, "RFC3339"), Id("value")),
+					Return(Err().Op("==").Nil()),
+				),
+				Case(Lit("date")).Block(
+					List(Id("_"), Err()).Op(":=").Qual(PackageTime, "Parse").Call(Qual(PackageTime, "DateOnly"), Id("value")),
+					Return(Err().Op("==").Nil()),
+				),
+				Case(Lit("uri")).Block(
+					List(Id("u"), Err()).Op(":=").Qual(PackageURL, "Parse").Call(Id("value")),
+					Return(Err().Op("==").Nil().Op("&&").Id("u").Dot("Scheme").Op("!=").Lit("")),
+				),
+				Case(Lit("ipv4")).Block(
+					List(Id("addr"), Err()).Op(":=").Qual(PackageNetIP, "ParseAddr").Call(Id("value")),
+					Return(Err().Op("==").Nil().Op("&&").Id("addr").Dot("Is4").Call()),
+				),
+				Case(Lit("ipv6")).Block(
+					List(Id("addr"), Err()).Op(":=").Qual(PackageNetIP, "ParseAddr").Call(Id("value")),
+					Return(Err().Op("==").Nil().Op("&&").Id("addr").Dot("Is6").Call()),
+				),
+			),
+			Return(True()),
+		).
+		Line().
+		Func().Id("validUUID").Params(Id("value").String()).Bool().
+		Block(
+			If(Len(Id("value")).Op("!=").Lit(36)).Block(
+				Return(False()),
+			),
+			For(Id("i").Op(":=").Range().Len(Id("value"))).Block(
+				Id("c").Op(":=").Id("value").Index(Id("i")),
+				Switch(Id("i")).Block(
+					Case(Lit(8), Lit(13), Lit(18), Lit(23)).Block(
+						If(Id("c").Op("!=").LitRune('-')).Block(Return(False())),
+					),
+					Default().Block(
+						If(Op("!").Parens(
+							Id("c").Op(">=").LitRune('0').Op("&&").Id("c").Op("<=").LitRune('9').
+								Op("||").Id("c").Op(">=").LitRune('a').Op("&&").Id("c").Op("<=").LitRune('f').
+								Op("||").Id("c").Op(">=").LitRune('A').Op("&&").Id("c").Op("<=").LitRune('F'),
+						)).Block(Return(False())),
+					),
+				),
+			),
+			Return(True()),
+		)
+}
+
+func (r *transportRenderer) errRequestValidationType() (c Code) {
+
+	return Comment("errRequestValidation — ошибка 400 со списком всех нарушений валидации запроса.").
+		Line().
+		Type().Id("errRequestValidation").Struct(
+		Id("TrKey").String().Tag(map[string]string{"json": "trKey"}),
+		Id("Data").String().Tag(map[string]string{"json": "data,omitempty"}),
+		Id("Violations").Index().Id("validationViolation").Tag(map[string]string{"json": "violations"}),
+	).
+		Line().
+		Func().Params(Id("e").Op("*").Id("errRequestValidation")).Id("Error").Params().String().
+		Block(Return(Id("e").Dot("Data"))).
+		Line().
+		Func().Params(Id("e").Op("*").Id("errRequestValidation")).Id("Code").Params().Int().
+		Block(Return(Lit(400))).
+		Line().
+		Func().Id("newRequestValidationError").Params(Id("violations").Index().Id("validationViolation")).Op("*").Id("errRequestValidation").
+		Block(
+			Return(Op("&").Id("errRequestValidation").Values(Dict{
+				Id("TrKey"):      Lit("badRequest"),
+				Id("Data"):       Id("validationSummary").Call(Id("violations")),
+				Id("Violations"): Id("violations"),
+			})),
+		).
+		Line().
+		Func().Id("validationSummary").Params(Id("violations").Index().Id("validationViolation")).String().
+		Block(
+			Id("parts").Op(":=").Make(Index().String(), Lit(0), Len(Id("violations"))),
+			For(List(Id("_"), Id("violation")).Op(":=").Range().Id("violations")).Block(
+				Id("parts").Op("=").Append(Id("parts"), Id("violation").Dot("Field").Op("+").Lit(": ").Op("+").Id("violation").Dot("Message")),
+			),
+			Return(Lit("request validation failed: ").Op("+").Qual(PackageStrings, "Join").Call(Id("parts"), Lit("; "))),
+		)
+}
+
+// typeValidationFunc генерирует функцию проверки структуры или именованного слайса/map.
+func (r *transportRenderer) typeValidationFunc(typeGen *types.Generator, typeID string) (c Code) {
+
+	typ := r.project.Types[typeID]
+	var code []Code
+	switch typ.Kind {
+	case model.TypeKindStruct:
+		for _, field := range r.validatedStructFields(typ) {
+			jsonName := structFieldJSONName(field)
+			fieldCode := Id("fieldPath").Call(Id("field"), Lit(validationFieldName(jsonName, field.Name)))
+			if jsonName == "" && field.Name == model.TypeNameFromTypeID(r.project, field.TypeID) {
+				// Встроенная структура без json-имени разворачивается в родителя.
+				fieldCode = Id("field")
+			}
+			r.validateValueCode(&code, Id("value").Dot(field.Name), fieldCode, field.TypeRef, annotationRules(field.Annotations), 0)
+		}
+	case model.TypeKindArray:
+		r.validateItemsCode(&code, Id("value"), Id("field"), model.TypeRef{TypeID: typ.ArrayOfID, NumberOfPointers: typ.ElementPointers}, validationRules{}, 0, "i")
+	case model.TypeKindMap:
+		r.validateItemsCode(&code, Id("value"), Id("field"), *typ.MapValue, validationRules{}, 0, "key")
+	}
+	return Func().Id(r.validationIndex().funcNames[typeID]).
+		Params(Id("v").Op("*").Id("requestValidator"), Id("field").String(), Id("value").Add(typeGen.FieldType(typeID, 0, false))).
+		Block(code...)
+}
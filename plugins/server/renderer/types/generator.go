@@ -296,14 +296,28 @@ func (g *Generator) fieldTypeNamed(typ *model.Type, c *Statement) *Statement {
 		}
 		if typ.ImportAlias != "" && typ.ImportAlias != packageName {
 			g.srcFile.ImportAlias(typ.ImportPkgPath, typ.ImportAlias)
-			return c.Qual(typ.ImportPkgPath, typ.TypeName)
+		} else {
+			g.srcFile.ImportName(typ.ImportPkgPath, packageName)
+		}
+		if typ.GenericOf != "" {
+			return g.fieldTypeGeneric(typ, c)
 		}
-		g.srcFile.ImportName(typ.ImportPkgPath, packageName)
 		return c.Qual(typ.ImportPkgPath, typ.TypeName)
 	}
 	return c.Id(typ.TypeName)
 }
 
+// Экземпляр обобщённого типа сервер использует как есть (Page[User]): это тип из сигнатуры контракта.
+func (g *Generator) fieldTypeGeneric(typ *model.Type, c *Statement) *Statement {
+
+	originName := typ.GenericOf[strings.LastIndex(typ.GenericOf, ":")+1:]
+	typeArgs := make([]Code, 0, len(typ.TypeArgs))
+	for _, typeArg := range typ.TypeArgs {
+		typeArgs = append(typeArgs, g.FieldTypeFromTypeRef(typeArg, false))
+	}
+	return c.Qual(typ.ImportPkgPath, originName).Types(typeArgs...)
+}
+
 func (g *Generator) FuncDefinitionParams(vars []*model.Variable) *Statement {
 
 	c := &Statement{}
@@ -1020,7 +1020,7 @@ func (r *contractRenderer) httpRetCookies(method *model.Method) *Statement {
 		)
 		simpleCookieStmts := func(vExpr Code) []Code {
 			return []Code{
-				Id("cookie").Op(":=").Qual(PackageFiber, "Cookie").Values(Dict{
+				Id("cookie").Op(":=").Qual(r.httpPkg(), "Cookie").Values(Dict{
 					Id("Name"):  Lit(cookieName),
 					Id("Value"): r.resultToHeaderStringExpr(ret, vExpr),
 				}),
@@ -0,0 +1,448 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/common"
+	"tgp/internal/converter"
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+const (
+	tagEnums  = "enums"
+	tagFormat = "format"
+)
+
+// validationFormats — форматы, которые проверяются сгенерированным validFormat; остальные значения format игнорируются.
+var validationFormats = map[string]struct{}{
+	"uuid":      {},
+	"email":     {},
+	"date-time": {},
+	"date":      {},
+	"uri":       {},
+	"ipv4":      {},
+	"ipv6":      {},
+}
+
+// validationRules — правила одного значения из аннотаций required, enums и format.
+type validationRules struct {
+	required bool
+	enums    []string
+	format   string
+}
+
+func annotationRules(annotations tags.DocTags) (rules validationRules) {
+
+	if annotations == nil {
+		return
+	}
+	rules.required = annotations.IsSet(model.TagRequired)
+	if enums := annotations.Value(tagEnums, ""); enums != "" {
+		for _, value := range strings.Split(enums, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				rules.enums = append(rules.enums, value)
+			}
+		}
+	}
+	if format := annotations.Value(tagFormat, ""); format != "" {
+		if _, known := validationFormats[format]; known {
+			rules.format = format
+		}
+	}
+	return
+}
+
+func (rules validationRules) isEmpty() (empty bool) {
+
+	return !rules.required && len(rules.enums) == 0 && rules.format == ""
+}
+
+// validationIndex — типы проекта, значения которых требуют проверки, и имена их функций валидации.
+type validationIndex struct {
+	types     map[string]bool
+	funcNames map[string]string
+}
+
+func (r *baseRenderer) validationIndex() (idx *validationIndex) {
+
+	if r.validation != nil {
+		return r.validation
+	}
+	idx = &validationIndex{types: make(map[string]bool), funcNames: make(map[string]string)}
+	typeIDs := common.SortedKeys(r.project.Types)
+	for changed := true; changed; {
+		changed = false
+		for _, typeID := range typeIDs {
+			if !idx.types[typeID] && r.typeNeedsValidation(idx, r.project.Types[typeID]) {
+				idx.types[typeID] = true
+				changed = true
+			}
+		}
+	}
+	used := make(map[string]int)
+	for _, typeID := range typeIDs {
+		if !idx.types[typeID] || !r.typeHasValidationFunc(typeID) {
+			continue
+		}
+		typ := r.project.Types[typeID]
+		name := "validate" + toCamel(typ.PkgName) + toCamel(typ.TypeName)
+		if used[name]++; used[name] > 1 {
+			name += strconv.Itoa(used[name])
+		}
+		idx.funcNames[typeID] = name
+	}
+	r.validation = idx
+	return
+}
+
+func (r *baseRenderer) typeNeedsValidation(idx *validationIndex, typ *model.Type) (needed bool) {
+
+	if typ == nil {
+		return false
+	}
+	if len(typ.Enums) > 0 {
+		return true
+	}
+	switch typ.Kind {
+	case model.TypeKindAlias:
+		return idx.types[typ.AliasOf]
+	case model.TypeKindArray:
+		return idx.types[typ.ArrayOfID]
+	case model.TypeKindMap:
+		return typ.MapValue != nil && idx.refNeedsValidation(typ.MapValue)
+	case model.TypeKindStruct:
+		for _, field := range r.validatedStructFields(typ) {
+			if !annotationRules(field.Annotations).isEmpty() || idx.refNeedsValidation(&field.TypeRef) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (idx *validationIndex) refNeedsValidation(ref *model.TypeRef) (needed bool) {
+
+	if ref.MapValue != nil {
+		return idx.refNeedsValidation(ref.MapValue)
+	}
+	return idx.types[ref.TypeID]
+}
+
+// typeHasValidationFunc сообщает, проверяется ли тип отдельной функцией: структуры и именованные слайсы/map.
+// Именованные скалярные перечисления проверяются на месте, алиасы — через исходный тип.
+func (r *baseRenderer) typeHasValidationFunc(typeID string) (ok bool) {
+
+	typ, found := r.project.Types[typeID]
+	if !found {
+		return false
+	}
+	switch typ.Kind {
+	case model.TypeKindStruct, model.TypeKindArray, model.TypeKindMap:
+		return true
+	}
+	return false
+}
+
+// validatedStructFields возвращает поля структуры, доступные для проверки: экспортируемые и не исключённые из JSON.
+func (r *baseRenderer) validatedStructFields(typ *model.Type) (fields []*model.StructField) {
+
+	for _, field := range typ.StructFields {
+		if field.Name == "" || !unicode.IsUpper([]rune(field.Name)[0]) {
+			continue
+		}
+		if jsonName := structFieldJSONName(field); jsonName == "-" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return
+}
+
+func structFieldJSONName(field *model.StructField) (name string) {
+
+	if values := field.Tags["json"]; len(values) > 0 {
+		return values[0]
+	}
+	return
+}
+
+func (r *baseRenderer) resolveValidationType(typeID string) (typ *model.Type) {
+
+	typ = r.project.Types[typeID]
+	for typ != nil && typ.Kind == model.TypeKindAlias && typ.AliasOf != "" {
+		typ = r.project.Types[typ.AliasOf]
+	}
+	return
+}
+
+// validationKind возвращает базовый вид значения: для встроенных типов — сам typeID, для именованных — подлежащий вид.
+func (r *baseRenderer) validationKind(typeID string) (kind model.TypeKind) {
+
+	if converter.IsBuiltinTypeID(typeID) {
+		return model.TypeKind(typeID)
+	}
+	typ := r.resolveValidationType(typeID)
+	if typ == nil {
+		return
+	}
+	if typ.UnderlyingKind != "" {
+		return typ.UnderlyingKind
+	}
+	return typ.Kind
+}
+
+// methodHasValidation сообщает, генерируется ли для запроса метода проверка аргументов.
+func (r *baseRenderer) methodHasValidation(contract *model.Contract, method *model.Method) (ok bool) {
+
+	if model.MethodIsStream(r.project, contract, method) {
+		return false
+	}
+	if !model.MethodIsHTTP(r.project, contract, method) && !model.MethodIsJSONRPC(r.project, contract, method) {
+		return false
+	}
+	idx := r.validationIndex()
+	for _, arg := range argsWithoutContext(method) {
+		if !annotationRules(model.EffectiveVariable(method, arg).Annotations).isEmpty() || idx.refNeedsValidation(&arg.TypeRef) {
+			return true
+		}
+	}
+	return false
+}
+
+// validationFuncTypes возвращает отсортированные typeID, для которых нужны функции проверки, достижимые из аргументов проверяемых методов.
+func (r *baseRenderer) validationFuncTypes() (typeIDs []string) {
+
+	idx := r.validationIndex()
+	seen := make(map[string]bool)
+	var visitRef func(ref *model.TypeRef)
+	var visitType func(typeID string)
+	visitRef = func(ref *model.TypeRef) {
+		if ref.MapValue != nil {
+			visitRef(ref.MapValue)
+			return
+		}
+		visitType(ref.TypeID)
+	}
+	visitType = func(typeID string) {
+		if seen[typeID] || !idx.types[typeID] {
+			return
+		}
+		seen[typeID] = true
+		typ := r.project.Types[typeID]
+		switch typ.Kind {
+		case model.TypeKindAlias:
+			visitType(typ.AliasOf)
+		case model.TypeKindArray:
+			visitType(typ.ArrayOfID)
+		case model.TypeKindMap:
+			visitRef(typ.MapValue)
+		case model.TypeKindStruct:
+			for _, field := range r.validatedStructFields(typ) {
+				visitRef(&field.TypeRef)
+			}
+		}
+	}
+	for _, contract := range r.contractsSorted() {
+		if !model.ContractIsHTTPFamily(r.project, contract) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if !r.methodHasValidation(contract, method) {
+				continue
+			}
+			for _, arg := range argsWithoutContext(method) {
+				visitRef(&arg.TypeRef)
+			}
+		}
+	}
+	for _, typeID := range common.SortedKeys(seen) {
+		if _, ok := idx.funcNames[typeID]; ok {
+			typeIDs = append(typeIDs, typeID)
+		}
+	}
+	return
+}
+
+func (r *baseRenderer) hasValidatedMethods() (ok bool) {
+
+	for _, contract := range r.contractsSorted() {
+		if !model.ContractIsHTTPFamily(r.project, contract) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if r.methodHasValidation(contract, method) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateValueCode дописывает в out проверки значения value по ссылке на тип и правилам аннотаций.
+// Путь поля field — выражение типа string; depth задаёт суффикс переменных циклов.
+func (r *baseRenderer) validateValueCode(out *[]Code, value *Statement, field *Statement, ref model.TypeRef, rules validationRules, depth int) {
+
+	if ref.NumberOfPointers > 0 {
+		if rules.required {
+			*out = append(*out, Id("v").Dot("required").Call(field.Clone(), value.Clone().Op("!=").Nil()))
+		}
+		inner := ref
+		inner.NumberOfPointers--
+		innerRules := rules
+		innerRules.required = false
+		var code []Code
+		r.validateValueCode(&code, Op("*").Add(value.Clone()), field, inner, innerRules, depth)
+		if len(code) > 0 {
+			*out = append(*out, If(value.Clone().Op("!=").Nil()).Block(code...))
+		}
+		return
+	}
+
+	switch {
+	case ref.MapValue != nil:
+		if rules.required {
+			*out = append(*out, Id("v").Dot("required").Call(field.Clone(), Len(value.Clone()).Op(">").Lit(0)))
+		}
+		itemRules := rules
+		itemRules.required = false
+		r.validateItemsCode(out, value, field, *ref.MapValue, itemRules, depth, "key")
+	case ref.IsSlice || ref.ArrayLen > 0 || ref.IsEllipsis:
+		if rules.required && ref.ArrayLen == 0 {
+			*out = append(*out, Id("v").Dot("required").Call(field.Clone(), Len(value.Clone()).Op(">").Lit(0)))
+		}
+		itemRules := rules
+		itemRules.required = false
+		item := model.TypeRef{TypeID: ref.TypeID, NumberOfPointers: ref.ElementPointers}
+		r.validateItemsCode(out, value, field, item, itemRules, depth, "i")
+	default:
+		r.validateScalarCode(out, value, field, ref.TypeID, rules)
+	}
+}
+
+func (r *baseRenderer) validateItemsCode(out *[]Code, value *Statement, field *Statement, item model.TypeRef, rules validationRules, depth int, indexName string) {
+
+	if rules.isEmpty() && !r.validationIndex().refNeedsValidation(&item) {
+		return
+	}
+	suffix := ""
+	if depth > 0 {
+		suffix = strconv.Itoa(depth)
+	}
+	indexVar := indexName + suffix
+	itemVar := "item" + suffix
+	var code []Code
+	r.validateValueCode(&code, Id(itemVar), Id("indexPath").Call(field.Clone(), Id(indexVar)), item, rules, depth+1)
+	if len(code) > 0 {
+		*out = append(*out, For(List(Id(indexVar), Id(itemVar)).Op(":=").Range().Add(value.Clone())).Block(code...))
+	}
+}
+
+func (r *baseRenderer) validateScalarCode(out *[]Code, value *Statement, field *Statement, typeID string, rules validationRules) {
+
+	kind := r.validationKind(typeID)
+	if rules.required {
+		var isSet *Statement
+		switch kind {
+		case model.TypeKindString:
+			isSet = value.Clone().Op("!=").Lit("")
+		case model.TypeKindInterface, model.TypeKindAny, model.TypeKindError:
+			isSet = value.Clone().Op("!=").Nil()
+		case model.TypeKindArray, model.TypeKindMap:
+			isSet = Len(value.Clone()).Op(">").Lit(0)
+		default:
+			isSet = Id("isSet").Call(value.Clone())
+		}
+		*out = append(*out, Id("v").Dot("required").Call(field.Clone(), isSet))
+	}
+	if typ := r.resolveValidationType(typeID); typ != nil && len(typ.Enums) > 0 {
+		allowed := make([]string, 0, len(typ.Enums))
+		for _, enum := range typ.Enums {
+			allowed = append(allowed, enum.Value)
+		}
+		if code := enumCheckCode(value, field, kind, allowed); code != nil {
+			*out = append(*out, code)
+		}
+	}
+	if len(rules.enums) > 0 {
+		if code := enumCheckCode(value, field, kind, rules.enums); code != nil {
+			*out = append(*out, code)
+		}
+	}
+	if rules.format != "" && kind == model.TypeKindString {
+		formatValue := value.Clone()
+		if typeID != string(model.TypeKindString) {
+			formatValue = String().Call(value.Clone())
+		}
+		*out = append(*out, Id("v").Dot("format").Call(field.Clone(), Lit(rules.format), formatValue))
+	}
+	if funcName, ok := r.validationIndex().funcNames[r.aliasTargetID(typeID)]; ok {
+		*out = append(*out, Id(funcName).Call(Id("v"), field.Clone(), value.Clone()))
+	}
+}
+
+// aliasTargetID возвращает typeID исходного типа для цепочки алиасов или сам typeID.
+func (r *baseRenderer) aliasTargetID(typeID string) (targetID string) {
+
+	targetID = typeID
+	for typ := r.project.Types[targetID]; typ != nil && typ.Kind == model.TypeKindAlias && typ.AliasOf != ""; typ = r.project.Types[targetID] {
+		targetID = typ.AliasOf
+	}
+	return
+}
+
+// enumCheckCode генерирует вызов validateEnum; значения, не подходящие к виду типа, пропускаются.
+func enumCheckCode(value *Statement, field *Statement, kind model.TypeKind, allowed []string) (c Code) {
+
+	args := []Code{Id("v"), field.Clone(), value.Clone()}
+	for _, raw := range allowed {
+		if literal := enumLiteral(kind, raw); literal != nil {
+			args = append(args, literal)
+		}
+	}
+	if len(args) == 3 {
+		return nil
+	}
+	return Id("validateEnum").Call(args...)
+}
+
+func enumLiteral(kind model.TypeKind, raw string) (c Code) {
+
+	switch kind {
+	case model.TypeKindString:
+		return Lit(raw)
+	case model.TypeKindBool:
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return Lit(value)
+		}
+	case model.TypeKindInt, model.TypeKindInt8, model.TypeKindInt16, model.TypeKindInt32, model.TypeKindInt64,
+		model.TypeKindUint, model.TypeKindUint8, model.TypeKindUint16, model.TypeKindUint32, model.TypeKindUint64,
+		model.TypeKindByte, model.TypeKindRune:
+		if _, err := strconv.ParseInt(raw, 0, 64); err == nil {
+			return Op(raw)
+		}
+		if _, err := strconv.ParseUint(raw, 0, 64); err == nil {
+			return Op(raw)
+		}
+	case model.TypeKindFloat32, model.TypeKindFloat64:
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return Op(raw)
+		}
+	}
+	return nil
+}
+
+// validationFieldName возвращает имя поля в пути нарушения: имя из json-тега или имя поля/аргумента.
+func validationFieldName(jsonTag string, name string) (fieldName string) {
+
+	if jsonName, _, _ := strings.Cut(jsonTag, ","); jsonName != "" && jsonName != "-" {
+		return jsonName
+	}
+	return name
+}
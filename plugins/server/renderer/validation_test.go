@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func validationProject() (project *model.Project) {
+
+	return &model.Project{
+		ModulePath: "example",
+		Types: map[string]*model.Type{
+			"example/dto:Role": {
+				Kind:          model.TypeKindString,
+				TypeName:      "Role",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				Enums:         []*model.EnumValue{{Name: "RoleAdmin", Value: "admin"}, {Name: "RoleUser", Value: "user"}},
+			},
+			"example/dto:Address": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Address",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "City", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"city"}}, Annotations: tags.DocTags{model.TagRequired: ""}},
+				},
+			},
+			"example/dto:User": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "User",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}}, Annotations: tags.DocTags{"format": "uuid"}},
+					{Name: "Roles", TypeRef: model.TypeRef{TypeID: "example/dto:Role", IsSlice: true}, Tags: map[string][]string{"json": {"roles"}}},
+					{Name: "Home", TypeRef: model.TypeRef{TypeID: "example/dto:Address", NumberOfPointers: 1}, Tags: map[string][]string{"json": {"home"}}},
+					{Name: "Level", TypeRef: model.TypeRef{TypeID: "int"}, Tags: map[string][]string{"json": {"level"}}, Annotations: tags.DocTags{"enums": "1,2"}},
+					{Name: "Secret", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"-"}}, Annotations: tags.DocTags{model.TagRequired: ""}},
+				},
+			},
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:    "Users",
+				PkgPath: "example/contracts",
+				Annotations: tags.DocTags{
+					model.TagServerHTTP:    "",
+					model.TagServerJsonRPC: "",
+					model.TagHttpPrefix:    "api/v1",
+				},
+				Methods: []*model.Method{
+					{
+						Name: "Create",
+						Annotations: tags.DocTags{
+							model.TagHTTPMethod: "POST",
+							model.TagHttpPath:   "/users",
+							"user.required":     "",
+							"since.format":      "date-time",
+							"comment.format":    "password",
+							"mode.enums":        "fast,slow",
+						},
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+							{Name: "user", TypeRef: model.TypeRef{TypeID: "example/dto:User", NumberOfPointers: 1}},
+							{Name: "since", TypeRef: model.TypeRef{TypeID: "string"}},
+							{Name: "comment", TypeRef: model.TypeRef{TypeID: "string"}},
+							{Name: "mode", TypeRef: model.TypeRef{TypeID: "string"}},
+						},
+						Results: []*model.Variable{
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+					},
+					{
+						Name: "SetRole",
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+							{Name: "role", TypeRef: model.TypeRef{TypeID: "example/dto:Role"}},
+						},
+						Results: []*model.Variable{
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+					},
+					{
+						Name: "Ping",
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+							{Name: "note", TypeRef: model.TypeRef{TypeID: "string"}},
+						},
+						Results: []*model.Variable{
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readGenerated(t *testing.T, path string) (source string) {
+
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestRenderExchange_ValidateMethod(t *testing.T) {
+
+	project := validationProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber).RenderExchange(); err != nil {
+		t.Fatalf("RenderExchange: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "users-exchange.go"))
+
+	for _, want := range []string{
+		"func (r *requestUsersCreate) validate() (violations []validationViolation)",
+		`v.required("user", r.User != nil)`,
+		`validateDtoUser(v, "user", *r.User)`,
+		`v.format("since", "date-time", r.Since)`,
+		`validateEnum(v, "mode", r.Mode, "fast", "slow")`,
+		"func (r *requestUsersSetRole) validate()",
+		`validateEnum(v, "role", r.Role, "admin", "user")`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("exchange must contain %q:\n%s", want, source)
+		}
+	}
+	if strings.Contains(source, `"password"`) {
+		t.Fatalf("unknown formats must not be validated:\n%s", source)
+	}
+	if strings.Contains(source, "requestUsersPing) validate") {
+		t.Fatalf("requests without rules must not get validate():\n%s", source)
+	}
+}
+
+func TestRenderTransportValidation_TypeFuncs(t *testing.T) {
+
+	project := validationProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportValidation(); err != nil {
+		t.Fatalf("RenderTransportValidation: %v", err)
+	}
+	source := readGenerated(t, filepath.Join(dir, "validation.go"))
+
+	for _, want := range []string{
+		"type validationViolation struct",
+		"func validateEnum[T comparable](",
+		"func newRequestValidationError(violations []validationViolation) *errRequestValidation",
+		"func validateDtoAddress(v *requestValidator, field string, value dto.Address)",
+		`v.required(fieldPath(field, "city"), value.City != "")`,
+		"func validateDtoUser(v *requestValidator, field string, value dto.User)",
+		`v.format(fieldPath(field, "id"), "uuid", value.ID)`,
+		`validateEnum(v, indexPath(fieldPath(field, "roles"), i), item, "admin", "user")`,
+		`validateDtoAddress(v, fieldPath(field, "home"), *value.Home)`,
+		`validateEnum(v, fieldPath(field, "level"), value.Level, 1, 2)`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("validation.go must contain %q:\n%s", want, source)
+		}
+	}
+	if strings.Contains(source, "Secret") {
+		t.Fatalf("fields excluded from JSON must not be validated:\n%s", source)
+	}
+}
+
+func TestRenderTransportValidation_SkipsWithoutRules(t *testing.T) {
+
+	project := validationProject()
+	project.Contracts[0].Methods = project.Contracts[0].Methods[2:]
+	dir := t.TempDir()
+	if err := NewTransportRenderer(project, dir, TargetFiber).RenderTransportValidation(); err != nil {
+		t.Fatalf("RenderTransportValidation: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "validation.go")); !os.IsNotExist(err) {
+		t.Fatalf("validation.go must not be generated without rules, stat err = %v", err)
+	}
+}
+
+func TestRenderREST_ValidatesRequest(t *testing.T) {
+
+	project := validationProject()
+	dir := filepath.Join(t.TempDir(), "transport")
+	contractRenderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
+	if err := contractRenderer.RenderREST(); err != nil {
+		t.Fatalf("RenderREST: %v", err)
+	}
+	if err := contractRenderer.RenderJsonRPC(); err != nil {
+		t.Fatalf("RenderJsonRPC: %v", err)
+	}
+	rest := readGenerated(t, filepath.Join(dir, "users-rest.go"))
+	if !strings.Contains(rest, "if violations := request.validate(); len(violations) > 0 {") ||
+		!strings.Contains(rest, "return sendResponse(ftx, newRequestValidationError(violations))") {
+		t.Fatalf("REST handler must reject invalid requests with 400:\n%s", rest)
+	}
+	jsonRPC := readGenerated(t, filepath.Join(dir, "users-jsonrpc.go"))
+	if !strings.Contains(jsonRPC, "return makeErrorResponseJsonRPC(requestBase.ID, invalidParamsError, validationSummary(violations), violations)") {
+		t.Fatalf("JSON-RPC handler must reject invalid params with -32602:\n%s", jsonRPC)
+	}
+}
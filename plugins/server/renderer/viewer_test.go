@@ -46,7 +46,7 @@ func TestRenderViewer_RespectsLogValuer(t *testing.T) {
 	}
 	root := t.TempDir()
 	dir := filepath.Join(root, "transport")
-	renderer := NewContractRenderer(project, project.Contracts[0], dir)
+	renderer := NewContractRenderer(project, project.Contracts[0], dir, TargetFiber)
 	if err := renderer.RenderLogger(); err != nil {
 		t.Fatalf("RenderLogger: %v", err)
 	}
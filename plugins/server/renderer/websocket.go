@@ -25,11 +25,13 @@ func (r *contractRenderer) RenderWebSocket() (err error) {
 	srcFile.ImportName(PackageFmt, "fmt")
 	srcFile.ImportName(PackageStrings, "strings")
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
-	srcFile.ImportName(PackageFiberWebsocket, "websocket")
+	if !r.isNetHTTP() {
+		srcFile.ImportName(PackageFiberWebsocket, "websocket")
+	}
 	srcFile.ImportName(fmt.Sprintf("%s/stream", r.pkgPath(r.outDir)), "stream")
 
 	typeGen := types.NewGenerator(r.project, &srcFile)
-	srcFile.Type().Id("ws" + r.contract.Name + "Conn").Struct(Id("c").Op("*").Qual(PackageFiberWebsocket, "Conn"))
+	srcFile.Type().Id("ws" + r.contract.Name + "Conn").Struct(Id("c").Op("*").Add(r.wsConn()))
 	srcFile.Line().Add(r.wsReadJSON())
 	srcFile.Line().Add(r.wsWriteJSON())
 	srcFile.Line().Add(r.wsClose())
@@ -72,7 +74,7 @@ func (r *contractRenderer) wsServe() (c Code) {
 	streamPath := fmt.Sprintf("%s/stream", r.pkgPath(r.outDir))
 	headerNames, cookieNames, queryNames, pathNames := r.streamWSHTTPKeys()
 	return Func().Params(Id("http").Op("*").Id("http" + r.contract.Name)).Id("serveWS").
-		Params(Id("conn").Op("*").Qual(PackageFiberWebsocket, "Conn")).BlockFunc(func(bg *Group) {
+		Params(Id("conn").Op("*").Add(r.wsConn())).BlockFunc(func(bg *Group) {
 		bg.Id("handlers").Op(":=").Map(String()).Qual(streamPath, "Handler").Values(DictFunc(func(dg Dict) {
 			for _, method := range r.contract.Methods {
 				if !model.MethodIsWS(r.project, r.contract, method) {
@@ -100,7 +102,13 @@ func (r *contractRenderer) wsServe() (c Code) {
 		for _, name := range pathNames {
 			bg.Id("overlay").Index(Lit(name)).Op("=").Qual(PackageStrings, "Clone").Call(Id("conn").Dot("Params").Call(Lit(name)))
 		}
-		bg.Id("ctx").Op(":=").Qual(PackageContext, "WithValue").Call(Qual(PackageContext, "Background").Call(), Qual(streamPath, "KeyOverlay"), Id("overlay"))
+		if r.hasSecurity() {
+			bg.List(Id("authContext"), Id("ok")).Op(":=").Id("conn").Dot("Locals").Call(Lit("authContext")).Assert(Qual(PackageContext, "Context"))
+			bg.If(Op("!").Id("ok")).Block(
+				Id("authContext").Op("=").Qual(PackageContext, "Background").Call(),
+			)
+		}
+		bg.Id("ctx").Op(":=").Qual(PackageContext, "WithValue").Call(r.wsBaseContext(), Qual(streamPath, "KeyOverlay"), Id("overlay"))
 		bg.Id("session").Op(":=").Qual(streamPath, "NewSession").Call(
 			Op("&").Id("ws"+r.contract.Name+"Conn").Values(Dict{Id("c"): Id("conn")}),
 			Id("handlers"),
@@ -126,6 +134,7 @@ func (r *contractRenderer) wsHandler(srcFile *GoFile, typeGen *types.Generator,
 		).
 		Params(Id("result").Qual(PackageStdJSON, "RawMessage"), Id("err").Error()).
 		BlockFunc(func(bg *Group) {
+			bg.Add(r.wsAuthCheck(method))
 			bg.Var().Id("request").Id(requestStructName(r.contract.Name, method.Name))
 			bg.If(Len(Id("requestBase").Dot("Params")).Op(">").Lit(0)).Block(
 				If(Err().Op("=").Qual(PackageStdJSON, "Unmarshal").Call(Id("requestBase").Dot("Params"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
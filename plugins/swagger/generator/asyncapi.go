@@ -0,0 +1,475 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"tgp/internal/content"
+	"tgp/internal/model"
+	"tgp/internal/validate"
+	"tgp/plugins/swagger/types"
+)
+
+// streamMessages — идентификаторы сообщений stream-метода в components.messages (пусто — сообщения нет).
+type streamMessages struct {
+	open   string
+	chunk  string
+	upload string
+	result string
+}
+
+// GenerateAsyncAPI строит документ AsyncAPI 3 для Kafka-топиков и stream-методов WebSocket и SSE.
+// Схемы типов общие с OpenAPI и лежат в components.schemas.
+func GenerateAsyncAPI(project *model.Project, ifaces ...string) (doc types.AsyncAPI, err error) {
+
+	if err = validate.Project(project); err != nil {
+		return doc, fmt.Errorf("invalid project: %w", err)
+	}
+
+	for _, contract := range project.Contracts {
+		if err = validate.Contract(contract, project); err != nil {
+			return doc, fmt.Errorf("validate contract %q: %w", contract.Name, err)
+		}
+	}
+
+	gen := newGenerator(project)
+
+	doc.AsyncAPI = asyncAPIVersion
+	doc.Info.Title = model.GetAnnotationValue(project, nil, nil, nil, tagTitle, project.ModulePath)
+	doc.Info.Version = model.GetAnnotationValue(project, nil, nil, nil, tagAppVersion, defaultVersion)
+	doc.Info.Description = descriptionFromProject(project)
+	doc.Channels = make(map[string]types.AsyncAPIChannel)
+	doc.Operations = make(map[string]types.AsyncAPIOperation)
+	doc.Components.Messages = make(map[string]types.AsyncAPIMessage)
+
+	var hasWS, hasSSE bool
+	var contracts []*model.Contract
+	include, exclude := splitContractFilters(ifaces)
+	for _, contract := range model.ContractsSorted(project.Contracts) {
+		if !contractSelected(contract, include, exclude) {
+			continue
+		}
+		contracts = append(contracts, contract)
+		hasWS = hasWS || model.ContractHasWS(project, contract)
+		hasSSE = hasSSE || model.ContractHasSSE(project, contract)
+	}
+	wsServers, httpServers := asyncAPIServers(&doc, projectServers(project), hasWS, hasSSE)
+
+	for _, contract := range contracts {
+		if model.ContractIsKafka(project, contract) {
+			for _, method := range contract.Methods {
+				gen.asyncAPIKafkaMethod(&doc, contract, method)
+			}
+		}
+		gen.asyncAPIStreams(&doc, contract, wsServers, httpServers)
+	}
+
+	doc.Components.Schemas = gen.schemas
+
+	return
+}
+
+// asyncAPIServers переносит серверы проекта: для WebSocket — со схемой ws/wss, для SSE — http/https.
+// Адреса Kafka-брокеров зависят от окружения и в документ не попадают.
+func asyncAPIServers(doc *types.AsyncAPI, servers []types.Server, hasWS bool, hasSSE bool) (wsServers []types.AsyncAPIRef, httpServers []types.AsyncAPIRef) {
+
+	for i, server := range servers {
+		parsed, err := url.Parse(server.URL)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		secure := parsed.Scheme == "https" || parsed.Scheme == "wss"
+		var suffix string
+		if len(servers) > 1 {
+			suffix = "-" + strconv.Itoa(i+1)
+		}
+		add := func(name string, protocol string) (ref types.AsyncAPIRef) {
+			if doc.Servers == nil {
+				doc.Servers = make(map[string]types.AsyncAPIServer)
+			}
+			doc.Servers[name] = types.AsyncAPIServer{
+				Host:        parsed.Host,
+				Protocol:    protocol,
+				Pathname:    strings.TrimSuffix(parsed.Path, "/"),
+				Description: server.Description,
+			}
+			return types.AsyncAPIRef{Ref: serversPrefix + name}
+		}
+		if hasWS {
+			protocol := "ws"
+			if secure {
+				protocol = "wss"
+			}
+			wsServers = append(wsServers, add("ws"+suffix, protocol))
+		}
+		if hasSSE {
+			protocol := "http"
+			if secure {
+				protocol = "https"
+			}
+			httpServers = append(httpServers, add("http"+suffix, protocol))
+		}
+	}
+	return
+}
+
+// asyncAPIKafkaMethod описывает топик метода: канал, сообщение с ключом и заголовками записи и операцию send.
+func (g *generator) asyncAPIKafkaMethod(doc *types.AsyncAPI, contract *model.Contract, method *model.Method) {
+
+	topic := model.MethodKafkaTopic(g.project, contract, method)
+	messageArg, ok := model.MethodKafkaMessageArg(g.project, contract, method)
+	if topic == "" || !ok {
+		return
+	}
+
+	id := types.ToCamel(contract.Name) + types.ToCamel(method.Name)
+	codec := model.MethodKafkaCodec(g.project, contract, method)
+	message := types.AsyncAPIMessage{
+		Name:        id,
+		Title:       method.Name,
+		Summary:     methodSummary(method),
+		Description: descriptionFromMethod(method),
+		ContentType: kafkaContentType(codec),
+		Headers:     g.kafkaHeadersSchema(contract, method),
+		Payload:     g.kafkaPayloadSchema(contract, messageArg, codec),
+	}
+	if keyArg := methodArgByName(method, model.MethodKafkaKeyArg(g.project, contract, method)); keyArg != nil {
+		key := kafkaRecordFieldSchema(&keyArg.TypeRef)
+		message.Bindings = &types.AsyncAPIBindings{Kafka: &types.AsyncAPIKafkaBinding{Key: &key, BindingVersion: kafkaBindingVersion}}
+	}
+	doc.Components.Messages[id] = message
+
+	doc.Channels[id] = types.AsyncAPIChannel{
+		Address:     topic,
+		Description: g.kafkaChannelDescription(contract, method, topic),
+		Messages:    map[string]types.AsyncAPIRef{id: {Ref: componentsMessagesPrefix + id}},
+		Bindings:    &types.AsyncAPIBindings{Kafka: &types.AsyncAPIKafkaBinding{Topic: topic, BindingVersion: kafkaBindingVersion}},
+	}
+	doc.Operations[model.JsonRPCWireMethod(contract.Name, method.Name)] = types.AsyncAPIOperation{
+		Action:      "send",
+		Channel:     asyncAPIChannelRef(id),
+		Summary:     methodSummary(method),
+		Description: descriptionFromMethod(method),
+		Tags:        g.asyncAPITags(contract, method),
+		Messages:    []types.AsyncAPIRef{asyncAPIChannelMessageRef(id, id)},
+	}
+}
+
+// kafkaPayloadSchema — схема одной записи: срез сообщений публикуется записью на элемент, []byte и codec bytes — бинарные.
+func (g *generator) kafkaPayloadSchema(contract *model.Contract, messageArg *model.Variable, codec string) (schema *types.Schema) {
+
+	element, _ := model.TypeRefKafkaMessageElement(&messageArg.TypeRef)
+	if codec == model.KafkaCodecBytes || model.TypeRefIsByteSlice(&element) {
+		return &types.Schema{Type: "string", Format: "binary"}
+	}
+	if schema = g.typeRefToSchema(&element, messageArg.Annotations, contract.PkgPath, true); schema == nil {
+		schema = &types.Schema{}
+	}
+	return
+}
+
+func (g *generator) kafkaHeadersSchema(contract *model.Contract, method *model.Method) (schema *types.Schema) {
+
+	for _, item := range model.MethodKafkaHeaderItems(g.project, contract, method) {
+		arg := methodArgByName(method, item.Arg)
+		if arg == nil {
+			continue
+		}
+		if schema == nil {
+			schema = &types.Schema{Type: "object", Properties: make(types.Properties)}
+		}
+		schema.Properties[item.Key] = kafkaRecordFieldSchema(&arg.TypeRef)
+	}
+	return
+}
+
+// kafkaRecordFieldSchema — схема ключа или заголовка записи: строка, байты или их список для повторяющегося заголовка.
+func kafkaRecordFieldSchema(typeRef *model.TypeRef) (schema types.Schema) {
+
+	switch {
+	case model.TypeRefIsByteSlice(typeRef):
+		return types.Schema{Type: "string", Format: "binary"}
+	case model.TypeRefIsByteSliceSlice(typeRef):
+		return types.Schema{Type: "array", Items: &types.Schema{Type: "string", Format: "binary"}}
+	case typeRef.IsSlice:
+		return types.Schema{Type: "array", Items: &types.Schema{Type: "string"}}
+	default:
+		return types.Schema{Type: "string"}
+	}
+}
+
+func (g *generator) kafkaChannelDescription(contract *model.Contract, method *model.Method, topic string) (description string) {
+
+	var notes []string
+	if retries := model.MethodKafkaRetry(g.project, contract, method); retries > 0 {
+		notes = append(notes, fmt.Sprintf("Retry topics: %s … %s.", model.KafkaRetryTopic(topic, 1), model.KafkaRetryTopic(topic, retries)))
+	}
+	if dlq := model.MethodKafkaDLQ(g.project, contract, method); dlq != "" {
+		notes = append(notes, fmt.Sprintf("Dead letter topic: %s.", dlq))
+	}
+	return strings.Join(notes, " ")
+}
+
+// kafkaContentType — MIME записи по кодеку метода.
+func kafkaContentType(codec string) (contentType string) {
+
+	switch codec {
+	case model.KafkaCodecBytes:
+		return contentOctetStream
+	case model.KafkaCodecAvro:
+		return "application/vnd.apache.avro"
+	case model.KafkaCodecProto:
+		return "application/x-protobuf"
+	default:
+		return content.CanonicalMIME(codec)
+	}
+}
+
+// asyncAPIStreams описывает stream-методы контракта: один WebSocket-канал на контракт и SSE-канал на каждый server-stream метод.
+// Сообщения следуют потоковому профилю JSON-RPC: открытие с id, элементы $/stream, завершение клиента $/stream.end, отмена $/cancel.
+func (g *generator) asyncAPIStreams(doc *types.AsyncAPI, contract *model.Contract, wsServers []types.AsyncAPIRef, httpServers []types.AsyncAPIRef) {
+
+	contractName := types.ToCamel(contract.Name)
+	wsChannelID := contractName + "WebSocket"
+	endID := contractName + "StreamEnd"
+	cancelID := contractName + "Cancel"
+	var wsChannel *types.AsyncAPIChannel
+
+	for _, method := range contract.Methods {
+		isWS := model.MethodIsWS(g.project, contract, method)
+		isSSE := model.MethodIsSSE(g.project, contract, method)
+		if !isWS && !isSSE {
+			continue
+		}
+		messages := g.asyncAPIStreamMessages(doc, contract, method)
+		wireMethod := model.JsonRPCWireMethod(contract.Name, method.Name)
+		operation := types.AsyncAPIOperation{
+			Summary:     methodSummary(method),
+			Description: descriptionFromMethod(method),
+			Tags:        g.asyncAPITags(contract, method),
+		}
+
+		if isWS {
+			if wsChannel == nil {
+				wsChannel = &types.AsyncAPIChannel{
+					Address:     model.ContractWSPath(g.project, contract),
+					Title:       contract.Name,
+					Description: "WebSocket JSON-RPC 2.0 streaming profile: open with id and params; chunks use $/stream; client ends with $/stream.end; cancellation uses $/cancel.",
+					Servers:     wsServers,
+					Messages:    make(map[string]types.AsyncAPIRef),
+					Bindings:    &types.AsyncAPIBindings{WS: &types.AsyncAPIWSBinding{Method: "GET", BindingVersion: wsBindingVersion}},
+				}
+			}
+			for _, id := range []string{messages.open, messages.chunk, messages.upload, messages.result} {
+				if id != "" {
+					wsChannel.Messages[id] = types.AsyncAPIRef{Ref: componentsMessagesPrefix + id}
+				}
+			}
+
+			open := operation
+			open.Action = "receive"
+			open.Channel = asyncAPIChannelRef(wsChannelID)
+			open.Messages = []types.AsyncAPIRef{asyncAPIChannelMessageRef(wsChannelID, messages.open)}
+			replyChannel := asyncAPIChannelRef(wsChannelID)
+			open.Reply = &types.AsyncAPIReply{
+				Channel:  &replyChannel,
+				Messages: []types.AsyncAPIRef{asyncAPIChannelMessageRef(wsChannelID, messages.result)},
+			}
+			doc.Operations[wireMethod+".open"] = open
+
+			if messages.chunk != "" {
+				items := operation
+				items.Action = "send"
+				items.Channel = asyncAPIChannelRef(wsChannelID)
+				items.Messages = []types.AsyncAPIRef{asyncAPIChannelMessageRef(wsChannelID, messages.chunk)}
+				doc.Operations[wireMethod+".stream"] = items
+			}
+			if messages.upload != "" {
+				if _, found := doc.Components.Messages[endID]; !found {
+					doc.Components.Messages[endID] = jsonRPCNotificationMessage(endID, model.JSONRPCStreamEndMethod, "Client ends its stream of the request with this id.")
+				}
+				wsChannel.Messages[endID] = types.AsyncAPIRef{Ref: componentsMessagesPrefix + endID}
+				upload := operation
+				upload.Action = "receive"
+				upload.Channel = asyncAPIChannelRef(wsChannelID)
+				upload.Messages = []types.AsyncAPIRef{
+					asyncAPIChannelMessageRef(wsChannelID, messages.upload),
+					asyncAPIChannelMessageRef(wsChannelID, endID),
+				}
+				doc.Operations[wireMethod+".upload"] = upload
+			}
+		}
+
+		if isSSE {
+			sseChannelID := contractName + types.ToCamel(method.Name) + "SSE"
+			doc.Channels[sseChannelID] = types.AsyncAPIChannel{
+				Address:     model.MethodSSEPath(g.project, contract, method),
+				Title:       contract.Name + "." + method.Name,
+				Description: "Server-Sent Events JSON-RPC streaming profile: the POST body opens the stream; each event contains a $/stream notification; the final event is the JSON-RPC result.",
+				Servers:     httpServers,
+				Messages: map[string]types.AsyncAPIRef{
+					messages.open:   {Ref: componentsMessagesPrefix + messages.open},
+					messages.chunk:  {Ref: componentsMessagesPrefix + messages.chunk},
+					messages.result: {Ref: componentsMessagesPrefix + messages.result},
+				},
+			}
+			sse := operation
+			sse.Action = "receive"
+			sse.Channel = asyncAPIChannelRef(sseChannelID)
+			sse.Messages = []types.AsyncAPIRef{asyncAPIChannelMessageRef(sseChannelID, messages.open)}
+			replyChannel := asyncAPIChannelRef(sseChannelID)
+			sse.Reply = &types.AsyncAPIReply{
+				Channel: &replyChannel,
+				Messages: []types.AsyncAPIRef{
+					asyncAPIChannelMessageRef(sseChannelID, messages.chunk),
+					asyncAPIChannelMessageRef(sseChannelID, messages.result),
+				},
+			}
+			sse.Bindings = &types.AsyncAPIBindings{HTTP: &types.AsyncAPIHTTPBinding{Method: "POST", BindingVersion: httpBindingVersion}}
+			doc.Operations[wireMethod+".sse"] = sse
+		}
+	}
+
+	if wsChannel == nil {
+		return
+	}
+	doc.Components.Messages[cancelID] = jsonRPCNotificationMessage(cancelID, model.JSONRPCCancelMethod, "Client cancels the request with this id; the server answers with the final JSON-RPC response.")
+	wsChannel.Messages[cancelID] = types.AsyncAPIRef{Ref: componentsMessagesPrefix + cancelID}
+	doc.Channels[wsChannelID] = *wsChannel
+	doc.Operations[model.LowerCamel(contract.Name)+".cancel"] = types.AsyncAPIOperation{
+		Action:   "receive",
+		Channel:  asyncAPIChannelRef(wsChannelID),
+		Summary:  "Cancel a stream",
+		Messages: []types.AsyncAPIRef{asyncAPIChannelMessageRef(wsChannelID, cancelID)},
+	}
+}
+
+// asyncAPIStreamMessages регистрирует сообщения stream-метода: открытие, элементы в каждую сторону и итоговый ответ.
+func (g *generator) asyncAPIStreamMessages(doc *types.AsyncAPI, contract *model.Contract, method *model.Method) (messages streamMessages) {
+
+	prefix := types.ToCamel(contract.Name) + types.ToCamel(method.Name)
+	requestName := g.requestStructName(contract, method)
+	responseName := g.responseStructName(contract, method)
+	g.registerStruct(requestName, contract.PkgPath, method, streamNonChannelVariables(g.project, method.Args), contentJSON, true)
+	g.registerStruct(responseName, contract.PkgPath, method, streamNonChannelVariables(g.project, method.Results), contentJSON, false)
+
+	open := types.JSONRPCSchemaPerPath("params", g.toSchema(requestName))
+	open.Properties["method"] = types.Schema{Type: "string", Enum: []string{model.JsonRPCWireMethod(contract.Name, method.Name)}}
+	open.Required = append(open.Required, "id", "method")
+	messages.open = prefix + "Open"
+	doc.Components.Messages[messages.open] = types.AsyncAPIMessage{
+		Name:          messages.open,
+		Title:         method.Name,
+		Summary:       methodSummary(method),
+		Description:   descriptionFromMethod(method),
+		ContentType:   contentJSON,
+		Payload:       &open,
+		CorrelationID: &types.AsyncAPICorrelationID{Location: "$message.payload#/id"},
+	}
+
+	messages.result = prefix + "Result"
+	doc.Components.Messages[messages.result] = types.AsyncAPIMessage{
+		Name:        messages.result,
+		Description: "Final JSON-RPC response with the id of the open request.",
+		ContentType: contentJSON,
+		Payload: &types.Schema{OneOf: []types.Schema{
+			types.JSONRPCSchemaPerPath("result", g.toSchema(responseName)),
+			types.JSONRPCErrorSchema(),
+		}},
+		CorrelationID: &types.AsyncAPICorrelationID{Location: "$message.payload#/id"},
+	}
+
+	if _, element, ok := model.MethodStreamOutChan(g.project, method); ok {
+		messages.chunk = prefix + "Chunk"
+		doc.Components.Messages[messages.chunk] = g.streamChunkMessage(messages.chunk, contract, element, "Server stream item.")
+	}
+	if _, element, ok := model.MethodStreamInChan(g.project, method); ok {
+		messages.upload = prefix + "Upload"
+		doc.Components.Messages[messages.upload] = g.streamChunkMessage(messages.upload, contract, element, "Client stream item.")
+	}
+	return
+}
+
+// streamChunkMessage — notification $/stream: id открывающего запроса, порядковый номер и элемент канала.
+func (g *generator) streamChunkMessage(name string, contract *model.Contract, element *model.TypeRef, description string) (message types.AsyncAPIMessage) {
+
+	item := g.typeRefToSchema(element, nil, contract.PkgPath, false)
+	if item == nil {
+		item = &types.Schema{}
+	}
+	message = jsonRPCNotificationMessage(name, model.JSONRPCStreamMethod, description)
+	params := message.Payload.Properties["params"]
+	params.Properties["seq"] = types.Schema{Type: "integer"}
+	params.Properties["item"] = *item
+	params.Required = append(params.Required, "seq", "item")
+	message.Payload.Properties["params"] = params
+	return
+}
+
+// jsonRPCNotificationMessage — JSON-RPC notification потокового профиля с params.id открывающего запроса.
+func jsonRPCNotificationMessage(name string, method string, description string) (message types.AsyncAPIMessage) {
+
+	return types.AsyncAPIMessage{
+		Name:        name,
+		Description: description,
+		ContentType: contentJSON,
+		Payload: &types.Schema{
+			Type: "object",
+			Properties: types.Properties{
+				"jsonrpc": {Type: "string", Example: "2.0"},
+				"method":  {Type: "string", Enum: []string{method}},
+				"params": {
+					Type:       "object",
+					Properties: types.Properties{"id": {Type: "number"}},
+					Required:   []string{"id"},
+				},
+			},
+			Required: []string{"jsonrpc", "method", "params"},
+		},
+		CorrelationID: &types.AsyncAPICorrelationID{Location: "$message.payload#/params/id"},
+	}
+}
+
+func (g *generator) asyncAPITags(contract *model.Contract, method *model.Method) (out []types.Tag) {
+
+	serviceTags := g.serviceTags(contract, method)
+	for _, tag := range serviceTags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, types.Tag{Name: tag})
+		}
+	}
+	return
+}
+
+func asyncAPIChannelRef(channelID string) (ref types.AsyncAPIRef) {
+	return types.AsyncAPIRef{Ref: channelsPrefix + channelID}
+}
+
+func asyncAPIChannelMessageRef(channelID string, messageID string) (ref types.AsyncAPIRef) {
+	return types.AsyncAPIRef{Ref: channelsPrefix + channelID + "/messages/" + messageID}
+}
+
+func methodSummary(method *model.Method) (summary string) {
+
+	if method.Annotations != nil {
+		summary = method.Annotations.Value(tagSummary, "")
+	}
+	return
+}
+
+func methodArgByName(method *model.Method, name string) (arg *model.Variable) {
+
+	if name == "" {
+		return nil
+	}
+	for _, candidate := range method.Args {
+		if candidate.Name == name {
+			return candidate
+		}
+	}
+	return nil
+}
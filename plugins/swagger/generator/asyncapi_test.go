@@ -0,0 +1,267 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func asyncAPITestProject() (project *model.Project) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Annotations: tags.DocTags{
+			tagServers: "https://api.example.com/;prod",
+		},
+		Types: map[string]*model.Type{
+			"example/dto:Order": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Order",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}}},
+				},
+			},
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:        "OrderEvents",
+				ID:          "OrderEvents",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagKafka: ""},
+				Methods: []*model.Method{
+					{
+						Name: "Created",
+						Annotations: tags.DocTags{
+							tagSummary:            "Order created",
+							model.TagKafkaTopic:   "orders.created",
+							model.TagKafkaKey:     "key",
+							model.TagKafkaHeaders: "traceID|x-trace-id,tags|x-tag",
+							model.TagKafkaRetry:   "2",
+							model.TagKafkaDLQ:     "orders.created.dlq",
+						},
+						Args: []*model.Variable{
+							ctx,
+							{Name: "key", TypeRef: model.TypeRef{TypeID: "string"}},
+							{Name: "traceID", TypeRef: model.TypeRef{TypeID: "string"}},
+							{Name: "tags", TypeRef: model.TypeRef{TypeID: "string", IsSlice: true}},
+							{Name: "orders", TypeRef: model.TypeRef{TypeID: "example/dto:Order", IsEllipsis: true}},
+						},
+						Results: []*model.Variable{errResult},
+					},
+					{
+						Name:        "Raw",
+						Annotations: tags.DocTags{model.TagKafkaTopic: "orders.raw", model.TagKafkaCodec: model.KafkaCodecBytes},
+						Args:        []*model.Variable{ctx, {Name: "payload", TypeRef: model.TypeRef{TypeID: "byte", IsSlice: true}}},
+						Results:     []*model.Variable{errResult},
+					},
+				},
+			},
+			{
+				Name:    "Live",
+				ID:      "Live",
+				PkgPath: "example/contracts",
+				Annotations: tags.DocTags{
+					model.TagServerWS:   "",
+					model.TagServerSSE:  "",
+					model.TagHttpPrefix: "api/v1",
+				},
+				Methods: []*model.Method{
+					{
+						Name:        "Chat",
+						Annotations: tags.DocTags{model.TagStream: model.StreamModeBidi},
+						Args: []*model.Variable{
+							ctx,
+							{Name: "room", TypeRef: model.TypeRef{TypeID: "string"}},
+							{Name: "in", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "string"}, ChanDirection: 2}},
+						},
+						Results: []*model.Variable{
+							{Name: "out", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "string"}, ChanDirection: 2}},
+							errResult,
+						},
+					},
+					{
+						Name:        "Watch",
+						Annotations: tags.DocTags{model.TagStream: model.StreamModeServer},
+						Args:        []*model.Variable{ctx, {Name: "symbol", TypeRef: model.TypeRef{TypeID: "string"}}},
+						Results: []*model.Variable{
+							{Name: "orders", TypeRef: model.TypeRef{ChanOf: &model.TypeRef{TypeID: "example/dto:Order"}, ChanDirection: 2}},
+							{Name: "count", TypeRef: model.TypeRef{TypeID: "int"}},
+							errResult,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateAsyncAPI_kafkaChannels(t *testing.T) {
+
+	doc, err := GenerateAsyncAPI(asyncAPITestProject())
+	if err != nil {
+		t.Fatalf("GenerateAsyncAPI: %v", err)
+	}
+	if doc.AsyncAPI != asyncAPIVersion {
+		t.Fatalf("asyncapi = %q", doc.AsyncAPI)
+	}
+
+	channel, ok := doc.Channels["OrderEventsCreated"]
+	if !ok || channel.Address != "orders.created" || channel.Bindings.Kafka.Topic != "orders.created" {
+		t.Fatalf("kafka channel: %+v", channel)
+	}
+	if !strings.Contains(channel.Description, "orders.created.retry.1 … orders.created.retry.2") || !strings.Contains(channel.Description, "orders.created.dlq") {
+		t.Fatalf("kafka channel description: %q", channel.Description)
+	}
+
+	message := doc.Components.Messages["OrderEventsCreated"]
+	if message.ContentType != contentJSON || message.Summary != "Order created" {
+		t.Fatalf("kafka message: %+v", message)
+	}
+	if message.Payload == nil || message.Payload.Ref != componentsSchemasPrefix+"dto.Order" {
+		t.Fatalf("payload must reference the element of the variadic message arg: %+v", message.Payload)
+	}
+	if message.Bindings == nil || message.Bindings.Kafka.Key.Type != "string" {
+		t.Fatalf("key binding: %+v", message.Bindings)
+	}
+	if message.Headers.Properties["x-trace-id"].Type != "string" || message.Headers.Properties["x-tag"].Type != "array" {
+		t.Fatalf("headers: %+v", message.Headers)
+	}
+
+	raw := doc.Components.Messages["OrderEventsRaw"]
+	if raw.ContentType != contentOctetStream || raw.Payload.Format != "binary" || raw.Bindings != nil || raw.Headers != nil {
+		t.Fatalf("bytes message: %+v", raw)
+	}
+
+	operation := doc.Operations["orderEvents.created"]
+	if operation.Action != "send" || operation.Channel.Ref != "#/channels/OrderEventsCreated" {
+		t.Fatalf("kafka operation: %+v", operation)
+	}
+	if len(operation.Messages) != 1 || operation.Messages[0].Ref != "#/channels/OrderEventsCreated/messages/OrderEventsCreated" {
+		t.Fatalf("kafka operation messages: %+v", operation.Messages)
+	}
+}
+
+func TestGenerateAsyncAPI_streamProtocol(t *testing.T) {
+
+	doc, err := GenerateAsyncAPI(asyncAPITestProject())
+	if err != nil {
+		t.Fatalf("GenerateAsyncAPI: %v", err)
+	}
+
+	if doc.Servers["ws"].Protocol != "wss" || doc.Servers["http"].Protocol != "https" || doc.Servers["ws"].Host != "api.example.com" {
+		t.Fatalf("servers: %+v", doc.Servers)
+	}
+
+	ws, ok := doc.Channels["LiveWebSocket"]
+	if !ok || ws.Address != "/api/v1/ws/live" || ws.Bindings.WS == nil {
+		t.Fatalf("ws channel: %+v", ws)
+	}
+	for _, id := range []string{"LiveChatOpen", "LiveChatChunk", "LiveChatUpload", "LiveChatResult", "LiveWatchOpen", "LiveStreamEnd", "LiveCancel"} {
+		if _, found := ws.Messages[id]; !found {
+			t.Fatalf("ws channel missing message %s: %+v", id, ws.Messages)
+		}
+	}
+
+	open := doc.Operations["live.chat.open"]
+	if open.Action != "receive" || open.Reply == nil || open.Reply.Messages[0].Ref != "#/channels/LiveWebSocket/messages/LiveChatResult" {
+		t.Fatalf("open operation: %+v", open)
+	}
+	if doc.Operations["live.chat.stream"].Action != "send" || doc.Operations["live.chat.upload"].Action != "receive" {
+		t.Fatalf("chunk operations: %+v", doc.Operations)
+	}
+	if _, found := doc.Operations["live.watch.upload"]; found {
+		t.Fatalf("server stream must not accept uploads")
+	}
+	if len(doc.Operations["live.cancel"].Messages) != 1 {
+		t.Fatalf("cancel operation: %+v", doc.Operations["live.cancel"])
+	}
+
+	openPayload := doc.Components.Messages["LiveChatOpen"].Payload
+	if openPayload.Properties["method"].Enum[0] != "live.chat" || openPayload.Properties["params"].Ref != componentsSchemasPrefix+"LiveChatRequest" {
+		t.Fatalf("open payload: %+v", openPayload)
+	}
+	chunk := doc.Components.Messages["LiveWatchChunk"].Payload
+	if chunk.Properties["method"].Enum[0] != model.JSONRPCStreamMethod || chunk.Properties["params"].Properties["item"].Ref != componentsSchemasPrefix+"dto.Order" {
+		t.Fatalf("chunk payload: %+v", chunk)
+	}
+	if doc.Components.Messages["LiveCancel"].Payload.Properties["method"].Enum[0] != model.JSONRPCCancelMethod {
+		t.Fatalf("cancel payload: %+v", doc.Components.Messages["LiveCancel"].Payload)
+	}
+	if _, found := doc.Components.Schemas["LiveWatchResponse"].Properties["orders"]; found {
+		t.Fatalf("final result must not contain the stream channel: %+v", doc.Components.Schemas["LiveWatchResponse"])
+	}
+
+	sse, ok := doc.Channels["LiveWatchSSE"]
+	if !ok || sse.Address != "/api/v1/sse/live/watch" || sse.Servers[0].Ref != "#/servers/http" {
+		t.Fatalf("sse channel: %+v", sse)
+	}
+	if op := doc.Operations["live.watch.sse"]; op.Bindings.HTTP.Method != "POST" || len(op.Reply.Messages) != 2 {
+		t.Fatalf("sse operation: %+v", op)
+	}
+	if _, found := doc.Channels["LiveChatSSE"]; found {
+		t.Fatalf("bidi method must not get an SSE channel")
+	}
+}
+
+func TestGenerateAsyncAPI_refsResolve(t *testing.T) {
+
+	doc, err := GenerateAsyncAPI(asyncAPITestProject())
+	if err != nil {
+		t.Fatalf("GenerateAsyncAPI: %v", err)
+	}
+	raw, err := doc.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	var tree map[string]any
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	var walk func(node any)
+	walk = func(node any) {
+		switch value := node.(type) {
+		case map[string]any:
+			if ref, ok := value["$ref"].(string); ok {
+				if !asyncAPIPointerExists(tree, ref) {
+					t.Fatalf("unresolved $ref %q", ref)
+				}
+			}
+			for _, child := range value {
+				walk(child)
+			}
+		case []any:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(tree)
+
+	if _, err = doc.ToYAML(); err != nil {
+		t.Fatalf("ToYAML: %v", err)
+	}
+}
+
+func asyncAPIPointerExists(tree map[string]any, ref string) (ok bool) {
+
+	var node any = tree
+	for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, isObject := node.(map[string]any)
+		if !isObject {
+			return false
+		}
+		if node, ok = object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -15,13 +15,26 @@ const (
 	tagServers               = "servers"
 	tagSwaggerTags           = "swaggerTags"
 	tagDeprecated            = "deprecated"
+	tagExample               = "example"
 	tagHttpResponse          = "http-response"
+	tagWebhook               = "webhook"
 	typeIDIOReader           = "io:Reader"
 	typeIDIOReadCloser       = "io:ReadCloser"
 	contentMultipartFormData = "multipart/form-data"
 	contentOctetStream       = "application/octet-stream"
 	openAPIVersion           = "3.0.0"
+	openAPI31Version         = "3.1.0"
+	openRPCVersion           = "1.3.2"
+	asyncAPIVersion          = "3.0.0"
+	kafkaBindingVersion      = "0.5.0"
+	wsBindingVersion         = "0.1.0"
+	httpBindingVersion       = "0.3.0"
+	jsonRPCInternalError     = -32603
 	defaultVersion           = "1.0.0"
 	componentsSchemasPrefix  = "#/components/schemas/"
+	problemDetailsSchemaName = "ProblemDetails"
+	componentsMessagesPrefix = "#/components/messages/"
+	channelsPrefix           = "#/channels/"
+	serversPrefix            = "#/servers/"
 	responseKeyDefault       = "default"
 )
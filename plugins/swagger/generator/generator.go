@@ -29,6 +29,15 @@ func newGenerator(project *model.Project) (gen *generator) {
 }
 
 func GenerateDoc(project *model.Project, ifaces ...string) (swaggerDoc types.Object, err error) {
+	return generateDoc(project, openAPIVersion, ifaces)
+}
+
+// GenerateDoc31 строит документ OpenAPI 3.1: схемы в семантике JSON Schema 2020-12 и webhooks контрактов с @tg webhook.
+func GenerateDoc31(project *model.Project, ifaces ...string) (swaggerDoc types.Object, err error) {
+	return generateDoc(project, openAPI31Version, ifaces)
+}
+
+func generateDoc(project *model.Project, version string, ifaces []string) (swaggerDoc types.Object, err error) {
 
 	if err = validate.Project(project); err != nil {
 		return swaggerDoc, fmt.Errorf("invalid project: %w", err)
@@ -42,33 +51,19 @@ func GenerateDoc(project *model.Project, ifaces ...string) (swaggerDoc types.Obj
 
 	gen := newGenerator(project)
 
-	swaggerDoc.OpenAPI = openAPIVersion
+	swaggerDoc.OpenAPI = version
 	swaggerDoc.Info.Title = model.GetAnnotationValue(project, nil, nil, nil, tagTitle, project.ModulePath)
 	swaggerDoc.Info.Version = model.GetAnnotationValue(project, nil, nil, nil, tagAppVersion, defaultVersion)
 	swaggerDoc.Info.Description = descriptionFromProject(project)
 	swaggerDoc.Paths = make(map[string]types.Path)
 
-	var servers string
-	if servers = model.GetAnnotationValue(project, nil, nil, nil, tagServers, ""); servers != "" {
-		serverList := strings.Split(servers, "|")
-		for _, server := range serverList {
-			serverValues := strings.Split(server, ";")
-			serverURL := serverValues[0]
-			var serverDesc string
-			if len(serverValues) > 1 {
-				serverDesc = serverValues[1]
-			}
-			swaggerDoc.Servers = append(swaggerDoc.Servers, types.Server{
-				URL:         serverURL,
-				Description: serverDesc,
-			})
-		}
-	}
+	swaggerDoc.Servers = projectServers(project)
 
 	securityValue := model.GetAnnotationValue(project, nil, nil, nil, tagSecurity, "")
 	if securityValue != "" {
 		swaggerDoc.Security, swaggerDoc.Components.SecuritySchemes = parseSecurityAnnotations(securityValue)
 	}
+	swaggerDoc.Components.SecuritySchemes = appendSecuritySchemes(project, swaggerDoc.Components.SecuritySchemes)
 
 	contracts := model.ContractsSorted(project.Contracts)
 	paths := gen.generatePaths(contracts, ifaces)
@@ -81,8 +76,38 @@ func GenerateDoc(project *model.Project, ifaces ...string) (swaggerDoc types.Obj
 		swaggerDoc.Tags = append(swaggerDoc.Tags, types.Tag{Name: name, Description: tagDescs[name]})
 	}
 
+	if version == openAPI31Version {
+		swaggerDoc.Webhooks = gen.generateWebhooks(contracts, ifaces)
+	}
+
 	swaggerDoc.Components.Schemas = gen.schemas
 
+	if version == openAPI31Version {
+		toOpenAPI31(&swaggerDoc)
+	}
+
+	return
+}
+
+// projectServers разбирает аннотацию пакета servers в формате «url;описание|url;описание».
+func projectServers(project *model.Project) (servers []types.Server) {
+
+	raw := model.GetAnnotationValue(project, nil, nil, nil, tagServers, "")
+	if raw == "" {
+		return
+	}
+	for _, server := range strings.Split(raw, "|") {
+		serverValues := strings.Split(server, ";")
+		serverURL := serverValues[0]
+		var serverDesc string
+		if len(serverValues) > 1 {
+			serverDesc = serverValues[1]
+		}
+		servers = append(servers, types.Server{
+			URL:         serverURL,
+			Description: serverDesc,
+		})
+	}
 	return
 }
 
@@ -90,7 +115,7 @@ func parseSecurityAnnotations(raw string) (security []types.Security, schemes ty
 
 	schemes = make(types.SecuritySchemes)
 
-	for _, token := range splitSecurityTokens(raw) {
+	for _, token := range model.SplitSecurityTokens(raw) {
 		name, scheme := buildSecurityScheme(token)
 		if name == "" {
 			continue
@@ -103,43 +128,41 @@ func parseSecurityAnnotations(raw string) (security []types.Security, schemes ty
 	return
 }
 
-func splitSecurityTokens(raw string) (tokens []string) {
-
-	var current string
+// appendSecuritySchemes добавляет к схемам документа схемы @tg security контрактов и методов.
+func appendSecuritySchemes(project *model.Project, schemes types.SecuritySchemes) (merged types.SecuritySchemes) {
 
-	for _, part := range strings.Split(raw, ",") {
-		trimmed := strings.TrimSpace(part)
-		if trimmed == "" {
-			continue
-		}
-
-		isNew := false
-		if index := strings.Index(trimmed, ":"); index > 0 {
-			kind := strings.ToLower(strings.TrimSpace(trimmed[:index]))
-			switch kind {
-			case "http", "apikey", "openid", "oauth2":
-				isNew = true
-			}
+	merged = schemes
+	for _, contract := range project.Contracts {
+		values := []string{contract.Annotations[tagSecurity]}
+		for _, method := range contract.Methods {
+			values = append(values, method.Annotations[tagSecurity])
 		}
-
-		switch {
-		case isNew:
-			if current != "" {
-				tokens = append(tokens, current)
+		for _, value := range values {
+			_, found := parseSecurityAnnotations(value)
+			for name, scheme := range found {
+				if merged == nil {
+					merged = make(types.SecuritySchemes)
+				}
+				merged[name] = scheme
 			}
-			current = trimmed
-		case current == "":
-			current = trimmed
-		default:
-			current += "," + trimmed
 		}
 	}
+	return
+}
 
-	if current != "" {
-		tokens = append(tokens, current)
-	}
+// operationSecurity — security операции, если @tg security метода или контракта отличается от пакета;
+// для @tg security=none — пустой список (операция без аутентификации).
+func (g *generator) operationSecurity(contract *model.Contract, method *model.Method) (security *[]types.Security) {
 
-	return
+	value := model.GetAnnotationValue(g.project, contract, method, nil, tagSecurity, "")
+	if value == model.GetAnnotationValue(g.project, nil, nil, nil, tagSecurity, "") {
+		return nil
+	}
+	list, _ := parseSecurityAnnotations(value)
+	if list == nil {
+		list = []types.Security{}
+	}
+	return &list
 }
 
 func buildSecurityScheme(token string) (name string, scheme types.SecurityScheme) {
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+	"tgp/plugins/swagger/types"
+)
+
+func genericsTestProject() (project *model.Project) {
+
+	const (
+		userID = "example/dto:User"
+		pageID = "example/dto:Page_User"
+		pairID = "example/dto:Pair_String_PtrUserList_7cf70d12"
+	)
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Types: map[string]*model.Type{
+			userID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "User",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"name"}}},
+				},
+			},
+			pageID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Page_User",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				GenericOf:     "example/dto:Page",
+				TypeArgs:      []*model.TypeRef{{TypeID: userID}},
+				StructFields: []*model.StructField{
+					{Name: "Items", TypeRef: model.TypeRef{TypeID: userID, IsSlice: true}, Tags: map[string][]string{"json": {"items"}}},
+					{Name: "Total", TypeRef: model.TypeRef{TypeID: "int"}, Tags: map[string][]string{"json": {"total"}}},
+				},
+			},
+			pairID: {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Pair_String_PtrUserList_7cf70d12",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				GenericOf:     "example/dto:Pair",
+				TypeArgs:      []*model.TypeRef{{TypeID: "string"}, {TypeID: userID, IsSlice: true, ElementPointers: 1}},
+				StructFields: []*model.StructField{
+					{Name: "Key", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"key"}}},
+					{Name: "Value", TypeRef: model.TypeRef{TypeID: userID, IsSlice: true, ElementPointers: 1}, Tags: map[string][]string{"json": {"value"}}},
+				},
+			},
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:        "Users",
+				ID:          "Users",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: ""},
+				Methods: []*model.Method{
+					{
+						Name:        "List",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "POST", model.TagHttpPath: "/users"},
+						Args:        []*model.Variable{ctx, {Name: "filter", TypeRef: model.TypeRef{TypeID: pageID}}},
+						Results:     []*model.Variable{{Name: "index", TypeRef: model.TypeRef{TypeID: pairID}}, errResult},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateDoc_genericInstancesAreSchemas(t *testing.T) {
+
+	for name, generate := range map[string]func(project *model.Project, ifaces ...string) (types.Object, error){
+		"3.0": GenerateDoc,
+		"3.1": GenerateDoc31,
+	} {
+		doc, err := generate(genericsTestProject())
+		if err != nil {
+			t.Fatalf("%s: generate: %v", name, err)
+		}
+		page, ok := doc.Components.Schemas["dto.Page_User"]
+		if !ok {
+			t.Fatalf("%s: Page[User] instance must be a component schema: %v", name, doc.Components.Schemas)
+		}
+		if items := page.Properties["items"]; items.Items == nil || items.Items.Ref != componentsSchemasPrefix+"dto.User" {
+			t.Fatalf("%s: Page_User.items must reference dto.User: %+v", name, items)
+		}
+		if _, ok = doc.Components.Schemas["dto.Pair_String_PtrUserList_7cf70d12"]; !ok {
+			t.Fatalf("%s: hashed instance name must be a component schema: %v", name, doc.Components.Schemas)
+		}
+		request := doc.Components.Schemas["UsersListRequest"]
+		if filter := request.Properties["filter"]; filter.Ref != componentsSchemasPrefix+"dto.Page_User" {
+			t.Fatalf("%s: request argument must reference dto.Page_User: %+v", name, filter)
+		}
+	}
+}
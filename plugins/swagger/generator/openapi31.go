@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"tgp/internal/model"
+	"tgp/plugins/swagger/types"
+)
+
+// generateWebhooks описывает методы контрактов с @tg webhook как исходящие POST-запросы сервиса к подписчику.
+// Ключ — wire-имя метода, тело — аргументы метода, ответ — его результаты.
+func (g *generator) generateWebhooks(contracts []*model.Contract, ifaces []string) (webhooks map[string]types.Path) {
+
+	include, exclude := splitContractFilters(ifaces)
+	for _, contract := range contracts {
+		if !model.IsAnnotationSet(g.project, contract, nil, nil, tagWebhook) {
+			continue
+		}
+		if !contractSelected(contract, include, exclude) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if webhooks == nil {
+				webhooks = make(map[string]types.Path)
+			}
+			webhooks[model.JsonRPCWireMethod(contract.Name, method.Name)] = types.Path{Post: g.webhookOperation(contract, method)}
+		}
+	}
+	return
+}
+
+func (g *generator) webhookOperation(contract *model.Contract, method *model.Method) (operation *types.Operation) {
+
+	requestName := g.requestStructName(contract, method)
+	g.registerStruct(requestName, contract.PkgPath, method, method.Args, contentJSON, true)
+	operation = &types.Operation{
+		OperationID: types.ToCamel(contract.Name) + types.ToCamel(method.Name) + "Webhook",
+		Summary:     methodSummary(method),
+		Description: descriptionFromMethod(method),
+		Tags:        g.serviceTags(contract, method),
+		Deprecated:  model.IsAnnotationSet(g.project, contract, method, nil, tagDeprecated),
+		RequestBody: &types.RequestBody{
+			Description: requestBodyDescription(method),
+			Content:     types.Content{contentJSON: types.Media{Schema: g.toSchema(requestName)}},
+		},
+		Responses: types.Responses{"200": {Description: "Webhook accepted"}},
+	}
+	for _, result := range method.Results {
+		if result.TypeID == "error" {
+			continue
+		}
+		responseName := g.responseStructName(contract, method)
+		g.registerStruct(responseName, contract.PkgPath, method, method.Results, contentJSON, false)
+		operation.Responses["200"] = types.Response{
+			Description: "Webhook accepted",
+			Content:     types.Content{contentJSON: types.Media{Schema: g.toSchema(responseName)}},
+		}
+		break
+	}
+	return
+}
+
+// toOpenAPI31 переводит документ в OpenAPI 3.1: схемы получают семантику JSON Schema 2020-12.
+func toOpenAPI31(doc *types.Object) {
+
+	for name, schema := range doc.Components.Schemas {
+		doc.Components.Schemas[name] = schema31(schema, "")
+	}
+	for _, paths := range []map[string]types.Path{doc.Paths, doc.Webhooks} {
+		for _, path := range paths {
+			for _, operation := range []*types.Operation{path.Get, path.Post, path.Patch, path.Put, path.Delete, path.Options} {
+				operation31(operation)
+			}
+		}
+	}
+}
+
+func operation31(operation *types.Operation) {
+
+	if operation == nil {
+		return
+	}
+	for i := range operation.Parameters {
+		operation.Parameters[i].Schema = schema31(operation.Parameters[i].Schema, "")
+	}
+	if operation.RequestBody != nil {
+		content31(operation.RequestBody.Content)
+	}
+	for _, response := range operation.Responses {
+		content31(response.Content)
+		for name, header := range response.Headers {
+			header.Schema = schema31(header.Schema, "")
+			response.Headers[name] = header
+		}
+	}
+}
+
+func content31(content types.Content) {
+
+	for mediaType, media := range content {
+		media.Schema = schema31(media.Schema, mediaType)
+		for name, part := range media.Schema.Properties {
+			if encoding, ok := media.Encoding[name]; ok && part.ContentMediaType != "" && encoding.ContentType != "" {
+				part.ContentMediaType = encoding.ContentType
+				media.Schema.Properties[name] = part
+			}
+		}
+		content[mediaType] = media
+	}
+}
+
+// schema31 переводит схему OpenAPI 3.0 в JSON Schema 2020-12:
+// nullable — в type: [T, "null"] (с null в enum), описание рядом с $ref без allOf, example — в examples, enum из одного значения — в const,
+// бинарные строки — в contentMediaType (тип содержимого тела или application/octet-stream), base64 — в contentEncoding.
+func schema31(schema types.Schema, mediaType string) (out types.Schema) {
+
+	out = schema
+	if schema.Properties != nil {
+		out.Properties = make(types.Properties, len(schema.Properties))
+		for name, property := range schema.Properties {
+			out.Properties[name] = schema31(property, "")
+		}
+	}
+	if schema.Items != nil {
+		items := schema31(*schema.Items, "")
+		out.Items = &items
+	}
+	switch additional := schema.AdditionalProperties.(type) {
+	case *types.Schema:
+		converted := schema31(*additional, "")
+		out.AdditionalProperties = &converted
+	case types.Schema:
+		out.AdditionalProperties = schema31(additional, "")
+	}
+	out.OneOf = schemas31(schema.OneOf)
+	out.AllOf = schemas31(schema.AllOf)
+
+	if out.Example != nil {
+		out.Examples = []any{out.Example}
+		out.Example = nil
+	}
+	if len(out.Enum) == 1 && !out.Nullable {
+		out.Const = out.Enum[0]
+		out.Enum = nil
+	}
+	if out.Type == "string" {
+		switch out.Format {
+		case "binary":
+			out.Format = ""
+			out.ContentMediaType = mediaType
+			if out.ContentMediaType == "" || out.ContentMediaType == contentMultipartFormData {
+				out.ContentMediaType = contentOctetStream
+			}
+		case "byte":
+			out.Format = ""
+			out.ContentEncoding = "base64"
+		}
+	}
+
+	if out.Nullable {
+		out.Nullable = false
+		switch {
+		case out.Type != "":
+			out.TypeNull = true
+			nullableEnum(&out)
+		case out.IsEmpty() && out.Description == "":
+			out.Type = "null"
+		default:
+			out = types.Schema{OneOf: []types.Schema{out, {Type: "null"}}}
+		}
+	}
+	if len(out.OneOf) == 2 && out.OneOf[1].Type == "null" && isPlainTypedSchema(out.OneOf[0]) && out.Type == "" && out.Description == "" {
+		nullable := out.OneOf[0]
+		nullable.TypeNull = true
+		nullableEnum(&nullable)
+		out = nullable
+	}
+	if out.Ref == "" && len(out.AllOf) > 0 && out.AllOf[0].Ref != "" && isRefSibling(out.AllOf[1:]) {
+		out.Ref = out.AllOf[0].Ref
+		for _, sibling := range out.AllOf[1:] {
+			out.Description = sibling.Description
+		}
+		out.AllOf = nil
+	}
+	return
+}
+
+// nullableEnum возвращает const схемы с type: [T, "null"] в enum: const из одного значения не пропустил бы null,
+// а в enum значение null дописывает сериализация TypeNull.
+func nullableEnum(schema *types.Schema) {
+
+	if value, ok := schema.Const.(string); ok && len(schema.Enum) == 0 {
+		schema.Enum = []string{value}
+		schema.Const = nil
+	}
+}
+
+func schemas31(schemas []types.Schema) (out []types.Schema) {
+
+	for _, schema := range schemas {
+		out = append(out, schema31(schema, ""))
+	}
+	return
+}
+
+// isPlainTypedSchema — схема с одним типом без ссылок и композиции: её можно сделать nullable через type: [T, "null"].
+func isPlainTypedSchema(schema types.Schema) (ok bool) {
+
+	return schema.Type != "" && schema.Type != "null" && !schema.TypeNull && schema.Ref == "" && len(schema.OneOf) == 0 && len(schema.AllOf) == 0
+}
+
+// isRefSibling — элементы allOf рядом с $ref содержат только описание и в 3.1 становятся соседями ссылки.
+func isRefSibling(schemas []types.Schema) (ok bool) {
+
+	if len(schemas) > 1 {
+		return false
+	}
+	for _, schema := range schemas {
+		if schema.Description == "" || !schema.IsEmpty() || schema.Type != "" {
+			return false
+		}
+	}
+	return true
+}
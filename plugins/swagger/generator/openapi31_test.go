@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+	"tgp/plugins/swagger/types"
+)
+
+func openAPI31TestProject() (project *model.Project) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	return &model.Project{
+		ModulePath: "example",
+		Types: map[string]*model.Type{
+			"example/dto:Customer": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Customer",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "Name", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"name"}}},
+				},
+			},
+			"example/dto:Order": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "Order",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}}},
+					{Name: "Note", TypeRef: model.TypeRef{TypeID: "string", NumberOfPointers: 1}, Tags: map[string][]string{"json": {"note", "omitempty"}}},
+					{Name: "Customer", TypeRef: model.TypeRef{TypeID: "example/dto:Customer"}, Docs: []string{"// Покупатель"}, Tags: map[string][]string{"json": {"customer"}}},
+				},
+			},
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:        "Orders",
+				ID:          "Orders",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: ""},
+				Methods: []*model.Method{
+					{
+						Name:        "Update",
+						Annotations: tags.DocTags{"id.example": "42"},
+						Args:        []*model.Variable{ctx, {Name: "id", TypeRef: model.TypeRef{TypeID: "string"}}, {Name: "order", TypeRef: model.TypeRef{TypeID: "example/dto:Order"}}},
+						Results:     []*model.Variable{errResult},
+					},
+					{
+						Name:        "Upload",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "POST"},
+						Args:        []*model.Variable{ctx, {Name: "body", TypeRef: model.TypeRef{TypeID: typeIDIOReader}}},
+						Results:     []*model.Variable{errResult},
+					},
+				},
+			},
+			{
+				Name:        "OrderHooks",
+				ID:          "OrderHooks",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{tagWebhook: ""},
+				Methods: []*model.Method{
+					{
+						Name:    "Paid",
+						Args:    []*model.Variable{ctx, {Name: "order", TypeRef: model.TypeRef{TypeID: "example/dto:Order"}}},
+						Results: []*model.Variable{errResult},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateDoc31_schemaSemantics(t *testing.T) {
+
+	doc, err := GenerateDoc31(openAPI31TestProject())
+	if err != nil {
+		t.Fatalf("GenerateDoc31: %v", err)
+	}
+	if doc.OpenAPI != openAPI31Version {
+		t.Fatalf("openapi = %q", doc.OpenAPI)
+	}
+
+	order := doc.Components.Schemas["dto.Order"]
+	note := order.Properties["note"]
+	if !note.TypeNull || note.Type != "string" || note.Nullable {
+		t.Fatalf("pointer field must become type [string, null]: %+v", note)
+	}
+	customer := order.Properties["customer"]
+	if customer.Ref != componentsSchemasPrefix+"dto.Customer" || !strings.Contains(customer.Description, "Покупатель") || len(customer.AllOf) != 0 {
+		t.Fatalf("description must be a sibling of $ref: %+v", customer)
+	}
+
+	request := doc.Components.Schemas["OrdersUpdateRequest"]
+	if id := request.Properties["id"]; id.Example != nil || len(id.Examples) != 1 || id.Examples[0] != "42" {
+		t.Fatalf("example must become examples: %+v", id)
+	}
+
+	raw, err := doc.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if !strings.Contains(string(raw), `"type": [`) || strings.Contains(string(raw), `"nullable"`) {
+		t.Fatalf("3.1 document must use type arrays instead of nullable:\n%s", raw)
+	}
+	yamlRaw, err := doc.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML: %v", err)
+	}
+	if !strings.Contains(string(yamlRaw), "type: [string, \"null\"]") {
+		t.Fatalf("yaml must contain type list:\n%s", yamlRaw)
+	}
+}
+
+func TestGenerateDoc31_readerBodyAndWebhooks(t *testing.T) {
+
+	doc, err := GenerateDoc31(openAPI31TestProject())
+	if err != nil {
+		t.Fatalf("GenerateDoc31: %v", err)
+	}
+
+	upload := doc.Paths["/orders/upload"].Post
+	if upload == nil {
+		t.Fatalf("upload operation: %+v", doc.Paths)
+	}
+	body := upload.RequestBody.Content[contentOctetStream].Schema
+	if body.Format != "" || body.ContentMediaType != contentOctetStream {
+		t.Fatalf("io.Reader body must use contentMediaType: %+v", body)
+	}
+
+	hook, ok := doc.Webhooks["orderHooks.paid"]
+	if !ok || hook.Post == nil {
+		t.Fatalf("webhooks: %+v", doc.Webhooks)
+	}
+	if hook.Post.RequestBody.Content[contentJSON].Schema.Ref != componentsSchemasPrefix+"OrderHooksPaidRequest" {
+		t.Fatalf("webhook body: %+v", hook.Post.RequestBody)
+	}
+	if _, found := doc.Components.Schemas["OrderHooksPaidRequest"]; !found {
+		t.Fatalf("webhook request schema must be registered")
+	}
+}
+
+func TestGenerateDoc_staysOpenAPI30(t *testing.T) {
+
+	doc, err := GenerateDoc(openAPI31TestProject())
+	if err != nil {
+		t.Fatalf("GenerateDoc: %v", err)
+	}
+	if doc.OpenAPI != openAPIVersion || doc.Webhooks != nil {
+		t.Fatalf("3.0 document changed: openapi=%q webhooks=%+v", doc.OpenAPI, doc.Webhooks)
+	}
+	if note := doc.Components.Schemas["dto.Order"].Properties["note"]; len(note.OneOf) != 2 || !note.OneOf[1].Nullable || note.TypeNull {
+		t.Fatalf("3.0 pointer field must stay nullable: %+v", note)
+	}
+}
+
+func TestSchema31_enumAndBytes(t *testing.T) {
+
+	out := schema31(types.Schema{Type: "string", Enum: []string{"paid"}}, "")
+	if out.Const != "paid" || out.Enum != nil {
+		t.Fatalf("single enum must become const: %+v", out)
+	}
+	out = schema31(types.Schema{Type: "string", Format: "byte"}, "")
+	if out.Format != "" || out.ContentEncoding != "base64" {
+		t.Fatalf("byte format must become contentEncoding: %+v", out)
+	}
+	out = schema31(types.Schema{OneOf: []types.Schema{{Type: "integer"}, {Nullable: true}}}, "")
+	if out.Type != "integer" || !out.TypeNull || out.OneOf != nil {
+		t.Fatalf("oneOf with null must collapse into a type list: %+v", out)
+	}
+	out = schema31(types.Schema{Type: "string", Enum: []string{"paid"}, Nullable: true}, "")
+	data, err := json.Marshal(out)
+	if err != nil || string(data) != `{"type":["string","null"],"enum":["paid",null]}` {
+		t.Fatalf("nullable enum must accept null: %s %v", data, err)
+	}
+	out = schema31(types.Schema{OneOf: []types.Schema{{Type: "string", Enum: []string{"paid", "new"}}, {Nullable: true}}}, "")
+	if data, err = json.Marshal(out); err != nil || string(data) != `{"type":["string","null"],"enum":["paid","new",null]}` {
+		t.Fatalf("collapsed nullable enum must accept null: %s %v", data, err)
+	}
+	out = schema31(types.Schema{OneOf: []types.Schema{{Type: "string", Enum: []string{"paid"}, Description: "status"}, {Nullable: true}}}, "")
+	if out.Const != nil {
+		t.Fatalf("nullable single enum must not become const: %+v", out)
+	}
+	if data, err = yaml.Marshal(out); err != nil || string(data) != "type: [string, \"null\"]\nenum:\n    - paid\n    - null\ndescription: status\n" {
+		t.Fatalf("nullable enum yaml: %q %v", data, err)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"tgp/internal/common"
+	"tgp/internal/model"
+	"tgp/internal/validate"
+	"tgp/plugins/swagger/types"
+)
+
+// GenerateOpenRPC строит документ OpenRPC для unary-методов контрактов с jsonRPC-server.
+// Схемы типов общие с OpenAPI и лежат в components.schemas.
+func GenerateOpenRPC(project *model.Project, ifaces ...string) (doc types.OpenRPC, err error) {
+
+	if err = validate.Project(project); err != nil {
+		return doc, fmt.Errorf("invalid project: %w", err)
+	}
+
+	for _, contract := range project.Contracts {
+		if err = validate.Contract(contract, project); err != nil {
+			return doc, fmt.Errorf("validate contract %q: %w", contract.Name, err)
+		}
+	}
+
+	gen := newGenerator(project)
+
+	doc.OpenRPC = openRPCVersion
+	doc.Info.Title = model.GetAnnotationValue(project, nil, nil, nil, tagTitle, project.ModulePath)
+	doc.Info.Version = model.GetAnnotationValue(project, nil, nil, nil, tagAppVersion, defaultVersion)
+	doc.Info.Description = descriptionFromProject(project)
+	doc.Methods = make([]types.OpenRPCMethod, 0)
+
+	servers := projectServers(project)
+	for _, server := range servers {
+		doc.Servers = append(doc.Servers, types.OpenRPCServer{URL: server.URL, Description: server.Description})
+	}
+
+	include, exclude := splitContractFilters(ifaces)
+	for _, contract := range model.ContractsSorted(project.Contracts) {
+		if !model.IsAnnotationSet(project, contract, nil, nil, model.TagServerJsonRPC) {
+			continue
+		}
+		if !contractSelected(contract, include, exclude) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if !model.MethodIsJSONRPC(project, contract, method) {
+				continue
+			}
+			doc.Methods = append(doc.Methods, gen.openRPCMethod(contract, method, servers))
+		}
+	}
+
+	doc.Components.Schemas = gen.schemas
+
+	return
+}
+
+func (g *generator) openRPCMethod(contract *model.Contract, method *model.Method, servers []types.Server) (rpcMethod types.OpenRPCMethod) {
+
+	serviceTags := g.serviceTags(contract, method)
+
+	rpcMethod = types.OpenRPCMethod{
+		Name:           model.JsonRPCWireMethod(contract.Name, method.Name),
+		Description:    descriptionFromMethod(method),
+		Deprecated:     model.IsAnnotationSet(g.project, contract, method, nil, tagDeprecated),
+		ParamStructure: "by-name",
+		Servers:        openRPCMethodServers(servers, model.JSONRPCServiceBatchPath(g.project, contract)),
+		Params:         g.openRPCParams(contract, method),
+		Errors:         openRPCErrors(method),
+	}
+	if method.Annotations != nil {
+		rpcMethod.Summary = method.Annotations.Value(tagSummary, "")
+	}
+	for _, tag := range serviceTags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			rpcMethod.Tags = append(rpcMethod.Tags, types.Tag{Name: tag})
+		}
+	}
+
+	responseStructName := g.responseStructName(contract, method)
+	g.registerStruct(responseStructName, contract.PkgPath, method, method.Results, contentJSON, false)
+	rpcMethod.Result = &types.ContentDescriptor{
+		Name:   "result",
+		Schema: g.effectiveResponseSchema(contract, method, responseStructName, nil),
+	}
+
+	if example, ok := g.openRPCExample(contract, method, rpcMethod.Params); ok {
+		rpcMethod.Examples = []types.OpenRPCExamplePairing{example}
+	}
+	return
+}
+
+// openRPCMethodServers возвращает адреса эндпоинта контракта: JSON-RPC запросы и batch принимаются по его пути.
+func openRPCMethodServers(servers []types.Server, servicePath string) (out []types.OpenRPCServer) {
+
+	if len(servers) == 0 {
+		return []types.OpenRPCServer{{URL: servicePath}}
+	}
+	for _, server := range servers {
+		out = append(out, types.OpenRPCServer{
+			URL:         strings.TrimSuffix(server.URL, "/") + servicePath,
+			Description: server.Description,
+		})
+	}
+	return
+}
+
+// openRPCParams описывает params по именам полей JSON; аргументы с json-inline раскрываются в свои поля.
+func (g *generator) openRPCParams(contract *model.Contract, method *model.Method) (params []types.ContentDescriptor) {
+
+	params = make([]types.ContentDescriptor, 0)
+	for _, arg := range g.bodyArgs(method, contract, "") {
+		effective := model.EffectiveVariable(method, arg)
+		schema := g.variableToSchema(effective, contract.PkgPath, true)
+		if schema == nil {
+			continue
+		}
+		if g.resultHasJsonInline(method, arg) {
+			inline := g.resolveSchemaForMerge(schema)
+			for _, name := range common.SortedKeys(inline.Properties) {
+				params = append(params, types.ContentDescriptor{
+					Name:     name,
+					Required: slices.Contains(inline.Required, name),
+					Schema:   inline.Properties[name],
+				})
+			}
+			continue
+		}
+		name := g.paramJSONName(effective)
+		params = append(params, types.ContentDescriptor{
+			Name:        name,
+			Description: descriptionFromVariable(effective),
+			Required:    isRequiredGeneratedRequestField(effective, method.Annotations),
+			Schema:      *schema,
+		})
+	}
+	return
+}
+
+// openRPCErrors переносит ошибки метода: код — значение Code() типа ошибки, без него сервер отвечает internal error.
+func openRPCErrors(method *model.Method) (rpcErrors []types.OpenRPCError) {
+
+	seen := make(map[string]bool)
+	for _, errInfo := range method.Errors {
+		if errInfo == nil || errInfo.TypeID == "" {
+			continue
+		}
+		code := errInfo.HTTPCode
+		if code == 0 {
+			code = jsonRPCInternalError
+		}
+		key := fmt.Sprintf("%d:%s", code, errInfo.TypeName)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rpcErrors = append(rpcErrors, types.OpenRPCError{Code: code, Message: errInfo.TypeName})
+	}
+	slices.SortStableFunc(rpcErrors, func(a, b types.OpenRPCError) int { return a.Code - b.Code })
+	return
+}
+
+// openRPCExample собирает пример вызова из аннотаций example аргументов и результатов метода.
+func (g *generator) openRPCExample(contract *model.Contract, method *model.Method, params []types.ContentDescriptor) (example types.OpenRPCExamplePairing, ok bool) {
+
+	paramSchemas := make(map[string]types.Schema, len(params))
+	for _, param := range params {
+		paramSchemas[param.Name] = param.Schema
+	}
+	example.Params = make([]types.OpenRPCExample, 0)
+	for _, arg := range method.Args {
+		effective := model.EffectiveVariable(method, arg)
+		raw := effective.Annotations.Value(tagExample, "")
+		if raw == "" {
+			continue
+		}
+		name := g.paramJSONName(effective)
+		schema, found := paramSchemas[name]
+		if !found {
+			continue
+		}
+		example.Params = append(example.Params, types.OpenRPCExample{Name: name, Value: g.exampleValue(raw, schema)})
+	}
+
+	var results []*model.Variable
+	for _, result := range method.Results {
+		if result.TypeID != "error" {
+			results = append(results, result)
+		}
+	}
+	resultValues := make(map[string]any)
+	var inlineValue any
+	inlineSingle := len(results) == 1 && model.IsAnnotationSet(g.project, contract, method, nil, model.TagHttpEnableInlineSingle)
+	for _, result := range results {
+		effective := model.EffectiveVariable(method, result)
+		raw := effective.Annotations.Value(tagExample, "")
+		if raw == "" {
+			continue
+		}
+		var schema types.Schema
+		if s := g.variableToSchema(effective, contract.PkgPath, false); s != nil {
+			schema = *s
+		}
+		if inlineSingle {
+			inlineValue = g.exampleValue(raw, schema)
+			continue
+		}
+		name := g.paramJSONName(effective)
+		resultValues[name] = g.exampleValue(raw, schema)
+	}
+	switch {
+	case inlineValue != nil:
+		example.Result = &types.OpenRPCExample{Name: "result", Value: inlineValue}
+	case len(resultValues) > 0:
+		example.Result = &types.OpenRPCExample{Name: "result", Value: resultValues}
+	}
+
+	if len(example.Params) == 0 && example.Result == nil {
+		return example, false
+	}
+	example.Name = model.JsonRPCWireMethod(contract.Name, method.Name)
+	return example, true
+}
+
+func (g *generator) paramJSONName(variable *model.Variable) (name string) {
+
+	if name = g.getJSONFieldName(variable); name == "" || name == "-" {
+		name = types.ToLowerCamel(variable.Name)
+	}
+	return
+}
+
+// exampleValue возвращает значение примера: для нестроковых схем — разобранный JSON, иначе исходная строка.
+func (g *generator) exampleValue(raw string, schema types.Schema) (value any) {
+
+	if resolved, ok := g.resolveRefToSchema(schema.Ref); ok {
+		schema = resolved
+	}
+	if schema.Type == "string" {
+		return raw
+	}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	return
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+	"tgp/plugins/swagger/types"
+)
+
+func openRPCTestProject() (project *model.Project) {
+
+	return &model.Project{
+		ModulePath: "example",
+		Annotations: tags.DocTags{
+			tagServers: "https://api.example.com/;prod",
+		},
+		Types: map[string]*model.Type{
+			"example/dto:User": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "User",
+				PkgName:       "dto",
+				ImportPkgPath: "example/dto",
+				StructFields: []*model.StructField{
+					{Name: "ID", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"id"}}},
+				},
+			},
+			"example/errs:ErrNotFound": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "ErrNotFound",
+				PkgName:       "errs",
+				ImportPkgPath: "example/errs",
+			},
+		},
+		Contracts: []*model.Contract{
+			{
+				Name:    "Users",
+				ID:      "Users",
+				PkgPath: "example/contracts",
+				Annotations: tags.DocTags{
+					model.TagServerJsonRPC: "",
+					model.TagServerHTTP:    "",
+					model.TagHttpPrefix:    "api/v1",
+				},
+				Methods: []*model.Method{
+					{
+						Name: "GetUser",
+						Annotations: tags.DocTags{
+							tagSummary:      "Get user",
+							"id.example":    "42",
+							"limit.example": "10",
+						},
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+							{Name: "id", TypeRef: model.TypeRef{TypeID: "string"}},
+							{Name: "limit", TypeRef: model.TypeRef{TypeID: "int"}},
+						},
+						Results: []*model.Variable{
+							{Name: "user", TypeRef: model.TypeRef{TypeID: "example/dto:User", NumberOfPointers: 1}},
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+						Errors: []*model.ErrorInfo{
+							{TypeName: "ErrNotFound", TypeID: "example/errs:ErrNotFound", HTTPCode: 404},
+							{TypeName: "ErrUnknown", TypeID: "example/errs:ErrUnknown"},
+						},
+					},
+					{
+						Name:        "Export",
+						Annotations: tags.DocTags{model.TagHTTPMethod: "GET", model.TagHttpPath: "/export"},
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+						},
+						Results: []*model.Variable{
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+					},
+				},
+			},
+			{
+				Name:        "Reports",
+				ID:          "Reports",
+				PkgPath:     "example/contracts",
+				Annotations: tags.DocTags{model.TagServerHTTP: ""},
+				Methods: []*model.Method{
+					{
+						Name: "List",
+						Args: []*model.Variable{
+							{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}},
+						},
+						Results: []*model.Variable{
+							{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateOpenRPC_JsonRPCMethods(t *testing.T) {
+
+	doc, err := GenerateOpenRPC(openRPCTestProject())
+	if err != nil {
+		t.Fatalf("GenerateOpenRPC: %v", err)
+	}
+	if doc.OpenRPC != openRPCVersion {
+		t.Fatalf("openrpc version = %q", doc.OpenRPC)
+	}
+	if len(doc.Methods) != 1 {
+		t.Fatalf("only unary JSON-RPC methods must be described, got %#v", doc.Methods)
+	}
+	method := doc.Methods[0]
+	if method.Name != "users.getUser" || method.Summary != "Get user" || method.ParamStructure != "by-name" {
+		t.Fatalf("unexpected method header: %#v", method)
+	}
+	if len(method.Servers) != 1 || method.Servers[0].URL != "https://api.example.com/api/v1/users" {
+		t.Fatalf("method server must point to the contract endpoint: %#v", method.Servers)
+	}
+	if len(method.Params) != 2 || method.Params[0].Name != "id" || method.Params[1].Name != "limit" || method.Params[1].Schema.Type != "integer" {
+		t.Fatalf("unexpected params: %#v", method.Params)
+	}
+	if method.Result == nil || method.Result.Schema.Ref != componentsSchemasPrefix+"UsersGetUserResponse" {
+		t.Fatalf("unexpected result: %#v", method.Result)
+	}
+	if _, ok := doc.Components.Schemas["UsersGetUserResponse"]; !ok {
+		t.Fatalf("result schema must be registered in components: %#v", doc.Components.Schemas)
+	}
+	wantErrors := []types.OpenRPCError{
+		{Code: jsonRPCInternalError, Message: "ErrUnknown"},
+		{Code: 404, Message: "ErrNotFound"},
+	}
+	if len(method.Errors) != len(wantErrors) || method.Errors[0] != wantErrors[0] || method.Errors[1] != wantErrors[1] {
+		t.Fatalf("errors = %#v, want %#v", method.Errors, wantErrors)
+	}
+	if len(method.Examples) != 1 || len(method.Examples[0].Params) != 2 {
+		t.Fatalf("example pairing must be built from example annotations: %#v", method.Examples)
+	}
+	if id := method.Examples[0].Params[0].Value; id != "42" {
+		t.Fatalf("string example must stay a string, got %#v", id)
+	}
+	if limit := method.Examples[0].Params[1].Value; limit != float64(10) {
+		t.Fatalf("integer example must be decoded as JSON, got %#v", limit)
+	}
+}
+
+func TestGenerateOpenRPC_ContractFilter(t *testing.T) {
+
+	doc, err := GenerateOpenRPC(openRPCTestProject(), "!Users")
+	if err != nil {
+		t.Fatalf("GenerateOpenRPC: %v", err)
+	}
+	if len(doc.Methods) != 0 {
+		t.Fatalf("excluded contract must not be described: %#v", doc.Methods)
+	}
+}
@@ -4,6 +4,7 @@ package generator
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,42 +18,13 @@ func (g *generator) generatePaths(contracts []*model.Contract, ifaces []string)
 
 	paths = make(map[string]types.Path)
 
-	var include, exclude []string
-	for _, iface := range ifaces {
-		if strings.HasPrefix(iface, "!") {
-			exclude = append(exclude, strings.TrimPrefix(iface, "!"))
-		} else {
-			include = append(include, iface)
-		}
-	}
-
+	include, exclude := splitContractFilters(ifaces)
 	for _, contract := range contracts {
 		if !model.ContractIsHTTPFamily(g.project, contract) {
 			continue
 		}
-		if len(include) > 0 {
-			found := false
-			for _, iface := range include {
-				if contract.Name == iface || contract.ID == iface {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
-		}
-		if len(exclude) > 0 {
-			excluded := false
-			for _, iface := range exclude {
-				if contract.Name == iface || contract.ID == iface {
-					excluded = true
-					break
-				}
-			}
-			if excluded {
-				continue
-			}
+		if !contractSelected(contract, include, exclude) {
+			continue
 		}
 
 		for _, method := range contract.Methods {
@@ -63,12 +35,30 @@ func (g *generator) generatePaths(contracts []*model.Contract, ifaces []string)
 	return
 }
 
-func (g *generator) generateMethodPath(paths map[string]types.Path, contract *model.Contract, method *model.Method) {
+// splitContractFilters разделяет фильтр контрактов на включаемые имена и исключения с префиксом «!».
+func splitContractFilters(ifaces []string) (include []string, exclude []string) {
 
-	serviceTags := strings.Split(model.GetAnnotationValue(g.project, contract, nil, nil, tagSwaggerTags, contract.Name), ",")
-	if model.IsAnnotationSet(g.project, contract, method, nil, tagSwaggerTags) {
-		serviceTags = strings.Split(model.GetAnnotationValue(g.project, contract, method, nil, tagSwaggerTags, ""), ",")
+	for _, iface := range ifaces {
+		if strings.HasPrefix(iface, "!") {
+			exclude = append(exclude, strings.TrimPrefix(iface, "!"))
+		} else {
+			include = append(include, iface)
+		}
 	}
+	return
+}
+
+func contractSelected(contract *model.Contract, include []string, exclude []string) (selected bool) {
+
+	if len(include) > 0 && !slices.ContainsFunc(include, func(iface string) bool { return contract.Name == iface || contract.ID == iface }) {
+		return false
+	}
+	return !slices.ContainsFunc(exclude, func(iface string) bool { return contract.Name == iface || contract.ID == iface })
+}
+
+func (g *generator) generateMethodPath(paths map[string]types.Path, contract *model.Contract, method *model.Method) {
+
+	serviceTags := g.serviceTags(contract, method)
 
 	isWS := model.MethodIsWS(g.project, contract, method)
 	isSSE := model.MethodIsSSE(g.project, contract, method)
@@ -91,6 +81,16 @@ func (g *generator) generateMethodPath(paths map[string]types.Path, contract *mo
 	}
 }
 
+// serviceTags — группы операции: swaggerTags метода, иначе контракта, по умолчанию имя контракта.
+func (g *generator) serviceTags(contract *model.Contract, method *model.Method) (serviceTags []string) {
+
+	serviceTags = strings.Split(model.GetAnnotationValue(g.project, contract, nil, nil, tagSwaggerTags, contract.Name), ",")
+	if model.IsAnnotationSet(g.project, contract, method, nil, tagSwaggerTags) {
+		serviceTags = strings.Split(model.GetAnnotationValue(g.project, contract, method, nil, tagSwaggerTags, ""), ",")
+	}
+	return
+}
+
 func (g *generator) generateWebSocketPath(paths map[string]types.Path, contract *model.Contract, method *model.Method, serviceTags []string) {
 
 	requestName := g.requestStructName(contract, method)
@@ -109,6 +109,7 @@ func (g *generator) generateWebSocketPath(paths map[string]types.Path, contract
 			Responses: types.Responses{
 				"101": {Description: "Switching Protocols"},
 			},
+			Security: g.operationSecurity(contract, nil),
 		}
 	} else {
 		pathValue.Get.Description += "\n\n" + methodBlock
@@ -129,6 +130,7 @@ func (g *generator) generateSSEPath(paths map[string]types.Path, contract *model
 		Responses: types.Responses{
 			"200": {Description: "Event stream", Content: types.Content{"text/event-stream": {Schema: types.Schema{Type: "string"}}}},
 		},
+		Security: g.operationSecurity(contract, method),
 	}
 	paths[model.MethodSSEPath(g.project, contract, method)] = types.Path{Post: operation}
 }
@@ -195,12 +197,13 @@ func (g *generator) generateJsonRPCPath(paths map[string]types.Path, contract *m
 				},
 			},
 		},
+		Security: g.operationSecurity(contract, method),
 	}
 
 	g.addHeaderParameters(operation, contract, method)
 	g.addCookieParameters(operation, contract, method)
 	g.addResponseHeaders(operation, contract, method, 200)
-	g.fillErrors(operation.Responses, method)
+	g.fillErrors(operation.Responses, contract, method, false)
 
 	paths[jsonrpcPath] = types.Path{Post: operation}
 }
@@ -605,6 +608,7 @@ func (g *generator) generateHTTPPath(paths map[string]types.Path, contract *mode
 		Tags:        serviceTags,
 		Deprecated:  model.IsAnnotationSet(g.project, contract, method, nil, tagDeprecated),
 		Responses:   responses,
+		Security:    g.operationSecurity(contract, method),
 	}
 
 	g.addPathParameters(operation, contract, method, httpPath)
@@ -645,7 +649,7 @@ func (g *generator) generateHTTPPath(paths map[string]types.Path, contract *mode
 	}
 
 	g.addResponseHeaders(operation, contract, method, successCode)
-	g.fillErrors(operation.Responses, method)
+	g.fillErrors(operation.Responses, contract, method, model.HTTPErrorsAsProblem(g.project, contract, method))
 
 	openAPIPath := pathParamColonToBraces(httpPath)
 	pathValue, found := paths[openAPIPath]
@@ -832,7 +836,8 @@ func (g *generator) addResponseHeaders(operation *types.Operation, contract *mod
 	}
 }
 
-func (g *generator) fillErrors(responses types.Responses, method *model.Method) {
+// fillErrors описывает ответы с ошибками метода; problem — ошибки отдаются в формате RFC 9457 (@tg http-errors=problem).
+func (g *generator) fillErrors(responses types.Responses, contract *model.Contract, method *model.Method, problem bool) {
 
 	if len(method.Errors) == 0 {
 		return
@@ -853,6 +858,15 @@ func (g *generator) fillErrors(responses types.Responses, method *model.Method)
 		withoutHTTPCode = append(withoutHTTPCode, errInfo)
 	}
 
+	errorsSchema := g.errorInfosSchema
+	contentType := contentJSON
+	if problem {
+		errorsSchema = func(errInfos []*model.ErrorInfo) (schema *types.Schema) {
+			return g.problemErrorInfosSchema(contract, errInfos)
+		}
+		contentType = model.ProblemContentType
+	}
+
 	codes := make([]int, 0, len(byCode))
 	for code := range byCode {
 		codes = append(codes, code)
@@ -860,7 +874,7 @@ func (g *generator) fillErrors(responses types.Responses, method *model.Method)
 	sort.Ints(codes)
 	for _, code := range codes {
 		errInfos := byCode[code]
-		schema := g.errorInfosSchema(errInfos)
+		schema := errorsSchema(errInfos)
 		if schema == nil {
 			continue
 		}
@@ -872,19 +886,19 @@ func (g *generator) fillErrors(responses types.Responses, method *model.Method)
 		responses[key] = types.Response{
 			Description: desc,
 			Content: types.Content{
-				contentJSON: types.Media{Schema: *schema},
+				contentType: types.Media{Schema: *schema},
 			},
 		}
 	}
 
-	schema := g.errorInfosSchema(withoutHTTPCode)
+	schema := errorsSchema(withoutHTTPCode)
 	if schema == nil {
 		return
 	}
 	responses[responseKeyDefault] = types.Response{
 		Description: "Error",
 		Content: types.Content{
-			contentJSON: types.Media{Schema: *schema},
+			contentType: types.Media{Schema: *schema},
 		},
 	}
 }
@@ -909,6 +923,56 @@ func (g *generator) errorInfosSchema(errInfos []*model.ErrorInfo) (schema *types
 	}
 }
 
+// problemErrorInfosSchema — схемы ошибок в формате RFC 9457: ProblemDetails с фиксированным type
+// и полями структуры ошибки как членами-расширениями.
+func (g *generator) problemErrorInfosSchema(contract *model.Contract, errInfos []*model.ErrorInfo) (schema *types.Schema) {
+
+	var schemas []types.Schema
+	for _, errInfo := range errInfos {
+		problemSchema := types.Schema{AllOf: []types.Schema{
+			g.problemDetailsSchema(),
+			{
+				Type:       "object",
+				Properties: types.Properties{"type": {Type: "string", Format: "uri-reference", Enum: []string{model.ProblemType(g.project, contract, errInfo)}}},
+			},
+		}}
+		if typeInfo := g.errorInfoToType(errInfo); typeInfo != nil {
+			if p := g.structTypeToSchema(typeInfo, nil); p != nil {
+				problemSchema.AllOf = append(problemSchema.AllOf, *p)
+			}
+		}
+		schemas = append(schemas, problemSchema)
+	}
+	switch len(schemas) {
+	case 0:
+		return nil
+	case 1:
+		return &schemas[0]
+	default:
+		return &types.Schema{OneOf: schemas}
+	}
+}
+
+// problemDetailsSchema регистрирует компонент ProblemDetails (RFC 9457) и возвращает ссылку на него.
+func (g *generator) problemDetailsSchema() (ref types.Schema) {
+
+	if _, found := g.schemas[problemDetailsSchemaName]; !found {
+		g.schemas[problemDetailsSchemaName] = types.Schema{
+			Type:        "object",
+			Description: "RFC 9457 problem details",
+			Required:    []string{"type", "title", "status"},
+			Properties: types.Properties{
+				"type":     {Type: "string", Format: "uri-reference", Description: "URI типа проблемы"},
+				"title":    {Type: "string", Description: "Краткое описание типа проблемы"},
+				"status":   {Type: "integer", Description: "HTTP-код ответа"},
+				"detail":   {Type: "string", Description: "Описание конкретного случая"},
+				"instance": {Type: "string", Format: "uri-reference", Description: "Путь запроса, в котором возникла проблема"},
+			},
+		}
+	}
+	return types.Schema{Ref: componentsSchemasPrefix + problemDetailsSchemaName}
+}
+
 func (g *generator) errorInfoToType(errInfo *model.ErrorInfo) (typeInfo *model.Type) {
 
 	if errInfo == nil || errInfo.TypeID == "" {
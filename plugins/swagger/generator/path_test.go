@@ -165,3 +165,45 @@ func TestEffectiveResponseSchema_enableInlineSinglePointerStruct(t *testing.T) {
 		t.Fatalf("expected registered schema %q", cfgTypeName)
 	}
 }
+
+func TestGenerateDoc_operationSecurity(t *testing.T) {
+
+	ctx := &model.Variable{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}
+	errResult := &model.Variable{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}
+	project := &model.Project{
+		ModulePath:  "example",
+		Annotations: tags.DocTags{tagSecurity: "http:bearer"},
+		Contracts: []*model.Contract{{
+			Name:        "Orders",
+			ID:          "Orders",
+			PkgPath:     "example/contracts",
+			Annotations: tags.DocTags{model.TagServerHTTP: ""},
+			Methods: []*model.Method{
+				{Name: "List", Annotations: tags.DocTags{model.TagHTTPMethod: "GET"}, Args: []*model.Variable{ctx}, Results: []*model.Variable{errResult}},
+				{Name: "Health", Annotations: tags.DocTags{model.TagHTTPMethod: "GET", tagSecurity: model.SecurityNone}, Args: []*model.Variable{ctx}, Results: []*model.Variable{errResult}},
+				{Name: "Import", Annotations: tags.DocTags{model.TagHTTPMethod: "POST", tagSecurity: "apiKey:header:X-Import-Key"}, Args: []*model.Variable{ctx}, Results: []*model.Variable{errResult}},
+			},
+		}},
+	}
+
+	doc, err := GenerateDoc(project)
+	if err != nil {
+		t.Fatalf("GenerateDoc: %v", err)
+	}
+	if _, found := doc.Components.SecuritySchemes["apiKey_header_X-Import-Key"]; !found {
+		t.Fatalf("method-level scheme must be a component: %+v", doc.Components.SecuritySchemes)
+	}
+	if security := doc.Paths["/orders/list"].Get.Security; security != nil {
+		t.Fatalf("method without security must inherit the document security, got %+v", *security)
+	}
+	if security := doc.Paths["/orders/health"].Get.Security; security == nil || len(*security) != 0 {
+		t.Fatalf("security=none must produce an empty security list, got %+v", security)
+	}
+	security := doc.Paths["/orders/import"].Post.Security
+	if security == nil || len(*security) != 1 {
+		t.Fatalf("method security must override the document, got %+v", security)
+	}
+	if _, found := (*security)[0]["apiKey_header_X-Import-Key"]; !found {
+		t.Fatalf("unexpected method security: %+v", *security)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"testing"
+
+	"tgp/internal/model"
+	"tgp/internal/tags"
+)
+
+func TestGenerateDoc_problemErrors(t *testing.T) {
+
+	project := &model.Project{
+		ModulePath: "example",
+		Types: map[string]*model.Type{
+			"example/errs:ErrOutOfStock": {
+				Kind:          model.TypeKindStruct,
+				TypeName:      "ErrOutOfStock",
+				PkgName:       "errs",
+				ImportPkgPath: "example/errs",
+				StructFields: []*model.StructField{
+					{Name: "Sku", TypeRef: model.TypeRef{TypeID: "string"}, Tags: map[string][]string{"json": {"sku"}}},
+				},
+			},
+		},
+		Contracts: []*model.Contract{{
+			Name:        "Orders",
+			ID:          "Orders",
+			PkgPath:     "example/contracts",
+			Annotations: tags.DocTags{model.TagServerHTTP: "", model.TagHttpErrors: model.HTTPErrorsProblem},
+			Methods: []*model.Method{{
+				Name:        "Create",
+				Annotations: tags.DocTags{model.TagHTTPMethod: "POST"},
+				Args:        []*model.Variable{{Name: "ctx", TypeRef: model.TypeRef{TypeID: "context:Context"}}, {Name: "sku", TypeRef: model.TypeRef{TypeID: "string"}}},
+				Results:     []*model.Variable{{Name: "err", TypeRef: model.TypeRef{TypeID: "error"}}},
+				Errors: []*model.ErrorInfo{
+					{PkgPath: "example/errs", TypeName: "ErrOutOfStock", TypeID: "example/errs:ErrOutOfStock", HTTPCode: 409, HTTPCodeText: "Conflict"},
+				},
+			}},
+		}},
+	}
+
+	doc, err := GenerateDoc(project)
+	if err != nil {
+		t.Fatalf("GenerateDoc: %v", err)
+	}
+	if _, found := doc.Components.Schemas[problemDetailsSchemaName]; !found {
+		t.Fatalf("ProblemDetails component must be registered: %+v", doc.Components.Schemas)
+	}
+	response := doc.Paths["/orders/create"].Post.Responses["409"]
+	if _, found := response.Content[contentJSON]; found {
+		t.Fatalf("problem errors must not be described as application/json: %+v", response)
+	}
+	schema := response.Content[model.ProblemContentType].Schema
+	if len(schema.AllOf) != 3 || schema.AllOf[0].Ref != componentsSchemasPrefix+problemDetailsSchemaName {
+		t.Fatalf("problem schema must extend ProblemDetails: %+v", schema)
+	}
+	if typ := schema.AllOf[1].Properties["type"]; len(typ.Enum) != 1 || typ.Enum[0] != "urn:problem-type:out-of-stock" {
+		t.Fatalf("problem type must be fixed: %+v", typ)
+	}
+}
@@ -9,10 +9,15 @@ import (
 	"strings"
 
 	"tgp/core/i18n"
-	"tgp/plugins/swagger/types"
 )
 
-func SaveFile(swaggerDoc types.Object, outFilePath string) (err error) {
+// document — сериализуемый документ плагина: OpenAPI, OpenRPC или AsyncAPI.
+type document interface {
+	ToJSON() (data []byte, err error)
+	ToYAML() (data []byte, err error)
+}
+
+func SaveFile(doc document, outFilePath string) (err error) {
 
 	dir := filepath.Dir(outFilePath)
 	if err = os.MkdirAll(dir, 0700); err != nil {
@@ -23,11 +28,11 @@ func SaveFile(swaggerDoc types.Object, outFilePath string) (err error) {
 	ext := strings.ToLower(filepath.Ext(outFilePath))
 	switch ext {
 	case ".json":
-		if docData, err = swaggerDoc.ToJSON(); err != nil {
+		if docData, err = doc.ToJSON(); err != nil {
 			return fmt.Errorf("%s: %w", i18n.Msg("JSON marshaling error"), err)
 		}
 	case ".yaml", ".yml":
-		if docData, err = swaggerDoc.ToYAML(); err != nil {
+		if docData, err = doc.ToYAML(); err != nil {
 			return fmt.Errorf("%s: %w", i18n.Msg("YAML marshaling error"), err)
 		}
 	default:
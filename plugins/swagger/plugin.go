@@ -17,9 +17,14 @@ import (
 	"tgp/internal/stats"
 	"tgp/plugins/swagger/generator"
 	"tgp/plugins/swagger/server"
+	"tgp/plugins/swagger/types"
 )
 
-const defaultServeAddr = ":8080"
+const (
+	defaultServeAddr = ":8080"
+	openAPI30        = "3.0"
+	openAPI31        = "3.1"
+)
 
 //go:embed plugin.md
 var pluginDoc string
@@ -49,6 +54,14 @@ func (p *SwaggerPlugin) Execute(request data.Storage) (response data.Storage, er
 		return nil, fmt.Errorf("%s: %w", i18n.Msg("generate Swagger"), err)
 	}
 
+	openAPIVersion, _ := data.Get[string](request, "openapi-version")
+	switch openAPIVersion {
+	case "", openAPI30:
+	case openAPI31:
+	default:
+		return nil, fmt.Errorf(i18n.Msg("unsupported OpenAPI version: %s (supported: 3.0, 3.1)"), openAPIVersion)
+	}
+
 	var addr string
 	var output string
 	if rawOut, _ := data.Get[string](request, "out"); rawOut != "" {
@@ -58,7 +71,13 @@ func (p *SwaggerPlugin) Execute(request data.Storage) (response data.Storage, er
 		attrs := stats.StartSwaggerGenerationAttrs(swaggerStats, output)
 		slog.Info(i18n.Msg("generating Swagger documentation"), attrs...)
 
-		if err = generator.SaveFile(swaggerDoc, output); err != nil {
+		outputDoc := swaggerDoc
+		if openAPIVersion == openAPI31 {
+			if outputDoc, err = generator.GenerateDoc31(project, contracts...); err != nil {
+				return nil, fmt.Errorf("%s: %w", i18n.Msg("generate Swagger"), err)
+			}
+		}
+		if err = generator.SaveFile(outputDoc, output); err != nil {
 			slog.Error(i18n.Msg("failed to generate Swagger documentation"), "error", err)
 			return nil, fmt.Errorf("%s: %w", i18n.Msg("generate Swagger"), err)
 		}
@@ -68,12 +87,52 @@ func (p *SwaggerPlugin) Execute(request data.Storage) (response data.Storage, er
 		slog.Info(i18n.Msg("Swagger documentation generated successfully"), attrs...)
 	}
 
+	var openRPCOutput string
+	if rawOut, _ := data.Get[string](request, "openrpc"); rawOut != "" {
+		openRPCOutput = common.NormalizeWASMPath(rawOut)
+	}
+	if openRPCOutput != "" {
+		var openRPCDoc types.OpenRPC
+		if openRPCDoc, err = generator.GenerateOpenRPC(project, contracts...); err != nil {
+			return nil, fmt.Errorf("%s: %w", i18n.Msg("generate OpenRPC"), err)
+		}
+		if err = generator.SaveFile(openRPCDoc, openRPCOutput); err != nil {
+			slog.Error(i18n.Msg("failed to generate OpenRPC document"), "error", err)
+			return nil, fmt.Errorf("%s: %w", i18n.Msg("generate OpenRPC"), err)
+		}
+		slog.Info(i18n.Msg("OpenRPC document generated successfully"), "out", openRPCOutput, "methods", len(openRPCDoc.Methods))
+	}
+
+	var asyncAPIOutput string
+	if rawOut, _ := data.Get[string](request, "asyncapi"); rawOut != "" {
+		asyncAPIOutput = common.NormalizeWASMPath(rawOut)
+	}
+
 	addr, _ = data.Get[string](request, "serve")
-	if addr == "" && output == "" {
+	if addr == "" && output == "" && openRPCOutput == "" && asyncAPIOutput == "" {
 		addr = defaultServeAddr
 	}
+
+	var asyncAPIDoc *types.AsyncAPI
+	if asyncAPIOutput != "" || addr != "" {
+		var doc types.AsyncAPI
+		if doc, err = generator.GenerateAsyncAPI(project, contracts...); err != nil {
+			return nil, fmt.Errorf("%s: %w", i18n.Msg("generate AsyncAPI"), err)
+		}
+		if asyncAPIOutput != "" {
+			if err = generator.SaveFile(doc, asyncAPIOutput); err != nil {
+				slog.Error(i18n.Msg("failed to generate AsyncAPI document"), "error", err)
+				return nil, fmt.Errorf("%s: %w", i18n.Msg("generate AsyncAPI"), err)
+			}
+			slog.Info(i18n.Msg("AsyncAPI document generated successfully"), "out", asyncAPIOutput, "channels", len(doc.Channels))
+		}
+		if len(doc.Channels) > 0 {
+			asyncAPIDoc = &doc
+		}
+	}
+
 	if addr != "" {
-		if err = server.Serve(addr, swaggerDoc); err != nil {
+		if err = server.Serve(addr, swaggerDoc, asyncAPIDoc); err != nil {
 			slog.Error(i18n.Msg("failed to start swagger server"), "error", err)
 			return nil, fmt.Errorf("%s: %w", i18n.Msg("failed to start swagger server"), err)
 		}
@@ -106,12 +165,50 @@ func (p *SwaggerPlugin) Info() (info plugin.Info, err error) {
 						Required:    false,
 						Default:     "contracts",
 					},
+					{
+						Name:        "contracts-recursive",
+						Type:        "bool",
+						Description: i18n.Msg("Search contracts recursively in sub-packages of contracts-dir"),
+						Required:    false,
+						Default:     false,
+					},
+					{
+						Name:        "contracts-include",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files to include (relative to contracts-dir)"),
+						Required:    false,
+					},
+					{
+						Name:        "contracts-exclude",
+						Type:        "string",
+						Description: i18n.Msg("Comma-separated glob patterns of contract files or directories to exclude (relative to contracts-dir)"),
+						Required:    false,
+					},
 					{
 						Name:        "out",
 						Type:        "string",
 						Description: i18n.Msg("Path to output file (.json and .yaml/.yml supported)"),
 						Required:    false,
 					},
+					{
+						Name:        "openapi-version",
+						Type:        "string",
+						Description: i18n.Msg("OpenAPI version of the output file: 3.0 or 3.1 (JSON Schema 2020-12, webhooks)"),
+						Required:    false,
+						Default:     openAPI30,
+					},
+					{
+						Name:        "openrpc",
+						Type:        "string",
+						Description: i18n.Msg("Path to OpenRPC document for jsonRPC-server contracts (.json and .yaml/.yml supported)"),
+						Required:    false,
+					},
+					{
+						Name:        "asyncapi",
+						Type:        "string",
+						Description: i18n.Msg("Path to AsyncAPI document for Kafka, WebSocket and SSE contracts (.json and .yaml/.yml supported)"),
+						Required:    false,
+					},
 					{
 						Name:        "serve",
 						Type:        "string",
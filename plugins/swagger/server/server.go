@@ -3,6 +3,7 @@
 package server
 
 import (
+	_ "embed"
 	"fmt"
 	"log/slog"
 
@@ -13,7 +14,11 @@ import (
 	"tgp/plugins/swagger/types"
 )
 
-func Serve(addr string, swaggerDoc types.Object) (err error) {
+//go:embed templates/asyncapi.html
+var asyncAPIViewer []byte
+
+// Serve отдаёт Swagger UI на «/»; при непустом asyncAPIDoc — документ на /asyncapi.json и его просмотр на /asyncapi.
+func Serve(addr string, swaggerDoc types.Object, asyncAPIDoc *types.AsyncAPI) (err error) {
 
 	var specBytes []byte
 	if specBytes, err = swaggerDoc.ToJSON(); err != nil {
@@ -26,6 +31,15 @@ func Serve(addr string, swaggerDoc types.Object) (err error) {
 
 	mux := http.NewServeMux()
 	mux.Handle("/", swaggerui.Handler(specBytes))
+	if asyncAPIDoc != nil {
+		var asyncAPIBytes []byte
+		if asyncAPIBytes, err = asyncAPIDoc.ToJSON(); err != nil {
+			return fmt.Errorf("%s: %w", i18n.Msg("failed to generate spec"), err)
+		}
+		mux.Handle("/asyncapi.json", staticHandler("application/json", asyncAPIBytes))
+		mux.Handle("/asyncapi", staticHandler("text/html; charset=utf-8", asyncAPIViewer))
+		slog.Info(i18n.Msg("AsyncAPI document is available"), slog.String("url", AddressToURL(addr)+"/asyncapi"))
+	}
 
 	slog.Info(i18n.Msg("starting swagger server"), slog.String("addr", addr))
 
@@ -50,3 +64,11 @@ func Serve(addr string, swaggerDoc types.Object) (err error) {
 
 	return
 }
+
+func staticHandler(contentType string, body []byte) (handler http.Handler) {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body)
+	})
+}
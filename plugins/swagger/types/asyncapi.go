@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package types
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AsyncAPI — документ AsyncAPI 3.x для Kafka, WebSocket и SSE контрактов.
+type AsyncAPI struct {
+	AsyncAPI   string                       `json:"asyncapi" yaml:"asyncapi"`
+	Info       Info                         `json:"info" yaml:"info"`
+	Servers    map[string]AsyncAPIServer    `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Channels   map[string]AsyncAPIChannel   `json:"channels" yaml:"channels"`
+	Operations map[string]AsyncAPIOperation `json:"operations" yaml:"operations"`
+	Components AsyncAPIComponents           `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+func (a AsyncAPI) ToJSON() (data []byte, err error) {
+	return json.MarshalIndent(a, "", "    ")
+}
+
+func (a AsyncAPI) ToYAML() (data []byte, err error) {
+	return yaml.Marshal(a)
+}
+
+type AsyncAPIServer struct {
+	Host        string `json:"host" yaml:"host"`
+	Protocol    string `json:"protocol" yaml:"protocol"`
+	Pathname    string `json:"pathname,omitempty" yaml:"pathname,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type AsyncAPIChannel struct {
+	Address     string                 `json:"address" yaml:"address"`
+	Title       string                 `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Servers     []AsyncAPIRef          `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Messages    map[string]AsyncAPIRef `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Bindings    *AsyncAPIBindings      `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+}
+
+type AsyncAPIOperation struct {
+	Action      string            `json:"action" yaml:"action"`
+	Channel     AsyncAPIRef       `json:"channel" yaml:"channel"`
+	Title       string            `json:"title,omitempty" yaml:"title,omitempty"`
+	Summary     string            `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []Tag             `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Messages    []AsyncAPIRef     `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Reply       *AsyncAPIReply    `json:"reply,omitempty" yaml:"reply,omitempty"`
+	Bindings    *AsyncAPIBindings `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+}
+
+type AsyncAPIReply struct {
+	Channel  *AsyncAPIRef  `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Messages []AsyncAPIRef `json:"messages,omitempty" yaml:"messages,omitempty"`
+}
+
+type AsyncAPIMessage struct {
+	Name          string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Title         string                 `json:"title,omitempty" yaml:"title,omitempty"`
+	Summary       string                 `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description   string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	ContentType   string                 `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Headers       *Schema                `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Payload       *Schema                `json:"payload,omitempty" yaml:"payload,omitempty"`
+	CorrelationID *AsyncAPICorrelationID `json:"correlationId,omitempty" yaml:"correlationId,omitempty"`
+	Bindings      *AsyncAPIBindings      `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+}
+
+type AsyncAPICorrelationID struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Location    string `json:"location" yaml:"location"`
+}
+
+// AsyncAPIBindings — привязки к протоколу; набор полей зависит от объекта, к которому привязка относится.
+type AsyncAPIBindings struct {
+	Kafka *AsyncAPIKafkaBinding `json:"kafka,omitempty" yaml:"kafka,omitempty"`
+	WS    *AsyncAPIWSBinding    `json:"ws,omitempty" yaml:"ws,omitempty"`
+	HTTP  *AsyncAPIHTTPBinding  `json:"http,omitempty" yaml:"http,omitempty"`
+}
+
+type AsyncAPIKafkaBinding struct {
+	Topic          string  `json:"topic,omitempty" yaml:"topic,omitempty"`
+	Key            *Schema `json:"key,omitempty" yaml:"key,omitempty"`
+	BindingVersion string  `json:"bindingVersion" yaml:"bindingVersion"`
+}
+
+type AsyncAPIWSBinding struct {
+	Method         string `json:"method,omitempty" yaml:"method,omitempty"`
+	BindingVersion string `json:"bindingVersion" yaml:"bindingVersion"`
+}
+
+type AsyncAPIHTTPBinding struct {
+	Method         string `json:"method,omitempty" yaml:"method,omitempty"`
+	BindingVersion string `json:"bindingVersion" yaml:"bindingVersion"`
+}
+
+type AsyncAPIComponents struct {
+	Schemas  Schemas                    `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Messages map[string]AsyncAPIMessage `json:"messages,omitempty" yaml:"messages,omitempty"`
+}
+
+type AsyncAPIRef struct {
+	Ref string `json:"$ref" yaml:"$ref"`
+}
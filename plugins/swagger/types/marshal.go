@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaFields — поля Schema без собственных методов сериализации.
+type schemaFields Schema
+
+func (s Schema) MarshalJSON() (data []byte, err error) {
+
+	if !s.TypeNull {
+		return json.Marshal(schemaFields(s))
+	}
+	fields := schemaFields(s)
+	fields.Type, fields.Enum = "", nil
+	if data, err = json.Marshal(fields); err != nil {
+		return
+	}
+	var typeList []byte
+	if typeList, err = json.Marshal(s.nullableTypes()); err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"type":`)
+	buf.Write(typeList)
+	if len(s.Enum) != 0 {
+		var enum []byte
+		if enum, err = json.Marshal(s.nullableEnum()); err != nil {
+			return
+		}
+		buf.WriteString(`,"enum":`)
+		buf.Write(enum)
+	}
+	if len(data) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.Write(data[1:])
+	return buf.Bytes(), nil
+}
+
+func (s Schema) MarshalYAML() (value any, err error) {
+
+	if !s.TypeNull {
+		return schemaFields(s), nil
+	}
+	fields := schemaFields(s)
+	fields.Type, fields.Enum = "", nil
+	var node, typeList yaml.Node
+	if err = node.Encode(fields); err != nil {
+		return
+	}
+	if err = typeList.Encode(s.nullableTypes()); err != nil {
+		return
+	}
+	typeList.Style = yaml.FlowStyle
+	head := []*yaml.Node{{Kind: yaml.ScalarNode, Value: "type"}, &typeList}
+	if len(s.Enum) != 0 {
+		var enum yaml.Node
+		if err = enum.Encode(s.nullableEnum()); err != nil {
+			return
+		}
+		head = append(head, &yaml.Node{Kind: yaml.ScalarNode, Value: "enum"}, &enum)
+	}
+	node.Content = append(head, node.Content...)
+	return &node, nil
+}
+
+func (s Schema) nullableTypes() (list []string) {
+
+	if s.Type != "" {
+		list = append(list, s.Type)
+	}
+	return append(list, "null")
+}
+
+// nullableEnum дополняет enum значением null: иначе null не проходит проверку enum при type: [T, "null"].
+func (s Schema) nullableEnum() (list []any) {
+
+	for _, value := range s.Enum {
+		list = append(list, value)
+	}
+	return append(list, nil)
+}
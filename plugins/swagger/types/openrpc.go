@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Khramtsov Aleksei (seniorGolang@gmail.com).
+// conditions defined in file 'LICENSE', which is part of this project source code.
+package types
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenRPC — документ OpenRPC 1.x для JSON-RPC контрактов.
+type OpenRPC struct {
+	OpenRPC    string            `json:"openrpc" yaml:"openrpc"`
+	Info       Info              `json:"info" yaml:"info"`
+	Servers    []OpenRPCServer   `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Methods    []OpenRPCMethod   `json:"methods" yaml:"methods"`
+	Components OpenRPCComponents `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+func (o OpenRPC) ToJSON() (data []byte, err error) {
+	return json.MarshalIndent(o, "", "    ")
+}
+
+func (o OpenRPC) ToYAML() (data []byte, err error) {
+	return yaml.Marshal(o)
+}
+
+type OpenRPCServer struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	URL         string `json:"url" yaml:"url"`
+	Summary     string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type OpenRPCMethod struct {
+	Name           string                  `json:"name" yaml:"name"`
+	Tags           []Tag                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary        string                  `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description    string                  `json:"description,omitempty" yaml:"description,omitempty"`
+	Servers        []OpenRPCServer         `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Params         []ContentDescriptor     `json:"params" yaml:"params"`
+	Result         *ContentDescriptor      `json:"result,omitempty" yaml:"result,omitempty"`
+	Deprecated     bool                    `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Errors         []OpenRPCError          `json:"errors,omitempty" yaml:"errors,omitempty"`
+	ParamStructure string                  `json:"paramStructure,omitempty" yaml:"paramStructure,omitempty"`
+	Examples       []OpenRPCExamplePairing `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+type ContentDescriptor struct {
+	Name        string `json:"name" yaml:"name"`
+	Summary     string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      Schema `json:"schema" yaml:"schema"`
+	Deprecated  bool   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+type OpenRPCError struct {
+	Code    int    `json:"code" yaml:"code"`
+	Message string `json:"message" yaml:"message"`
+	Data    any    `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+type OpenRPCExamplePairing struct {
+	Name        string           `json:"name" yaml:"name"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Params      []OpenRPCExample `json:"params" yaml:"params"`
+	Result      *OpenRPCExample  `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+type OpenRPCExample struct {
+	Name  string `json:"name" yaml:"name"`
+	Value any    `json:"value" yaml:"value"`
+}
+
+type OpenRPCComponents struct {
+	Schemas Schemas `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
@@ -15,6 +15,7 @@ type Object struct {
 	Tags       []Tag           `json:"tags,omitempty" yaml:"tags,omitempty"`
 	Schemes    []string        `json:"schemes,omitempty" yaml:"schemes,omitempty"`
 	Paths      map[string]Path `json:"paths" yaml:"paths"`
+	Webhooks   map[string]Path `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
 	Components Components      `json:"components,omitempty" yaml:"components,omitempty"`
 	Security   []Security      `json:"security,omitempty" yaml:"security,omitempty"`
 }
@@ -53,6 +54,8 @@ type Operation struct {
 	Servers     []Server     `json:"servers,omitempty" yaml:"servers,omitempty"`
 	CodeSamples []CodeSample `json:"x-code-samples,omitempty" yaml:"x-code-samples,omitempty"`
 	XWebSocket  bool         `json:"x-websocket,omitempty" yaml:"x-websocket,omitempty"`
+	// Security переопределяет security документа; пустой список — операция без аутентификации.
+	Security *[]Security `json:"security,omitempty" yaml:"security,omitempty"`
 }
 
 type CodeSample struct {
@@ -120,6 +123,13 @@ type Schema struct {
 	Example     any        `json:"example,omitempty" yaml:"example,omitempty"`
 	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
 
+	// Поля OpenAPI 3.1 (JSON Schema 2020-12); TypeNull сериализует type как [type, "null"].
+	TypeNull         bool   `json:"-" yaml:"-"`
+	Const            any    `json:"const,omitempty" yaml:"const,omitempty"`
+	Examples         []any  `json:"examples,omitempty" yaml:"examples,omitempty"`
+	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+	ContentEncoding  string `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+
 	OneOf []Schema `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
 	AllOf []Schema `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 